@@ -0,0 +1,124 @@
+package main
+
+import (
+	"time"
+
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/infrastructure/ai/codexcli"
+	"github.com/tesso57/reazy/internal/infrastructure/aicache"
+	"github.com/tesso57/reazy/internal/infrastructure/config"
+	"github.com/tesso57/reazy/internal/infrastructure/export"
+	"github.com/tesso57/reazy/internal/infrastructure/feed"
+	"github.com/tesso57/reazy/internal/infrastructure/history"
+	"github.com/tesso57/reazy/internal/infrastructure/hook"
+)
+
+// app holds the services built from the loaded config.
+type app struct {
+	store         *config.Store
+	subscriptions *usecase.SubscriptionService
+	reading       *usecase.ReadingService
+	insights      *usecase.InsightService
+	newsDigests   *usecase.NewsDigestService
+	feedGrouping  *usecase.FeedGroupingService
+	tokens        *usecase.TokenMeter
+}
+
+// newApp wires the application services to their infrastructure: the config
+// store for subscriptions, the SQLite history (with the AI sidecar when
+// ai.separate_store is set), the HTTP feed, OPML and article fetchers,
+// Markdown export files, the on_new_item command, and Codex CLI for AI
+// features when it is enabled. It also applies the service settings from
+// the config, such as min_content_length, so every entry point shares them.
+func newApp(store *config.Store) *app {
+	cfg := store.Settings
+	readingSvc := usecase.NewReadingService(feed.Fetcher{}, historyRepository(cfg), time.Now)
+	readingSvc.Extractor = feed.ArticleExtractor{}
+	readingSvc.Markdown = export.Converter{}
+	readingSvc.Exports = export.FileWriter{Dir: cfg.ExportDir}
+	readingSvc.ContentFilter = reading.ContentFilter{
+		MinLength:     cfg.MinContentLength,
+		FeedMinLength: cfg.MinContentLengths(),
+	}
+	// Only set the hook when a command is configured, so the interface
+	// doesn't hold a nil *NewItemCommand.
+	if h := hook.NewNewItemCommand(hook.Config{Command: cfg.OnNewItem, MaxPerMinute: cfg.OnNewItemPerMinute}); h != nil {
+		readingSvc.NewItemHook = h
+	}
+
+	var insightGen usecase.InsightGenerator
+	var digestGen usecase.NewsDigestGenerator
+	var groupingGen usecase.FeedGroupingGenerator
+	if cfg.Codex.Enabled {
+		text := codexcli.NewClient(codexConfig(cfg.Codex))
+		insightGen = usecase.NewPromptInsightGenerator(text)
+		digestGen = usecase.NewPromptNewsDigestGenerator(text)
+		if !cfg.GroupsHeuristically() {
+			groupingGen = usecase.NewPromptFeedGroupingGenerator(text)
+		}
+	}
+	// One meter totals the AI prompts of every service for the session.
+	tokens := &usecase.TokenMeter{}
+	heuristic := usecase.HeuristicFeedGrouping{Keywords: cfg.Grouping.Keywords}
+	if cfg.GroupsHeuristically() {
+		groupingGen = heuristic
+	}
+	feedGrouping := usecase.NewFeedGroupingService(groupingGen)
+	feedGrouping.Tokens = tokens
+	if cfg.AI.FallbackWhenUnavailable && !cfg.GroupsHeuristically() {
+		feedGrouping.Fallback = heuristic
+	}
+	insights := usecase.NewInsightService(insightGen, time.Now)
+	insights.Tokens = tokens
+	newsDigests := usecase.NewNewsDigestService(digestGen, time.Now, nil)
+	newsDigests.Tokens = tokens
+	newsDigests.MaxTopics = cfg.NewsDigest.MaxTopics
+	newsDigests.MaxTopicArticles = cfg.NewsDigest.MaxTopicArticles
+	newsDigests.MergeThreshold = cfg.NewsDigest.MergeThreshold
+	subscriptions := usecase.NewSubscriptionService(store)
+	subscriptions.OPMLFetcher = feed.OPMLFetcher{Headers: cfg.OPMLHeaders}
+	return &app{
+		store:         store,
+		subscriptions: subscriptions,
+		reading:       readingSvc,
+		insights:      insights,
+		newsDigests:   newsDigests,
+		feedGrouping:  feedGrouping,
+		tokens:        tokens,
+	}
+}
+
+// loadApp loads the config at configPath, or the default one when it is
+// empty, and wires the services for it. Close app.reading when done.
+func loadApp(configPath string) (*app, error) {
+	store, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return newApp(store), nil
+}
+
+// historyRepository opens the history database, keeping article insights in
+// the ai_cache.json sidecar when ai.separate_store is set.
+func historyRepository(cfg settings.Settings) usecase.HistoryRepository {
+	manager := history.NewManager(cfg.HistoryFile)
+	if cfg.AI.SeparateStore {
+		return aicache.NewRepository(manager, aicache.PathFor(cfg.HistoryFile))
+	}
+	return manager
+}
+
+func codexConfig(cfg settings.CodexConfig) codexcli.Config {
+	return codexcli.Config{
+		Command:          cfg.Command,
+		Model:            cfg.Model,
+		WebSearch:        cfg.WebSearch,
+		ReasoningEffort:  cfg.ReasoningEffort,
+		ReasoningSummary: cfg.ReasoningSummary,
+		Verbosity:        cfg.Verbosity,
+		Sandbox:          cfg.Sandbox,
+		Timeout:          time.Duration(cfg.TimeoutSeconds) * time.Second,
+	}
+}
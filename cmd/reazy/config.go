@@ -0,0 +1,23 @@
+package main
+
+import "github.com/tesso57/reazy/internal/infrastructure/config"
+
+// configCmd groups commands that inspect the configuration.
+type configCmd struct {
+	Show configShowCmd `cmd:"" help:"Print the resolved settings and the paths in use"`
+}
+
+// configShowCmd prints the settings after defaults and environment
+// variables are applied.
+type configShowCmd struct {
+	Format string `enum:"yaml,json" default:"yaml" help:"Output format (yaml or json)"`
+}
+
+// Run loads the config and writes it to the output in the chosen format.
+func (c configShowCmd) Run(globals *cli) error {
+	store, err := config.Load(globals.Config)
+	if err != nil {
+		return err
+	}
+	return store.Show(globals.Out, c.Format)
+}
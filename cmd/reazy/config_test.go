@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestConfigShowPrintsYAML(t *testing.T) {
+	path := writeTestConfig(t, "http://example.com/feed", "")
+
+	out, err := runCLI(t, path, "config", "show")
+	if err != nil {
+		t.Fatalf("config show: %v", err)
+	}
+	if !strings.Contains(out, "paths:") || !strings.Contains(out, "http://example.com/feed") {
+		t.Fatalf("output = %q, want paths and the subscribed feed", out)
+	}
+}
+
+func TestConfigShowPrintsJSON(t *testing.T) {
+	path := writeTestConfig(t, "http://example.com/feed", "")
+
+	out, err := runCLI(t, path, "config", "show", "--format", "json")
+	if err != nil {
+		t.Fatalf("config show --format json: %v", err)
+	}
+	var shown struct {
+		Paths    map[string]any `json:"paths"`
+		Settings struct {
+			Feeds []string `json:"feeds"`
+		} `json:"settings"`
+	}
+	if err := json.Unmarshal([]byte(out), &shown); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if len(shown.Paths) == 0 || len(shown.Settings.Feeds) != 1 {
+		t.Fatalf("shown = %#v, want paths and one feed", shown)
+	}
+
+	if _, err := runCLI(t, path, "config", "show", "--format", "toml"); err == nil {
+		t.Fatal("config show --format toml succeeded, want an error")
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/presentation/control"
+	"github.com/tesso57/reazy/internal/presentation/tui"
+)
+
+// startControl serves the control API on control_socket while program
+// runs, working on the history the TUI shows. The returned function stops
+// the server and reports why it ended early, if it did.
+func startControl(cfg settings.Settings, app *app, program *tea.Program) (func() error, error) {
+	if strings.TrimSpace(cfg.ControlSocket) == "" {
+		return func() error { return nil }, nil
+	}
+	listener, err := control.Listen(cfg.ControlSocket)
+	if err != nil {
+		return nil, fmt.Errorf("control_socket: %w", err)
+	}
+	bridge := tui.NewHistoryBridge(program)
+	server := control.NewServer(app.subscriptions, app.reading)
+	server.History = bridge
+	server.Token = cfg.ControlToken
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(ctx, listener) }()
+	return func() error {
+		bridge.Close()
+		cancel()
+		return <-done
+	}, nil
+}
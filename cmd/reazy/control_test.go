@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/presentation/control"
+	"github.com/tesso57/reazy/internal/presentation/tui"
+)
+
+func TestControlAPISharesTheRunningTUIHistory(t *testing.T) {
+	srv := newTestServer(t)
+	// Unix socket paths are limited to about 100 bytes, too short for the
+	// test's own temporary directory on some systems.
+	socketDir, err := os.MkdirTemp("", "reazy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(socketDir) })
+	socket := filepath.Join(socketDir, "control.sock")
+	store := loadTestStore(t, srv.URL+"/feed", "control_socket: "+socket+"\ncontrol_token: secret\n")
+	app := newApp(store)
+	t.Cleanup(func() { _ = app.reading.Close() })
+
+	model := tui.NewModelWithServices(store.Settings, app.subscriptions, app.reading, app.insights, app.newsDigests, app.feedGrouping, app.tokens)
+	program := tea.NewProgram(model, tea.WithInput(nil), tea.WithOutput(io.Discard), tea.WithoutRenderer())
+	stopControl, err := startControl(store.Settings, app, program)
+	if err != nil {
+		t.Fatalf("startControl() error = %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := program.Run()
+		done <- err
+	}()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	call := func(method, path string, body any) int {
+		t.Helper()
+		req, err := http.NewRequest(method, "http://reazy"+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer secret")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if body != nil {
+			if err := json.NewDecoder(resp.Body).Decode(body); err != nil {
+				t.Fatalf("%s %s: decode: %v", method, path, err)
+			}
+		}
+		return resp.StatusCode
+	}
+
+	var refreshed control.RefreshResponse
+	if code := call(http.MethodPost, "/refresh", &refreshed); code != http.StatusOK || refreshed.New != 1 {
+		t.Fatalf("refresh status = %d, response = %#v", code, refreshed)
+	}
+	var article control.ArticleResponse
+	if code := call(http.MethodPost, "/articles/story-1/read", &article); code != http.StatusOK || !article.Read {
+		t.Fatalf("read status = %d, response = %#v", code, article)
+	}
+	var unread control.UnreadResponse
+	if code := call(http.MethodGet, "/unread", &unread); code != http.StatusOK || unread.Total != 0 {
+		t.Fatalf("unread status = %d, response = %#v", code, unread)
+	}
+
+	program.Quit()
+	if err := <-done; err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if err := stopControl(); err != nil {
+		t.Fatalf("stopControl() error = %v", err)
+	}
+	stored, err := app.reading.LoadHistoryItem("story-1")
+	if err != nil || stored == nil || !stored.IsRead {
+		t.Fatalf("story-1 = %#v, err = %v; want it saved as read", stored, err)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// importCmd groups commands that bring in data from files.
+type importCmd struct {
+	History importHistoryCmd `cmd:"" help:"Merge a JSONL history file into the history database"`
+}
+
+// importHistoryCmd merges a JSONL history, such as the one older versions
+// kept, into the database.
+type importHistoryCmd struct {
+	File string `arg:"" help:"JSONL history file" type:"existingfile"`
+}
+
+// Run imports the file and reports how many lines were imported or skipped.
+func (c importHistoryCmd) Run(globals *cli) error {
+	app, err := loadApp(globals.Config)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = app.reading.Close() }()
+
+	report, supported, err := app.reading.ImportHistoryFile(c.File)
+	if !supported {
+		return errors.New("importing history is not supported")
+	}
+	if err != nil {
+		return fmt.Errorf("import history: %w", err)
+	}
+	_, err = fmt.Fprintf(globals.Out, "Imported %d items from %s; skipped lines: %d\n", report.Imported, c.File, report.Skipped)
+	return err
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+)
+
+func TestImportHistoryMergesJSONL(t *testing.T) {
+	path := writeTestConfig(t, "http://example.com/feed", "")
+	seedHistory(t, path, &reading.HistoryItem{GUID: "existing", Kind: reading.ArticleKind, FeedURL: "http://example.com/feed"})
+	file := filepath.Join(t.TempDir(), "backup.jsonl")
+	lines := `{"guid":"old-1","title":"Old 1"}
+not json
+{"guid":"old-2","title":"Old 2","is_bookmarked":true}
+`
+	if err := os.WriteFile(file, []byte(lines), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCLI(t, path, "import", "history", file)
+	if err != nil {
+		t.Fatalf("import history error = %v", err)
+	}
+	if want := "Imported 2 items from " + file + "; skipped lines: 1\n"; out != want {
+		t.Fatalf("printed %q, want %q", out, want)
+	}
+	if guids := historyGUIDs(t, path); len(guids) != 3 || !guids["existing"] || !guids["old-1"] || !guids["old-2"] {
+		t.Fatalf("history = %v, want the imported items next to the existing one", guids)
+	}
+
+	if _, err := runCLI(t, path, "import", "history", filepath.Join(t.TempDir(), "missing.jsonl")); err == nil || !strings.Contains(err.Error(), "missing.jsonl") {
+		t.Fatalf("importing a missing file error = %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+// Command reazy is a terminal RSS/Atom reader.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alecthomas/kong"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/presentation/tui"
+)
+
+// cli lists the command line flags and subcommands.
+type cli struct {
+	Config string `help:"Config file path (default ~/.config/reazy/config.yaml)" type:"path"`
+	// Out receives the output of commands other than run.
+	Out io.Writer `kong:"-"`
+
+	Run         runCmd         `cmd:"" default:"1" help:"Start the reader (default)"`
+	Add         addCmd         `cmd:"" help:"Subscribe to a feed"`
+	Rm          rmCmd          `cmd:"" help:"Unsubscribe from a feed"`
+	Import      importCmd      `cmd:"" help:"Import data from files"`
+	Reset       resetCmd       `cmd:"" help:"Delete saved data"`
+	Maintenance maintenanceCmd `cmd:"" help:"Maintain the history database"`
+	ConfigCmd   configCmd      `cmd:"" name:"config" help:"Inspect the configuration"`
+}
+
+// runCmd starts the TUI.
+type runCmd struct{}
+
+// Run builds the services from the config and runs the TUI until it quits.
+func (runCmd) Run(globals *cli) error {
+	app, err := loadApp(globals.Config)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = app.reading.Close() }()
+
+	// Import before the model loads history so a migrated history shows up
+	// on first launch.
+	importStatus := legacyHistoryStatus(app.reading.ImportLegacyHistory())
+	model := tui.NewModelWithServices(app.store.Settings, app.subscriptions, app.reading, app.insights, app.newsDigests, app.feedGrouping, app.tokens)
+	model.ShowStatus(importStatus)
+	program := tea.NewProgram(model, tea.WithAltScreen())
+	stopControl, err := startControl(app.store.Settings, app, program)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := stopControl(); err != nil {
+			fmt.Fprintln(os.Stderr, "reazy: control API:", err)
+		}
+	}()
+
+	_, err = program.Run()
+	return err
+}
+
+// legacyHistoryStatus describes the result of importing a pre-SQLite JSONL
+// history for the status bar.
+func legacyHistoryStatus(count int, _ bool, err error) string {
+	switch {
+	case err != nil && count > 0:
+		return fmt.Sprintf("Imported %d items from legacy history, but %v", count, err)
+	case err != nil:
+		return fmt.Sprintf("Legacy history import skipped: %v", err)
+	case count > 0:
+		return fmt.Sprintf("Imported %d items from legacy history", count)
+	}
+	return ""
+}
+
+func main() {
+	args := cli{Out: os.Stdout}
+	ctx := kong.Parse(&args,
+		kong.Name("reazy"),
+		kong.Description("A terminal RSS/Atom reader."),
+		kong.UsageOnError(),
+	)
+	if err := ctx.Run(&args); err != nil {
+		fmt.Fprintln(os.Stderr, "reazy:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/infrastructure/aicache"
+	"github.com/tesso57/reazy/internal/infrastructure/config"
+	"github.com/tesso57/reazy/internal/infrastructure/hook"
+	"github.com/tesso57/reazy/internal/presentation/tui"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+const testArticleText = "Reader mode pulled the whole story from the page."
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("/feed", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprintf(w, `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Example</title><link>%[1]s</link>
+<item><title>Story</title><link>%[1]s/story</link><guid>story-1</guid><description>Teaser</description></item>
+</channel></rss>`, srv.URL)
+	})
+	mux.HandleFunc("/story", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, `<html><head><title>Story</title></head><body>
+<nav>Home | About</nav>
+<article><h1>Story</h1><p>%s</p><p>It has more than one paragraph of body text.</p></article>
+</body></html>`, testArticleText)
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// writeTestConfig writes a config subscribed to feedURL with its history in
+// a temporary directory and returns its path; extra is appended to the YAML.
+func writeTestConfig(t *testing.T, feedURL, extra string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	body := fmt.Sprintf("feeds:\n  - %s\nhistory_file: %s\ncodex:\n  enabled: false\n%s", feedURL, filepath.Join(dir, "history.db"), extra)
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// loadTestStore loads the config writeTestConfig writes.
+func loadTestStore(t *testing.T, feedURL, extra string) *config.Store {
+	t.Helper()
+	store, err := config.Load(writeTestConfig(t, feedURL, extra))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return store
+}
+
+// runUntil executes cmd, descending into batches, and returns the first
+// message of type T it produces.
+func runUntil[T tea.Msg](t *testing.T, cmd tea.Cmd) T {
+	t.Helper()
+	if cmd != nil {
+		if msg, ok := tryRun[T](cmd); ok {
+			return msg
+		}
+	}
+	var zero T
+	t.Fatalf("command did not produce %T", zero)
+	return zero
+}
+
+func tryRun[T tea.Msg](cmd tea.Cmd) (T, bool) {
+	switch msg := cmd().(type) {
+	case T:
+		return msg, true
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if c == nil {
+				continue
+			}
+			if found, ok := tryRun[T](c); ok {
+				return found, true
+			}
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+func press(m tea.Model, key string) (tea.Model, tea.Cmd) {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	if key == "enter" {
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	}
+	return m.Update(msg)
+}
+
+func TestReaderModeFetchesFullTextThroughApp(t *testing.T) {
+	srv := newTestServer(t)
+	store := loadTestStore(t, srv.URL+"/feed", "")
+	app := newApp(store)
+	t.Cleanup(func() { _ = app.reading.Close() })
+
+	var m tea.Model = tui.NewModelWithServices(store.Settings, app.subscriptions, app.reading, app.insights, app.newsDigests, app.feedGrouping, app.tokens)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m, cmd := press(m, "enter")
+	m, _ = m.Update(runUntil[update.FeedFetchedMsg](t, cmd))
+	m, _ = press(m, "j") // past the date header
+	m, cmd = press(m, "enter")
+	if cmd != nil {
+		if loaded, ok := tryRun[update.ArticleDetailLoadedMsg](cmd); ok {
+			m, _ = m.Update(loaded)
+		}
+	}
+	if !strings.Contains(m.View(), "Teaser") {
+		t.Fatalf("detail view should show the feed excerpt first:\n%s", m.View())
+	}
+
+	m, cmd = press(m, "F")
+	m, _ = m.Update(runUntil[update.ArticleDetailLoadedMsg](t, cmd))
+	if !strings.Contains(m.View(), testArticleText) {
+		t.Fatalf("detail view should show the extracted article:\n%s", m.View())
+	}
+
+	stored, err := app.reading.LoadHistoryItem("story-1")
+	if err != nil {
+		t.Fatalf("LoadHistoryItem: %v", err)
+	}
+	if !strings.Contains(stored.Content, testArticleText) {
+		t.Fatalf("stored content = %q, want the extracted text", stored.Content)
+	}
+}
+
+func TestNewAppKeepsInsightsInSidecarWhenSeparateStoreIsSet(t *testing.T) {
+	store := loadTestStore(t, "http://example.com/feed", "ai:\n  separate_store: true\n")
+	if repo := newApp(store).reading.HistoryRepo; !isAICache(repo) {
+		t.Fatalf("HistoryRepo = %T, want *aicache.Repository", repo)
+	}
+
+	store = loadTestStore(t, "http://example.com/feed", "")
+	if isAICache(newApp(store).reading.HistoryRepo) {
+		t.Fatal("the sidecar should only be used when ai.separate_store is set")
+	}
+}
+
+func TestNewAppRunsOnNewItemOnlyWhenConfigured(t *testing.T) {
+	store := loadTestStore(t, "http://example.com/feed", "on_new_item: notify-send \"$REAZY_TITLE\"\n")
+	if h := newApp(store).reading.NewItemHook; h == nil {
+		t.Fatal("on_new_item should set the new item hook")
+	} else if _, ok := h.(*hook.NewItemCommand); !ok {
+		t.Fatalf("NewItemHook = %T, want *hook.NewItemCommand", h)
+	}
+
+	store = loadTestStore(t, "http://example.com/feed", "")
+	if h := newApp(store).reading.NewItemHook; h != nil {
+		t.Fatalf("NewItemHook = %#v, want nil without on_new_item", h)
+	}
+}
+
+func TestNewAppAppliesMinContentLength(t *testing.T) {
+	store := loadTestStore(t, "http://example.com/feed", "min_content_length: 40\n")
+	if got := newApp(store).reading.ContentFilter.MinLength; got != 40 {
+		t.Fatalf("ContentFilter.MinLength = %d, want 40 without the TUI", got)
+	}
+}
+
+func TestNewAppAppliesNewsDigestLimits(t *testing.T) {
+	store := loadTestStore(t, "http://example.com/feed", "news_digest:\n  max_topics: 3\n  max_topic_articles: 4\n  merge_threshold: 0.5\n")
+	svc := newApp(store).newsDigests
+	if svc.MaxTopics != 3 || svc.MaxTopicArticles != 4 || svc.MergeThreshold != 0.5 {
+		t.Fatalf("limits = %d/%d/%v, want 3/4/0.5", svc.MaxTopics, svc.MaxTopicArticles, svc.MergeThreshold)
+	}
+}
+
+func TestNewAppChoosesFeedGroupingGenerator(t *testing.T) {
+	store := loadTestStore(t, "http://example.com/feed", "grouping:\n  strategy: heuristic\n")
+	store.Settings.Codex.Enabled = true
+	svc := newApp(store).feedGrouping
+	if _, ok := svc.Generator.(usecase.HeuristicFeedGrouping); !ok {
+		t.Fatalf("Generator = %T, want HeuristicFeedGrouping for the heuristic strategy", svc.Generator)
+	}
+	if svc.Fallback != nil {
+		t.Fatalf("Fallback = %T, want none when grouping is already heuristic", svc.Fallback)
+	}
+
+	store = loadTestStore(t, "http://example.com/feed", "ai:\n  fallback_when_unavailable: true\n")
+	store.Settings.Codex.Enabled = true
+	svc = newApp(store).feedGrouping
+	if _, ok := svc.Generator.(usecase.PromptFeedGroupingGenerator); !ok {
+		t.Fatalf("Generator = %T, want PromptFeedGroupingGenerator", svc.Generator)
+	}
+	if _, ok := svc.Fallback.(usecase.HeuristicFeedGrouping); !ok {
+		t.Fatalf("Fallback = %T, want HeuristicFeedGrouping", svc.Fallback)
+	}
+}
+
+func TestNewAppSharesOneTokenMeter(t *testing.T) {
+	app := newApp(loadTestStore(t, "http://example.com/feed", ""))
+	if app.tokens == nil || app.insights.Tokens != app.tokens || app.newsDigests.Tokens != app.tokens || app.feedGrouping.Tokens != app.tokens {
+		t.Fatal("every AI service should report to app.tokens")
+	}
+}
+
+func TestNewAppImportsOPMLFromURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer opml-token" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "text/x-opml")
+		fmt.Fprint(w, `<?xml version="1.0"?><opml version="2.0"><body><outline type="rss" text="Go" xmlUrl="https://go.dev/blog/feed.atom"/></body></opml>`)
+	}))
+	t.Cleanup(srv.Close)
+	store := loadTestStore(t, "http://example.com/feed", "opml_headers:\n  127.0.0.1:\n    Authorization: Bearer opml-token\n")
+
+	entries, err := newApp(store).subscriptions.DownloadOPML(t.Context(), srv.URL+"/subs.opml")
+	if err != nil {
+		t.Fatalf("DownloadOPML() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].URL != "https://go.dev/blog/feed.atom" {
+		t.Fatalf("entries = %+v, want the Go blog feed", entries)
+	}
+}
+
+func TestLegacyHistoryStatus(t *testing.T) {
+	tests := []struct {
+		count int
+		err   error
+		want  string
+	}{
+		{0, nil, ""},
+		{3, nil, "Imported 3 items from legacy history"},
+		{0, errors.New("bad file"), "Legacy history import skipped: bad file"},
+		{3, errors.New("rename failed"), "Imported 3 items from legacy history, but rename failed"},
+	}
+	for _, tt := range tests {
+		if got := legacyHistoryStatus(tt.count, true, tt.err); got != tt.want {
+			t.Errorf("legacyHistoryStatus(%d, %v) = %q, want %q", tt.count, tt.err, got, tt.want)
+		}
+	}
+}
+
+func isAICache(repo any) bool {
+	_, ok := repo.(*aicache.Repository)
+	return ok
+}
+
+// runCLI runs the reazy command line with args against the config at
+// configPath and returns what it printed.
+func runCLI(t *testing.T, configPath string, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	globals := cli{Out: &out}
+	parser, err := kong.New(&globals, kong.Name("reazy"), kong.Exit(func(int) { t.Fatal("the command line parser tried to exit") }))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	ctx, err := parser.Parse(append([]string{"--config", configPath}, args...))
+	if err != nil {
+		return "", err
+	}
+	err = ctx.Run(&globals)
+	return out.String(), err
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maintenanceCmd groups housekeeping commands for the history database.
+type maintenanceCmd struct {
+	Optimize optimizeCmd `cmd:"" help:"Compact the history database and rebuild its indexes"`
+}
+
+// optimizeCmd compacts the history database, e.g. after clearing history.
+type optimizeCmd struct{}
+
+// Run optimizes the history database and reports its size before and after.
+func (optimizeCmd) Run(globals *cli) error {
+	app, err := loadApp(globals.Config)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = app.reading.Close() }()
+
+	report, supported, err := app.reading.OptimizeHistory()
+	if !supported {
+		return errors.New("optimizing history is not supported")
+	}
+	if err != nil {
+		return fmt.Errorf("optimize history: %w", err)
+	}
+	_, err = fmt.Fprintf(globals.Out, "History optimized: %s -> %s\n", formatSize(report.SizeBefore), formatSize(report.SizeAfter))
+	return err
+}
+
+// formatSize renders a byte count with a binary unit, e.g. "1.5 MiB".
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value, suffix := float64(bytes)/unit, "KiB"
+	for _, next := range []string{"MiB", "GiB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
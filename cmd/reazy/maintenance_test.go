@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/infrastructure/config"
+)
+
+func TestOptimizeReportsHistorySize(t *testing.T) {
+	path := writeTestConfig(t, "http://example.com/feed", "")
+	store, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	app := newApp(store)
+	items := make([]*reading.HistoryItem, 0, 100)
+	for i := range 100 {
+		items = append(items, &reading.HistoryItem{GUID: fmt.Sprint(i), Kind: reading.ArticleKind, Content: strings.Repeat("body ", 200)})
+	}
+	if err := app.reading.HistoryRepo.Upsert(items); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if _, _, err := app.reading.ClearHistory(false); err != nil {
+		t.Fatalf("ClearHistory: %v", err)
+	}
+	_ = app.reading.Close()
+
+	var out bytes.Buffer
+	if err := (optimizeCmd{}).Run(&cli{Config: path, Out: &out}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	var before, after float64
+	var beforeUnit, afterUnit string
+	if _, err := fmt.Sscanf(out.String(), "History optimized: %f %s -> %f %s", &before, &beforeUnit, &after, &afterUnit); err != nil {
+		t.Fatalf("output = %q: %v", out.String(), err)
+	}
+	if beforeUnit == afterUnit && after >= before {
+		t.Fatalf("output = %q, want the cleared pages reclaimed", out.String())
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	for bytes, want := range map[int64]string{
+		512:              "512 B",
+		1536:             "1.5 KiB",
+		5 << 20:          "5.0 MiB",
+		3 << 30:          "3.0 GiB",
+		(3 << 30) * 2048: "6144.0 GiB",
+	} {
+		if got := formatSize(bytes); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", bytes, got, want)
+		}
+	}
+}
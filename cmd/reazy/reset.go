@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// resetCmd groups commands that delete saved data.
+type resetCmd struct {
+	History resetHistoryCmd `cmd:"" help:"Delete reading history"`
+}
+
+// resetHistoryCmd clears history like the reader's clear history dialog.
+type resetHistoryCmd struct {
+	KeepBookmarks bool `help:"Keep bookmarked and dismissed articles"`
+	DryRun        bool `help:"Show what would be deleted without deleting anything"`
+}
+
+// dryRunExamples caps the titles listed by a dry run.
+const dryRunExamples = 5
+
+// Run deletes history and compacts the database afterwards.
+func (c resetHistoryCmd) Run(globals *cli) error {
+	app, err := loadApp(globals.Config)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = app.reading.Close() }()
+
+	if c.DryRun {
+		return c.preview(app, globals)
+	}
+	_, supported, err := app.reading.ClearHistory(c.KeepBookmarks)
+	if !supported {
+		return errors.New("clearing history is not supported")
+	}
+	if err != nil {
+		return fmt.Errorf("clear history: %w", err)
+	}
+	// Best effort, as in the reader: the history is already cleared.
+	_, _, _ = app.reading.OptimizeHistory()
+	msg := "History cleared"
+	if c.KeepBookmarks {
+		msg += " (bookmarks kept)"
+	}
+	_, err = fmt.Fprintln(globals.Out, msg)
+	return err
+}
+
+// preview prints how many items a reset would delete with a few titles.
+func (c resetHistoryCmd) preview(app *app, globals *cli) error {
+	preview, supported, err := app.reading.PreviewClearHistory(c.KeepBookmarks, dryRunExamples)
+	if !supported {
+		return errors.New("previewing a history reset is not supported")
+	}
+	if err != nil {
+		return fmt.Errorf("preview history reset: %w", err)
+	}
+	noun := "items"
+	if preview.Count == 1 {
+		noun = "item"
+	}
+	lines := []string{fmt.Sprintf("Would delete %d %s", preview.Count, noun)}
+	for _, title := range preview.Examples {
+		if title = strings.TrimSpace(title); title == "" {
+			title = "(untitled)"
+		}
+		lines = append(lines, "  - "+title)
+	}
+	if more := preview.Count - len(preview.Examples); more > 0 && len(preview.Examples) > 0 {
+		lines = append(lines, fmt.Sprintf("  … and %d more", more))
+	}
+	_, err = fmt.Fprintln(globals.Out, strings.Join(lines, "\n"))
+	return err
+}
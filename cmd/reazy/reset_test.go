@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+)
+
+// seedHistory saves items to the history of the config at path.
+func seedHistory(t *testing.T, path string, items ...*reading.HistoryItem) {
+	t.Helper()
+	app, err := loadApp(path)
+	if err != nil {
+		t.Fatalf("loadApp: %v", err)
+	}
+	defer func() { _ = app.reading.Close() }()
+	if err := app.reading.HistoryRepo.Upsert(items); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+}
+
+// historyGUIDs returns the GUIDs saved in the history of the config at path.
+func historyGUIDs(t *testing.T, path string) map[string]bool {
+	t.Helper()
+	app, err := loadApp(path)
+	if err != nil {
+		t.Fatalf("loadApp: %v", err)
+	}
+	defer func() { _ = app.reading.Close() }()
+	history, err := app.reading.LoadHistoryMetadata()
+	if err != nil {
+		t.Fatalf("LoadHistoryMetadata: %v", err)
+	}
+	guids := make(map[string]bool)
+	for guid := range history.Items() {
+		guids[guid] = true
+	}
+	return guids
+}
+
+func TestResetHistory(t *testing.T) {
+	path := writeTestConfig(t, "http://example.com/feed", "")
+	seedHistory(t, path,
+		&reading.HistoryItem{GUID: "plain", Kind: reading.ArticleKind, FeedURL: "http://example.com/feed"},
+		&reading.HistoryItem{GUID: "saved", Kind: reading.ArticleKind, FeedURL: "http://example.com/feed", IsBookmarked: true},
+	)
+
+	out, err := runCLI(t, path, "reset", "history", "--keep-bookmarks")
+	if err != nil {
+		t.Fatalf("reset history --keep-bookmarks error = %v", err)
+	}
+	if out != "History cleared (bookmarks kept)\n" {
+		t.Fatalf("printed %q", out)
+	}
+	if guids := historyGUIDs(t, path); len(guids) != 1 || !guids["saved"] {
+		t.Fatalf("history = %v, want only the bookmark", guids)
+	}
+
+	out, err = runCLI(t, path, "reset", "history")
+	if err != nil {
+		t.Fatalf("reset history error = %v", err)
+	}
+	if out != "History cleared\n" {
+		t.Fatalf("printed %q", out)
+	}
+	if guids := historyGUIDs(t, path); len(guids) != 0 {
+		t.Fatalf("history = %v, want it empty", guids)
+	}
+}
+
+func TestResetHistoryDryRun(t *testing.T) {
+	path := writeTestConfig(t, "http://example.com/feed", "")
+	seedHistory(t, path,
+		&reading.HistoryItem{GUID: "plain", Title: "Plain", Kind: reading.ArticleKind, FeedURL: "http://example.com/feed"},
+		&reading.HistoryItem{GUID: "saved", Title: "Saved", Kind: reading.ArticleKind, FeedURL: "http://example.com/feed", IsBookmarked: true},
+	)
+
+	out, err := runCLI(t, path, "reset", "history", "--dry-run", "--keep-bookmarks")
+	if err != nil {
+		t.Fatalf("reset history --dry-run error = %v", err)
+	}
+	if out != "Would delete 1 item\n  - Plain\n" {
+		t.Fatalf("printed %q", out)
+	}
+	if guids := historyGUIDs(t, path); len(guids) != 2 {
+		t.Fatalf("history = %v, want nothing deleted", guids)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// addCmd subscribes to a feed from the command line.
+type addCmd struct {
+	URL string `arg:"" help:"Feed URL to subscribe to"`
+}
+
+// Run fetches the feed to check it, subscribes to it and saves its
+// articles, like adding a feed in the reader.
+func (c addCmd) Run(globals *cli) error {
+	app, err := loadApp(globals.Config)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = app.reading.Close() }()
+
+	url := strings.TrimSpace(c.URL)
+	feed, _, err := app.reading.FetchFeed(context.Background(), url, nil)
+	if err == nil && feed == nil {
+		err = errors.New("no feed found")
+	}
+	if err != nil {
+		return fmt.Errorf("%s is not a feed: %w", url, err)
+	}
+	if _, err := app.subscriptions.Add(url); err != nil {
+		return err
+	}
+	history, err := app.reading.LoadHistoryMetadata()
+	if err != nil {
+		return err
+	}
+	if _, err := app.reading.MergeHistory(history, feed); err != nil {
+		return err
+	}
+	name := url
+	if title := strings.TrimSpace(feed.Title); title != "" {
+		name = fmt.Sprintf("%s (%s)", title, url)
+	}
+	_, err = fmt.Fprintf(globals.Out, "Subscribed to %s\n", name)
+	return err
+}
+
+// rmCmd unsubscribes from a feed from the command line.
+type rmCmd struct {
+	URL string `arg:"" help:"Feed URL to unsubscribe from"`
+}
+
+// Run removes the subscription; its saved articles stay in history.
+func (c rmCmd) Run(globals *cli) error {
+	app, err := loadApp(globals.Config)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = app.reading.Close() }()
+
+	if _, err := app.subscriptions.RemoveURL(c.URL); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(globals.Out, "Unsubscribed from %s\n", strings.TrimSpace(c.URL))
+	return err
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/infrastructure/config"
+)
+
+func TestAddAndRemoveFeedFromTheCommandLine(t *testing.T) {
+	srv := newTestServer(t)
+	path := writeTestConfig(t, "http://example.com/feed", "")
+	feeds := func() []string {
+		t.Helper()
+		store, err := config.Load(path)
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		return store.Settings.FlattenedFeeds()
+	}
+
+	out, err := runCLI(t, path, "add", srv.URL+"/feed")
+	if err != nil {
+		t.Fatalf("add error = %v", err)
+	}
+	if want := "Subscribed to Example (" + srv.URL + "/feed)\n"; out != want {
+		t.Fatalf("add printed %q, want %q", out, want)
+	}
+	if !slices.Contains(feeds(), srv.URL+"/feed") {
+		t.Fatalf("feeds = %v, want the added feed", feeds())
+	}
+	app, err := loadApp(path)
+	if err != nil {
+		t.Fatalf("loadApp: %v", err)
+	}
+	item, err := app.reading.LoadHistoryItem("story-1")
+	_ = app.reading.Close()
+	if err != nil || item == nil {
+		t.Fatalf("the feed's articles should be saved, got %v, %v", item, err)
+	}
+
+	if _, err := runCLI(t, path, "add", srv.URL+"/feed"); !errors.Is(err, usecase.ErrFeedAlreadySubscribed) {
+		t.Fatalf("adding twice error = %v, want ErrFeedAlreadySubscribed", err)
+	}
+	if _, err := runCLI(t, path, "add", srv.URL+"/story"); err == nil || !strings.Contains(err.Error(), "is not a feed") {
+		t.Fatalf("adding a web page error = %v, want it rejected", err)
+	}
+
+	out, err = runCLI(t, path, "rm", srv.URL+"/feed/")
+	if err != nil {
+		t.Fatalf("rm error = %v", err)
+	}
+	if want := "Unsubscribed from " + srv.URL + "/feed/\n"; out != want {
+		t.Fatalf("rm printed %q, want %q", out, want)
+	}
+	if slices.Contains(feeds(), srv.URL+"/feed") {
+		t.Fatalf("feeds = %v, want the feed removed", feeds())
+	}
+	if _, err := runCLI(t, path, "rm", srv.URL+"/feed"); !errors.Is(err, usecase.ErrFeedNotSubscribed) {
+		t.Fatalf("removing twice error = %v, want ErrFeedNotSubscribed", err)
+	}
+}
@@ -1,28 +1,125 @@
 // Package settings defines application-level configuration data.
 package settings
 
-import "github.com/tesso57/reazy/internal/domain/subscription"
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/domain/subscription"
+)
 
 // KeyMapConfig defines the configuration for keybindings.
 type KeyMapConfig struct {
-	Up            string `yaml:"up" kong:"help='Up key',default='k'"`
-	Down          string `yaml:"down" kong:"help='Down key',default='j'"`
-	Left          string `yaml:"left" kong:"help='Left/Back key',default='h'"`
-	Right         string `yaml:"right" kong:"help='Right/Enter key',default='l'"`
-	UpPage        string `yaml:"up_page" kong:"help='Page Up key',default='ctrl+u'"`
-	DownPage      string `yaml:"down_page" kong:"help='Page Down key',default='ctrl+d'"`
-	Top           string `yaml:"top" kong:"help='Top key',default='g'"`
-	Bottom        string `yaml:"bottom" kong:"help='Bottom key',default='G'"`
-	Open          string `yaml:"open" kong:"help='Open key',default='enter'"`
-	Back          string `yaml:"back" kong:"help='Back key',default='esc'"`
-	Quit          string `yaml:"quit" kong:"help='Quit key',default='q'"`
-	AddFeed       string `yaml:"add_feed" kong:"help='Add feed key',default='a'"`
-	DeleteFeed    string `yaml:"delete_feed" kong:"help='Delete feed key',default='x'"`
-	GroupFeeds    string `yaml:"group_feeds" kong:"help='AI group feeds key',default='z'"`
-	Refresh       string `yaml:"refresh" kong:"help='Refresh key',default='r'"`
-	Bookmark      string `yaml:"bookmark" kong:"help='Bookmark key',default='b'"`
-	Summarize     string `yaml:"summarize" kong:"help='Generate AI summary/tags key',default='s'"`
-	ToggleSummary string `yaml:"toggle_summary" kong:"help='Toggle AI summary visibility key',default='S'"`
+	Up               string `yaml:"up" kong:"help='Up key',default='k'"`
+	Down             string `yaml:"down" kong:"help='Down key',default='j'"`
+	Left             string `yaml:"left" kong:"help='Left/Back key',default='h'"`
+	Right            string `yaml:"right" kong:"help='Right/Enter key',default='l'"`
+	UpPage           string `yaml:"up_page" kong:"help='Page Up key',default='ctrl+u'"`
+	DownPage         string `yaml:"down_page" kong:"help='Page Down key',default='ctrl+d'"`
+	HalfPageUp       string `yaml:"half_page_up" kong:"help='Scroll the article half a page up key',default='u'"`
+	HalfPageDown     string `yaml:"half_page_down" kong:"help='Scroll the article half a page down key',default='d'"`
+	Top              string `yaml:"top" kong:"help='Top key',default='g'"`
+	Bottom           string `yaml:"bottom" kong:"help='Bottom key',default='G'"`
+	Open             string `yaml:"open" kong:"help='Open key',default='enter'"`
+	Back             string `yaml:"back" kong:"help='Back key',default='esc'"`
+	Quit             string `yaml:"quit" kong:"help='Quit key',default='q'"`
+	AddFeed          string `yaml:"add_feed" kong:"help='Add feed key',default='a'"`
+	ImportFeeds      string `yaml:"import_feeds" kong:"help='Import feeds from OPML key',default='I'"`
+	DeleteFeed       string `yaml:"delete_feed" kong:"help='Delete feed key',default='x'"`
+	MarkFeedRead     string `yaml:"mark_feed_read" kong:"help='Mark every article of the selected feed read key',default='m'"`
+	GroupFeeds       string `yaml:"group_feeds" kong:"help='AI group feeds key',default='z'"`
+	Undo             string `yaml:"undo" kong:"help='Undo last AI feed grouping key',default='u'"`
+	ClearHistory     string `yaml:"clear_history" kong:"help='Clear reading history key',default='X'"`
+	ManageFeeds      string `yaml:"manage_feeds" kong:"help='Manage feeds screen key',default='M'"`
+	MoveToGroup      string `yaml:"move_to_group" kong:"help='Move the selected feed to another group key (Manage Feeds)',default='v'"`
+	RenameGroup      string `yaml:"rename_group" kong:"help='Rename the group of the selected feed key (Manage Feeds)',default='n'"`
+	Refresh          string `yaml:"refresh" kong:"help='Refresh key',default='r'"`
+	RefreshAll       string `yaml:"refresh_all" kong:"help='Refresh every feed, then build the daily news key',default='R'"`
+	RefreshGroup     string `yaml:"refresh_group" kong:"help='Refresh every feed in the group of the selected feed key',default='ctrl+r'"`
+	Bookmark         string `yaml:"bookmark" kong:"help='Bookmark key',default='b'"`
+	Summarize        string `yaml:"summarize" kong:"help='Generate AI summary/tags key',default='s'"`
+	NavBack          string `yaml:"nav_back" kong:"help='Go back to the previously opened article key',default='['"`
+	NavForward       string `yaml:"nav_forward" kong:"help='Go forward to the next opened article key',default=']'"`
+	AskAI            string `yaml:"ask_ai" kong:"help='Ask AI a question about the open article key',default='E'"`
+	SummarizeMissing string `yaml:"summarize_missing" kong:"help='Generate AI summaries for every listed article without one key',default='A'"`
+	ToggleSummary    string `yaml:"toggle_summary" kong:"help='Toggle AI summary visibility key',default='S'"`
+	ExpandRow        string `yaml:"expand_row" kong:"help='Show the full description under the selected article key',default='e'"`
+	ToggleTitles     string `yaml:"toggle_titles" kong:"help='Toggle original/translated related titles key',default='T'"`
+	UnreadFeeds      string `yaml:"unread_feeds" kong:"help='Show only feeds with unread articles key',default='U'"`
+	FetchFullText    string `yaml:"fetch_full_text" kong:"help='Fetch full article text (reader mode) key',default='F'"`
+	ToggleFocus      string `yaml:"toggle_focus" kong:"help='Toggle focus mode (article only) key',default='f'"`
+	DetailSearch     string `yaml:"detail_search" kong:"help='Search the open article key',default='/'"`
+	DetailNext       string `yaml:"detail_next" kong:"help='Jump to the next article search match key',default='n'"`
+	DetailPrev       string `yaml:"detail_prev" kong:"help='Jump to the previous article search match key',default='N'"`
+	OpenRandom       string `yaml:"open_random" kong:"help='Open a random unread article key',default='o'"`
+	GotoFeed         string `yaml:"goto_feed" kong:"help='Jump to a feed by typing its number key',default=':'"`
+	Snooze           string `yaml:"snooze" kong:"help='Snooze article key',default='Z'"`
+	Dismiss          string `yaml:"dismiss" kong:"help='Dismiss (or restore) article key',default='D'"`
+	ToggleSelect     string `yaml:"toggle_select" kong:"help='Select or unselect the highlighted article for export key',default='V'"`
+	ExportSelected   string `yaml:"export_selected" kong:"help='Export the selected articles as one Markdown file key',default='Y'"`
+	TagStats         string `yaml:"tag_stats" kong:"help='Show the most common AI tags in history key',default='i'"`
+}
+
+// DefaultKeyMapConfig returns the documented default keybindings. It mirrors
+// the kong defaults so settings built without the CLI parser stay usable.
+func DefaultKeyMapConfig() KeyMapConfig {
+	return KeyMapConfig{
+		Up:               "k",
+		Down:             "j",
+		Left:             "h",
+		Right:            "l",
+		UpPage:           "ctrl+u",
+		DownPage:         "ctrl+d",
+		HalfPageUp:       "u",
+		HalfPageDown:     "d",
+		Top:              "g",
+		Bottom:           "G",
+		Open:             "enter",
+		Back:             "esc",
+		Quit:             "q",
+		AddFeed:          "a",
+		ImportFeeds:      "I",
+		DeleteFeed:       "x",
+		MarkFeedRead:     "m",
+		GroupFeeds:       "z",
+		Undo:             "u",
+		ClearHistory:     "X",
+		ManageFeeds:      "M",
+		MoveToGroup:      "v",
+		RenameGroup:      "n",
+		Refresh:          "r",
+		RefreshAll:       "R",
+		RefreshGroup:     "ctrl+r",
+		Bookmark:         "b",
+		Summarize:        "s",
+		SummarizeMissing: "A",
+		AskAI:            "E",
+		NavBack:          "[",
+		NavForward:       "]",
+		ToggleSummary:    "S",
+		ExpandRow:        "e",
+		ToggleTitles:     "T",
+		UnreadFeeds:      "U",
+		FetchFullText:    "F",
+		ToggleFocus:      "f",
+		DetailSearch:     "/",
+		DetailNext:       "n",
+		DetailPrev:       "N",
+		OpenRandom:       "o",
+		GotoFeed:         ":",
+		Snooze:           "Z",
+		Dismiss:          "D",
+		ToggleSelect:     "V",
+		ExportSelected:   "Y",
+		TagStats:         "i",
+	}
 }
 
 // ThemeConfig defines the color theme configuration.
@@ -43,14 +140,442 @@ type CodexConfig struct {
 	Sandbox          string `yaml:"sandbox" kong:"help='Sandbox mode (read-only/workspace-write/danger-full-access)',default='read-only'"`
 }
 
+// AIConfig defines behavior shared by all AI features.
+type AIConfig struct {
+	FallbackWhenUnavailable bool `yaml:"fallback_when_unavailable" kong:"help='Use non-AI fallbacks when AI generation fails',default='false'"`
+	AutoSummarizeOnOpen     bool `yaml:"auto_summarize_on_open" kong:"help='Generate an AI summary when opening an article that has none',default='false'"`
+	SeparateStore           bool `yaml:"separate_store" kong:"help='Keep AI summaries and tags in ai_cache.json next to the history database',default='false'"`
+}
+
+// NewsDigestConfig bounds the daily news digest built from AI output.
+type NewsDigestConfig struct {
+	MaxTopics         int     `yaml:"max_topics" kong:"help='Maximum topics kept from one daily news generation',default='20'"`
+	MaxTopicArticles  int     `yaml:"max_topic_articles" kong:"help='Maximum related articles kept per news topic',default='10'"`
+	MergeThreshold    float64 `yaml:"merge_threshold" kong:"help='Merge news topics whose articles overlap at least this much (0-1, 0 disables)',default='0.8'"`
+	FallbackToToday   bool    `yaml:"fallback_to_today" kong:"help='List the articles published today in the News tab when AI is disabled',default='true'"`
+	GenerateOnStartup bool    `yaml:"generate_on_startup" kong:"help='Build the daily news digest in the background at startup, reusing one already made today',default='false'"`
+	RememberSelection bool    `yaml:"remember_selection" kong:"help='Reselect the last opened topic of the latest digest when the News tab is shown, across restarts',default='false'"`
+}
+
+// BookmarksConfig defines how bookmarked articles are shown.
+type BookmarksConfig struct {
+	IgnoreReadDim bool `yaml:"ignore_read_dim" kong:"help='Keep read bookmarks at full brightness in the Bookmarks view',default='false'"`
+}
+
+// GroupingConfig defines AI feed grouping behavior.
+type GroupingConfig struct {
+	PreserveManual bool   `yaml:"preserve_manual" kong:"help='Keep existing feed groups and only group ungrouped feeds',default='false'"`
+	MinFeeds       int    `yaml:"min_feeds" kong:"help='Minimum number of feeds before feed grouping is offered',default='2'"`
+	Strategy       string `yaml:"strategy" kong:"help='Feed grouping strategy (ai/heuristic)',default='ai'"`
+
+	Keywords map[string][]string `yaml:"keywords,omitempty" kong:"-"`
+}
+
+// FeedOptions holds per-feed preferences keyed by feed URL.
+type FeedOptions struct {
+	// FullText fetches the article page for items that only carry an excerpt.
+	FullText bool `yaml:"full_text"`
+	// Title replaces the feed URL in the sidebar.
+	Title string `yaml:"title,omitempty"`
+	// Tags records category hints, e.g. those an OPML import carried.
+	Tags []string `yaml:"tags,omitempty"`
+	// MinContentLength replaces Settings.MinContentLength for the feed when
+	// positive.
+	MinContentLength int `yaml:"min_content_length,omitempty"`
+}
+
+// IsZero reports whether no option is set.
+func (o FeedOptions) IsZero() bool {
+	return !o.FullText && o.Title == "" && len(o.Tags) == 0 && o.MinContentLength == 0
+}
+
+// FeedGroupingCache stores the last AI feed grouping input hash and result.
+type FeedGroupingCache struct {
+	InputHash string                   `yaml:"input_hash"`
+	Groups    []subscription.FeedGroup `yaml:"groups"`
+	Ungrouped []string                 `yaml:"ungrouped"`
+}
+
+// NewsSelection records the News topic opened last and the digest day it
+// belongs to.
+type NewsSelection struct {
+	Date string `yaml:"date"`
+	GUID string `yaml:"guid"`
+}
+
 // Settings represents the application configuration.
 type Settings struct {
-	Feeds       []string                 `yaml:"feeds" kong:"help='RSS/Atom Feed URLs',default='https://news.ycombinator.com/rss'"`
-	FeedGroups  []subscription.FeedGroup `yaml:"feed_groups"`
-	KeyMap      KeyMapConfig             `yaml:"keymap" kong:"embed,prefix='keymap.'"`
-	Theme       ThemeConfig              `yaml:"theme" kong:"embed,prefix='theme.'"`
-	Codex       CodexConfig              `yaml:"codex" kong:"embed,prefix='codex.'"`
-	HistoryFile string                   `yaml:"history_file" kong:"help='History file path'"`
+	Feeds                    []string                 `yaml:"feeds" kong:"help='RSS/Atom Feed URLs',default='https://news.ycombinator.com/rss'"`
+	FeedGroups               []subscription.FeedGroup `yaml:"feed_groups"`
+	KeyMap                   KeyMapConfig             `yaml:"keymap" kong:"embed,prefix='keymap.'"`
+	Theme                    ThemeConfig              `yaml:"theme" kong:"embed,prefix='theme.'"`
+	Codex                    CodexConfig              `yaml:"codex" kong:"embed,prefix='codex.'"`
+	AI                       AIConfig                 `yaml:"ai" kong:"embed,prefix='ai.'"`
+	Grouping                 GroupingConfig           `yaml:"grouping" kong:"embed,prefix='grouping.'"`
+	NewsDigest               NewsDigestConfig         `yaml:"news_digest" kong:"embed,prefix='news_digest.'"`
+	Bookmarks                BookmarksConfig          `yaml:"bookmarks" kong:"embed,prefix='bookmarks.'"`
+	ReadingWidth             int                      `yaml:"reading_width" kong:"help='Maximum article body width in columns (0 = full width)',default='0'"`
+	MinReadingWidth          int                      `yaml:"min_reading_width" kong:"help='Reading pane width below which the detail view hides the sidebar (0 = never)',default='0'"`
+	PageSize                 int                      `yaml:"page_size" kong:"help='Articles moved per page key (0 = terminal height)',default='0'"`
+	WrapListNavigation       bool                     `yaml:"wrap_list_navigation" kong:"help='Wrap up/down navigation around list ends',default='false'"`
+	ShowAISummaryDefault     bool                     `yaml:"show_ai_summary_default" kong:"help='Show the AI summary section in article details (toggled and saved with Shift+S)',default='true'"`
+	SectionHeaderFormat      string                   `yaml:"section_header_format" kong:"help='Date section header template using {label} and {count}',default='== {label} ({count}) =='"`
+	FeedTagMaxChars          int                      `yaml:"feed_tag_max_chars" kong:"help='Maximum width in columns of the [feed] tag in All Feeds rows (0 = no limit)',default='24'"`
+	MinContentLength         int                      `yaml:"min_content_length" kong:"help='Mark new articles read when their title and description together are shorter than this many characters (0 = off)',default='0'"`
+	ExpandRowLines           int                      `yaml:"expand_row_lines" kong:"help='Maximum lines of description shown under an expanded article row',default='3'"`
+	MaxArticleAge            string                   `yaml:"max_article_age" kong:"help='Hide articles older than this from feed views, e.g. 30d, 2w or 72h (0 = show all)',default='0'"`
+	CollapseDuplicateTitles  bool                     `yaml:"collapse_duplicate_titles" kong:"help='Show same-title articles from several feeds as one All Feeds row with a source count',default='false'"`
+	ResolveRelativeLinks     bool                     `yaml:"resolve_relative_links" kong:"help='Rewrite relative links in article text against the article URL',default='true'"`
+	FeedPreview              bool                     `yaml:"feed_preview" kong:"help='Show the newest unread article title of the highlighted feed in the header',default='true'"`
+	Icons                    string                   `yaml:"icons" kong:"help='Indicator glyphs in the header and lists (emoji/ascii/nerdfont)',default='emoji'"`
+	DefaultOpenAction        string                   `yaml:"default_open_action" kong:"help='What opening an article does (detail/browser)',default='detail'"`
+	MarkReadViews            []string                 `yaml:"mark_read_views" kong:"help='Views where opening an article marks it read (all/news/bookmarks/dismissed/feeds)',default='all,news,dismissed,feeds'"`
+	BuiltinTabs              []string                 `yaml:"builtin_tabs" kong:"help='Built-in sidebar tabs in display order (all/news/bookmarks/dismissed)',default='all,news,bookmarks'"`
+	GroupSort                string                   `yaml:"group_sort" kong:"help='Sidebar feed group order (manual/alpha/unread-desc)',default='manual'"`
+	FilterExit               string                   `yaml:"filter_exit" kong:"help='How to leave list filtering (jj/esc)',default='jj'"`
+	FollowPermanentRedirects bool                     `yaml:"follow_permanent_redirects" kong:"help='Update subscriptions without asking when a feed permanently redirects',default='false'"`
+	ValidateNewFeeds         bool                     `yaml:"validate_new_feeds" kong:"help='Fetch a feed before subscribing to check it is a valid RSS/Atom feed',default='true'"`
+	ControlSocket            string                   `yaml:"control_socket" kong:"help='Local control API address: a unix socket path or localhost:port (empty = disabled)'"`
+	ControlToken             string                   `yaml:"control_token" kong:"help='Bearer token control API requests must send (required for a localhost:port control_socket)'"`
+	HistoryFile              string                   `yaml:"history_file" kong:"help='History file path'"`
+	ExportDir                string                   `yaml:"export_dir" kong:"help='Directory for Markdown exports of selected articles (empty = current directory)'"`
+	OnNewItem                string                   `yaml:"on_new_item" kong:"help='Shell command run for each new unread article, with its details in REAZY_* environment variables (empty = disabled)'"`
+	OnNewItemPerMinute       int                      `yaml:"on_new_item_per_minute" kong:"help='Maximum runs of on_new_item per minute; articles beyond it are skipped',default='10'"`
+	LoadingTimeoutSeconds    int                      `yaml:"loading_timeout_seconds" kong:"help='Seconds the loading spinner may run before it stops with a timeout message (0 = never)',default='120'"`
+	ShutdownTimeoutSeconds   int                      `yaml:"shutdown_timeout_seconds" kong:"help='Seconds quitting waits for background saves to finish',default='3'"`
+
+	ContentStripPatterns []string `yaml:"content_strip_patterns,omitempty" kong:"-"`
+	// ContentSanitizer holds ContentStripPatterns compiled when the config
+	// was loaded.
+	ContentSanitizer *reading.ContentSanitizer `yaml:"-" kong:"-"`
+	FeedOptions      map[string]FeedOptions    `yaml:"feed_options,omitempty" kong:"-"`
+	// OPMLHeaders holds request headers, keyed by host, sent when importing
+	// an OPML list from a URL on that host.
+	OPMLHeaders       map[string]map[string]string `yaml:"opml_headers,omitempty" kong:"-"`
+	FeedGroupingCache *FeedGroupingCache           `yaml:"feed_grouping_cache,omitempty" kong:"-"`
+	NewsSelection     *NewsSelection               `yaml:"news_selection,omitempty" kong:"-"`
+}
+
+const (
+	// OpenActionDetail opens articles in the in-app detail view.
+	OpenActionDetail = "detail"
+	// OpenActionBrowser opens articles directly in the browser.
+	OpenActionBrowser = "browser"
+)
+
+// OpensInBrowser reports whether opening an article should skip the detail view.
+func (s Settings) OpensInBrowser() bool {
+	return strings.EqualFold(strings.TrimSpace(s.DefaultOpenAction), OpenActionBrowser)
+}
+
+// ValidateOpenAction checks that a default_open_action value is known.
+// Empty means OpenActionDetail.
+func ValidateOpenAction(action string) error {
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "", OpenActionDetail, OpenActionBrowser:
+		return nil
+	}
+	return fmt.Errorf("unknown action %q (use %s or %s)", action, OpenActionDetail, OpenActionBrowser)
+}
+
+// ParseArticleAge parses a max_article_age value: a whole number of days
+// ("30d") or weeks ("2w"), or a Go duration ("72h"). Empty or "0" means no
+// limit and returns zero.
+func ParseArticleAge(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(value, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	var age time.Duration
+	if unit > 0 {
+		count, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid age %q (use e.g. 30d, 2w or 72h)", value)
+		}
+		age = time.Duration(count) * unit
+	} else {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid age %q (use e.g. 30d, 2w or 72h)", value)
+		}
+		age = parsed
+	}
+	if age < 0 {
+		return 0, fmt.Errorf("age %q must not be negative", value)
+	}
+	return age, nil
+}
+
+// ArticleMaxAge returns the parsed MaxArticleAge, or zero (no limit) when it
+// is unset or invalid.
+func (s Settings) ArticleMaxAge() time.Duration {
+	age, _ := ParseArticleAge(s.MaxArticleAge)
+	return age
+}
+
+const (
+	// MarkReadViewAll is the aggregated All Feeds view.
+	MarkReadViewAll = "all"
+	// MarkReadViewNews is the daily News view and its topics.
+	MarkReadViewNews = "news"
+	// MarkReadViewBookmarks is the Bookmarks view.
+	MarkReadViewBookmarks = "bookmarks"
+	// MarkReadViewDismissed is the Dismissed view.
+	MarkReadViewDismissed = "dismissed"
+	// MarkReadViewFeeds covers individual subscribed feeds.
+	MarkReadViewFeeds = "feeds"
+)
+
+// DefaultMarkReadViews returns the views that mark articles read on open when
+// MarkReadViews is unset. Previewing a bookmark leaves it unread.
+func DefaultMarkReadViews() []string {
+	return []string{MarkReadViewAll, MarkReadViewNews, MarkReadViewDismissed, MarkReadViewFeeds}
+}
+
+// MarksReadOnOpen reports whether opening an article in view marks it read.
+func (s Settings) MarksReadOnOpen(view string) bool {
+	views := s.MarkReadViews
+	if views == nil {
+		views = DefaultMarkReadViews()
+	}
+	for _, v := range views {
+		if strings.EqualFold(strings.TrimSpace(v), view) {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// BuiltinTabAll is the aggregated All Feeds tab.
+	BuiltinTabAll = "all"
+	// BuiltinTabNews is the daily News tab.
+	BuiltinTabNews = "news"
+	// BuiltinTabBookmarks is the Bookmarks tab.
+	BuiltinTabBookmarks = "bookmarks"
+	// BuiltinTabDismissed is the Dismissed tab. It is hidden unless listed.
+	BuiltinTabDismissed = "dismissed"
+)
+
+// DefaultBuiltinTabs returns the built-in sidebar tabs shown when BuiltinTabs
+// is unset.
+func DefaultBuiltinTabs() []string {
+	return []string{BuiltinTabAll, BuiltinTabNews, BuiltinTabBookmarks}
+}
+
+// EnabledBuiltinTabs returns the built-in sidebar tabs to show, in order and
+// lowercased. Tabs left out of BuiltinTabs are hidden.
+func (s Settings) EnabledBuiltinTabs() []string {
+	if s.BuiltinTabs == nil {
+		return DefaultBuiltinTabs()
+	}
+	tabs := make([]string, 0, len(s.BuiltinTabs))
+	for _, tab := range s.BuiltinTabs {
+		if tab = strings.ToLower(strings.TrimSpace(tab)); tab != "" {
+			tabs = append(tabs, tab)
+		}
+	}
+	return tabs
+}
+
+// ValidateBuiltinTabs checks that every tab is a known built-in tab and is
+// listed at most once.
+func ValidateBuiltinTabs(tabs []string) error {
+	seen := make(map[string]bool, len(tabs))
+	for _, tab := range tabs {
+		tab = strings.ToLower(strings.TrimSpace(tab))
+		if tab == "" {
+			continue
+		}
+		if !slices.Contains(DefaultBuiltinTabs(), tab) && tab != BuiltinTabDismissed {
+			return fmt.Errorf("unknown tab %q (use %s, %s, %s or %s)", tab, BuiltinTabAll, BuiltinTabNews, BuiltinTabBookmarks, BuiltinTabDismissed)
+		}
+		if seen[tab] {
+			return fmt.Errorf("tab %q is listed twice", tab)
+		}
+		seen[tab] = true
+	}
+	return nil
+}
+
+// ParseControlSocket splits a control_socket value into a network and an
+// address for net.Listen. Values starting with "unix:" or containing a slash
+// are unix socket paths; anything else must be host:port on a loopback host.
+// An empty value disables the control API and returns an empty network.
+func ParseControlSocket(value string) (network, address string, err error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", "", nil
+	}
+	if path, ok := strings.CutPrefix(value, "unix:"); ok {
+		if path == "" {
+			return "", "", errors.New("unix socket path is empty")
+		}
+		return "unix", path, nil
+	}
+	if strings.Contains(value, "/") {
+		return "unix", value, nil
+	}
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		return "", "", fmt.Errorf("want a socket path or localhost:port: %w", err)
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", "", fmt.Errorf("invalid port %q", port)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return "", "", fmt.Errorf("host %q is not a loopback address", host)
+	}
+	return "tcp", value, nil
+}
+
+const (
+	// GroupSortManual keeps feed groups in configured order.
+	GroupSortManual = "manual"
+	// GroupSortAlpha orders feed groups by name.
+	GroupSortAlpha = "alpha"
+	// GroupSortUnreadDesc puts feed groups with more unread articles first.
+	GroupSortUnreadDesc = "unread-desc"
+)
+
+// ValidateGroupSort checks that a group_sort value is known. Empty means
+// GroupSortManual.
+func ValidateGroupSort(groupSort string) error {
+	switch groupSort {
+	case "", GroupSortManual, GroupSortAlpha, GroupSortUnreadDesc:
+		return nil
+	}
+	return fmt.Errorf("unknown order %q (use %s, %s or %s)", groupSort, GroupSortManual, GroupSortAlpha, GroupSortUnreadDesc)
+}
+
+const (
+	// IconsEmoji draws indicators with emoji.
+	IconsEmoji = "emoji"
+	// IconsASCII draws indicators with plain ASCII for minimal terminals.
+	IconsASCII = "ascii"
+	// IconsNerdFont draws indicators with Nerd Font icons.
+	IconsNerdFont = "nerdfont"
+)
+
+// NormalizeIcons lowercases and trims an icons value. Empty means IconsEmoji.
+func NormalizeIcons(icons string) string {
+	icons = strings.ToLower(strings.TrimSpace(icons))
+	if icons == "" {
+		return IconsEmoji
+	}
+	return icons
+}
+
+// ValidateIcons checks that an icons value names a known glyph set.
+func ValidateIcons(icons string) error {
+	switch NormalizeIcons(icons) {
+	case IconsEmoji, IconsASCII, IconsNerdFont:
+		return nil
+	}
+	return fmt.Errorf("unknown glyph set %q (use %s, %s or %s)", icons, IconsEmoji, IconsASCII, IconsNerdFont)
+}
+
+const (
+	// FilterExitJJ leaves list filtering by typing "jj", like a vim insert-mode escape.
+	FilterExitJJ = "jj"
+	// FilterExitEsc leaves list filtering with the Back key.
+	FilterExitEsc = "esc"
+)
+
+// ExitsFilterWithEsc reports whether the Back key, rather than "jj", clears a list filter.
+func (s Settings) ExitsFilterWithEsc() bool {
+	return strings.EqualFold(strings.TrimSpace(s.FilterExit), FilterExitEsc)
+}
+
+// FeedTitles returns the display names set for feeds, keyed by feed URL.
+func (s Settings) FeedTitles() map[string]string {
+	titles := make(map[string]string)
+	for url, opts := range s.FeedOptions {
+		if title := strings.TrimSpace(opts.Title); title != "" {
+			titles[url] = title
+		}
+	}
+	return titles
+}
+
+// FullTextFeeds returns the feed URLs that opted into full-text extraction.
+func (s Settings) FullTextFeeds() map[string]bool {
+	feeds := make(map[string]bool)
+	for url, opts := range s.FeedOptions {
+		if opts.FullText {
+			feeds[url] = true
+		}
+	}
+	return feeds
+}
+
+// MinContentLengths returns the per-feed minimum article lengths set in
+// feed_options, keyed by feed URL.
+func (s Settings) MinContentLengths() map[string]int {
+	lengths := make(map[string]int)
+	for url, opts := range s.FeedOptions {
+		if opts.MinContentLength > 0 {
+			lengths[url] = opts.MinContentLength
+		}
+	}
+	return lengths
+}
+
+const (
+	// SectionHeaderLabel is replaced by the section's date label.
+	SectionHeaderLabel = "{label}"
+	// SectionHeaderCount is replaced by the number of articles in the section.
+	SectionHeaderCount = "{count}"
+	// DefaultSectionHeaderFormat renders headers like "== 2026-02-14 (Sat) (3) ==".
+	DefaultSectionHeaderFormat = "== {label} ({count}) =="
+)
+
+var sectionHeaderPlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ValidateSectionHeaderFormat checks that a section header template shows
+// the label and uses no placeholders other than {label} and {count}.
+func ValidateSectionHeaderFormat(format string) error {
+	if !strings.Contains(format, SectionHeaderLabel) {
+		return fmt.Errorf("must contain %s", SectionHeaderLabel)
+	}
+	for _, placeholder := range sectionHeaderPlaceholder.FindAllString(format, -1) {
+		if placeholder != SectionHeaderLabel && placeholder != SectionHeaderCount {
+			return fmt.Errorf("unknown placeholder %s (use %s or %s)", placeholder, SectionHeaderLabel, SectionHeaderCount)
+		}
+	}
+	if strings.ContainsAny(format, "\n\r") {
+		return errors.New("must be a single line")
+	}
+	return nil
+}
+
+const (
+	// GroupingStrategyAI groups feeds with the configured AI generator.
+	GroupingStrategyAI = "ai"
+	// GroupingStrategyHeuristic groups feeds by keyword and registrable domain without AI.
+	GroupingStrategyHeuristic = "heuristic"
+)
+
+// GroupsHeuristically reports whether feed grouping should run without AI.
+func (s Settings) GroupsHeuristically() bool {
+	return strings.EqualFold(strings.TrimSpace(s.Grouping.Strategy), GroupingStrategyHeuristic)
+}
+
+// ValidateGroupingStrategy checks that a grouping.strategy value is known.
+// Empty means GroupingStrategyAI.
+func ValidateGroupingStrategy(strategy string) error {
+	switch strings.ToLower(strings.TrimSpace(strategy)) {
+	case "", GroupingStrategyAI, GroupingStrategyHeuristic:
+		return nil
+	}
+	return fmt.Errorf("unknown strategy %q (use %s or %s)", strategy, GroupingStrategyAI, GroupingStrategyHeuristic)
 }
 
 // FlattenedFeeds returns grouped feeds first, then ungrouped feeds.
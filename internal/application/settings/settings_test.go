@@ -1,7 +1,10 @@
 package settings
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/tesso57/reazy/internal/domain/subscription"
 )
@@ -30,3 +33,233 @@ func TestSettings_FlattenedFeeds(t *testing.T) {
 		}
 	}
 }
+
+func TestSettings_OpensInBrowser(t *testing.T) {
+	tests := []struct {
+		action string
+		want   bool
+	}{
+		{action: "", want: false},
+		{action: OpenActionDetail, want: false},
+		{action: OpenActionBrowser, want: true},
+		{action: " Browser ", want: true},
+	}
+	for _, tt := range tests {
+		if got := (Settings{DefaultOpenAction: tt.action}).OpensInBrowser(); got != tt.want {
+			t.Fatalf("OpensInBrowser(%q) = %v, want %v", tt.action, got, tt.want)
+		}
+	}
+}
+
+func TestSettings_ExitsFilterWithEsc(t *testing.T) {
+	tests := []struct {
+		exit string
+		want bool
+	}{
+		{exit: "", want: false},
+		{exit: FilterExitJJ, want: false},
+		{exit: FilterExitEsc, want: true},
+		{exit: " ESC ", want: true},
+	}
+	for _, tt := range tests {
+		if got := (Settings{FilterExit: tt.exit}).ExitsFilterWithEsc(); got != tt.want {
+			t.Fatalf("ExitsFilterWithEsc(%q) = %v, want %v", tt.exit, got, tt.want)
+		}
+	}
+}
+
+func TestSettings_MarksReadOnOpen(t *testing.T) {
+	defaults := Settings{}
+	if !defaults.MarksReadOnOpen(MarkReadViewFeeds) || !defaults.MarksReadOnOpen(MarkReadViewAll) || !defaults.MarksReadOnOpen(MarkReadViewNews) || !defaults.MarksReadOnOpen(MarkReadViewDismissed) {
+		t.Fatal("unset MarkReadViews should mark read in feeds, all, news and dismissed")
+	}
+	if defaults.MarksReadOnOpen(MarkReadViewBookmarks) {
+		t.Fatal("unset MarkReadViews should not mark bookmarks read")
+	}
+
+	custom := Settings{MarkReadViews: []string{" Bookmarks "}}
+	if !custom.MarksReadOnOpen(MarkReadViewBookmarks) || custom.MarksReadOnOpen(MarkReadViewFeeds) {
+		t.Fatalf("custom MarkReadViews not honored: %#v", custom.MarkReadViews)
+	}
+	if (Settings{MarkReadViews: []string{}}).MarksReadOnOpen(MarkReadViewFeeds) {
+		t.Fatal("an empty MarkReadViews list should disable marking read on open")
+	}
+}
+
+func TestDefaultKeyMapConfig_MatchesKongDefaults(t *testing.T) {
+	defaults := reflect.ValueOf(DefaultKeyMapConfig())
+	typ := defaults.Type()
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		tag := field.Tag.Get("kong")
+		_, after, ok := strings.Cut(tag, "default='")
+		if !ok {
+			t.Fatalf("%s has no kong default", field.Name)
+		}
+		want, _, _ := strings.Cut(after, "'")
+		if got := defaults.Field(i).String(); got != want {
+			t.Fatalf("DefaultKeyMapConfig().%s = %q, want kong default %q", field.Name, got, want)
+		}
+	}
+}
+
+func TestSettings_GroupsHeuristically(t *testing.T) {
+	for strategy, want := range map[string]bool{
+		"":                        false,
+		GroupingStrategyAI:        false,
+		GroupingStrategyHeuristic: true,
+		" Heuristic ":             true,
+	} {
+		if got := (Settings{Grouping: GroupingConfig{Strategy: strategy}}).GroupsHeuristically(); got != want {
+			t.Fatalf("GroupsHeuristically(%q) = %v, want %v", strategy, got, want)
+		}
+	}
+}
+
+func TestValidateSectionHeaderFormat(t *testing.T) {
+	for format, wantErr := range map[string]bool{
+		DefaultSectionHeaderFormat: false,
+		"── {label} ──":            false,
+		"{label}":                  false,
+		"{count} articles":         true,
+		"{label} {date}":           true,
+		"{label}\n{count}":         true,
+		"":                         true,
+	} {
+		if err := ValidateSectionHeaderFormat(format); (err != nil) != wantErr {
+			t.Fatalf("ValidateSectionHeaderFormat(%q) error = %v, wantErr %v", format, err, wantErr)
+		}
+	}
+}
+
+func TestSettings_EnabledBuiltinTabs(t *testing.T) {
+	if got := (Settings{}).EnabledBuiltinTabs(); !reflect.DeepEqual(got, DefaultBuiltinTabs()) {
+		t.Fatalf("unset BuiltinTabs = %#v, want defaults", got)
+	}
+	custom := Settings{BuiltinTabs: []string{" Bookmarks ", "", "all"}}
+	if got := custom.EnabledBuiltinTabs(); !reflect.DeepEqual(got, []string{BuiltinTabBookmarks, BuiltinTabAll}) {
+		t.Fatalf("EnabledBuiltinTabs() = %#v", got)
+	}
+	if got := (Settings{BuiltinTabs: []string{}}).EnabledBuiltinTabs(); len(got) != 0 {
+		t.Fatalf("an empty BuiltinTabs list should hide every tab, got %#v", got)
+	}
+}
+
+func TestValidateBuiltinTabs(t *testing.T) {
+	tests := []struct {
+		tabs    []string
+		wantErr bool
+	}{
+		{tabs: nil},
+		{tabs: []string{"bookmarks", "News"}},
+		{tabs: []string{"all", "dismissed"}},
+		{tabs: []string{"today"}, wantErr: true},
+		{tabs: []string{"all", " ALL "}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := ValidateBuiltinTabs(tt.tabs); (err != nil) != tt.wantErr {
+			t.Fatalf("ValidateBuiltinTabs(%#v) error = %v, wantErr %v", tt.tabs, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseControlSocket(t *testing.T) {
+	tests := []struct {
+		value       string
+		wantNetwork string
+		wantAddress string
+		wantErr     bool
+	}{
+		{value: "", wantNetwork: "", wantAddress: ""},
+		{value: "/run/user/1000/reazy.sock", wantNetwork: "unix", wantAddress: "/run/user/1000/reazy.sock"},
+		{value: "~/.reazy.sock", wantNetwork: "unix", wantAddress: "~/.reazy.sock"},
+		{value: "unix:reazy.sock", wantNetwork: "unix", wantAddress: "reazy.sock"},
+		{value: "localhost:7373", wantNetwork: "tcp", wantAddress: "localhost:7373"},
+		{value: "127.0.0.1:7373", wantNetwork: "tcp", wantAddress: "127.0.0.1:7373"},
+		{value: "[::1]:7373", wantNetwork: "tcp", wantAddress: "[::1]:7373"},
+		{value: "unix:", wantErr: true},
+		{value: "0.0.0.0:7373", wantErr: true},
+		{value: "example.com:7373", wantErr: true},
+		{value: "localhost:http", wantErr: true},
+		{value: "7373", wantErr: true},
+	}
+	for _, tt := range tests {
+		network, address, err := ParseControlSocket(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseControlSocket(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+		if network != tt.wantNetwork || address != tt.wantAddress {
+			t.Fatalf("ParseControlSocket(%q) = %q, %q, want %q, %q", tt.value, network, address, tt.wantNetwork, tt.wantAddress)
+		}
+	}
+}
+
+func TestValidateIcons(t *testing.T) {
+	for _, value := range []string{"", IconsEmoji, "ASCII", IconsNerdFont} {
+		if err := ValidateIcons(value); err != nil {
+			t.Fatalf("ValidateIcons(%q) error = %v", value, err)
+		}
+	}
+	if err := ValidateIcons("unicode"); err == nil {
+		t.Fatal("ValidateIcons() should reject unknown glyph sets")
+	}
+}
+
+func TestValidateGroupSort(t *testing.T) {
+	for _, value := range []string{"", GroupSortManual, GroupSortAlpha, GroupSortUnreadDesc} {
+		if err := ValidateGroupSort(value); err != nil {
+			t.Fatalf("ValidateGroupSort(%q) error = %v", value, err)
+		}
+	}
+	if err := ValidateGroupSort("unread"); err == nil {
+		t.Fatal("ValidateGroupSort() should reject unknown orders")
+	}
+}
+
+func TestValidateOpenAction(t *testing.T) {
+	for _, value := range []string{"", OpenActionDetail, OpenActionBrowser, "Browser"} {
+		if err := ValidateOpenAction(value); err != nil {
+			t.Fatalf("ValidateOpenAction(%q) error = %v", value, err)
+		}
+	}
+	if err := ValidateOpenAction("brwoser"); err == nil {
+		t.Fatal("ValidateOpenAction() should reject unknown actions")
+	}
+}
+
+func TestValidateGroupingStrategy(t *testing.T) {
+	for _, value := range []string{"", GroupingStrategyAI, GroupingStrategyHeuristic, "Heuristic"} {
+		if err := ValidateGroupingStrategy(value); err != nil {
+			t.Fatalf("ValidateGroupingStrategy(%q) error = %v", value, err)
+		}
+	}
+	if err := ValidateGroupingStrategy("heuristics"); err == nil {
+		t.Fatal("ValidateGroupingStrategy() should reject unknown strategies")
+	}
+}
+
+func TestParseArticleAge(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "0", want: 0},
+		{value: "30d", want: 30 * 24 * time.Hour},
+		{value: " 2W ", want: 14 * 24 * time.Hour},
+		{value: "72h", want: 72 * time.Hour},
+		{value: "month", wantErr: true},
+		{value: "xd", wantErr: true},
+		{value: "-3d", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseArticleAge(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Fatalf("ParseArticleAge(%q) = %v, %v; want %v, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+	if got := (Settings{MaxArticleAge: "1w"}).ArticleMaxAge(); got != 7*24*time.Hour {
+		t.Fatalf("ArticleMaxAge() = %v", got)
+	}
+}
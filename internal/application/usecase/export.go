@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+)
+
+// ErrExportUnavailable is returned when no export writer is configured.
+var ErrExportUnavailable = errors.New("article export is not available")
+
+// MarkdownConverter turns an article body, which feeds usually deliver as
+// HTML, into Markdown.
+type MarkdownConverter interface {
+	ToMarkdown(body string) string
+}
+
+// ExportWriter stores an exported Markdown document and returns where it
+// went, e.g. a file path.
+type ExportWriter interface {
+	WriteExport(markdown string) (string, error)
+}
+
+// ExportReport describes a finished article export.
+type ExportReport struct {
+	Articles int
+	Location string
+}
+
+// FormatArticleMarkdown renders one article as a Markdown section. The body
+// goes through convert when it is set and is used as is otherwise.
+func FormatArticleMarkdown(item *reading.HistoryItem, convert MarkdownConverter) string {
+	if item == nil {
+		return ""
+	}
+	title := strings.TrimSpace(item.Title)
+	if title == "" {
+		title = "(untitled)"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", title)
+	if feed := strings.TrimSpace(item.FeedTitle); feed != "" {
+		fmt.Fprintf(&b, "- Feed: %s\n", feed)
+	}
+	if published := strings.TrimSpace(item.Published); published != "" {
+		fmt.Fprintf(&b, "- Published: %s\n", published)
+	}
+	if link := strings.TrimSpace(item.Link); link != "" {
+		fmt.Fprintf(&b, "- Link: <%s>\n", link)
+	}
+	if summary := strings.TrimSpace(item.AISummary); summary != "" {
+		fmt.Fprintf(&b, "\n> %s\n", strings.ReplaceAll(summary, "\n", "\n> "))
+	}
+	body := item.Content
+	if strings.TrimSpace(body) == "" {
+		body = item.Description
+	}
+	if convert != nil {
+		body = convert.ToMarkdown(body)
+	}
+	if body = strings.TrimSpace(body); body != "" {
+		fmt.Fprintf(&b, "\n%s\n", body)
+	}
+	return b.String()
+}
+
+// FormatArticlesMarkdown renders several articles as one Markdown document,
+// one section per article in the given order.
+func FormatArticlesMarkdown(items []*reading.HistoryItem, convert MarkdownConverter) string {
+	sections := make([]string, 0, len(items))
+	for _, item := range items {
+		if section := FormatArticleMarkdown(item, convert); section != "" {
+			sections = append(sections, section)
+		}
+	}
+	return strings.Join(sections, "\n---\n\n")
+}
+
+// ExportArticles loads the given articles from history and writes them as
+// one Markdown document through Exports. Unknown GUIDs are skipped.
+func (s *ReadingService) ExportArticles(guids []string) (ExportReport, error) {
+	if s.Exports == nil {
+		return ExportReport{}, ErrExportUnavailable
+	}
+	items := make([]*reading.HistoryItem, 0, len(guids))
+	for _, guid := range guids {
+		item, err := s.LoadHistoryItem(guid)
+		if err != nil {
+			return ExportReport{}, err
+		}
+		if item != nil {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return ExportReport{}, errors.New("no articles to export")
+	}
+	location, err := s.Exports.WriteExport(FormatArticlesMarkdown(items, s.Markdown))
+	if err != nil {
+		return ExportReport{}, err
+	}
+	return ExportReport{Articles: len(items), Location: location}, nil
+}
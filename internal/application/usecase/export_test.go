@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+)
+
+type upperConverter struct{}
+
+func (upperConverter) ToMarkdown(body string) string { return strings.ToUpper(body) }
+
+type recordingExportWriter struct {
+	markdown string
+}
+
+func (w *recordingExportWriter) WriteExport(markdown string) (string, error) {
+	w.markdown = markdown
+	return "/tmp/export.md", nil
+}
+
+func TestFormatArticleMarkdown(t *testing.T) {
+	got := FormatArticleMarkdown(&reading.HistoryItem{
+		Title:     "Go 1.26",
+		FeedTitle: "Go Blog",
+		Published: "2026-02-14",
+		Link:      "https://go.dev/blog/go1.26",
+		AISummary: "Release notes.\nMore details.",
+		Content:   "Body",
+	}, upperConverter{})
+	want := "## Go 1.26\n\n- Feed: Go Blog\n- Published: 2026-02-14\n- Link: <https://go.dev/blog/go1.26>\n\n> Release notes.\n> More details.\n\nBODY\n"
+	if got != want {
+		t.Fatalf("FormatArticleMarkdown() = %q, want %q", got, want)
+	}
+}
+
+func TestReadingService_ExportArticles(t *testing.T) {
+	repo := &mockHistoryRepo{}
+	repo.On("LoadByGUID", "a").Return(&reading.HistoryItem{GUID: "a", Title: "First", Content: "first body"}, nil).Once()
+	repo.On("LoadByGUID", "b").Return(&reading.HistoryItem{GUID: "b", Title: "Second", Description: "second excerpt"}, nil).Once()
+	repo.On("LoadByGUID", "missing").Return(nil, nil).Once()
+	writer := &recordingExportWriter{}
+	svc := NewReadingService(nil, repo, nil)
+	svc.Markdown = upperConverter{}
+	svc.Exports = writer
+
+	report, err := svc.ExportArticles([]string{"b", "a", "missing"})
+	if err != nil {
+		t.Fatalf("ExportArticles() error = %v", err)
+	}
+	if report.Articles != 2 || report.Location != "/tmp/export.md" {
+		t.Fatalf("report = %+v", report)
+	}
+	got := writer.markdown
+	if strings.Count(got, "## ") != 2 || !strings.Contains(got, "FIRST BODY") || !strings.Contains(got, "SECOND EXCERPT") {
+		t.Fatalf("unexpected export:\n%s", got)
+	}
+	if strings.Index(got, "## Second") > strings.Index(got, "## First") {
+		t.Fatalf("articles should keep the requested order:\n%s", got)
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestReadingService_ExportArticlesUnavailable(t *testing.T) {
+	svc := NewReadingService(nil, &mockHistoryRepo{}, nil)
+	if _, err := svc.ExportArticles([]string{"a"}); !errors.Is(err, ErrExportUnavailable) {
+		t.Fatalf("err = %v, want ErrExportUnavailable", err)
+	}
+}
@@ -3,17 +3,27 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/tesso57/reazy/internal/domain/subscription"
+	"golang.org/x/net/publicsuffix"
 )
 
 const maxFeedGroupingFeeds = 200
 
+// DefaultFeedGroupingInterval is the least time between two AI grouping
+// requests made by a service from NewFeedGroupingService.
+const DefaultFeedGroupingInterval = time.Minute
+
 // FeedGroupingFeed is one input feed for AI grouping.
 type FeedGroupingFeed struct {
 	URL  string `json:"url"`
@@ -21,15 +31,37 @@ type FeedGroupingFeed struct {
 	Path string `json:"path"`
 }
 
+// FeedGroupingKeepGroup is an existing group the generator must leave intact.
+type FeedGroupingKeepGroup struct {
+	Name  string   `json:"name"`
+	Feeds []string `json:"feeds"`
+}
+
 // FeedGroupingRequest is the payload for AI feed grouping.
 type FeedGroupingRequest struct {
-	Feeds []FeedGroupingFeed `json:"feeds"`
+	Feeds      []FeedGroupingFeed      `json:"feeds"`
+	KeepGroups []FeedGroupingKeepGroup `json:"keep_groups,omitempty"`
 }
 
 // FeedGroupingResult contains grouped and ungrouped feeds.
 type FeedGroupingResult struct {
 	Groups    []subscription.FeedGroup
 	Ungrouped []string
+	InputHash string
+	UsedCache bool
+	// UsedFallback reports that the generator failed and Fallback grouped the feeds.
+	UsedFallback bool
+	// Heuristic reports that the groups were built without AI.
+	Heuristic bool
+	// UnknownFeeds counts distinct suggested feed URLs that were not in the input.
+	UnknownFeeds int
+}
+
+// FeedGroupingCache stores the last grouping input hash and its result.
+type FeedGroupingCache struct {
+	InputHash string
+	Groups    []subscription.FeedGroup
+	Ungrouped []string
 }
 
 // FeedGroupingGenerator abstracts AI grouping generation.
@@ -40,11 +72,24 @@ type FeedGroupingGenerator interface {
 // FeedGroupingService coordinates AI grouping.
 type FeedGroupingService struct {
 	Generator FeedGroupingGenerator
+	// Fallback, when set, groups feeds if Generator returns an error.
+	Fallback FeedGroupingGenerator
+	// Tokens, when set, totals the estimated prompt tokens sent to the AI.
+	Tokens *TokenMeter
+	// MinInterval, when positive, rejects AI grouping requests made sooner
+	// than this after the last successful one. Heuristic grouping and cache
+	// hits are not limited.
+	MinInterval time.Duration
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+
+	mu          sync.Mutex
+	lastRequest time.Time
 }
 
 // NewFeedGroupingService constructs a FeedGroupingService.
 func NewFeedGroupingService(generator FeedGroupingGenerator) *FeedGroupingService {
-	return new(FeedGroupingService{Generator: generator})
+	return new(FeedGroupingService{Generator: generator, MinInterval: DefaultFeedGroupingInterval})
 }
 
 // Enabled reports whether AI grouping is available.
@@ -64,12 +109,12 @@ func (s *FeedGroupingService) Group(ctx context.Context, feeds []string) (FeedGr
 	}
 
 	req := buildFeedGroupingRequest(normalizedFeeds)
-	suggestedGroups, err := s.Generator.Generate(ctx, req)
+	suggestedGroups, usedFallback, err := s.generate(ctx, req)
 	if err != nil {
 		return FeedGroupingResult{}, err
 	}
 
-	groups := normalizeSuggestedFeedGroups(suggestedGroups, normalizedFeeds)
+	groups, unknownFeeds := normalizeSuggestedFeedGroups(suggestedGroups, normalizedFeeds)
 	if len(groups) == 0 {
 		return FeedGroupingResult{}, errors.New("feed grouping returned no valid groups")
 	}
@@ -90,11 +135,299 @@ func (s *FeedGroupingService) Group(ctx context.Context, feeds []string) (FeedGr
 	}
 
 	return FeedGroupingResult{
-		Groups:    groups,
-		Ungrouped: ungrouped,
+		Groups:       groups,
+		Ungrouped:    ungrouped,
+		InputHash:    FeedGroupingInputHash(normalizedFeeds),
+		UsedFallback: usedFallback,
+		Heuristic:    usedFallback || s.heuristic(),
+		UnknownFeeds: unknownFeeds,
 	}, nil
 }
 
+// GroupPreserving organizes only feeds outside keepGroups and merges the
+// suggestions into the kept groups, which are never reassigned.
+func (s *FeedGroupingService) GroupPreserving(ctx context.Context, feeds []string, keepGroups []subscription.FeedGroup) (FeedGroupingResult, error) {
+	if len(keepGroups) == 0 {
+		return s.Group(ctx, feeds)
+	}
+	if !s.Enabled() {
+		return FeedGroupingResult{}, errors.New("codex integration is disabled")
+	}
+
+	normalizedFeeds := normalizeFeedURLList(feeds)
+	kept := make(map[string]struct{}, len(normalizedFeeds))
+	for _, group := range keepGroups {
+		for _, feedURL := range group.Feeds {
+			kept[strings.TrimSpace(feedURL)] = struct{}{}
+		}
+	}
+	candidates := make([]string, 0, len(normalizedFeeds))
+	for _, feedURL := range normalizedFeeds {
+		if _, ok := kept[feedURL]; ok {
+			continue
+		}
+		candidates = append(candidates, feedURL)
+	}
+	if len(candidates) == 0 {
+		return FeedGroupingResult{}, errors.New("no ungrouped feeds to organize")
+	}
+
+	req := buildFeedGroupingRequest(candidates)
+	for _, group := range keepGroups {
+		req.KeepGroups = append(req.KeepGroups, FeedGroupingKeepGroup{
+			Name:  group.Name,
+			Feeds: slices.Clone(group.Feeds),
+		})
+	}
+	suggestedGroups, usedFallback, err := s.generate(ctx, req)
+	if err != nil {
+		return FeedGroupingResult{}, err
+	}
+
+	// Kept feeds echoed back in the suggestions are known, just not movable,
+	// so they are dropped before unknown feeds are counted.
+	suggested, unknownFeeds := normalizeSuggestedFeedGroups(withoutFeeds(suggestedGroups, kept), candidates)
+	if len(suggested) == 0 {
+		return FeedGroupingResult{}, errors.New("feed grouping returned no valid groups")
+	}
+
+	groups := mergeFeedGroups(keepGroups, suggested)
+	assigned := make(map[string]struct{}, len(candidates))
+	for _, group := range suggested {
+		for _, feedURL := range group.Feeds {
+			assigned[feedURL] = struct{}{}
+		}
+	}
+	ungrouped := make([]string, 0, len(candidates))
+	for _, feedURL := range candidates {
+		if _, ok := assigned[feedURL]; ok {
+			continue
+		}
+		ungrouped = append(ungrouped, feedURL)
+	}
+
+	return FeedGroupingResult{
+		Groups:       groups,
+		Ungrouped:    ungrouped,
+		InputHash:    feedGroupingInputHash(normalizedFeeds, keepGroups),
+		UsedFallback: usedFallback,
+		Heuristic:    usedFallback || s.heuristic(),
+		UnknownFeeds: unknownFeeds,
+	}, nil
+}
+
+// heuristic reports whether the primary generator groups feeds without AI.
+func (s *FeedGroupingService) heuristic() bool {
+	_, ok := s.Generator.(HeuristicFeedGrouping)
+	return ok
+}
+
+func (s *FeedGroupingService) generate(ctx context.Context, req FeedGroupingRequest) ([]subscription.FeedGroup, bool, error) {
+	heuristic := s.heuristic()
+	if !heuristic {
+		if err := s.checkInterval(); err != nil {
+			return nil, false, err
+		}
+		s.Tokens.Add(req.EstimatedTokens())
+	}
+	groups, err := s.Generator.Generate(ctx, req)
+	if err == nil && !heuristic {
+		s.recordRequest()
+	}
+	if err == nil || s.Fallback == nil {
+		return groups, false, err
+	}
+	fallbackGroups, fallbackErr := s.Fallback.Generate(ctx, req)
+	if fallbackErr != nil {
+		return nil, false, errors.Join(err, fallbackErr)
+	}
+	return fallbackGroups, true, nil
+}
+
+func (s *FeedGroupingService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// checkInterval fails when the last successful AI grouping was less than
+// MinInterval ago. Failed requests do not count, so a retry is allowed.
+func (s *FeedGroupingService) checkInterval() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.MinInterval <= 0 || s.lastRequest.IsZero() {
+		return nil
+	}
+	if wait := s.MinInterval - s.now().Sub(s.lastRequest); wait > 0 {
+		return fmt.Errorf("AI grouping ran recently; try again in %s", wait.Round(time.Second))
+	}
+	return nil
+}
+
+func (s *FeedGroupingService) recordRequest() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastRequest = s.now()
+}
+
+// HeuristicFeedGrouping groups feeds without AI. Feeds whose host or path
+// contains one of a group's Keywords join that group; the rest are grouped by
+// registrable domain, leaving domains with a single feed ungrouped.
+type HeuristicFeedGrouping struct {
+	// Keywords maps group names to case-insensitive keywords.
+	Keywords map[string][]string
+}
+
+// Generate implements FeedGroupingGenerator.
+func (g HeuristicFeedGrouping) Generate(_ context.Context, req FeedGroupingRequest) ([]subscription.FeedGroup, error) {
+	keywordGroups := make(map[string]*subscription.FeedGroup, len(g.Keywords))
+	domainGroups := map[string]*subscription.FeedGroup{}
+	var keywordOrder, domainOrder []string
+	for _, feed := range req.Feeds {
+		if name, ok := g.matchKeyword(feed); ok {
+			if _, exists := keywordGroups[name]; !exists {
+				keywordGroups[name] = &subscription.FeedGroup{Name: name}
+				keywordOrder = append(keywordOrder, name)
+			}
+			keywordGroups[name].Feeds = append(keywordGroups[name].Feeds, feed.URL)
+			continue
+		}
+		domain := registrableDomain(feed.Host)
+		if domain == "" {
+			continue
+		}
+		if _, exists := domainGroups[domain]; !exists {
+			domainGroups[domain] = &subscription.FeedGroup{Name: domain}
+			domainOrder = append(domainOrder, domain)
+		}
+		domainGroups[domain].Feeds = append(domainGroups[domain].Feeds, feed.URL)
+	}
+
+	result := make([]subscription.FeedGroup, 0, len(keywordOrder)+len(domainOrder))
+	for _, name := range keywordOrder {
+		result = append(result, *keywordGroups[name])
+	}
+	for _, domain := range domainOrder {
+		if group := domainGroups[domain]; len(group.Feeds) >= 2 {
+			result = append(result, *group)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.New("no feeds share a domain or keyword")
+	}
+	return result, nil
+}
+
+// matchKeyword returns the first group, in name order, with a keyword found
+// in the feed host or path.
+func (g HeuristicFeedGrouping) matchKeyword(feed FeedGroupingFeed) (string, bool) {
+	if len(g.Keywords) == 0 {
+		return "", false
+	}
+	target := strings.ToLower(feed.Host + "/" + feed.Path)
+	names := make([]string, 0, len(g.Keywords))
+	for name := range g.Keywords {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		trimmedName := strings.TrimSpace(name)
+		if trimmedName == "" {
+			continue
+		}
+		for _, keyword := range g.Keywords[name] {
+			keyword = strings.ToLower(strings.TrimSpace(keyword))
+			if keyword != "" && strings.Contains(target, keyword) {
+				return trimmedName, true
+			}
+		}
+	}
+	return "", false
+}
+
+func registrableDomain(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+	if host == "" {
+		return ""
+	}
+	if domain, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return domain
+	}
+	return host
+}
+
+// GroupWithCache reuses the cached result when the input is unchanged,
+// otherwise it generates new groups like GroupPreserving. Heuristic grouping
+// is cheap and deterministic, so it always regenerates.
+func (s *FeedGroupingService) GroupWithCache(ctx context.Context, feeds []string, keepGroups []subscription.FeedGroup, cache FeedGroupingCache) (FeedGroupingResult, error) {
+	inputHash := feedGroupingInputHash(feeds, keepGroups)
+	if !s.heuristic() && cache.InputHash != "" && cache.InputHash == inputHash && len(cache.Groups) > 0 {
+		return FeedGroupingResult{
+			Groups:    cloneFeedGroupList(cache.Groups),
+			Ungrouped: slices.Clone(cache.Ungrouped),
+			InputHash: inputHash,
+			UsedCache: true,
+		}, nil
+	}
+	return s.GroupPreserving(ctx, feeds, keepGroups)
+}
+
+// FeedGroupingInputHash returns a stable hash of the normalized, sorted feed set.
+func FeedGroupingInputHash(feeds []string) string {
+	return feedGroupingInputHash(feeds, nil)
+}
+
+func feedGroupingInputHash(feeds []string, keepGroups []subscription.FeedGroup) string {
+	normalized := normalizeFeedURLList(feeds)
+	slices.Sort(normalized)
+	lines := normalized
+	for _, group := range keepGroups {
+		groupFeeds := slices.Clone(group.Feeds)
+		slices.Sort(groupFeeds)
+		lines = append(lines, "keep:"+strings.TrimSpace(group.Name)+"="+strings.Join(groupFeeds, ","))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// mergeFeedGroups appends suggested feeds to kept groups with the same name
+// (case-insensitive) and adds the remaining suggestions as new groups.
+func mergeFeedGroups(keepGroups, suggested []subscription.FeedGroup) []subscription.FeedGroup {
+	merged := cloneFeedGroupList(keepGroups)
+	indexByName := make(map[string]int, len(merged))
+	for idx, group := range merged {
+		indexByName[strings.ToLower(strings.TrimSpace(group.Name))] = idx
+	}
+	for _, group := range suggested {
+		key := strings.ToLower(strings.TrimSpace(group.Name))
+		if idx, ok := indexByName[key]; ok {
+			merged[idx].Feeds = append(merged[idx].Feeds, group.Feeds...)
+			continue
+		}
+		merged = append(merged, subscription.FeedGroup{
+			Name:  group.Name,
+			Feeds: slices.Clone(group.Feeds),
+		})
+		indexByName[key] = len(merged) - 1
+	}
+	return merged
+}
+
+func cloneFeedGroupList(groups []subscription.FeedGroup) []subscription.FeedGroup {
+	if len(groups) == 0 {
+		return nil
+	}
+	out := make([]subscription.FeedGroup, 0, len(groups))
+	for _, group := range groups {
+		out = append(out, subscription.FeedGroup{
+			Name:  group.Name,
+			Feeds: slices.Clone(group.Feeds),
+		})
+	}
+	return out
+}
+
 func normalizeFeedURLList(feeds []string) []string {
 	if len(feeds) == 0 {
 		return nil
@@ -138,9 +471,24 @@ func buildFeedGroupingRequest(feeds []string) FeedGroupingRequest {
 	return result
 }
 
-func normalizeSuggestedFeedGroups(groups []subscription.FeedGroup, feeds []string) []subscription.FeedGroup {
+// withoutFeeds returns groups with the feeds in drop removed.
+func withoutFeeds(groups []subscription.FeedGroup, drop map[string]struct{}) []subscription.FeedGroup {
+	out := make([]subscription.FeedGroup, 0, len(groups))
+	for _, group := range groups {
+		feeds := slices.DeleteFunc(slices.Clone(group.Feeds), func(feedURL string) bool {
+			_, ok := drop[strings.TrimSpace(feedURL)]
+			return ok
+		})
+		out = append(out, subscription.FeedGroup{Name: group.Name, Feeds: feeds})
+	}
+	return out
+}
+
+// normalizeSuggestedFeedGroups keeps valid input feeds in suggested groups and
+// reports how many distinct unknown feed URLs were dropped.
+func normalizeSuggestedFeedGroups(groups []subscription.FeedGroup, feeds []string) ([]subscription.FeedGroup, int) {
 	if len(groups) == 0 || len(feeds) == 0 {
-		return nil
+		return nil, 0
 	}
 
 	validFeeds := make(map[string]struct{}, len(feeds))
@@ -149,6 +497,7 @@ func normalizeSuggestedFeedGroups(groups []subscription.FeedGroup, feeds []strin
 	}
 
 	assigned := make(map[string]struct{}, len(feeds))
+	unknown := map[string]struct{}{}
 	result := make([]subscription.FeedGroup, 0, len(groups))
 	groupIndexByName := map[string]int{}
 
@@ -166,6 +515,7 @@ func normalizeSuggestedFeedGroups(groups []subscription.FeedGroup, feeds []strin
 				continue
 			}
 			if _, ok := validFeeds[feedURL]; !ok {
+				unknown[feedURL] = struct{}{}
 				continue
 			}
 			if _, alreadyAssigned := assigned[feedURL]; alreadyAssigned {
@@ -197,7 +547,7 @@ func normalizeSuggestedFeedGroups(groups []subscription.FeedGroup, feeds []strin
 		}
 	}
 
-	return result
+	return result, len(unknown)
 }
 
 // PromptFeedGroupingGenerator builds prompts and parses feed grouping JSON output.
@@ -233,9 +583,15 @@ func buildFeedGroupingPrompt(req FeedGroupingRequest) string {
 			Path: strings.TrimSpace(feed.Path),
 		})
 	}
+	for _, group := range req.KeepGroups {
+		payload.KeepGroups = append(payload.KeepGroups, FeedGroupingKeepGroup{
+			Name:  strings.TrimSpace(group.Name),
+			Feeds: group.Feeds,
+		})
+	}
 	data, _ := json.Marshal(payload)
 
-	return strings.Join([]string{
+	lines := []string{
 		"You are helping an RSS reader organize feed subscriptions.",
 		"Propose concise feed groups based on feed URL/host/path hints.",
 		`Return ONLY valid JSON without markdown: {"groups":[{"name":"...","feeds":["..."]}]}`,
@@ -245,9 +601,15 @@ func buildFeedGroupingPrompt(req FeedGroupingRequest) string {
 		"- each feed must appear in at most one group.",
 		"- omit uncertain feeds instead of forcing a wrong group.",
 		"- return the best possible grouping even if partial.",
-		"Input JSON:",
-		string(data),
-	}, "\n")
+	}
+	if len(payload.KeepGroups) > 0 {
+		lines = append(lines,
+			"- keep_groups are the user's own groups: keep them as they are.",
+			"- you may add input feeds to a keep_groups name when it clearly fits.",
+		)
+	}
+	lines = append(lines, "Input JSON:", string(data))
+	return strings.Join(lines, "\n")
 }
 
 func parseFeedGroupingOutput(raw string) ([]subscription.FeedGroup, error) {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/tesso57/reazy/internal/domain/subscription"
@@ -61,6 +62,42 @@ func TestFeedGroupingService_Group(t *testing.T) {
 	if len(got.Ungrouped) != 1 || got.Ungrouped[0] != "https://planetpython.org/rss20.xml" {
 		t.Fatalf("ungrouped = %#v, want [planetpython]", got.Ungrouped)
 	}
+	if got.UnknownFeeds != 1 {
+		t.Fatalf("UnknownFeeds = %d, want 1", got.UnknownFeeds)
+	}
+	gen.AssertExpectations(t)
+}
+
+func TestFeedGroupingService_GroupWithCache(t *testing.T) {
+	feeds := []string{"https://a.example.com/rss", "https://b.example.com/rss"}
+	cache := FeedGroupingCache{
+		InputHash: FeedGroupingInputHash([]string{feeds[1], feeds[0]}),
+		Groups:    []subscription.FeedGroup{{Name: "Cached", Feeds: []string{feeds[0]}}},
+		Ungrouped: []string{feeds[1]},
+	}
+
+	gen := &mockFeedGroupingGenerator{}
+	svc := NewFeedGroupingService(gen)
+	result, err := svc.GroupWithCache(context.Background(), feeds, nil, cache)
+	if err != nil {
+		t.Fatalf("GroupWithCache() error = %v", err)
+	}
+	if !result.UsedCache || len(result.Groups) != 1 || result.Groups[0].Name != "Cached" {
+		t.Fatalf("unexpected cached result: %#v", result)
+	}
+	gen.AssertNotCalled(t, "Generate", mock.Anything, mock.Anything)
+
+	gen.On("Generate", mock.Anything, mock.Anything).Return([]subscription.FeedGroup{
+		{Name: "Fresh", Feeds: []string{"https://c.example.com/rss"}},
+	}, nil).Once()
+	changed := append(append([]string(nil), feeds...), "https://c.example.com/rss")
+	result, err = svc.GroupWithCache(context.Background(), changed, nil, cache)
+	if err != nil {
+		t.Fatalf("GroupWithCache() error = %v", err)
+	}
+	if result.UsedCache || result.InputHash != FeedGroupingInputHash(changed) {
+		t.Fatalf("expected fresh grouping, got %#v", result)
+	}
 	gen.AssertExpectations(t)
 }
 
@@ -107,6 +144,131 @@ func TestFeedGroupingService_GroupErrors(t *testing.T) {
 	gen.AssertExpectations(t)
 }
 
+func TestFeedGroupingService_GroupPreserving(t *testing.T) {
+	keep := []subscription.FeedGroup{
+		{Name: "Manual", Feeds: []string{"https://a.example.com/rss"}},
+	}
+	gen := &mockFeedGroupingGenerator{}
+	gen.On("Generate", mock.Anything, mock.MatchedBy(func(req FeedGroupingRequest) bool {
+		return len(req.Feeds) == 2 &&
+			req.Feeds[0].URL == "https://b.example.com/rss" &&
+			len(req.KeepGroups) == 1 && req.KeepGroups[0].Name == "Manual"
+	})).Return([]subscription.FeedGroup{
+		{Name: "manual", Feeds: []string{"https://b.example.com/rss"}},
+		{Name: "Takeover", Feeds: []string{"https://a.example.com/rss"}},
+	}, nil).Once()
+	svc := NewFeedGroupingService(gen)
+
+	result, err := svc.GroupPreserving(context.Background(), []string{
+		"https://a.example.com/rss",
+		"https://b.example.com/rss",
+		"https://c.example.com/rss",
+	}, keep)
+	if err != nil {
+		t.Fatalf("GroupPreserving() error = %v", err)
+	}
+	if len(result.Groups) != 1 || result.Groups[0].Name != "Manual" {
+		t.Fatalf("unexpected groups: %#v", result.Groups)
+	}
+	if got := result.Groups[0].Feeds; len(got) != 2 || got[0] != "https://a.example.com/rss" || got[1] != "https://b.example.com/rss" {
+		t.Fatalf("unexpected merged feeds: %#v", got)
+	}
+	if len(result.Ungrouped) != 1 || result.Ungrouped[0] != "https://c.example.com/rss" {
+		t.Fatalf("unexpected ungrouped: %#v", result.Ungrouped)
+	}
+	if result.UnknownFeeds != 0 {
+		t.Fatalf("UnknownFeeds = %d, want kept feeds not counted as unknown", result.UnknownFeeds)
+	}
+	if result.InputHash == FeedGroupingInputHash([]string{"https://a.example.com/rss", "https://b.example.com/rss", "https://c.example.com/rss"}) {
+		t.Fatal("preserving hash should differ from full regroup hash")
+	}
+	gen.AssertExpectations(t)
+
+	if _, err := svc.GroupPreserving(context.Background(), []string{"https://a.example.com/rss"}, keep); err == nil {
+		t.Fatal("expected error when every feed is already grouped")
+	}
+}
+
+func TestFeedGroupingService_FallbackWhenGeneratorFails(t *testing.T) {
+	gen := &mockFeedGroupingGenerator{}
+	gen.On("Generate", mock.Anything, mock.Anything).Return(nil, errors.New("codex unreachable")).Twice()
+	svc := NewFeedGroupingService(gen)
+	feeds := []string{
+		"https://github.com/golang/go/releases.atom",
+		"https://www.github.com/rust-lang/rust/releases.atom",
+		"https://planetpython.org/rss20.xml",
+	}
+
+	if _, err := svc.Group(context.Background(), feeds); err == nil {
+		t.Fatal("Group() without fallback should return the generator error")
+	}
+
+	svc.Fallback = HeuristicFeedGrouping{}
+	got, err := svc.Group(context.Background(), feeds)
+	if err != nil {
+		t.Fatalf("Group() with fallback error = %v", err)
+	}
+	if !got.UsedFallback || !got.Heuristic {
+		t.Fatalf("UsedFallback=%v Heuristic=%v, want both true", got.UsedFallback, got.Heuristic)
+	}
+	if len(got.Groups) != 1 || got.Groups[0].Name != "github.com" || len(got.Groups[0].Feeds) != 2 {
+		t.Fatalf("groups = %+v, want one github.com group with 2 feeds", got.Groups)
+	}
+	if len(got.Ungrouped) != 1 || got.Ungrouped[0] != "https://planetpython.org/rss20.xml" {
+		t.Fatalf("ungrouped = %v, want planetpython feed", got.Ungrouped)
+	}
+	gen.AssertExpectations(t)
+}
+
+func TestHeuristicFeedGrouping_Generate(t *testing.T) {
+	req := buildFeedGroupingRequest([]string{
+		"https://blog.golang.org/feed.atom",
+		"https://news.bbc.co.uk/rss.xml",
+		"https://sport.bbc.co.uk/rss.xml",
+		"https://example.com/golang/rss",
+		"https://planetpython.org/rss20.xml",
+	})
+	groups, err := HeuristicFeedGrouping{Keywords: map[string][]string{"Go": {"GoLang"}}}.Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("groups = %+v, want Go and bbc.co.uk", groups)
+	}
+	if groups[0].Name != "Go" || len(groups[0].Feeds) != 2 {
+		t.Fatalf("keyword group = %+v, want 2 Go feeds", groups[0])
+	}
+	if groups[1].Name != "bbc.co.uk" || len(groups[1].Feeds) != 2 {
+		t.Fatalf("domain group = %+v, want 2 bbc.co.uk feeds", groups[1])
+	}
+
+	_, err = HeuristicFeedGrouping{}.Generate(context.Background(), buildFeedGroupingRequest([]string{
+		"https://a.example.com/rss",
+		"https://example.org/rss",
+	}))
+	if err == nil {
+		t.Fatal("expected error when no feeds share a domain or keyword")
+	}
+}
+
+func TestFeedGroupingService_HeuristicSkipsCache(t *testing.T) {
+	svc := NewFeedGroupingService(HeuristicFeedGrouping{})
+	feeds := []string{"https://a.example.com/rss", "https://b.example.com/rss"}
+	got, err := svc.GroupWithCache(context.Background(), feeds, nil, FeedGroupingCache{
+		InputHash: FeedGroupingInputHash(feeds),
+		Groups:    []subscription.FeedGroup{{Name: "Cached", Feeds: feeds}},
+	})
+	if err != nil {
+		t.Fatalf("GroupWithCache() error = %v", err)
+	}
+	if got.UsedCache || !got.Heuristic {
+		t.Fatalf("UsedCache=%v Heuristic=%v, want fresh heuristic result", got.UsedCache, got.Heuristic)
+	}
+	if len(got.Groups) != 1 || got.Groups[0].Name != "example.com" {
+		t.Fatalf("groups = %+v, want example.com", got.Groups)
+	}
+}
+
 func TestPromptFeedGroupingGenerator_Generate(t *testing.T) {
 	client := &mockTextGenerator{}
 	client.On("Generate", mock.Anything, mock.AnythingOfType("string")).Return(`{"groups":[{"name":"Tech","feeds":["https://news.ycombinator.com/rss"]}]}`, nil).Once()
@@ -132,6 +294,28 @@ func TestPromptFeedGroupingGenerator_Generate(t *testing.T) {
 	if !strings.Contains(prompt, `"groups":[{"name":"...","feeds":["..."]}]`) {
 		t.Fatalf("prompt missing JSON contract: %q", prompt)
 	}
+	if strings.Contains(prompt, "keep_groups") {
+		t.Fatalf("prompt should not mention keep_groups without kept groups: %q", prompt)
+	}
+	client.AssertExpectations(t)
+}
+
+func TestPromptFeedGroupingGenerator_KeepGroups(t *testing.T) {
+	client := &mockTextGenerator{}
+	client.On("Generate", mock.Anything, mock.AnythingOfType("string")).Return(`{"groups":[]}`, nil).Once()
+	gen := NewPromptFeedGroupingGenerator(client)
+
+	_, err := gen.Generate(context.Background(), FeedGroupingRequest{
+		Feeds:      []FeedGroupingFeed{{URL: "https://b.example.com/rss"}},
+		KeepGroups: []FeedGroupingKeepGroup{{Name: "Manual", Feeds: []string{"https://a.example.com/rss"}}},
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	prompt, _ := client.Calls[0].Arguments.Get(1).(string)
+	if !strings.Contains(prompt, `"keep_groups":[{"name":"Manual","feeds":["https://a.example.com/rss"]}]`) {
+		t.Fatalf("prompt missing keep_groups payload: %q", prompt)
+	}
 	client.AssertExpectations(t)
 }
 
@@ -186,3 +370,31 @@ func TestPromptFeedGroupingGenerator_GenerateErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestFeedGroupingService_RateLimitsAIRequests(t *testing.T) {
+	gen := &mockFeedGroupingGenerator{}
+	gen.On("Generate", mock.Anything, mock.Anything).Return(nil, errors.New("codex unreachable")).Once()
+	gen.On("Generate", mock.Anything, mock.Anything).Return([]subscription.FeedGroup{
+		{Name: "Tech", Feeds: []string{"https://a.example.com/rss", "https://b.example.com/rss"}},
+	}, nil).Twice()
+	now := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	svc := NewFeedGroupingService(gen)
+	svc.Now = func() time.Time { return now }
+	feeds := []string{"https://a.example.com/rss", "https://b.example.com/rss"}
+
+	if _, err := svc.Group(context.Background(), feeds); err == nil {
+		t.Fatal("Group() should return the generator error")
+	}
+	if _, err := svc.Group(context.Background(), feeds); err != nil {
+		t.Fatalf("Group() after a failed request error = %v, want a retry allowed", err)
+	}
+	now = now.Add(20 * time.Second)
+	if _, err := svc.Group(context.Background(), feeds); err == nil || !strings.Contains(err.Error(), "try again in 40s") {
+		t.Fatalf("Group() within the interval error = %v, want it rate-limited", err)
+	}
+	now = now.Add(DefaultFeedGroupingInterval)
+	if _, err := svc.Group(context.Background(), feeds); err != nil {
+		t.Fatalf("Group() after the interval error = %v", err)
+	}
+	gen.AssertExpectations(t)
+}
@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/tesso57/reazy/internal/domain/subscription"
+)
+
+// FeedImportResult reports what a bulk feed import did with each URL.
+type FeedImportResult struct {
+	// Added lists the feeds subscribed by the import, in import order.
+	Added []string
+	// Duplicates lists URLs already subscribed or repeated in the import.
+	Duplicates []string
+	// Invalid lists entries that are not http(s) feed URLs.
+	Invalid []string
+}
+
+// FeedMetadata holds display details of a subscribed feed.
+type FeedMetadata struct {
+	// Title replaces the feed URL in the sidebar.
+	Title string
+	// Tags records category hints for the feed.
+	Tags []string
+}
+
+// OPMLFetcher downloads an OPML subscription list published at a URL.
+type OPMLFetcher interface {
+	FetchOPML(ctx context.Context, url string) ([]byte, error)
+}
+
+// IsOPMLURL reports whether source names a hosted OPML list rather than a
+// local file.
+func IsOPMLURL(source string) bool {
+	return isImportableFeedURL(strings.TrimSpace(source))
+}
+
+// ImportOPML subscribes to the feeds listed in the OPML file at path.
+// A leading "~/" is expanded to the home directory. See ImportFeeds.
+// Added feeds keep the OPML organization where the repository supports it:
+// folders become feed groups and outline titles and categories are stored
+// as feed metadata.
+func (s *SubscriptionService) ImportOPML(path string) ([]string, FeedImportResult, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, FeedImportResult{}, fmt.Errorf("opml path is empty")
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, FeedImportResult{}, err
+	}
+	defer func() { _ = f.Close() }()
+	entries, err := subscription.ParseOPMLFeeds(f)
+	if err != nil {
+		return nil, FeedImportResult{}, fmt.Errorf("read opml: %w", err)
+	}
+	return s.ImportOPMLFeeds(entries)
+}
+
+// DownloadOPML fetches the OPML list at rawURL with the OPML fetcher and
+// returns its feeds for ImportOPMLFeeds. It does not change subscriptions,
+// so it can run in the background.
+func (s *SubscriptionService) DownloadOPML(ctx context.Context, rawURL string) ([]subscription.OPMLFeed, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if !IsOPMLURL(rawURL) {
+		return nil, errors.New("invalid opml url")
+	}
+	if s.OPMLFetcher == nil {
+		return nil, errors.New("importing opml from a url is not supported")
+	}
+	data, err := s.OPMLFetcher.FetchOPML(ctx, rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch opml: %w", err)
+	}
+	entries, err := subscription.ParseOPMLFeeds(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("read opml: %w", err)
+	}
+	return entries, nil
+}
+
+// DownloadsOPML reports whether DownloadOPML can fetch hosted OPML lists.
+func (s *SubscriptionService) DownloadsOPML() bool {
+	return s != nil && s.OPMLFetcher != nil
+}
+
+// ImportOPMLFeeds subscribes to the feeds of a parsed OPML list the way
+// ImportOPML does.
+func (s *SubscriptionService) ImportOPMLFeeds(entries []subscription.OPMLFeed) ([]string, FeedImportResult, error) {
+	urls := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		urls = append(urls, entry.URL)
+	}
+	var feeds []string
+	var result FeedImportResult
+	err := s.batch(func() error {
+		var err error
+		feeds, result, err = s.ImportFeeds(urls)
+		if err != nil || len(result.Added) == 0 {
+			return err
+		}
+		feeds, err = s.applyOPMLOrganization(entries, result.Added)
+		return err
+	})
+	return feeds, result, err
+}
+
+// batch runs fn as a single transaction when the repository supports it:
+// one save on success and no partial changes when fn fails.
+func (s *SubscriptionService) batch(fn func() error) error {
+	if repo, ok := s.Repo.(batchSubscriptionRepository); ok {
+		return repo.Batch(fn)
+	}
+	return fn()
+}
+
+// applyOPMLOrganization files the added feeds into groups named after their
+// OPML folders and stores their titles and categories, then returns the
+// updated list.
+func (s *SubscriptionService) applyOPMLOrganization(entries []subscription.OPMLFeed, added []string) ([]string, error) {
+	byURL := make(map[string]subscription.OPMLFeed, len(entries))
+	for _, entry := range entries {
+		if _, ok := byURL[entry.URL]; !ok {
+			byURL[entry.URL] = entry
+		}
+	}
+
+	if repo, ok := s.Repo.(feedMetadataRepository); ok {
+		metadata := make(map[string]FeedMetadata)
+		for _, feed := range added {
+			if entry := byURL[feed]; entry.Title != "" || len(entry.Categories) > 0 {
+				metadata[feed] = FeedMetadata{Title: entry.Title, Tags: entry.Categories}
+			}
+		}
+		if len(metadata) > 0 {
+			if err := repo.SaveFeedMetadata(metadata); err != nil {
+				return nil, fmt.Errorf("save feed metadata: %w", err)
+			}
+		}
+	}
+
+	groups, supported, err := s.ListGroups()
+	if err != nil || !supported {
+		feeds, listErr := s.Repo.List()
+		return feeds, errors.Join(err, listErr)
+	}
+	moved := 0
+	for _, feed := range added {
+		folder := byURL[feed].Folder
+		if folder == "" {
+			continue
+		}
+		index := slices.IndexFunc(groups, func(group subscription.FeedGroup) bool { return group.Name == folder })
+		if index < 0 {
+			groups = append(groups, subscription.FeedGroup{Name: folder})
+			index = len(groups) - 1
+		}
+		groups[index].Feeds = append(groups[index].Feeds, feed)
+		moved++
+	}
+	if moved == 0 {
+		return s.Repo.List()
+	}
+	current, err := s.Repo.List()
+	if err != nil {
+		return nil, err
+	}
+	grouped := make(map[string]bool)
+	for _, group := range groups {
+		for _, feed := range group.Feeds {
+			grouped[feed] = true
+		}
+	}
+	var ungrouped []string
+	for _, feed := range current {
+		if !grouped[feed] {
+			ungrouped = append(ungrouped, feed)
+		}
+	}
+	feeds, _, err := s.ReplaceFeedGroups(groups, ungrouped)
+	return feeds, err
+}
+
+// ImportFeeds subscribes to every valid URL that is not subscribed yet and
+// returns the updated list with a per-URL report. If adding a feed fails,
+// the feeds added so far are removed again and the error is returned.
+func (s *SubscriptionService) ImportFeeds(urls []string) ([]string, FeedImportResult, error) {
+	var feeds []string
+	var result FeedImportResult
+	err := s.batch(func() error {
+		var err error
+		feeds, result, err = s.importFeeds(urls)
+		return err
+	})
+	return feeds, result, err
+}
+
+func (s *SubscriptionService) importFeeds(urls []string) ([]string, FeedImportResult, error) {
+	var result FeedImportResult
+	existing, err := s.Repo.List()
+	if err != nil {
+		return nil, result, err
+	}
+	seen := make(map[string]bool, len(existing)+len(urls))
+	for _, feed := range existing {
+		seen[feedURLKey(feed)] = true
+	}
+	for _, raw := range urls {
+		feed := strings.TrimSpace(raw)
+		if !isImportableFeedURL(feed) {
+			result.Invalid = append(result.Invalid, feed)
+			continue
+		}
+		key := feedURLKey(feed)
+		if seen[key] {
+			result.Duplicates = append(result.Duplicates, feed)
+			continue
+		}
+		if err := s.Repo.Add(feed); err != nil {
+			if _, undoErr := s.UndoImport(result); undoErr != nil {
+				return nil, FeedImportResult{}, fmt.Errorf("add %s: %w (undo failed: %v)", feed, err, undoErr)
+			}
+			return nil, FeedImportResult{}, fmt.Errorf("add %s: %w", feed, err)
+		}
+		seen[key] = true
+		result.Added = append(result.Added, feed)
+	}
+	feeds, err := s.Repo.List()
+	return feeds, result, err
+}
+
+// UndoImport unsubscribes the feeds an import added, clears the titles and
+// tags it stored for them, and returns the updated list. Feeds that were
+// already subscribed before the import are kept.
+func (s *SubscriptionService) UndoImport(result FeedImportResult) ([]string, error) {
+	var feeds []string
+	err := s.batch(func() error {
+		var err error
+		feeds, err = s.undoImport(result)
+		return err
+	})
+	return feeds, err
+}
+
+func (s *SubscriptionService) undoImport(result FeedImportResult) ([]string, error) {
+	feeds, err := s.Repo.List()
+	if err != nil {
+		return nil, err
+	}
+	// Remove from the end so earlier indexes stay valid.
+	for index := len(feeds) - 1; index >= 0; index-- {
+		if !slices.Contains(result.Added, feeds[index]) {
+			continue
+		}
+		if err := s.Repo.Remove(index); err != nil {
+			return nil, err
+		}
+	}
+	if repo, ok := s.Repo.(feedMetadataRepository); ok && len(result.Added) > 0 {
+		cleared := make(map[string]FeedMetadata, len(result.Added))
+		for _, feed := range result.Added {
+			cleared[feed] = FeedMetadata{}
+		}
+		if err := repo.SaveFeedMetadata(cleared); err != nil {
+			return nil, fmt.Errorf("clear feed metadata: %w", err)
+		}
+	}
+	return s.Repo.List()
+}
+
+func isImportableFeedURL(feed string) bool {
+	if feed == "" || strings.ContainsAny(feed, " \t\r\n") {
+		return false
+	}
+	parsed, err := url.Parse(feed)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	return parsed.Scheme == "http" || parsed.Scheme == "https"
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/tesso57/reazy/internal/domain/subscription"
+)
+
+func TestSubscriptionService_ImportFeeds(t *testing.T) {
+	repo := &stubSubscriptionRepo{feeds: []string{"https://example.com/rss"}}
+	svc := NewSubscriptionService(repo)
+
+	feeds, result, err := svc.ImportFeeds([]string{
+		"https://example.com/rss/",
+		"https://go.dev/blog/feed.atom",
+		"ftp://example.com/feed",
+		"not a url",
+		"https://GO.dev/blog/feed.atom",
+		"https://news.ycombinator.com/rss",
+	})
+	if err != nil {
+		t.Fatalf("ImportFeeds() error = %v", err)
+	}
+	if !reflect.DeepEqual(result.Added, []string{"https://go.dev/blog/feed.atom", "https://news.ycombinator.com/rss"}) {
+		t.Fatalf("Added = %#v", result.Added)
+	}
+	if !reflect.DeepEqual(result.Duplicates, []string{"https://example.com/rss/", "https://GO.dev/blog/feed.atom"}) {
+		t.Fatalf("Duplicates = %#v", result.Duplicates)
+	}
+	if !reflect.DeepEqual(result.Invalid, []string{"ftp://example.com/feed", "not a url"}) {
+		t.Fatalf("Invalid = %#v", result.Invalid)
+	}
+	if len(feeds) != 3 {
+		t.Fatalf("feeds = %#v, want 3 subscriptions", feeds)
+	}
+
+	feeds, err = svc.UndoImport(result)
+	if err != nil {
+		t.Fatalf("UndoImport() error = %v", err)
+	}
+	if !reflect.DeepEqual(feeds, []string{"https://example.com/rss"}) {
+		t.Fatalf("feeds after undo = %#v, want only the original subscription", feeds)
+	}
+}
+
+func TestSubscriptionService_ImportFeedsRollsBackOnError(t *testing.T) {
+	repo := &stubSubscriptionRepo{feeds: []string{"https://example.com/rss"}}
+	repo.On("List").Return([]string{"https://example.com/rss"}, nil).Once()
+	repo.On("Add", "https://a.example.com/rss").Return(nil).Once()
+	repo.On("Add", "https://b.example.com/rss").Return(errors.New("disk full")).Once()
+	repo.On("List").Return([]string{"https://example.com/rss", "https://a.example.com/rss"}, nil).Once()
+	repo.On("Remove", 1).Return(nil).Once()
+	repo.On("List").Return([]string{"https://example.com/rss"}, nil).Once()
+	svc := NewSubscriptionService(repo)
+
+	if _, _, err := svc.ImportFeeds([]string{"https://a.example.com/rss", "https://b.example.com/rss"}); err == nil {
+		t.Fatal("ImportFeeds() should report the failed add")
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestSubscriptionService_ImportOPML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feeds.opml")
+	doc := `<opml version="2.0"><body><outline text="Go" xmlUrl="https://go.dev/blog/feed.atom"/></body></opml>`
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewSubscriptionService(&stubSubscriptionRepo{})
+
+	feeds, result, err := svc.ImportOPML(path)
+	if err != nil {
+		t.Fatalf("ImportOPML() error = %v", err)
+	}
+	if len(feeds) != 1 || len(result.Added) != 1 {
+		t.Fatalf("ImportOPML() = %#v, %#v", feeds, result)
+	}
+	if _, _, err := svc.ImportOPML(filepath.Join(t.TempDir(), "missing.opml")); err == nil {
+		t.Fatal("ImportOPML() should fail for a missing file")
+	}
+}
+
+type metadataSubscriptionRepo struct {
+	stubSubscriptionRepo
+	metadata map[string]FeedMetadata
+}
+
+func (s *metadataSubscriptionRepo) LoadFeedMetadata() (map[string]FeedMetadata, error) {
+	return s.metadata, nil
+}
+
+func (s *metadataSubscriptionRepo) SaveFeedMetadata(metadata map[string]FeedMetadata) error {
+	if s.metadata == nil {
+		s.metadata = make(map[string]FeedMetadata)
+	}
+	for url, meta := range metadata {
+		if meta.Title == "" && len(meta.Tags) == 0 {
+			delete(s.metadata, url)
+			continue
+		}
+		s.metadata[url] = meta
+	}
+	return nil
+}
+
+func TestSubscriptionService_ImportOPMLKeepsOrganization(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feeds.opml")
+	doc := `<opml version="2.0"><body>
+  <outline text="Go Blog" xmlUrl="https://go.dev/blog/feed.atom" category="Programming"/>
+  <outline text="Tech">
+    <outline text="HN" xmlUrl="https://news.ycombinator.com/rss"/>
+    <outline text="Lobsters" xmlUrl="https://lobste.rs/rss"/>
+  </outline>
+  <outline text="News">
+    <outline text="Example" xmlUrl="https://example.com/rss"/>
+  </outline>
+</body></opml>`
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	repo := &metadataSubscriptionRepo{stubSubscriptionRepo: stubSubscriptionRepo{
+		feeds:  []string{"https://example.com/rss"},
+		groups: []subscription.FeedGroup{{Name: "Tech", Feeds: []string{"https://blog.example.org/feed"}}},
+	}}
+	svc := NewSubscriptionService(repo)
+
+	_, result, err := svc.ImportOPML(path)
+	if err != nil || len(result.Added) != 3 {
+		t.Fatalf("ImportOPML() = %#v, %v", result, err)
+	}
+	wantGroups := []subscription.FeedGroup{{
+		Name:  "Tech",
+		Feeds: []string{"https://blog.example.org/feed", "https://news.ycombinator.com/rss", "https://lobste.rs/rss"},
+	}}
+	if !reflect.DeepEqual(repo.groups, wantGroups) {
+		t.Fatalf("groups = %#v, want %#v", repo.groups, wantGroups)
+	}
+	if !reflect.DeepEqual(repo.feeds, []string{"https://example.com/rss", "https://go.dev/blog/feed.atom"}) {
+		t.Fatalf("ungrouped = %#v, the already subscribed feed should stay put", repo.feeds)
+	}
+	wantMetadata := map[string]FeedMetadata{
+		"https://go.dev/blog/feed.atom":    {Title: "Go Blog", Tags: []string{"Programming"}},
+		"https://news.ycombinator.com/rss": {Title: "HN"},
+		"https://lobste.rs/rss":            {Title: "Lobsters"},
+	}
+	if metadata, _, _ := svc.FeedMetadata(); !reflect.DeepEqual(metadata, wantMetadata) {
+		t.Fatalf("FeedMetadata() = %#v, want %#v", metadata, wantMetadata)
+	}
+
+	if _, err := svc.UndoImport(result); err != nil {
+		t.Fatalf("UndoImport() error = %v", err)
+	}
+	if metadata, _, _ := svc.FeedMetadata(); len(metadata) != 0 {
+		t.Fatalf("FeedMetadata() after undo = %#v, want the imported titles and tags cleared", metadata)
+	}
+}
+
+type stubOPMLFetcher struct {
+	data []byte
+	err  error
+	urls []string
+}
+
+func (f *stubOPMLFetcher) FetchOPML(_ context.Context, url string) ([]byte, error) {
+	f.urls = append(f.urls, url)
+	return f.data, f.err
+}
+
+func TestSubscriptionService_DownloadOPML(t *testing.T) {
+	const listURL = "https://reader.example.com/export/subscriptions.opml"
+	fetcher := &stubOPMLFetcher{data: []byte(`<opml version="2.0"><body><outline text="Go" xmlUrl="https://go.dev/blog/feed.atom"/></body></opml>`)}
+	repo := &stubSubscriptionRepo{}
+	svc := NewSubscriptionService(repo)
+	svc.OPMLFetcher = fetcher
+
+	entries, err := svc.DownloadOPML(context.Background(), " "+listURL+" ")
+	if err != nil {
+		t.Fatalf("DownloadOPML() error = %v", err)
+	}
+	if !reflect.DeepEqual(fetcher.urls, []string{listURL}) {
+		t.Fatalf("fetched %#v, want %q", fetcher.urls, listURL)
+	}
+	if len(repo.feeds) != 0 {
+		t.Fatalf("DownloadOPML() should not subscribe, got %#v", repo.feeds)
+	}
+	feeds, result, err := svc.ImportOPMLFeeds(entries)
+	if err != nil {
+		t.Fatalf("ImportOPMLFeeds() error = %v", err)
+	}
+	if !reflect.DeepEqual(feeds, []string{"https://go.dev/blog/feed.atom"}) || len(result.Added) != 1 {
+		t.Fatalf("ImportOPMLFeeds() = %#v, %#v", feeds, result)
+	}
+
+	fetcher.err = errors.New("401 Unauthorized")
+	if _, err := svc.DownloadOPML(context.Background(), listURL); err == nil {
+		t.Fatal("DownloadOPML() should report a failed download")
+	}
+	fetcher.err = nil
+	fetcher.data = []byte("<html>Sign in</html>")
+	if _, err := svc.DownloadOPML(context.Background(), listURL); err == nil {
+		t.Fatal("DownloadOPML() should reject a document that is not OPML")
+	}
+	if _, err := svc.DownloadOPML(context.Background(), "feeds.opml"); err == nil {
+		t.Fatal("DownloadOPML() should reject a local path")
+	}
+	if _, err := NewSubscriptionService(&stubSubscriptionRepo{}).DownloadOPML(context.Background(), listURL); err == nil {
+		t.Fatal("DownloadOPML() should fail without an OPML fetcher")
+	}
+}
@@ -31,10 +31,26 @@ type InsightGenerator interface {
 	Generate(ctx context.Context, req InsightRequest) (Insight, error)
 }
 
+// ArticleQuestion is a free-form question about one article.
+type ArticleQuestion struct {
+	Article  InsightRequest
+	Question string
+}
+
+// articleAnswerer is implemented by insight generators that can also answer
+// free-form questions about an article.
+type articleAnswerer interface {
+	Answer(ctx context.Context, req ArticleQuestion) (string, error)
+}
+
 // InsightService coordinates insight generation and history updates.
 type InsightService struct {
 	Generator InsightGenerator
 	Now       func() time.Time
+	// Tokens, when set, totals the estimated prompt tokens sent.
+	Tokens *TokenMeter
+
+	limiter chan struct{}
 }
 
 // NewInsightService constructs an InsightService.
@@ -50,6 +66,19 @@ func (s *InsightService) Enabled() bool {
 	return s != nil && s.Generator != nil
 }
 
+// LimitConcurrency caps how many generations run at once; further calls wait
+// for a slot or for their context to end. n <= 0 removes the limit.
+func (s *InsightService) LimitConcurrency(n int) {
+	if s == nil {
+		return
+	}
+	if n <= 0 {
+		s.limiter = nil
+		return
+	}
+	s.limiter = make(chan struct{}, n)
+}
+
 // Generate runs insight generation for the given request.
 func (s *InsightService) Generate(ctx context.Context, req InsightRequest) (Insight, error) {
 	if s == nil || s.Generator == nil {
@@ -59,6 +88,13 @@ func (s *InsightService) Generate(ctx context.Context, req InsightRequest) (Insi
 		return Insight{}, errors.New("article has no content to summarize")
 	}
 
+	release, err := s.acquire(ctx)
+	if err != nil {
+		return Insight{}, err
+	}
+	defer release()
+
+	s.Tokens.Add(req.EstimatedTokens())
 	insight, err := s.Generator.Generate(ctx, req)
 	if err != nil {
 		return Insight{}, err
@@ -72,6 +108,63 @@ func (s *InsightService) Generate(ctx context.Context, req InsightRequest) (Insi
 	return insight, nil
 }
 
+// Ask answers a free-form question about one article. It shares the
+// generation slots with Generate.
+func (s *InsightService) Ask(ctx context.Context, article InsightRequest, question string) (string, error) {
+	if s == nil || s.Generator == nil {
+		return "", errors.New("codex integration is disabled")
+	}
+	answerer, ok := s.Generator.(articleAnswerer)
+	if !ok {
+		return "", errors.New("asking about articles is not supported")
+	}
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return "", errors.New("question is empty")
+	}
+
+	release, err := s.acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	req := ArticleQuestion{Article: article, Question: question}
+	s.Tokens.Add(req.EstimatedTokens())
+	answer, err := answerer.Answer(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return "", errors.New("empty answer returned by codex")
+	}
+	return answer, nil
+}
+
+// acquire waits for a generation slot when concurrency is limited.
+func (s *InsightService) acquire(ctx context.Context) (func(), error) {
+	if s.limiter == nil {
+		return func() {}, nil
+	}
+	select {
+	case s.limiter <- struct{}{}:
+		return func() { <-s.limiter }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// FallbackInsight uses the feed's own description as a pseudo-summary when AI
+// generation is unavailable. It reports false when there is no description.
+func FallbackInsight(description string) (Insight, bool) {
+	summary := strings.TrimSpace(description)
+	if summary == "" {
+		return Insight{}, false
+	}
+	return Insight{Summary: summary}, true
+}
+
 // ApplyToHistory updates one article in history with generated insight.
 func (s *InsightService) ApplyToHistory(history *reading.History, guid string, insight Insight) bool {
 	if s == nil || history == nil {
@@ -41,7 +41,49 @@ func (g PromptInsightGenerator) Generate(ctx context.Context, req InsightRequest
 	return parseInsightOutput(raw)
 }
 
+// Answer answers a free-form question about an article in plain text.
+func (g PromptInsightGenerator) Answer(ctx context.Context, req ArticleQuestion) (string, error) {
+	if g.Client == nil {
+		return "", errors.New("ai client is not configured")
+	}
+	raw, err := g.Client.Generate(ctx, buildArticleQuestionPrompt(req))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(raw), nil
+}
+
 func buildInsightPrompt(req InsightRequest) string {
+	return strings.Join([]string{
+		"You are helping an RSS reader.",
+		"Summarize the article and propose relevant topic tags.",
+		`Return ONLY valid JSON without markdown: {"summary":"...","tags":["..."]}`,
+		"Rules:",
+		"- summary: in Japanese (ja-JP), readable in about 3 minutes (roughly 900 to 1500 Japanese characters).",
+		"- tags: 3 to 8 short tags in English, no duplicates.",
+		"- if content is sparse, still provide the best possible summary from available fields.",
+		"Article JSON:",
+		insightArticleJSON(req),
+	}, "\n")
+}
+
+func buildArticleQuestionPrompt(req ArticleQuestion) string {
+	return strings.Join([]string{
+		"You are helping an RSS reader.",
+		"Answer the reader's question about the article below.",
+		"Rules:",
+		"- answer in the language of the question, as plain text without markdown headings.",
+		"- base the answer on the article; say so when it does not cover the question.",
+		"Question:",
+		strings.TrimSpace(req.Question),
+		"Article JSON:",
+		insightArticleJSON(req.Article),
+	}, "\n")
+}
+
+// insightArticleJSON encodes the article fields sent to the AI, with long
+// texts cut to keep prompts bounded.
+func insightArticleJSON(req InsightRequest) string {
 	limited := struct {
 		Title       string `json:"title"`
 		Description string `json:"description"`
@@ -59,18 +101,7 @@ func buildInsightPrompt(req InsightRequest) string {
 	}
 
 	payload, _ := json.Marshal(limited)
-
-	return strings.Join([]string{
-		"You are helping an RSS reader.",
-		"Summarize the article and propose relevant topic tags.",
-		`Return ONLY valid JSON without markdown: {"summary":"...","tags":["..."]}`,
-		"Rules:",
-		"- summary: in Japanese (ja-JP), readable in about 3 minutes (roughly 900 to 1500 Japanese characters).",
-		"- tags: 3 to 8 short tags in English, no duplicates.",
-		"- if content is sparse, still provide the best possible summary from available fields.",
-		"Article JSON:",
-		string(payload),
-	}, "\n")
+	return string(payload)
 }
 
 func limitInsightText(s string, maxChars int) string {
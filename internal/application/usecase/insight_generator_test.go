@@ -57,6 +57,28 @@ func TestPromptInsightGenerator_Generate(t *testing.T) {
 	client.AssertExpectations(t)
 }
 
+func TestPromptInsightGenerator_Answer(t *testing.T) {
+	client := &mockTextGenerator{}
+	client.On("Generate", mock.Anything, mock.AnythingOfType("string")).Return("  Three takeaways.\n", nil).Once()
+	generator := NewPromptInsightGenerator(client)
+
+	got, err := generator.Answer(context.Background(), ArticleQuestion{
+		Article:  InsightRequest{Title: "Example", Content: "Body text"},
+		Question: "What are the key takeaways?",
+	})
+	if err != nil {
+		t.Fatalf("Answer() error = %v", err)
+	}
+	if got != "Three takeaways." {
+		t.Fatalf("answer = %q, want trimmed output", got)
+	}
+	prompt, _ := client.Calls[0].Arguments.Get(1).(string)
+	if !strings.Contains(prompt, "What are the key takeaways?") || !strings.Contains(prompt, `"content":"Body text"`) {
+		t.Fatalf("prompt missing question or article: %q", prompt)
+	}
+	client.AssertExpectations(t)
+}
+
 func TestPromptInsightGenerator_ParseOutputWithNoise(t *testing.T) {
 	client := &mockTextGenerator{}
 	client.On("Generate", mock.Anything, mock.AnythingOfType("string")).Return("warning line\n{\"summary\":\"ok\",\"tags\":[\"a\",\"b\"]}\n", nil).Once()
@@ -104,6 +104,15 @@ func TestInsightService_Generate(t *testing.T) {
 	})
 }
 
+func TestFallbackInsight(t *testing.T) {
+	if got, ok := FallbackInsight("  Feed description  "); !ok || got.Summary != "Feed description" {
+		t.Fatalf("FallbackInsight() = %+v, %v", got, ok)
+	}
+	if _, ok := FallbackInsight(" "); ok {
+		t.Fatal("FallbackInsight() should report false without a description")
+	}
+}
+
 func TestInsightService_ApplyToHistory(t *testing.T) {
 	now := time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC)
 	svc := NewInsightService(nil, func() time.Time { return now })
@@ -138,3 +147,63 @@ func TestInsightService_ApplyToHistory(t *testing.T) {
 		t.Fatal("ApplyToHistory should return false for nil history")
 	}
 }
+
+type blockingInsightGenerator struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (g *blockingInsightGenerator) Generate(_ context.Context, _ InsightRequest) (Insight, error) {
+	g.started <- struct{}{}
+	<-g.release
+	return Insight{Summary: "done"}, nil
+}
+
+func TestInsightService_LimitConcurrency(t *testing.T) {
+	gen := &blockingInsightGenerator{started: make(chan struct{}, 2), release: make(chan struct{})}
+	svc := NewInsightService(gen, nil)
+	svc.LimitConcurrency(1)
+	req := InsightRequest{Title: "Title"}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := svc.Generate(context.Background(), req)
+		done <- err
+	}()
+	<-gen.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := svc.Generate(ctx, req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second Generate() error = %v, want to wait for a free slot", err)
+	}
+
+	close(gen.release)
+	if err := <-done; err != nil {
+		t.Fatalf("first Generate() error = %v", err)
+	}
+	if _, err := svc.Generate(context.Background(), req); err != nil {
+		t.Fatalf("Generate() after release error = %v", err)
+	}
+}
+
+func TestInsightService_Ask(t *testing.T) {
+	client := &mockTextGenerator{}
+	client.On("Generate", mock.Anything, mock.AnythingOfType("string")).Return("An answer", nil).Once()
+	svc := NewInsightService(NewPromptInsightGenerator(client), nil)
+
+	answer, err := svc.Ask(context.Background(), InsightRequest{Title: "Title"}, " Why? ")
+	if err != nil || answer != "An answer" {
+		t.Fatalf("Ask() = %q, %v; want the answer", answer, err)
+	}
+	if _, err := svc.Ask(context.Background(), InsightRequest{Title: "Title"}, "  "); err == nil {
+		t.Fatal("Ask() with an empty question should fail")
+	}
+	if _, err := NewInsightService(&mockInsightGenerator{}, nil).Ask(context.Background(), InsightRequest{Title: "Title"}, "Why?"); err == nil {
+		t.Fatal("Ask() should fail when the generator cannot answer questions")
+	}
+	if _, err := NewInsightService(nil, nil).Ask(context.Background(), InsightRequest{Title: "Title"}, "Why?"); err == nil {
+		t.Fatal("Ask() should fail when AI is disabled")
+	}
+	client.AssertExpectations(t)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 	"time"
@@ -17,6 +18,11 @@ const (
 	maxNewsDigestArticles         = 60
 	maxNewsDigestDescriptionChars = 1200
 	maxNewsDigestContentChars     = 4000
+
+	// DefaultMaxNewsDigestTopics caps topics kept from one digest generation.
+	DefaultMaxNewsDigestTopics = 20
+	// DefaultMaxNewsDigestTopicArticles caps related articles kept per topic.
+	DefaultMaxNewsDigestTopicArticles = 10
 )
 
 // NewsDigestArticle is one source article for daily news generation.
@@ -42,6 +48,8 @@ type NewsDigestTopic struct {
 	Summary      string
 	Tags         []string
 	ArticleGUIDs []string
+	// ArticleTitles holds translated titles of the source articles keyed by GUID.
+	ArticleTitles map[string]string
 }
 
 // NewsDigestGenerator abstracts generation of daily news topics.
@@ -54,6 +62,14 @@ type DailyNewsDigest struct {
 	DateKey   string
 	Items     []*reading.HistoryItem
 	UsedCache bool
+	// Note explains how oversized AI output was truncated, if it was.
+	Note string
+}
+
+// NewsSelection identifies the News topic opened last by its digest day and GUID.
+type NewsSelection struct {
+	DateKey string
+	GUID    string
 }
 
 // NewsDigestService coordinates daily news generation and cache usage.
@@ -61,6 +77,18 @@ type NewsDigestService struct {
 	Generator NewsDigestGenerator
 	Now       func() time.Time
 	Location  func() *time.Location
+	// MaxTopics and MaxTopicArticles cap generated output; zero or negative
+	// values use the defaults.
+	MaxTopics        int
+	MaxTopicArticles int
+	// MergeThreshold merges topics whose article sets have at least this
+	// Jaccard similarity; zero disables merging.
+	MergeThreshold float64
+	// Tokens, when set, totals the estimated prompt tokens sent.
+	Tokens *TokenMeter
+	// Sanitizer, when set, strips boilerplate from article text before it
+	// is sent.
+	Sanitizer *reading.ContentSanitizer
 }
 
 // NewNewsDigestService constructs a NewsDigestService.
@@ -107,13 +135,14 @@ func (s *NewsDigestService) BuildDaily(ctx context.Context, history *reading.His
 		articles = articles[:maxNewsDigestArticles]
 	}
 
-	req := buildNewsDigestRequest(dateKey, articles)
+	req := buildNewsDigestRequest(dateKey, articles, s.Sanitizer)
+	s.Tokens.Add(req.EstimatedTokens())
 	topics, err := s.Generator.Generate(ctx, req)
 	if err != nil {
 		return DailyNewsDigest{}, err
 	}
 
-	normalized := normalizeNewsDigestTopics(topics, req.Articles)
+	normalized, truncation := normalizeNewsDigestTopics(topics, req.Articles, s.limits(), s.MergeThreshold)
 	if len(normalized) == 0 {
 		return DailyNewsDigest{}, errors.New("daily news generation returned no valid topics")
 	}
@@ -122,9 +151,52 @@ func (s *NewsDigestService) BuildDaily(ctx context.Context, history *reading.His
 		DateKey:   dateKey,
 		Items:     buildDigestHistoryItems(dateKey, normalized, s.now(), s.location()),
 		UsedCache: false,
+		Note:      truncation.note(),
 	}, nil
 }
 
+// newsDigestLimits bounds how much AI output one digest keeps.
+type newsDigestLimits struct {
+	topics        int
+	topicArticles int
+}
+
+// newsDigestTruncation records what normalization dropped to fit the limits.
+type newsDigestTruncation struct {
+	keptTopics    int
+	droppedTopics int
+	trimmedTopics int
+	topicArticles int
+}
+
+func (t newsDigestTruncation) note() string {
+	var parts []string
+	if t.droppedTopics > 0 {
+		parts = append(parts, fmt.Sprintf("kept %d of %d topics", t.keptTopics, t.keptTopics+t.droppedTopics))
+	}
+	if t.trimmedTopics > 0 {
+		parts = append(parts, fmt.Sprintf("%d topics trimmed to %d articles", t.trimmedTopics, t.topicArticles))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "News truncated: " + strings.Join(parts, ", ")
+}
+
+func (s *NewsDigestService) limits() newsDigestLimits {
+	limits := newsDigestLimits{
+		topics:        DefaultMaxNewsDigestTopics,
+		topicArticles: DefaultMaxNewsDigestTopicArticles,
+	}
+	if s != nil && s.MaxTopics > 0 {
+		limits.topics = s.MaxTopics
+	}
+	if s != nil && s.MaxTopicArticles > 0 {
+		limits.topicArticles = s.MaxTopicArticles
+	}
+	return limits
+}
+
 func (s *NewsDigestService) now() time.Time {
 	if s != nil && s.Now != nil {
 		return s.Now()
@@ -150,7 +222,7 @@ func (s *NewsDigestService) TodayDateKey() string {
 	return s.todayDateKey()
 }
 
-func buildNewsDigestRequest(dateKey string, articles []*reading.HistoryItem) NewsDigestRequest {
+func buildNewsDigestRequest(dateKey string, articles []*reading.HistoryItem, sanitizer *reading.ContentSanitizer) NewsDigestRequest {
 	result := NewsDigestRequest{
 		DateKey:  dateKey,
 		Articles: make([]NewsDigestArticle, 0, len(articles)),
@@ -165,16 +237,21 @@ func buildNewsDigestRequest(dateKey string, articles []*reading.HistoryItem) New
 			FeedTitle:   strings.TrimSpace(article.FeedTitle),
 			Published:   strings.TrimSpace(article.Published),
 			Link:        strings.TrimSpace(article.Link),
-			Description: limitInsightText(strings.TrimSpace(article.Description), maxNewsDigestDescriptionChars),
-			Content:     limitInsightText(strings.TrimSpace(article.Content), maxNewsDigestContentChars),
+			Description: limitInsightText(strings.TrimSpace(sanitizer.Clean(article.Description)), maxNewsDigestDescriptionChars),
+			Content:     limitInsightText(strings.TrimSpace(sanitizer.Clean(article.Content)), maxNewsDigestContentChars),
 		})
 	}
 	return result
 }
 
-func normalizeNewsDigestTopics(topics []NewsDigestTopic, source []NewsDigestArticle) []NewsDigestTopic {
+// normalizeNewsDigestTopics drops invalid topics and unknown article GUIDs,
+// merges near-duplicate topics (see mergeSimilarNewsDigestTopics), then keeps
+// at most limits.topics topics with limits.topicArticles articles each,
+// reporting what was cut.
+func normalizeNewsDigestTopics(topics []NewsDigestTopic, source []NewsDigestArticle, limits newsDigestLimits, mergeThreshold float64) ([]NewsDigestTopic, newsDigestTruncation) {
+	truncation := newsDigestTruncation{topicArticles: limits.topicArticles}
 	if len(topics) == 0 {
-		return nil
+		return nil, truncation
 	}
 
 	validGUIDs := make(map[string]struct{}, len(source))
@@ -185,7 +262,7 @@ func normalizeNewsDigestTopics(topics []NewsDigestTopic, source []NewsDigestArti
 		validGUIDs[article.GUID] = struct{}{}
 	}
 
-	normalized := make([]NewsDigestTopic, 0, len(topics))
+	candidates := make([]NewsDigestTopic, 0, len(topics))
 	for _, topic := range topics {
 		title := strings.TrimSpace(topic.Title)
 		summary := strings.TrimSpace(topic.Summary)
@@ -212,16 +289,125 @@ func normalizeNewsDigestTopics(topics []NewsDigestTopic, source []NewsDigestArti
 		if len(guids) == 0 {
 			continue
 		}
+		candidates = append(candidates, NewsDigestTopic{
+			Title:         title,
+			Summary:       summary,
+			Tags:          topic.Tags,
+			ArticleGUIDs:  guids,
+			ArticleTitles: topic.ArticleTitles,
+		})
+	}
+	candidates = mergeSimilarNewsDigestTopics(candidates, mergeThreshold)
+
+	normalized := make([]NewsDigestTopic, 0, min(len(candidates), limits.topics))
+	for _, topic := range candidates {
+		if len(normalized) >= limits.topics {
+			truncation.droppedTopics++
+			continue
+		}
+		guids := topic.ArticleGUIDs
+		if len(guids) > limits.topicArticles {
+			guids = guids[:limits.topicArticles]
+			truncation.trimmedTopics++
+		}
+		kept := make(map[string]struct{}, len(guids))
+		for _, guid := range guids {
+			kept[guid] = struct{}{}
+		}
 
 		normalized = append(normalized, NewsDigestTopic{
-			Title:        title,
-			Summary:      summary,
-			Tags:         normalizeTags(topic.Tags),
-			ArticleGUIDs: guids,
+			Title:         topic.Title,
+			Summary:       topic.Summary,
+			Tags:          normalizeTags(topic.Tags),
+			ArticleGUIDs:  guids,
+			ArticleTitles: normalizeArticleTitles(topic.ArticleTitles, kept),
+		})
+	}
+
+	truncation.keptTopics = len(normalized)
+	return normalized, truncation
+}
+
+// mergeSimilarNewsDigestTopics folds each topic into the first earlier topic
+// whose articles overlap with a Jaccard similarity of at least threshold.
+// The merged topic keeps the earlier position, joins distinct titles and
+// summaries, and unites tags, articles and article titles. A threshold of
+// zero or less disables merging.
+func mergeSimilarNewsDigestTopics(topics []NewsDigestTopic, threshold float64) []NewsDigestTopic {
+	if threshold <= 0 || len(topics) < 2 {
+		return topics
+	}
+	merged := make([]NewsDigestTopic, 0, len(topics))
+	for _, topic := range topics {
+		index := slices.IndexFunc(merged, func(existing NewsDigestTopic) bool {
+			return jaccardSimilarity(existing.ArticleGUIDs, topic.ArticleGUIDs) >= threshold
 		})
+		if index < 0 {
+			merged = append(merged, topic)
+			continue
+		}
+		existing := &merged[index]
+		existing.Title = joinDistinct(existing.Title, topic.Title, " / ")
+		existing.Summary = joinDistinct(existing.Summary, topic.Summary, "\n\n")
+		existing.Tags = append(slices.Clip(existing.Tags), topic.Tags...)
+		for _, guid := range topic.ArticleGUIDs {
+			if !slices.Contains(existing.ArticleGUIDs, guid) {
+				existing.ArticleGUIDs = append(existing.ArticleGUIDs, guid)
+			}
+		}
+		if len(topic.ArticleTitles) > 0 {
+			titles := maps.Clone(topic.ArticleTitles)
+			maps.Copy(titles, existing.ArticleTitles)
+			existing.ArticleTitles = titles
+		}
 	}
+	return merged
+}
 
-	return normalized
+// jaccardSimilarity returns |a ∩ b| / |a ∪ b| for two lists of distinct values.
+func jaccardSimilarity(a, b []string) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for _, value := range b {
+		if slices.Contains(a, value) {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// joinDistinct appends next to base with sep unless base already reads the
+// same, ignoring case.
+func joinDistinct(base, next, sep string) string {
+	if strings.EqualFold(base, next) {
+		return base
+	}
+	return base + sep + next
+}
+
+// normalizeArticleTitles keeps non-empty translated titles of the topic's own articles.
+func normalizeArticleTitles(titles map[string]string, guids map[string]struct{}) map[string]string {
+	if len(titles) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(titles))
+	for guid, title := range titles {
+		guid = strings.TrimSpace(guid)
+		title = strings.TrimSpace(title)
+		if title == "" {
+			continue
+		}
+		if _, ok := guids[guid]; !ok {
+			continue
+		}
+		out[guid] = title
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
 }
 
 func buildDigestHistoryItems(dateKey string, topics []NewsDigestTopic, savedAt time.Time, loc *time.Location) []*reading.HistoryItem {
@@ -244,13 +430,14 @@ func buildDigestHistoryItems(dateKey string, topics []NewsDigestTopic, savedAt t
 			Content:     topic.Summary,
 			Published:   dateKey,
 			// Keep topic order within one run while making newer runs naturally sort first.
-			Date:         runAt.Add(-time.Duration(index) * time.Nanosecond),
-			FeedTitle:    "Daily News",
-			FeedURL:      reading.NewsURL,
-			SavedAt:      savedAt,
-			DigestDate:   dateKey,
-			AITags:       append([]string(nil), topic.Tags...),
-			RelatedGUIDs: append([]string(nil), topic.ArticleGUIDs...),
+			Date:          runAt.Add(-time.Duration(index) * time.Nanosecond),
+			FeedTitle:     "Daily News",
+			FeedURL:       reading.NewsURL,
+			SavedAt:       savedAt,
+			DigestDate:    dateKey,
+			AITags:        append([]string(nil), topic.Tags...),
+			RelatedGUIDs:  append([]string(nil), topic.ArticleGUIDs...),
+			RelatedTitles: maps.Clone(topic.ArticleTitles),
 		})
 	}
 	return items
@@ -265,6 +452,7 @@ func cloneHistoryItems(items []*reading.HistoryItem) []*reading.HistoryItem {
 		copyItem := *item
 		copyItem.AITags = slices.Clone(item.AITags)
 		copyItem.RelatedGUIDs = slices.Clone(item.RelatedGUIDs)
+		copyItem.RelatedTitles = maps.Clone(item.RelatedTitles)
 		cloned = append(cloned, &copyItem)
 	}
 	return cloned
@@ -316,11 +504,12 @@ func buildNewsDigestPrompt(req NewsDigestRequest) string {
 	return strings.Join([]string{
 		"You are helping an RSS reader create a daily news digest.",
 		"Group today's articles into coherent topics and summarize each topic.",
-		`Return ONLY valid JSON without markdown: {"topics":[{"title":"...","summary":"...","tags":["..."],"article_guids":["..."]}]}`,
+		`Return ONLY valid JSON without markdown: {"topics":[{"title":"...","summary":"...","tags":["..."],"article_guids":["..."],"article_titles":{"<guid>":"..."}}]}`,
 		"Rules:",
 		"- summary: Japanese (ja-JP), concise and factual.",
 		"- tags: short English tags, 2 to 8 items, no duplicates.",
 		"- article_guids: must reference only provided GUIDs.",
+		"- article_titles: Japanese (ja-JP) translation of each referenced article title, keyed by GUID.",
 		"- ignore malformed entries and produce the best possible result.",
 		"Input JSON:",
 		string(data),
@@ -329,10 +518,11 @@ func buildNewsDigestPrompt(req NewsDigestRequest) string {
 
 func parseNewsDigestOutput(raw string) ([]NewsDigestTopic, error) {
 	type topicPayload struct {
-		Title        string   `json:"title"`
-		Summary      string   `json:"summary"`
-		Tags         []string `json:"tags"`
-		ArticleGUIDs []string `json:"article_guids"`
+		Title         string            `json:"title"`
+		Summary       string            `json:"summary"`
+		Tags          []string          `json:"tags"`
+		ArticleGUIDs  []string          `json:"article_guids"`
+		ArticleTitles map[string]string `json:"article_titles"`
 	}
 	type payload struct {
 		Topics []topicPayload `json:"topics"`
@@ -351,10 +541,11 @@ func parseNewsDigestOutput(raw string) ([]NewsDigestTopic, error) {
 		result := make([]NewsDigestTopic, 0, len(out.Topics))
 		for _, topic := range out.Topics {
 			result = append(result, NewsDigestTopic{
-				Title:        topic.Title,
-				Summary:      topic.Summary,
-				Tags:         topic.Tags,
-				ArticleGUIDs: topic.ArticleGUIDs,
+				Title:         topic.Title,
+				Summary:       topic.Summary,
+				Tags:          topic.Tags,
+				ArticleGUIDs:  topic.ArticleGUIDs,
+				ArticleTitles: topic.ArticleTitles,
 			})
 		}
 		return result, nil
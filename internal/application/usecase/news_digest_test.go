@@ -104,6 +104,39 @@ func TestNewsDigestService_BuildDaily_ForceRegenerates(t *testing.T) {
 	gen.AssertExpectations(t)
 }
 
+func TestNewsDigestService_BuildDaily_StripsBoilerplate(t *testing.T) {
+	loc := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 2, 14, 9, 0, 0, 0, loc)
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"a1": {
+			GUID:        "a1",
+			Title:       "Article 1",
+			FeedURL:     "feed1",
+			Description: "Summary. Share on Twitter",
+			Content:     "Body. Subscribe to our newsletter for more.",
+			Date:        time.Date(2026, 2, 14, 8, 0, 0, 0, loc),
+		},
+	})
+	gen := &mockNewsDigestGenerator{}
+	gen.On("Generate", mock.Anything, mock.Anything).Return([]NewsDigestTopic{
+		{Title: "Topic", Summary: "Summary", ArticleGUIDs: []string{"a1"}},
+	}, nil).Once()
+	sanitizer, err := reading.NewContentSanitizer([]string{"Share on Twitter", "(?s)Subscribe to our newsletter.*$"})
+	if err != nil {
+		t.Fatalf("NewContentSanitizer() error = %v", err)
+	}
+	svc := NewNewsDigestService(gen, func() time.Time { return now }, func() *time.Location { return loc })
+	svc.Sanitizer = sanitizer
+
+	if _, err := svc.BuildDaily(context.Background(), history, []string{"feed1"}, true); err != nil {
+		t.Fatalf("BuildDaily() error = %v", err)
+	}
+	article := gen.lastReq.Articles[0]
+	if article.Description != "Summary." || article.Content != "Body." {
+		t.Fatalf("digest prompt kept boilerplate: description %q, content %q", article.Description, article.Content)
+	}
+}
+
 func TestBuildDigestHistoryItems_UsesRunSpecificGUID(t *testing.T) {
 	loc := time.FixedZone("JST", 9*60*60)
 	topics := []NewsDigestTopic{
@@ -153,6 +186,10 @@ func TestNewsDigestService_BuildDaily_LimitsAndFilters(t *testing.T) {
 			Summary:      "S1",
 			Tags:         []string{"go", "go", "rss"},
 			ArticleGUIDs: []string{"a0", "unknown"},
+			ArticleTitles: map[string]string{
+				"a0":      " 記事 ",
+				"unknown": "不明",
+			},
 		},
 		{
 			Title:        " ",
@@ -178,6 +215,71 @@ func TestNewsDigestService_BuildDaily_LimitsAndFilters(t *testing.T) {
 	if len(got.Items[0].AITags) != 2 {
 		t.Fatalf("tags should be normalized, got %#v", got.Items[0].AITags)
 	}
+	if titles := got.Items[0].RelatedTitles; len(titles) != 1 || titles["a0"] != "記事" {
+		t.Fatalf("related titles = %#v, want only a0", titles)
+	}
+	gen.AssertExpectations(t)
+}
+
+func TestNewsDigestService_BuildDaily_TruncatesOversizedResponse(t *testing.T) {
+	loc := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 2, 14, 9, 0, 0, 0, loc)
+	items := make(map[string]*reading.HistoryItem)
+	guids := make([]string, 0, 50)
+	for i := range 50 {
+		guid := "a" + strconv.Itoa(i)
+		guids = append(guids, guid)
+		items[guid] = &reading.HistoryItem{
+			GUID:    guid,
+			Kind:    reading.ArticleKind,
+			Title:   "Article",
+			FeedURL: "feed1",
+			Date:    now.Add(-time.Duration(i) * time.Minute),
+		}
+	}
+	topics := make([]NewsDigestTopic, 0, 1000)
+	for i := range 1000 {
+		topics = append(topics, NewsDigestTopic{
+			Title:         "T" + strconv.Itoa(i),
+			Summary:       "S",
+			ArticleGUIDs:  guids,
+			ArticleTitles: map[string]string{"a0": "first", "a49": "last"},
+		})
+	}
+	gen := &mockNewsDigestGenerator{}
+	gen.On("Generate", mock.Anything, mock.Anything).Return(topics, nil).Twice()
+
+	svc := NewNewsDigestService(gen, func() time.Time { return now }, func() *time.Location { return loc })
+	got, err := svc.BuildDaily(context.Background(), reading.NewHistory(items), []string{"feed1"}, true)
+	if err != nil {
+		t.Fatalf("BuildDaily() error = %v", err)
+	}
+	if len(got.Items) != DefaultMaxNewsDigestTopics {
+		t.Fatalf("default topics = %d, want %d", len(got.Items), DefaultMaxNewsDigestTopics)
+	}
+	if len(got.Items[0].RelatedGUIDs) != DefaultMaxNewsDigestTopicArticles {
+		t.Fatalf("default related guids = %d, want %d", len(got.Items[0].RelatedGUIDs), DefaultMaxNewsDigestTopicArticles)
+	}
+
+	svc.MaxTopics = 3
+	svc.MaxTopicArticles = 2
+	got, err = svc.BuildDaily(context.Background(), reading.NewHistory(items), []string{"feed1"}, true)
+	if err != nil {
+		t.Fatalf("BuildDaily() error = %v", err)
+	}
+	if len(got.Items) != 3 {
+		t.Fatalf("topics = %d, want 3", len(got.Items))
+	}
+	if related := got.Items[0].RelatedGUIDs; len(related) != 2 || related[0] != "a0" || related[1] != "a1" {
+		t.Fatalf("related guids = %#v, want first two articles", related)
+	}
+	if titles := got.Items[0].RelatedTitles; len(titles) != 1 || titles["a0"] != "first" {
+		t.Fatalf("related titles = %#v, want only kept articles", titles)
+	}
+	want := "News truncated: kept 3 of 1000 topics, 3 topics trimmed to 2 articles"
+	if got.Note != want {
+		t.Fatalf("note = %q, want %q", got.Note, want)
+	}
 	gen.AssertExpectations(t)
 }
 
@@ -194,7 +296,7 @@ func TestNewsDigestService_BuildDaily_Errors(t *testing.T) {
 
 func TestPromptNewsDigestGenerator_Generate(t *testing.T) {
 	client := &mockTextGenerator{}
-	client.On("Generate", mock.Anything, mock.AnythingOfType("string")).Return(`{"topics":[{"title":"Top","summary":"要約","tags":["go","rss"],"article_guids":["a1","a2"]}]}`, nil).Once()
+	client.On("Generate", mock.Anything, mock.AnythingOfType("string")).Return(`{"topics":[{"title":"Top","summary":"要約","tags":["go","rss"],"article_guids":["a1","a2"],"article_titles":{"a1":"記事1"}}]}`, nil).Once()
 	gen := NewPromptNewsDigestGenerator(client)
 
 	topics, err := gen.Generate(context.Background(), NewsDigestRequest{
@@ -212,6 +314,9 @@ func TestPromptNewsDigestGenerator_Generate(t *testing.T) {
 	if topics[0].Title != "Top" {
 		t.Fatalf("title = %q, want Top", topics[0].Title)
 	}
+	if topics[0].ArticleTitles["a1"] != "記事1" {
+		t.Fatalf("article titles = %#v, want a1 translation", topics[0].ArticleTitles)
+	}
 	calls := client.Calls
 	if len(calls) != 1 {
 		t.Fatalf("expected one Generate call, got %d", len(calls))
@@ -274,3 +379,57 @@ func TestPromptNewsDigestGenerator_GenerateErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeNewsDigestTopics_MergesNearDuplicates(t *testing.T) {
+	source := []NewsDigestArticle{{GUID: "a"}, {GUID: "b"}, {GUID: "c"}, {GUID: "d"}, {GUID: "e"}, {GUID: "x"}, {GUID: "y"}}
+	topics := []NewsDigestTopic{
+		{Title: "Chip export rules", Summary: "New rules announced.", Tags: []string{"policy"}, ArticleGUIDs: []string{"a", "b", "c", "d"}, ArticleTitles: map[string]string{"a": "A"}},
+		{Title: "Local election", Summary: "Results are in.", ArticleGUIDs: []string{"x", "y"}},
+		{Title: "Chip Export Rules", Summary: "Industry reacts.", Tags: []string{"chips"}, ArticleGUIDs: []string{"a", "b", "c", "d", "e"}, ArticleTitles: map[string]string{"a": "other", "e": "E"}},
+	}
+	limits := newsDigestLimits{topics: DefaultMaxNewsDigestTopics, topicArticles: DefaultMaxNewsDigestTopicArticles}
+
+	got, _ := normalizeNewsDigestTopics(topics, source, limits, 0.8)
+	if len(got) != 2 {
+		t.Fatalf("topics = %d, want 2: %#v", len(got), got)
+	}
+	merged := got[0]
+	if merged.Title != "Chip export rules" {
+		t.Fatalf("title = %q, want the first title once", merged.Title)
+	}
+	if merged.Summary != "New rules announced.\n\nIndustry reacts." {
+		t.Fatalf("summary = %q", merged.Summary)
+	}
+	if strings.Join(merged.ArticleGUIDs, ",") != "a,b,c,d,e" {
+		t.Fatalf("guids = %#v", merged.ArticleGUIDs)
+	}
+	if strings.Join(merged.Tags, ",") != "policy,chips" {
+		t.Fatalf("tags = %#v", merged.Tags)
+	}
+	if merged.ArticleTitles["a"] != "A" || merged.ArticleTitles["e"] != "E" {
+		t.Fatalf("article titles = %#v", merged.ArticleTitles)
+	}
+	if got[1].Title != "Local election" {
+		t.Fatalf("distinct topic = %#v", got[1])
+	}
+
+	got, _ = normalizeNewsDigestTopics(topics, source, limits, 0)
+	if len(got) != 3 {
+		t.Fatalf("threshold 0 should not merge, got %d topics", len(got))
+	}
+}
+
+func TestNormalizeNewsDigestTopics_KeepsDistinctTopics(t *testing.T) {
+	source := []NewsDigestArticle{{GUID: "a"}, {GUID: "b"}, {GUID: "c"}, {GUID: "d"}}
+	topics := []NewsDigestTopic{
+		{Title: "One", Summary: "S1", ArticleGUIDs: []string{"a", "b", "c"}},
+		{Title: "Two", Summary: "S2", ArticleGUIDs: []string{"b", "c", "d"}},
+	}
+	limits := newsDigestLimits{topics: DefaultMaxNewsDigestTopics, topicArticles: DefaultMaxNewsDigestTopicArticles}
+
+	// Jaccard similarity is 2/4, below the threshold.
+	got, _ := normalizeNewsDigestTopics(topics, source, limits, 0.8)
+	if len(got) != 2 || got[0].Title != "One" || got[1].Title != "Two" {
+		t.Fatalf("topics = %#v, want both kept", got)
+	}
+}
@@ -2,6 +2,9 @@
 package usecase
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -12,6 +15,16 @@ import (
 type FeedFetchOptions struct {
 	PerFeedTimeout time.Duration
 	BatchTimeout   time.Duration
+	// OnProgress, when set, is called once per feed as its fetch completes.
+	// Calls are serialized and must not block.
+	OnProgress func(FeedFetchProgress)
+}
+
+// FeedFetchProgress reports the outcome of one feed during a multi-feed fetch.
+type FeedFetchProgress struct {
+	URL      string
+	Err      error
+	TimedOut bool
 }
 
 // FeedFetchReport represents aggregate results of multi-feed fetching.
@@ -20,6 +33,8 @@ type FeedFetchReport struct {
 	Succeeded int
 	Failed    int
 	TimedOut  int
+	// Moved maps subscribed URLs to their permanent redirect targets.
+	Moved map[string]string
 }
 
 var defaultFeedFetchOptions = FeedFetchOptions{
@@ -29,10 +44,34 @@ var defaultFeedFetchOptions = FeedFetchOptions{
 
 // FeedFetcher abstracts RSS fetching.
 type FeedFetcher interface {
-	Fetch(url string) (*reading.Feed, error)
-	FetchAll(urls []string, opt FeedFetchOptions) (*reading.Feed, FeedFetchReport, error)
+	Fetch(ctx context.Context, url string) (*reading.Feed, error)
+	FetchAll(ctx context.Context, urls []string, opt FeedFetchOptions) (*reading.Feed, FeedFetchReport, error)
+}
+
+// ArticleExtractor abstracts full-text extraction from an article page.
+type ArticleExtractor interface {
+	ExtractArticle(ctx context.Context, link string) (string, error)
+}
+
+// NewItemEvent describes an unread article a fetch added to history.
+type NewItemEvent struct {
+	GUID      string
+	Title     string
+	Link      string
+	Published string
+	FeedTitle string
+	FeedURL   string
+}
+
+// NewItemHook is told about the articles each merge adds, e.g. to run a
+// user command for desktop notifications. It must not block.
+type NewItemHook interface {
+	NotifyNewItems(events []NewItemEvent)
 }
 
+// ErrFullTextUnavailable is returned when no article extractor is configured.
+var ErrFullTextUnavailable = errors.New("full text extraction is not available")
+
 // HistoryRepository abstracts history persistence.
 type HistoryRepository interface {
 	LoadMetadata() (map[string]*reading.HistoryItem, error)
@@ -45,11 +84,109 @@ type HistoryRepository interface {
 	LoadTodayArticles(dateKey string, feeds []string, limit int, loc *time.Location) ([]*reading.HistoryItem, error)
 }
 
+type historyClearer interface {
+	ClearAll() error
+	ClearUnbookmarked() error
+}
+
+type historyOptimizer interface {
+	Optimize() (before, after int64, err error)
+}
+
+type historyClearPreviewer interface {
+	CountClearAll(limit int) (int, []string, error)
+	CountClearUnbookmarked(limit int) (int, []string, error)
+}
+
+type historyCloser interface {
+	Close() error
+}
+
+type newItemHookWaiter interface {
+	Wait()
+}
+
+type batchReadSetter interface {
+	SetReadMany(guids []string, isRead bool) error
+}
+
+type openCounter interface {
+	IncrementOpenCount(guid string) error
+}
+
+type scrollOffsetSetter interface {
+	SetScrollOffset(guid string, offset int) error
+}
+
+type feedURLMover interface {
+	MoveFeedURL(from, to string) error
+}
+
+type dismisser interface {
+	SetDismissed(guid string, dismissed bool) error
+}
+
+type legacyHistoryImporter interface {
+	ImportLegacyJSONL() (int, error)
+}
+
+type historyFileImporter interface {
+	ImportJSONL(path string) (imported, skipped int, err error)
+}
+
+// HistoryImportReport counts the outcome of importing a history file.
+type HistoryImportReport struct {
+	Imported int
+	Skipped  int
+}
+
+// HistoryOptimizeReport gives the history database size in bytes before and
+// after an optimization.
+type HistoryOptimizeReport struct {
+	SizeBefore int64
+	SizeAfter  int64
+}
+
+// ClearHistoryPreview describes what ClearHistory would delete.
+type ClearHistoryPreview struct {
+	Count int
+	// Examples holds titles of the newest items that would go.
+	Examples []string
+}
+
+// MergeResult lists the history items a merge of fetched feed items touched.
+type MergeResult struct {
+	// Added holds articles history didn't have before.
+	Added []*reading.HistoryItem
+	// Updated holds known articles the fetch refreshed.
+	Updated []*reading.HistoryItem
+}
+
+// NewByFeed counts the added articles per feed URL.
+func (r MergeResult) NewByFeed() map[string]int {
+	counts := make(map[string]int)
+	for _, item := range r.Added {
+		counts[item.FeedURL]++
+	}
+	return counts
+}
+
 // ReadingService coordinates feed fetching and history persistence.
 type ReadingService struct {
 	Fetcher     FeedFetcher
 	HistoryRepo HistoryRepository
 	Now         func() time.Time
+	// Extractor, when set, fetches full article text for excerpt-only items.
+	Extractor ArticleExtractor
+	// ContentFilter marks newly merged articles read when they are too short.
+	ContentFilter reading.ContentFilter
+	// NewItemHook, when set, is told about the unread articles a merge adds
+	// once they are saved.
+	NewItemHook NewItemHook
+	// Markdown converts article bodies for ExportArticles; Exports stores
+	// the result. Export is unavailable while Exports is nil.
+	Markdown MarkdownConverter
+	Exports  ExportWriter
 }
 
 // NewReadingService constructs a ReadingService.
@@ -61,13 +198,22 @@ func NewReadingService(fetcher FeedFetcher, historyRepo HistoryRepository, now f
 	})
 }
 
-// FetchFeed fetches a single feed or a virtual aggregated feed.
-func (s *ReadingService) FetchFeed(url string, all []string) (*reading.Feed, FeedFetchReport, error) {
+// FetchFeed fetches a single feed or a virtual aggregated feed. Canceling ctx
+// abandons the fetch.
+func (s *ReadingService) FetchFeed(ctx context.Context, url string, all []string) (*reading.Feed, FeedFetchReport, error) {
+	return s.FetchFeedWithProgress(ctx, url, all, nil)
+}
+
+// FetchFeedWithProgress behaves like FetchFeed and reports per-feed completion
+// of aggregated fetches through onProgress.
+func (s *ReadingService) FetchFeedWithProgress(ctx context.Context, url string, all []string, onProgress func(FeedFetchProgress)) (*reading.Feed, FeedFetchReport, error) {
+	opts := defaultFeedFetchOptions
+	opts.OnProgress = onProgress
 	if url == reading.AllFeedsURL {
-		return s.Fetcher.FetchAll(all, defaultFeedFetchOptions)
+		return s.Fetcher.FetchAll(ctx, all, opts)
 	}
 	if url == reading.NewsURL {
-		feed, report, err := s.Fetcher.FetchAll(all, defaultFeedFetchOptions)
+		feed, report, err := s.Fetcher.FetchAll(ctx, all, opts)
 		if feed != nil {
 			feed.URL = reading.NewsURL
 			if feed.Title == "" {
@@ -84,13 +230,32 @@ func (s *ReadingService) FetchFeed(url string, all []string) (*reading.Feed, Fee
 			Items: []reading.Item{},
 		}), FeedFetchReport{}, nil
 	}
-	feed, err := s.Fetcher.Fetch(url)
+	if url == reading.DismissedURL {
+		return new(reading.Feed{
+			Title: "Dismissed",
+			URL:   reading.DismissedURL,
+			Items: []reading.Item{},
+		}), FeedFetchReport{}, nil
+	}
+	if opts.PerFeedTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.PerFeedTimeout)
+		defer cancel()
+	}
+	feed, err := s.Fetcher.Fetch(ctx, url)
 	report := FeedFetchReport{Requested: 1}
-	if err != nil {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		report.TimedOut = 1
+		err = fmt.Errorf("feed timed out after %s: %w", opts.PerFeedTimeout, err)
+	case err != nil:
 		report.Failed = 1
-	} else {
+	default:
 		report.Succeeded = 1
 	}
+	if feed != nil && feed.MovedTo != "" {
+		report.Moved = map[string]string{url: feed.MovedTo}
+	}
 	return feed, report, err
 }
 
@@ -103,6 +268,80 @@ func (s *ReadingService) LoadHistoryMetadata() (*reading.History, error) {
 	return reading.NewHistory(items), err
 }
 
+// ImportLegacyHistory copies a pre-SQLite JSONL history into an empty store
+// when the repository supports it, returning how many items were imported.
+func (s *ReadingService) ImportLegacyHistory() (int, bool, error) {
+	repo, ok := s.HistoryRepo.(legacyHistoryImporter)
+	if !ok {
+		return 0, false, nil
+	}
+	count, err := repo.ImportLegacyJSONL()
+	return count, true, err
+}
+
+// ImportHistoryFile merges a legacy JSONL history file into persistence when
+// the repository supports it. Malformed lines are skipped and counted.
+func (s *ReadingService) ImportHistoryFile(path string) (HistoryImportReport, bool, error) {
+	repo, ok := s.HistoryRepo.(historyFileImporter)
+	if !ok {
+		return HistoryImportReport{}, false, nil
+	}
+	if strings.TrimSpace(path) == "" {
+		return HistoryImportReport{}, true, errors.New("history file path is empty")
+	}
+	imported, skipped, err := repo.ImportJSONL(path)
+	return HistoryImportReport{Imported: imported, Skipped: skipped}, true, err
+}
+
+// OptimizeHistory compacts the history database and refreshes its indexes
+// when the repository supports it.
+func (s *ReadingService) OptimizeHistory() (HistoryOptimizeReport, bool, error) {
+	repo, ok := s.HistoryRepo.(historyOptimizer)
+	if !ok {
+		return HistoryOptimizeReport{}, false, nil
+	}
+	before, after, err := repo.Optimize()
+	return HistoryOptimizeReport{SizeBefore: before, SizeAfter: after}, true, err
+}
+
+// ClearHistory deletes persisted history, optionally keeping bookmarks, and
+// reloads the remaining metadata when the repository supports clearing.
+func (s *ReadingService) ClearHistory(keepBookmarks bool) (*reading.History, bool, error) {
+	repo, ok := s.HistoryRepo.(historyClearer)
+	if !ok {
+		return nil, false, nil
+	}
+	var err error
+	if keepBookmarks {
+		err = repo.ClearUnbookmarked()
+	} else {
+		err = repo.ClearAll()
+	}
+	if err != nil {
+		return nil, true, err
+	}
+	history, err := s.LoadHistoryMetadata()
+	return history, true, err
+}
+
+// PreviewClearHistory reports what ClearHistory(keepBookmarks) would delete,
+// with up to limit example titles, without deleting anything.
+func (s *ReadingService) PreviewClearHistory(keepBookmarks bool, limit int) (ClearHistoryPreview, bool, error) {
+	repo, ok := s.HistoryRepo.(historyClearPreviewer)
+	if !ok {
+		return ClearHistoryPreview{}, false, nil
+	}
+	count := repo.CountClearAll
+	if keepBookmarks {
+		count = repo.CountClearUnbookmarked
+	}
+	n, examples, err := count(limit)
+	if err != nil {
+		return ClearHistoryPreview{}, true, err
+	}
+	return ClearHistoryPreview{Count: n, Examples: examples}, true, nil
+}
+
 // LoadHistoryItem loads one fully-hydrated history item by GUID.
 func (s *ReadingService) LoadHistoryItem(guid string) (*reading.HistoryItem, error) {
 	if s.HistoryRepo == nil || strings.TrimSpace(guid) == "" {
@@ -111,6 +350,50 @@ func (s *ReadingService) LoadHistoryItem(guid string) (*reading.HistoryItem, err
 	return s.HistoryRepo.LoadByGUID(guid)
 }
 
+// NeedsFullText reports whether an item only carries the feed's excerpt,
+// i.e. it has no content beyond its description.
+func NeedsFullText(item *reading.HistoryItem) bool {
+	if item == nil || strings.TrimSpace(item.Link) == "" {
+		return false
+	}
+	content := strings.TrimSpace(item.Content)
+	return content == "" || content == strings.TrimSpace(item.Description)
+}
+
+// HydrateFullText replaces an excerpt-only item's content with the text
+// extracted from its article page and persists it. It reports whether the
+// content changed; items that already have full content are left alone.
+func (s *ReadingService) HydrateFullText(ctx context.Context, item *reading.HistoryItem) (bool, error) {
+	if s.Extractor == nil || !NeedsFullText(item) {
+		return false, nil
+	}
+	if err := s.FetchFullText(ctx, item); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// FetchFullText replaces an item's content with the text extracted from its
+// article page and persists it, whatever the item currently holds. On error
+// the item is left unchanged.
+func (s *ReadingService) FetchFullText(ctx context.Context, item *reading.HistoryItem) error {
+	if s.Extractor == nil {
+		return ErrFullTextUnavailable
+	}
+	if item == nil || strings.TrimSpace(item.Link) == "" {
+		return errors.New("article has no link")
+	}
+	text, err := s.Extractor.ExtractArticle(ctx, item.Link)
+	if err != nil {
+		return err
+	}
+	item.Content = text
+	if s.HistoryRepo == nil {
+		return nil
+	}
+	return s.HistoryRepo.Upsert([]*reading.HistoryItem{item})
+}
+
 // LoadTodayArticles loads today's source articles for digest generation.
 func (s *ReadingService) LoadTodayArticles(dateKey string, feeds []string, limit int, loc *time.Location) ([]*reading.HistoryItem, error) {
 	if s.HistoryRepo == nil {
@@ -119,16 +402,89 @@ func (s *ReadingService) LoadTodayArticles(dateKey string, feeds []string, limit
 	return s.HistoryRepo.LoadTodayArticles(dateKey, feeds, limit, loc)
 }
 
-// MergeHistory merges fetched feed items into history and persists updated items.
-func (s *ReadingService) MergeHistory(history *reading.History, feed *reading.Feed) error {
-	if history == nil {
-		return nil
+// MergeHistory merges fetched feed items into history and persists the
+// items that changed, reporting which were added and which updated. Added
+// articles caught by ContentFilter are saved already read; the others are
+// passed to NewItemHook.
+func (s *ReadingService) MergeHistory(history *reading.History, feed *reading.Feed) (MergeResult, error) {
+	var result MergeResult
+	if history == nil || feed == nil {
+		return result, nil
+	}
+	known := make(map[string]bool, len(feed.Items))
+	for _, it := range feed.Items {
+		guid := it.HistoryGUID()
+		if _, exists := history.Item(guid); exists {
+			known[guid] = true
+		}
 	}
 	changed := history.MergeFeed(feed, s.now())
-	if len(changed) == 0 || s.HistoryRepo == nil {
+	listed := make(map[string]bool, len(changed))
+	for _, item := range changed {
+		// A feed repeating an item reports it once.
+		if listed[item.GUID] {
+			continue
+		}
+		listed[item.GUID] = true
+		if known[item.GUID] {
+			result.Updated = append(result.Updated, item)
+			continue
+		}
+		if s.ContentFilter.Filters(item) {
+			history.MarkRead(item.GUID)
+		}
+		result.Added = append(result.Added, item)
+	}
+	if len(changed) > 0 && s.HistoryRepo != nil {
+		if err := s.HistoryRepo.Upsert(changed); err != nil {
+			return result, err
+		}
+	}
+	s.notifyNewItems(result.Added)
+	return result, nil
+}
+
+// notifyNewItems passes the unread added articles to NewItemHook. Events
+// are copies, so the hook may read them while history changes.
+func (s *ReadingService) notifyNewItems(added []*reading.HistoryItem) {
+	if s.NewItemHook == nil {
+		return
+	}
+	events := make([]NewItemEvent, 0, len(added))
+	for _, item := range added {
+		if item.IsRead {
+			continue
+		}
+		events = append(events, NewItemEvent{
+			GUID:      item.GUID,
+			Title:     item.Title,
+			Link:      item.Link,
+			Published: item.Published,
+			FeedTitle: item.FeedTitle,
+			FeedURL:   item.FeedURL,
+		})
+	}
+	if len(events) > 0 {
+		s.NewItemHook.NotifyNewItems(events)
+	}
+}
+
+// WaitNewItemHook blocks until work NewItemHook started in the background,
+// such as running a user command, has finished.
+func (s *ReadingService) WaitNewItemHook() {
+	if hook, ok := s.NewItemHook.(newItemHookWaiter); ok {
+		hook.Wait()
+	}
+}
+
+// Close releases the history repository when it holds resources such as a
+// database connection. The service can't save history afterwards.
+func (s *ReadingService) Close() error {
+	repo, ok := s.HistoryRepo.(historyCloser)
+	if !ok {
 		return nil
 	}
-	return s.HistoryRepo.Upsert(changed)
+	return repo.Close()
 }
 
 // MarkRead marks an article as read and persists the change.
@@ -145,6 +501,75 @@ func (s *ReadingService) MarkRead(history *reading.History, guid string) error {
 	return s.HistoryRepo.SetRead(guid, true)
 }
 
+// MarkFeedRead marks every unread article of a feed read and persists the
+// change, in one batch when the repository supports it. It returns how many
+// articles were marked.
+func (s *ReadingService) MarkFeedRead(history *reading.History, feedURL string) (int, error) {
+	if history == nil || strings.TrimSpace(feedURL) == "" {
+		return 0, nil
+	}
+	guids := history.MarkFeedRead(feedURL)
+	if len(guids) == 0 || s.HistoryRepo == nil {
+		return len(guids), nil
+	}
+	if repo, ok := s.HistoryRepo.(batchReadSetter); ok {
+		return len(guids), repo.SetReadMany(guids, true)
+	}
+	for _, guid := range guids {
+		if err := s.HistoryRepo.SetRead(guid, true); err != nil {
+			return len(guids), err
+		}
+	}
+	return len(guids), nil
+}
+
+// MoveFeed rewrites the feed URL of a moved feed's articles, so they stay
+// under the feed, and persists it when the repository supports moves.
+func (s *ReadingService) MoveFeed(history *reading.History, from, to string) error {
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if history == nil || from == "" || to == "" || from == to {
+		return nil
+	}
+	if history.MoveFeed(from, to) == 0 {
+		return nil
+	}
+	repo, ok := s.HistoryRepo.(feedURLMover)
+	if !ok {
+		return nil
+	}
+	return repo.MoveFeedURL(from, to)
+}
+
+// RecordOpen counts one more open of an article and persists it when the
+// repository supports open counts.
+func (s *ReadingService) RecordOpen(history *reading.History, guid string) (int, error) {
+	if history == nil || strings.TrimSpace(guid) == "" {
+		return 0, nil
+	}
+	count, ok := history.IncrementOpenCount(guid)
+	if !ok {
+		return 0, nil
+	}
+	repo, ok := s.HistoryRepo.(openCounter)
+	if !ok {
+		return count, nil
+	}
+	return count, repo.IncrementOpenCount(guid)
+}
+
+// SaveScrollOffset remembers where the reading pane was left in an article
+// and persists it when the repository supports scroll offsets.
+func (s *ReadingService) SaveScrollOffset(history *reading.History, guid string, offset int) error {
+	if history == nil || !history.SetScrollOffset(guid, offset) {
+		return nil
+	}
+	repo, ok := s.HistoryRepo.(scrollOffsetSetter)
+	if !ok {
+		return nil
+	}
+	return repo.SetScrollOffset(guid, offset)
+}
+
 // ToggleBookmark toggles the bookmark status of an item and persists the change.
 func (s *ReadingService) ToggleBookmark(history *reading.History, guid string) error {
 	if history == nil || strings.TrimSpace(guid) == "" {
@@ -163,6 +588,22 @@ func (s *ReadingService) ToggleBookmark(history *reading.History, guid string) e
 	return nil
 }
 
+// SetDismissed dismisses or restores an article and persists it when the
+// repository supports dismissing.
+func (s *ReadingService) SetDismissed(history *reading.History, guid string, dismissed bool) error {
+	if history == nil || strings.TrimSpace(guid) == "" {
+		return nil
+	}
+	if !history.SetDismissed(guid, dismissed) {
+		return nil
+	}
+	repo, ok := s.HistoryRepo.(dismisser)
+	if !ok {
+		return nil
+	}
+	return repo.SetDismissed(guid, dismissed)
+}
+
 // ApplyInsight applies AI-generated insight and persists only updated fields.
 func (s *ReadingService) ApplyInsight(history *reading.History, guid string, insight Insight) (time.Time, bool, error) {
 	updatedAt := s.now()
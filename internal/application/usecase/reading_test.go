@@ -1,6 +1,9 @@
 package usecase
 
 import (
+	"context"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -143,6 +146,380 @@ func TestReadingService_MarkRead(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestReadingService_MergeHistoryReportsAddedAndUpdated(t *testing.T) {
+	repo := &mockHistoryRepo{}
+	svc := NewReadingService(nil, repo, func() time.Time { return time.Unix(100, 0) })
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"old": {GUID: "old", Title: "Old", FeedURL: "a", Kind: reading.ArticleKind},
+	})
+	feed := &reading.Feed{Items: []reading.Item{
+		{GUID: "old", Title: "Old, retitled", FeedURL: "a"},
+		{GUID: "a1", Title: "A1", FeedURL: "a"},
+		{Link: "https://b.example/1", Title: "B1", FeedURL: "b"},
+		{Link: "https://b.example/1", Title: "B1", FeedURL: "b"},
+	}}
+
+	repo.On("Upsert", mock.Anything).Return(nil).Once()
+	result, err := svc.MergeHistory(history, feed)
+	if err != nil {
+		t.Fatalf("MergeHistory() error = %v", err)
+	}
+	if len(result.Added) != 2 || len(result.Updated) != 1 || result.Updated[0].GUID != "old" {
+		t.Fatalf("Added = %d, Updated = %v; want two added and old updated", len(result.Added), result.Updated)
+	}
+	if counts := result.NewByFeed(); counts["a"] != 1 || counts["b"] != 1 {
+		t.Fatalf("NewByFeed() = %v, want one new article per feed", counts)
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestReadingService_MergeHistoryMarksShortArticlesRead(t *testing.T) {
+	repo := &mockHistoryRepo{}
+	svc := NewReadingService(nil, repo, nil)
+	svc.ContentFilter = reading.ContentFilter{MinLength: 20, FeedMinLength: map[string]int{"b": 5}}
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"known": {GUID: "known", Title: "Hi", FeedURL: "a", Kind: reading.ArticleKind},
+	})
+	feed := &reading.Feed{Items: []reading.Item{
+		{GUID: "known", Title: "Hi", Description: "<p>x</p>", FeedURL: "a"},
+		{GUID: "stub", Title: "Links", Description: "<a href=\"https://example.com\">here</a>", FeedURL: "a"},
+		{GUID: "post", Title: "A longer post", Description: "with a real description", FeedURL: "a"},
+		{GUID: "terse", Title: "Short", FeedURL: "b"},
+	}}
+
+	repo.On("Upsert", mock.Anything).Return(nil).Once()
+	if _, err := svc.MergeHistory(history, feed); err != nil {
+		t.Fatalf("MergeHistory() error = %v", err)
+	}
+	for guid, wantRead := range map[string]bool{"known": false, "stub": true, "post": false, "terse": false} {
+		item, _ := history.Item(guid)
+		if item.IsRead != wantRead {
+			t.Errorf("%s read = %v, want %v", guid, item.IsRead, wantRead)
+		}
+	}
+	repo.AssertExpectations(t)
+}
+
+type recordingNewItemHook struct {
+	events []NewItemEvent
+}
+
+func (h *recordingNewItemHook) NotifyNewItems(events []NewItemEvent) {
+	h.events = append(h.events, events...)
+}
+
+func TestReadingService_MergeHistoryNotifiesNewItemHook(t *testing.T) {
+	repo := &mockHistoryRepo{}
+	hook := &recordingNewItemHook{}
+	svc := NewReadingService(nil, repo, nil)
+	svc.ContentFilter = reading.ContentFilter{MinLength: 5}
+	svc.NewItemHook = hook
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"old": {GUID: "old", Title: "Old article", FeedURL: "a", Kind: reading.ArticleKind},
+	})
+	feed := &reading.Feed{Items: []reading.Item{
+		{GUID: "old", Title: "Old article, retitled", FeedURL: "a"},
+		{GUID: "new", Title: "New article", Link: "https://a.example/new", FeedTitle: "A", FeedURL: "a"},
+		{GUID: "stub", Title: "Hi", FeedURL: "a"},
+	}}
+
+	repo.On("Upsert", mock.Anything).Return(nil).Once()
+	if _, err := svc.MergeHistory(history, feed); err != nil {
+		t.Fatalf("MergeHistory() error = %v", err)
+	}
+	want := []NewItemEvent{{GUID: "new", Title: "New article", Link: "https://a.example/new", FeedTitle: "A", FeedURL: "a"}}
+	if !reflect.DeepEqual(hook.events, want) {
+		t.Fatalf("hook events = %+v, want only the unread added article", hook.events)
+	}
+
+	repo.On("Upsert", mock.Anything).Return(errors.New("disk full")).Once()
+	feed.Items = []reading.Item{{GUID: "later", Title: "Later article", FeedURL: "a"}}
+	if _, err := svc.MergeHistory(history, feed); err == nil {
+		t.Fatal("MergeHistory() should report the save error")
+	}
+	if len(hook.events) != 1 {
+		t.Fatalf("articles that failed to save should not reach the hook, got %+v", hook.events)
+	}
+	repo.AssertExpectations(t)
+}
+
+type mockClosingRepo struct {
+	mockHistoryRepo
+}
+
+func (m *mockClosingRepo) Close() error {
+	return m.Called().Error(0)
+}
+
+func TestReadingService_Close(t *testing.T) {
+	repo := &mockClosingRepo{}
+	repo.On("Close").Return(nil).Once()
+	if err := NewReadingService(nil, repo, nil).Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	repo.AssertExpectations(t)
+
+	if err := NewReadingService(nil, &mockHistoryRepo{}, nil).Close(); err != nil {
+		t.Fatalf("Close() without a closable repo error = %v", err)
+	}
+}
+
+type mockBatchReadRepo struct {
+	mockHistoryRepo
+}
+
+func (m *mockBatchReadRepo) SetReadMany(guids []string, isRead bool) error {
+	return m.Called(guids, isRead).Error(0)
+}
+
+func TestReadingService_MarkFeedRead(t *testing.T) {
+	newHistory := func() *reading.History {
+		return reading.NewHistory(map[string]*reading.HistoryItem{
+			"1": {GUID: "1", FeedURL: "a", Kind: reading.ArticleKind},
+			"2": {GUID: "2", FeedURL: "a", Kind: reading.ArticleKind},
+			"3": {GUID: "3", FeedURL: "b", Kind: reading.ArticleKind},
+		})
+	}
+
+	batchRepo := &mockBatchReadRepo{}
+	batchRepo.On("SetReadMany", []string{"1", "2"}, true).Return(nil).Once()
+	if n, err := NewReadingService(nil, batchRepo, nil).MarkFeedRead(newHistory(), "a"); err != nil || n != 2 {
+		t.Fatalf("MarkFeedRead() = %d, %v; want 2 marked in one batch", n, err)
+	}
+	batchRepo.AssertExpectations(t)
+
+	repo := &mockHistoryRepo{}
+	repo.On("SetRead", "1", true).Return(nil).Once()
+	repo.On("SetRead", "2", true).Return(nil).Once()
+	if n, err := NewReadingService(nil, repo, nil).MarkFeedRead(newHistory(), "a"); err != nil || n != 2 {
+		t.Fatalf("MarkFeedRead() = %d, %v; want 2 marked one by one", n, err)
+	}
+	repo.AssertExpectations(t)
+}
+
+type mockOpenCountingRepo struct {
+	mockHistoryRepo
+}
+
+func (m *mockOpenCountingRepo) IncrementOpenCount(guid string) error {
+	return m.Called(guid).Error(0)
+}
+
+type mockScrollOffsetRepo struct {
+	mockHistoryRepo
+}
+
+func (m *mockScrollOffsetRepo) SetScrollOffset(guid string, offset int) error {
+	return m.Called(guid, offset).Error(0)
+}
+
+func TestReadingService_SaveScrollOffset(t *testing.T) {
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"1": {GUID: "1"},
+	})
+	repo := &mockScrollOffsetRepo{}
+	repo.On("SetScrollOffset", "1", 12).Return(nil).Once()
+	svc := NewReadingService(nil, repo, nil)
+
+	if err := svc.SaveScrollOffset(history, "1", 12); err != nil {
+		t.Fatalf("SaveScrollOffset() = %v", err)
+	}
+	// An unchanged offset or unknown article is not written again.
+	if err := svc.SaveScrollOffset(history, "1", 12); err != nil {
+		t.Fatalf("SaveScrollOffset() = %v", err)
+	}
+	if err := svc.SaveScrollOffset(history, "missing", 3); err != nil {
+		t.Fatalf("SaveScrollOffset(missing) = %v", err)
+	}
+	if item, _ := history.Item("1"); item.ScrollOffset != 12 {
+		t.Fatalf("ScrollOffset = %d, want 12", item.ScrollOffset)
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestReadingService_RecordOpen(t *testing.T) {
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"1": {GUID: "1", OpenCount: 1},
+	})
+
+	plain := NewReadingService(nil, &mockHistoryRepo{}, nil)
+	if count, err := plain.RecordOpen(history, "1"); err != nil || count != 2 {
+		t.Fatalf("RecordOpen() = %d, %v, want 2, nil", count, err)
+	}
+
+	repo := &mockOpenCountingRepo{}
+	repo.On("IncrementOpenCount", "1").Return(nil).Once()
+	svc := NewReadingService(nil, repo, nil)
+	if count, err := svc.RecordOpen(history, "1"); err != nil || count != 3 {
+		t.Fatalf("RecordOpen() = %d, %v, want 3, nil", count, err)
+	}
+	if count, _ := svc.RecordOpen(history, "missing"); count != 0 {
+		t.Fatalf("RecordOpen(missing) = %d, want 0", count)
+	}
+	repo.AssertExpectations(t)
+}
+
+type mockLegacyImportingRepo struct {
+	mockHistoryRepo
+}
+
+func (m *mockLegacyImportingRepo) ImportLegacyJSONL() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func TestReadingService_ImportLegacyHistory(t *testing.T) {
+	plain := NewReadingService(nil, &mockHistoryRepo{}, nil)
+	if count, ok, err := plain.ImportLegacyHistory(); count != 0 || ok || err != nil {
+		t.Fatalf("ImportLegacyHistory() = %d, %v, %v, want unsupported", count, ok, err)
+	}
+
+	repo := &mockLegacyImportingRepo{}
+	repo.On("ImportLegacyJSONL").Return(3, nil).Once()
+	svc := NewReadingService(nil, repo, nil)
+	if count, ok, err := svc.ImportLegacyHistory(); count != 3 || !ok || err != nil {
+		t.Fatalf("ImportLegacyHistory() = %d, %v, %v, want 3, true, nil", count, ok, err)
+	}
+	repo.AssertExpectations(t)
+}
+
+type mockHistoryFileImportingRepo struct {
+	mockHistoryRepo
+}
+
+func (m *mockHistoryFileImportingRepo) ImportJSONL(path string) (int, int, error) {
+	args := m.Called(path)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func TestReadingService_ImportHistoryFile(t *testing.T) {
+	plain := NewReadingService(nil, &mockHistoryRepo{}, nil)
+	if _, ok, err := plain.ImportHistoryFile("old.jsonl"); ok || err != nil {
+		t.Fatalf("ImportHistoryFile() = %v, %v, want unsupported", ok, err)
+	}
+
+	repo := &mockHistoryFileImportingRepo{}
+	repo.On("ImportJSONL", "old.jsonl").Return(4, 1, nil).Once()
+	svc := NewReadingService(nil, repo, nil)
+	report, ok, err := svc.ImportHistoryFile("old.jsonl")
+	if err != nil || !ok {
+		t.Fatalf("ImportHistoryFile() = %v, %v, want supported, nil", ok, err)
+	}
+	if report != (HistoryImportReport{Imported: 4, Skipped: 1}) {
+		t.Fatalf("report = %+v, want 4 imported, 1 skipped", report)
+	}
+	if _, _, err := svc.ImportHistoryFile("  "); err == nil {
+		t.Fatal("ImportHistoryFile() should reject an empty path")
+	}
+	repo.AssertExpectations(t)
+}
+
+type mockOptimizingRepo struct {
+	mockHistoryRepo
+}
+
+func (m *mockOptimizingRepo) Optimize() (int64, int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func TestReadingService_OptimizeHistory(t *testing.T) {
+	plain := NewReadingService(nil, &mockHistoryRepo{}, nil)
+	if _, ok, err := plain.OptimizeHistory(); ok || err != nil {
+		t.Fatalf("OptimizeHistory() = %v, %v, want unsupported", ok, err)
+	}
+
+	repo := &mockOptimizingRepo{}
+	repo.On("Optimize").Return(int64(4096), int64(1024), nil).Once()
+	report, ok, err := NewReadingService(nil, repo, nil).OptimizeHistory()
+	if err != nil || !ok {
+		t.Fatalf("OptimizeHistory() = %v, %v, want supported, nil", ok, err)
+	}
+	if report != (HistoryOptimizeReport{SizeBefore: 4096, SizeAfter: 1024}) {
+		t.Fatalf("report = %+v", report)
+	}
+	repo.AssertExpectations(t)
+}
+
+type mockClearPreviewRepo struct {
+	mockHistoryRepo
+}
+
+func (m *mockClearPreviewRepo) CountClearAll(limit int) (int, []string, error) {
+	args := m.Called(limit)
+	return args.Int(0), args.Get(1).([]string), args.Error(2)
+}
+
+func (m *mockClearPreviewRepo) CountClearUnbookmarked(limit int) (int, []string, error) {
+	args := m.Called(limit)
+	return args.Int(0), args.Get(1).([]string), args.Error(2)
+}
+
+func TestReadingService_PreviewClearHistory(t *testing.T) {
+	plain := NewReadingService(nil, &mockHistoryRepo{}, nil)
+	if _, ok, err := plain.PreviewClearHistory(false, 3); ok || err != nil {
+		t.Fatalf("PreviewClearHistory() = %v, %v, want unsupported", ok, err)
+	}
+
+	repo := &mockClearPreviewRepo{}
+	repo.On("CountClearAll", 3).Return(5, []string{"A", "B", "C"}, nil).Once()
+	repo.On("CountClearUnbookmarked", 3).Return(1, []string{"A"}, nil).Once()
+	svc := NewReadingService(nil, repo, nil)
+
+	preview, ok, err := svc.PreviewClearHistory(false, 3)
+	if err != nil || !ok || preview.Count != 5 || len(preview.Examples) != 3 {
+		t.Fatalf("PreviewClearHistory(all) = %+v, %v, %v", preview, ok, err)
+	}
+	preview, ok, err = svc.PreviewClearHistory(true, 3)
+	if err != nil || !ok || preview.Count != 1 || len(preview.Examples) != 1 {
+		t.Fatalf("PreviewClearHistory(keep bookmarks) = %+v, %v, %v", preview, ok, err)
+	}
+	repo.AssertExpectations(t)
+}
+
+type stubArticleExtractor struct {
+	text  string
+	err   error
+	links []string
+}
+
+func (s *stubArticleExtractor) ExtractArticle(_ context.Context, link string) (string, error) {
+	s.links = append(s.links, link)
+	return s.text, s.err
+}
+
+func TestReadingService_HydrateFullText(t *testing.T) {
+	repo := &mockHistoryRepo{}
+	extractor := &stubArticleExtractor{text: "Full body"}
+	svc := NewReadingService(nil, repo, nil)
+	svc.Extractor = extractor
+
+	excerpt := &reading.HistoryItem{GUID: "1", Link: "https://example.com/1", Description: "Teaser", Content: "Teaser"}
+	repo.On("Upsert", []*reading.HistoryItem{excerpt}).Return(nil).Once()
+	changed, err := svc.HydrateFullText(context.Background(), excerpt)
+	if err != nil || !changed {
+		t.Fatalf("HydrateFullText() = %v, %v, want true, nil", changed, err)
+	}
+	if excerpt.Content != "Full body" {
+		t.Fatalf("Content = %q, want extracted text", excerpt.Content)
+	}
+
+	full := &reading.HistoryItem{GUID: "2", Link: "https://example.com/2", Description: "Teaser", Content: "Complete article"}
+	if changed, err := svc.HydrateFullText(context.Background(), full); changed || err != nil {
+		t.Fatalf("HydrateFullText(full) = %v, %v, want untouched", changed, err)
+	}
+	if len(extractor.links) != 1 {
+		t.Fatalf("extractor called for %v, want only the excerpt", extractor.links)
+	}
+
+	extractor.err = errors.New("blocked")
+	failing := &reading.HistoryItem{GUID: "3", Link: "https://example.com/3"}
+	if _, err := svc.HydrateFullText(context.Background(), failing); err == nil || failing.Content != "" {
+		t.Fatalf("HydrateFullText() should fail and keep the excerpt, got %v, %q", err, failing.Content)
+	}
+	repo.AssertExpectations(t)
+}
+
 func TestReadingService_ApplyInsight(t *testing.T) {
 	now := time.Date(2026, 2, 14, 9, 30, 0, 0, time.UTC)
 	repo := &mockHistoryRepo{}
@@ -168,15 +545,17 @@ func TestReadingService_ApplyInsight(t *testing.T) {
 
 type mockFeedFetcher struct {
 	mock.Mock
+	hadDeadline bool
 }
 
-func (m *mockFeedFetcher) Fetch(url string) (*reading.Feed, error) {
+func (m *mockFeedFetcher) Fetch(ctx context.Context, url string) (*reading.Feed, error) {
+	_, m.hadDeadline = ctx.Deadline()
 	args := m.Called(url)
 	feed, _ := args.Get(0).(*reading.Feed)
 	return feed, args.Error(1)
 }
 
-func (m *mockFeedFetcher) FetchAll(urls []string, opt FeedFetchOptions) (*reading.Feed, FeedFetchReport, error) {
+func (m *mockFeedFetcher) FetchAll(_ context.Context, urls []string, opt FeedFetchOptions) (*reading.Feed, FeedFetchReport, error) {
 	args := m.Called(urls, opt)
 	feed, _ := args.Get(0).(*reading.Feed)
 	report, _ := args.Get(1).(FeedFetchReport)
@@ -195,7 +574,7 @@ func TestReadingService_FetchFeed_NewsUsesFetchAll(t *testing.T) {
 		return opt.PerFeedTimeout > 0 && opt.BatchTimeout > 0
 	})).Return(feedFromFetcher, reportFromFetcher, nil).Once()
 
-	feed, report, err := svc.FetchFeed(reading.NewsURL, all)
+	feed, report, err := svc.FetchFeed(context.Background(), reading.NewsURL, all)
 	if err != nil {
 		t.Fatalf("FetchFeed(news) error = %v", err)
 	}
@@ -215,7 +594,7 @@ func TestReadingService_FetchFeed_BookmarksSkipsFetcher(t *testing.T) {
 	fetcher := &mockFeedFetcher{}
 	svc := NewReadingService(fetcher, nil, nil)
 
-	feed, report, err := svc.FetchFeed(reading.BookmarksURL, []string{"https://example.com/rss"})
+	feed, report, err := svc.FetchFeed(context.Background(), reading.BookmarksURL, []string{"https://example.com/rss"})
 	if err != nil {
 		t.Fatalf("FetchFeed(bookmarks) error = %v", err)
 	}
@@ -232,8 +611,77 @@ func TestReadingService_FetchFeed_BookmarksSkipsFetcher(t *testing.T) {
 	fetcher.AssertNotCalled(t, "FetchAll", mock.Anything, mock.Anything)
 }
 
+func TestReadingService_FetchFeed_SingleFeedTimeout(t *testing.T) {
+	const url = "https://example.com/rss"
+
+	t.Run("success", func(t *testing.T) {
+		fetcher := &mockFeedFetcher{}
+		fetcher.On("Fetch", url).Return(&reading.Feed{URL: url}, nil).Once()
+		svc := NewReadingService(fetcher, nil, nil)
+
+		_, report, err := svc.FetchFeed(context.Background(), url, nil)
+		if err != nil {
+			t.Fatalf("FetchFeed() error = %v", err)
+		}
+		if !fetcher.hadDeadline {
+			t.Fatal("single-feed fetch should carry a timeout")
+		}
+		if report.Requested != 1 || report.Succeeded != 1 || report.Succeeded+report.Failed+report.TimedOut != 1 {
+			t.Fatalf("report = %+v", report)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		fetcher := &mockFeedFetcher{}
+		fetcher.On("Fetch", url).Return(nil, context.DeadlineExceeded).Once()
+		svc := NewReadingService(fetcher, nil, nil)
+
+		_, report, err := svc.FetchFeed(context.Background(), url, nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("FetchFeed() error = %v, want deadline exceeded", err)
+		}
+		if report.Requested != 1 || report.TimedOut != 1 || report.Succeeded+report.Failed+report.TimedOut != 1 {
+			t.Fatalf("report = %+v", report)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		fetcher := &mockFeedFetcher{}
+		fetcher.On("Fetch", url).Return(nil, errors.New("boom")).Once()
+		svc := NewReadingService(fetcher, nil, nil)
+
+		_, report, _ := svc.FetchFeed(context.Background(), url, nil)
+		if report.Requested != 1 || report.Failed != 1 || report.Succeeded+report.Failed+report.TimedOut != 1 {
+			t.Fatalf("report = %+v", report)
+		}
+	})
+}
+
 type errValue string
 
 func (e errValue) Error() string { return string(e) }
 
 func assertErr(msg string) error { return errValue(msg) }
+
+func TestReadingService_FetchFullText(t *testing.T) {
+	repo := &mockHistoryRepo{}
+	svc := NewReadingService(nil, repo, nil)
+
+	item := &reading.HistoryItem{GUID: "1", Link: "https://example.com/1", Content: "Partial article"}
+	if err := svc.FetchFullText(context.Background(), item); !errors.Is(err, ErrFullTextUnavailable) {
+		t.Fatalf("FetchFullText() without extractor = %v, want ErrFullTextUnavailable", err)
+	}
+
+	svc.Extractor = &stubArticleExtractor{text: "Full body"}
+	repo.On("Upsert", []*reading.HistoryItem{item}).Return(nil).Once()
+	if err := svc.FetchFullText(context.Background(), item); err != nil {
+		t.Fatalf("FetchFullText() error = %v", err)
+	}
+	if item.Content != "Full body" {
+		t.Fatalf("Content = %q, want extracted text even when content was present", item.Content)
+	}
+	if err := svc.FetchFullText(context.Background(), &reading.HistoryItem{GUID: "2"}); err == nil {
+		t.Fatal("FetchFullText() should fail for an item without a link")
+	}
+	repo.AssertExpectations(t)
+}
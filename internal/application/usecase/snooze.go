@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+)
+
+type snoozer interface {
+	Snooze(guid string, until time.Time) error
+}
+
+// SnoozePreset names a choice offered when snoozing an article.
+type SnoozePreset int
+
+const (
+	// SnoozeLaterToday snoozes for a few hours.
+	SnoozeLaterToday SnoozePreset = iota
+	// SnoozeTomorrow snoozes until tomorrow morning.
+	SnoozeTomorrow
+	// SnoozeNextWeek snoozes until next Monday morning.
+	SnoozeNextWeek
+)
+
+const (
+	snoozeLaterTodayDelay = 3 * time.Hour
+	snoozeMorningHour     = 8
+)
+
+// SnoozeTime returns when an article snoozed at now with preset reappears.
+// Mornings are in now's location.
+func SnoozeTime(preset SnoozePreset, now time.Time) time.Time {
+	morning := func(days int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+days, snoozeMorningHour, 0, 0, 0, now.Location())
+	}
+	switch preset {
+	case SnoozeTomorrow:
+		return morning(1)
+	case SnoozeNextWeek:
+		days := (int(time.Monday) - int(now.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return morning(days)
+	default:
+		return now.Add(snoozeLaterTodayDelay)
+	}
+}
+
+// Snooze hides an article until the given time and persists it when the
+// repository supports snoozing.
+func (s *ReadingService) Snooze(history *reading.History, guid string, until time.Time) error {
+	if history == nil || strings.TrimSpace(guid) == "" {
+		return nil
+	}
+	if !history.Snooze(guid, until) {
+		return nil
+	}
+	repo, ok := s.HistoryRepo.(snoozer)
+	if !ok {
+		return nil
+	}
+	return repo.Snooze(guid, until)
+}
+
+// SnoozeFor snoozes an article for a preset counted from now and returns
+// when it will reappear.
+func (s *ReadingService) SnoozeFor(history *reading.History, guid string, preset SnoozePreset) (time.Time, error) {
+	until := SnoozeTime(preset, s.now())
+	return until, s.Snooze(history, guid, until)
+}
+
+// WakeSnoozed brings back articles whose snooze has ended as unread and
+// returns how many reappeared.
+func (s *ReadingService) WakeSnoozed(history *reading.History) (int, error) {
+	if history == nil {
+		return 0, nil
+	}
+	woken := history.WakeSnoozed(s.now())
+	if len(woken) == 0 || s.HistoryRepo == nil {
+		return len(woken), nil
+	}
+	repo, hasSnoozer := s.HistoryRepo.(snoozer)
+	for _, item := range woken {
+		if hasSnoozer {
+			if err := repo.Snooze(item.GUID, time.Time{}); err != nil {
+				return len(woken), err
+			}
+		}
+		if err := s.HistoryRepo.SetRead(item.GUID, false); err != nil {
+			return len(woken), err
+		}
+	}
+	return len(woken), nil
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+)
+
+type mockSnoozingRepo struct {
+	mockHistoryRepo
+}
+
+func (m *mockSnoozingRepo) Snooze(guid string, until time.Time) error {
+	return m.Called(guid, until).Error(0)
+}
+
+func TestSnoozeTime(t *testing.T) {
+	// 2026-02-14 is a Saturday.
+	now := time.Date(2026, 2, 14, 21, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		preset SnoozePreset
+		now    time.Time
+		want   time.Time
+	}{
+		{name: "later today", preset: SnoozeLaterToday, now: now, want: now.Add(3 * time.Hour)},
+		{name: "tomorrow", preset: SnoozeTomorrow, now: now, want: time.Date(2026, 2, 15, 8, 0, 0, 0, time.UTC)},
+		{name: "next week", preset: SnoozeNextWeek, now: now, want: time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)},
+		{
+			name:   "next week from a monday",
+			preset: SnoozeNextWeek,
+			now:    time.Date(2026, 2, 16, 7, 0, 0, 0, time.UTC),
+			want:   time.Date(2026, 2, 23, 8, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SnoozeTime(tt.preset, tt.now); !got.Equal(tt.want) {
+				t.Fatalf("SnoozeTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadingService_SnoozeAndWake(t *testing.T) {
+	now := time.Date(2026, 2, 14, 9, 0, 0, 0, time.UTC)
+	until := now.Add(time.Hour)
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"1": {GUID: "1", Kind: reading.ArticleKind, IsRead: true},
+	})
+
+	repo := &mockSnoozingRepo{}
+	repo.On("Snooze", "1", until).Return(nil).Once()
+	svc := NewReadingService(nil, repo, func() time.Time { return now })
+	if err := svc.Snooze(history, "1", until); err != nil {
+		t.Fatalf("Snooze() error = %v", err)
+	}
+	if woken, err := svc.WakeSnoozed(history); err != nil || woken != 0 {
+		t.Fatalf("WakeSnoozed() before the snooze ends = %d, %v", woken, err)
+	}
+
+	now = until
+	repo.On("Snooze", "1", time.Time{}).Return(nil).Once()
+	repo.On("SetRead", "1", false).Return(nil).Once()
+	if woken, err := svc.WakeSnoozed(history); err != nil || woken != 1 {
+		t.Fatalf("WakeSnoozed() = %d, %v, want 1, nil", woken, err)
+	}
+	repo.AssertExpectations(t)
+
+	plain := NewReadingService(nil, &mockHistoryRepo{}, nil)
+	if err := plain.Snooze(history, "1", until); err != nil {
+		t.Fatalf("Snooze() without repository support error = %v", err)
+	}
+	if item, _ := history.Item("1"); !item.IsSnoozed() {
+		t.Fatal("Snooze() should still hide the article in memory")
+	}
+}
@@ -2,7 +2,9 @@
 package usecase
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/tesso57/reazy/internal/domain/subscription"
@@ -15,9 +17,18 @@ type SubscriptionRepository interface {
 	Remove(index int) error
 }
 
+// ErrFeedAlreadySubscribed is returned when adding a feed URL that is already registered.
+var ErrFeedAlreadySubscribed = errors.New("feed is already subscribed")
+
+// ErrFeedNotSubscribed is returned when removing a feed URL that is not registered.
+var ErrFeedNotSubscribed = errors.New("feed is not subscribed")
+
 // SubscriptionService provides subscription-related operations.
 type SubscriptionService struct {
 	Repo SubscriptionRepository
+	// OPMLFetcher downloads hosted OPML lists for DownloadOPML; nil
+	// disables importing from a URL.
+	OPMLFetcher OPMLFetcher
 }
 
 type groupedSubscriptionRepository interface {
@@ -28,6 +39,34 @@ type groupedSubscriptionWriter interface {
 	ReplaceFeedGroups(groups []subscription.FeedGroup, ungrouped []string) error
 }
 
+type feedURLUpdater interface {
+	UpdateFeedURL(oldURL, newURL string) error
+}
+
+type feedMetadataRepository interface {
+	LoadFeedMetadata() (map[string]FeedMetadata, error)
+	SaveFeedMetadata(metadata map[string]FeedMetadata) error
+}
+
+// batchSubscriptionRepository runs several changes as one transaction that
+// saves once and is rolled back when any change fails.
+type batchSubscriptionRepository interface {
+	Batch(fn func() error) error
+}
+
+type aiSummaryPreferenceWriter interface {
+	SaveShowAISummary(show bool) error
+}
+
+type newsSelectionWriter interface {
+	SaveNewsSelection(selection NewsSelection) error
+}
+
+type feedGroupingCacheRepository interface {
+	LoadFeedGroupingCache() (FeedGroupingCache, error)
+	SaveFeedGroupingCache(cache FeedGroupingCache) error
+}
+
 // NewSubscriptionService constructs a SubscriptionService.
 func NewSubscriptionService(repo SubscriptionRepository) *SubscriptionService {
 	return new(SubscriptionService{Repo: repo})
@@ -61,21 +100,136 @@ func (s *SubscriptionService) ReplaceFeedGroups(groups []subscription.FeedGroup,
 	return feeds, true, err
 }
 
+// ApplyFeedGrouping persists a grouping result in a single save: the feed
+// groups and, for a fresh AI result, the grouping cache.
+func (s *SubscriptionService) ApplyFeedGrouping(result FeedGroupingResult) ([]string, bool, error) {
+	var feeds []string
+	var supported bool
+	err := s.batch(func() error {
+		if !result.UsedCache && !result.Heuristic {
+			if _, err := s.SaveFeedGroupingCache(FeedGroupingCache{
+				InputHash: result.InputHash,
+				Groups:    result.Groups,
+				Ungrouped: result.Ungrouped,
+			}); err != nil {
+				return err
+			}
+		}
+		var err error
+		feeds, supported, err = s.ReplaceFeedGroups(result.Groups, result.Ungrouped)
+		return err
+	})
+	return feeds, supported, err
+}
+
+// LoadFeedGroupingCache returns the stored AI grouping cache when the repository supports it.
+func (s *SubscriptionService) LoadFeedGroupingCache() (FeedGroupingCache, bool, error) {
+	repo, ok := s.Repo.(feedGroupingCacheRepository)
+	if !ok {
+		return FeedGroupingCache{}, false, nil
+	}
+	cache, err := repo.LoadFeedGroupingCache()
+	return cache, true, err
+}
+
+// SaveFeedGroupingCache persists the AI grouping cache when the repository supports it.
+func (s *SubscriptionService) SaveFeedGroupingCache(cache FeedGroupingCache) (bool, error) {
+	repo, ok := s.Repo.(feedGroupingCacheRepository)
+	if !ok {
+		return false, nil
+	}
+	return true, repo.SaveFeedGroupingCache(cache)
+}
+
+// FeedMetadata returns the stored display details of subscribed feeds, keyed
+// by feed URL, when the repository supports it.
+func (s *SubscriptionService) FeedMetadata() (map[string]FeedMetadata, bool, error) {
+	repo, ok := s.Repo.(feedMetadataRepository)
+	if !ok {
+		return nil, false, nil
+	}
+	metadata, err := repo.LoadFeedMetadata()
+	return metadata, true, err
+}
+
+// SaveShowAISummary persists whether article details show the AI summary
+// when the repository supports it.
+func (s *SubscriptionService) SaveShowAISummary(show bool) (bool, error) {
+	repo, ok := s.Repo.(aiSummaryPreferenceWriter)
+	if !ok {
+		return false, nil
+	}
+	return true, repo.SaveShowAISummary(show)
+}
+
+// SaveNewsSelection persists the News topic opened last when the repository
+// supports it.
+func (s *SubscriptionService) SaveNewsSelection(selection NewsSelection) (bool, error) {
+	repo, ok := s.Repo.(newsSelectionWriter)
+	if !ok {
+		return false, nil
+	}
+	return true, repo.SaveNewsSelection(selection)
+}
+
 // Add registers a new feed URL and returns the updated list.
-func (s *SubscriptionService) Add(url string) ([]string, error) {
-	trimmed := strings.TrimSpace(url)
+// It returns ErrFeedAlreadySubscribed when the URL is already registered,
+// including feeds that belong to a group.
+func (s *SubscriptionService) Add(feedURL string) ([]string, error) {
+	trimmed := strings.TrimSpace(feedURL)
 	if trimmed == "" {
 		return nil, fmt.Errorf("feed url is empty")
 	}
 	if strings.ContainsAny(trimmed, " \t\r\n") {
 		return nil, fmt.Errorf("feed url contains whitespace")
 	}
+	existing, err := s.Repo.List()
+	if err != nil {
+		return nil, err
+	}
+	key := feedURLKey(trimmed)
+	for _, feed := range existing {
+		if feedURLKey(feed) == key {
+			return nil, fmt.Errorf("%w: %s", ErrFeedAlreadySubscribed, trimmed)
+		}
+	}
 	if err := s.Repo.Add(trimmed); err != nil {
 		return nil, err
 	}
 	return s.Repo.List()
 }
 
+// UpdateFeedURL moves a subscription to a new URL, e.g. after a permanent
+// redirect, and returns the updated list. It reports false when the repository
+// cannot rewrite subscriptions. If newURL is already subscribed, it returns
+// ErrFeedAlreadySubscribed.
+func (s *SubscriptionService) UpdateFeedURL(oldURL, newURL string) ([]string, bool, error) {
+	repo, ok := s.Repo.(feedURLUpdater)
+	if !ok {
+		return nil, false, nil
+	}
+	oldURL = strings.TrimSpace(oldURL)
+	newURL = strings.TrimSpace(newURL)
+	if oldURL == "" || newURL == "" {
+		return nil, true, fmt.Errorf("feed url is empty")
+	}
+	existing, err := s.Repo.List()
+	if err != nil {
+		return nil, true, err
+	}
+	key := feedURLKey(newURL)
+	for _, feed := range existing {
+		if feed != oldURL && feedURLKey(feed) == key {
+			return nil, true, fmt.Errorf("%w: %s", ErrFeedAlreadySubscribed, newURL)
+		}
+	}
+	if err := repo.UpdateFeedURL(oldURL, newURL); err != nil {
+		return nil, true, err
+	}
+	feeds, err := s.Repo.List()
+	return feeds, true, err
+}
+
 // Remove deletes a feed by index and returns the updated list.
 func (s *SubscriptionService) Remove(index int) ([]string, error) {
 	if err := s.Repo.Remove(index); err != nil {
@@ -83,3 +237,40 @@ func (s *SubscriptionService) Remove(index int) ([]string, error) {
 	}
 	return s.Repo.List()
 }
+
+// RemoveURL deletes the subscription matching feedURL, wherever it sits in
+// the grouped list, and returns the updated list. URLs are matched like Add
+// matches duplicates. It returns ErrFeedNotSubscribed when nothing matches.
+func (s *SubscriptionService) RemoveURL(feedURL string) ([]string, error) {
+	trimmed := strings.TrimSpace(feedURL)
+	if trimmed == "" {
+		return nil, fmt.Errorf("feed url is empty")
+	}
+	existing, err := s.Repo.List()
+	if err != nil {
+		return nil, err
+	}
+	key := feedURLKey(trimmed)
+	for index, feed := range existing {
+		if feedURLKey(feed) == key {
+			return s.Remove(index)
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrFeedNotSubscribed, trimmed)
+}
+
+// feedURLKey normalizes a feed URL for duplicate detection.
+// Scheme and host are compared case-insensitively and a trailing slash is ignored.
+func feedURLKey(feedURL string) string {
+	trimmed := strings.TrimSpace(feedURL)
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" {
+		return strings.TrimSuffix(trimmed, "/")
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+	parsed.RawPath = ""
+	parsed.Fragment = ""
+	return parsed.String()
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -76,6 +77,16 @@ func (s *stubSubscriptionRepo) ReplaceFeedGroups(groups []subscription.FeedGroup
 	return nil
 }
 
+func (s *stubSubscriptionRepo) UpdateFeedURL(oldURL, newURL string) error {
+	for i, feed := range s.feeds {
+		if feed == oldURL {
+			s.feeds[i] = newURL
+			return nil
+		}
+	}
+	return errors.New("feed is not subscribed")
+}
+
 func TestSubscriptionAddTrimsWhitespace(t *testing.T) {
 	repo := &stubSubscriptionRepo{}
 	svc := NewSubscriptionService(repo)
@@ -108,6 +119,25 @@ func TestSubscriptionAddRejectsWhitespaceInside(t *testing.T) {
 	}
 }
 
+func TestSubscriptionAddRejectsDuplicate(t *testing.T) {
+	repo := &stubSubscriptionRepo{
+		feeds: []string{"https://Example.com/rss/"},
+	}
+	svc := NewSubscriptionService(repo)
+
+	_, err := svc.Add("https://example.COM/rss")
+	if !errors.Is(err, ErrFeedAlreadySubscribed) {
+		t.Fatalf("Add error = %v, want ErrFeedAlreadySubscribed", err)
+	}
+	if len(repo.feeds) != 1 {
+		t.Fatalf("duplicate feed should not be stored, got %#v", repo.feeds)
+	}
+
+	if _, err := svc.Add("https://example.com/other"); err != nil {
+		t.Fatalf("Add distinct feed failed: %v", err)
+	}
+}
+
 func TestSubscriptionListGroups(t *testing.T) {
 	repo := &stubSubscriptionRepo{
 		groups: []subscription.FeedGroup{
@@ -150,3 +180,143 @@ func TestSubscriptionReplaceFeedGroups(t *testing.T) {
 		t.Fatalf("unexpected stored groups: %#v", repo.groups)
 	}
 }
+
+func TestSubscriptionUpdateFeedURL(t *testing.T) {
+	repo := &stubSubscriptionRepo{
+		feeds: []string{"https://old.example.com/rss", "https://other.example.com/rss"},
+	}
+	svc := NewSubscriptionService(repo)
+
+	feeds, supported, err := svc.UpdateFeedURL("https://old.example.com/rss", " https://new.example.com/rss ")
+	if err != nil || !supported {
+		t.Fatalf("UpdateFeedURL = %v, %v", supported, err)
+	}
+	want := []string{"https://new.example.com/rss", "https://other.example.com/rss"}
+	if len(feeds) != len(want) || feeds[0] != want[0] || feeds[1] != want[1] {
+		t.Fatalf("feeds = %#v, want %#v", feeds, want)
+	}
+
+	_, _, err = svc.UpdateFeedURL("https://new.example.com/rss", "https://OTHER.example.com/rss/")
+	if !errors.Is(err, ErrFeedAlreadySubscribed) {
+		t.Fatalf("UpdateFeedURL error = %v, want ErrFeedAlreadySubscribed", err)
+	}
+}
+
+type preferenceSubscriptionRepo struct {
+	stubSubscriptionRepo
+	showAISummary *bool
+}
+
+func (s *preferenceSubscriptionRepo) SaveShowAISummary(show bool) error {
+	s.showAISummary = &show
+	return nil
+}
+
+func TestSubscriptionSaveShowAISummary(t *testing.T) {
+	if supported, err := NewSubscriptionService(&stubSubscriptionRepo{}).SaveShowAISummary(false); supported || err != nil {
+		t.Fatalf("SaveShowAISummary() = %v, %v, want unsupported", supported, err)
+	}
+
+	repo := &preferenceSubscriptionRepo{}
+	supported, err := NewSubscriptionService(repo).SaveShowAISummary(false)
+	if err != nil || !supported {
+		t.Fatalf("SaveShowAISummary() = %v, %v, want supported", supported, err)
+	}
+	if repo.showAISummary == nil || *repo.showAISummary {
+		t.Fatalf("saved preference = %v, want false", repo.showAISummary)
+	}
+}
+
+type newsSelectionSubscriptionRepo struct {
+	stubSubscriptionRepo
+	selection *NewsSelection
+}
+
+func (s *newsSelectionSubscriptionRepo) SaveNewsSelection(selection NewsSelection) error {
+	s.selection = &selection
+	return nil
+}
+
+func TestSubscriptionSaveNewsSelection(t *testing.T) {
+	selection := NewsSelection{DateKey: "2026-10-17", GUID: "digest"}
+	if supported, err := NewSubscriptionService(&stubSubscriptionRepo{}).SaveNewsSelection(selection); supported || err != nil {
+		t.Fatalf("SaveNewsSelection() = %v, %v, want unsupported", supported, err)
+	}
+
+	repo := &newsSelectionSubscriptionRepo{}
+	supported, err := NewSubscriptionService(repo).SaveNewsSelection(selection)
+	if err != nil || !supported {
+		t.Fatalf("SaveNewsSelection() = %v, %v, want supported", supported, err)
+	}
+	if repo.selection == nil || *repo.selection != selection {
+		t.Fatalf("saved selection = %v, want %v", repo.selection, selection)
+	}
+}
+
+func TestSubscriptionService_RemoveURL(t *testing.T) {
+	repo := &stubSubscriptionRepo{feeds: []string{"https://a.example.com/rss", "https://B.example.com/rss"}}
+	svc := NewSubscriptionService(repo)
+
+	feeds, err := svc.RemoveURL(" https://b.example.com/rss/ ")
+	if err != nil {
+		t.Fatalf("RemoveURL() error = %v", err)
+	}
+	if len(feeds) != 1 || feeds[0] != "https://a.example.com/rss" {
+		t.Fatalf("feeds = %#v, want only a.example.com", feeds)
+	}
+
+	if _, err := svc.RemoveURL("https://missing.example.com/rss"); !errors.Is(err, ErrFeedNotSubscribed) {
+		t.Fatalf("RemoveURL(missing) error = %v, want ErrFeedNotSubscribed", err)
+	}
+	if _, err := svc.RemoveURL(" "); err == nil {
+		t.Fatal("RemoveURL(empty) should fail")
+	}
+}
+
+type batchSubscriptionRepo struct {
+	stubSubscriptionRepo
+	cache   FeedGroupingCache
+	batches int
+	saves   int
+}
+
+func (s *batchSubscriptionRepo) Batch(fn func() error) error {
+	s.batches++
+	err := fn()
+	s.saves++
+	return err
+}
+
+func (s *batchSubscriptionRepo) LoadFeedGroupingCache() (FeedGroupingCache, error) {
+	return s.cache, nil
+}
+
+func (s *batchSubscriptionRepo) SaveFeedGroupingCache(cache FeedGroupingCache) error {
+	s.cache = cache
+	return nil
+}
+
+func TestSubscriptionService_ApplyFeedGroupingSavesOnce(t *testing.T) {
+	repo := &batchSubscriptionRepo{stubSubscriptionRepo: stubSubscriptionRepo{
+		feeds: []string{"https://a.example.com/rss", "https://b.example.com/rss", "https://c.example.com/rss"},
+	}}
+	svc := NewSubscriptionService(repo)
+
+	feeds, supported, err := svc.ApplyFeedGrouping(FeedGroupingResult{
+		Groups:    []subscription.FeedGroup{{Name: "Tech", Feeds: []string{"https://a.example.com/rss", "https://b.example.com/rss"}}},
+		Ungrouped: []string{"https://c.example.com/rss"},
+		InputHash: "hash",
+	})
+	if err != nil || !supported {
+		t.Fatalf("ApplyFeedGrouping() supported=%v err=%v", supported, err)
+	}
+	if len(feeds) != 1 || len(repo.groups) != 1 {
+		t.Fatalf("feeds = %v, groups = %v, want the grouping applied", feeds, repo.groups)
+	}
+	if repo.cache.InputHash != "hash" {
+		t.Fatalf("cache = %#v, want the AI result cached", repo.cache)
+	}
+	if repo.batches != 1 || repo.saves != 1 {
+		t.Fatalf("batches = %d, saves = %d, want one save", repo.batches, repo.saves)
+	}
+}
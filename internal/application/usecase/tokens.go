@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// asciiCharsPerToken approximates how many ASCII characters fit in one token.
+const asciiCharsPerToken = 4
+
+// EstimateTokens roughly estimates how many model tokens text takes: about
+// four ASCII characters per token and one token per other character, such as
+// Japanese. It is a usage hint, not a tokenizer.
+func EstimateTokens(text string) int {
+	ascii, other := 0, 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+asciiCharsPerToken-1)/asciiCharsPerToken + other
+}
+
+// EstimatedTokens estimates the prompt size of an insight request.
+func (r InsightRequest) EstimatedTokens() int {
+	return EstimateTokens(buildInsightPrompt(r))
+}
+
+// EstimatedTokens estimates the prompt size of an article question.
+func (q ArticleQuestion) EstimatedTokens() int {
+	return EstimateTokens(buildArticleQuestionPrompt(q))
+}
+
+// EstimatedTokens estimates the prompt size of a daily news request.
+func (r NewsDigestRequest) EstimatedTokens() int {
+	return EstimateTokens(buildNewsDigestPrompt(r))
+}
+
+// EstimatedTokens estimates the prompt size of a feed grouping request.
+func (r FeedGroupingRequest) EstimatedTokens() int {
+	return EstimateTokens(buildFeedGroupingPrompt(r))
+}
+
+// TokenMeter totals the estimated prompt tokens sent to the AI during a
+// session. It is safe for concurrent use, and a nil meter ignores additions.
+type TokenMeter struct {
+	total atomic.Int64
+}
+
+// Add records n more estimated tokens.
+func (m *TokenMeter) Add(n int) {
+	if m == nil || n <= 0 {
+		return
+	}
+	m.total.Add(int64(n))
+}
+
+// Total returns the estimated tokens recorded so far.
+func (m *TokenMeter) Total() int {
+	if m == nil {
+		return 0
+	}
+	return int(m.total.Load())
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{name: "empty", text: "", want: 0},
+		{name: "ascii rounds up", text: "hello", want: 2},
+		{name: "ascii words", text: "abcdefghijklmnop", want: 4},
+		{name: "japanese", text: "日本語", want: 3},
+		{name: "mixed", text: "Go言語", want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateTokens(tt.text); got != tt.want {
+				t.Fatalf("EstimateTokens(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenMeter(t *testing.T) {
+	var nilMeter *TokenMeter
+	nilMeter.Add(10)
+	if nilMeter.Total() != 0 {
+		t.Fatal("nil meter should stay empty")
+	}
+
+	meter := &TokenMeter{}
+	meter.Add(10)
+	meter.Add(-5)
+	meter.Add(5)
+	if got := meter.Total(); got != 15 {
+		t.Fatalf("Total() = %d, want 15", got)
+	}
+}
+
+func TestInsightService_GenerateCountsTokens(t *testing.T) {
+	gen := &mockInsightGenerator{}
+	gen.On("Generate", mock.Anything, mock.Anything).Return(Insight{Summary: "ok"}, nil).Once()
+	meter := &TokenMeter{}
+	svc := NewInsightService(gen, nil)
+	svc.Tokens = meter
+	req := InsightRequest{Title: "Title", Content: "Body"}
+
+	if _, err := svc.Generate(context.Background(), req); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if got, want := meter.Total(), req.EstimatedTokens(); got != want || want == 0 {
+		t.Fatalf("meter total = %d, want the request estimate %d", got, want)
+	}
+}
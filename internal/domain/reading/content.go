@@ -0,0 +1,133 @@
+package reading
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ContentSanitizer removes boilerplate such as newsletter prompts or share
+// links from article text using user-configured regular expressions.
+type ContentSanitizer struct {
+	patterns []*regexp.Regexp
+}
+
+// NewContentSanitizer compiles the given patterns. Invalid patterns are
+// reported in the returned error; the sanitizer still applies the valid ones.
+func NewContentSanitizer(patterns []string) (*ContentSanitizer, error) {
+	s := &ContentSanitizer{}
+	var errs []error
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid pattern %q: %w", pattern, err))
+			continue
+		}
+		s.patterns = append(s.patterns, re)
+	}
+	return s, errors.Join(errs...)
+}
+
+// Clean returns text with every pattern match removed. A nil sanitizer or one
+// without patterns returns text unchanged.
+func (s *ContentSanitizer) Clean(text string) string {
+	if s == nil || len(s.patterns) == 0 || text == "" {
+		return text
+	}
+	for _, re := range s.patterns {
+		text = re.ReplaceAllString(text, "")
+	}
+	return strings.TrimSpace(text)
+}
+
+// htmlTagPattern matches an HTML tag in a feed description.
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// ContentFilter picks out fetched articles too short to be worth reading,
+// such as link-only posts or "weekly links" stubs.
+type ContentFilter struct {
+	// MinLength is the fewest characters an article's title and description
+	// need together; zero or less keeps every article.
+	MinLength int
+	// FeedMinLength replaces MinLength for the feeds it lists, keyed by feed
+	// URL.
+	FeedMinLength map[string]int
+}
+
+// Filters reports whether item is shorter than the minimum length of its
+// feed. HTML tags and repeated whitespace don't count.
+func (f ContentFilter) Filters(item *HistoryItem) bool {
+	if item == nil {
+		return false
+	}
+	minLength := f.MinLength
+	if feedMin, ok := f.FeedMinLength[item.FeedURL]; ok {
+		minLength = feedMin
+	}
+	if minLength <= 0 {
+		return false
+	}
+	return contentLength(item.Title+" "+item.Description) < minLength
+}
+
+func contentLength(text string) int {
+	text = html.UnescapeString(htmlTagPattern.ReplaceAllString(text, " "))
+	return utf8.RuneCountInString(strings.Join(strings.Fields(text), " "))
+}
+
+const (
+	wordsPerMinute    = 200
+	cjkCharsPerMinute = 500
+)
+
+// TextLength summarizes how long an article is to read.
+type TextLength struct {
+	// Words counts space-separated words outside CJK text.
+	Words int
+	// CJKChars counts Han, Hiragana, Katakana and Hangul characters, which
+	// are not separated by spaces.
+	CJKChars int
+}
+
+// MeasureText counts the words and CJK characters in text. A word is a run of
+// non-space characters containing at least one letter or digit.
+func MeasureText(text string) TextLength {
+	var length TextLength
+	counted := false
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			length.CJKChars++
+			counted = false
+		case unicode.IsSpace(r):
+			counted = false
+		case !counted && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			length.Words++
+			counted = true
+		}
+	}
+	return length
+}
+
+// ReadingMinutes estimates reading time, rounded up to whole minutes. Empty
+// text takes zero minutes.
+func (l TextLength) ReadingMinutes() int {
+	if l.Words == 0 && l.CJKChars == 0 {
+		return 0
+	}
+	// Scale both rates to a common unit so mixed text is rounded once.
+	chars := l.CJKChars*wordsPerMinute + l.Words*cjkCharsPerMinute
+	perMinute := wordsPerMinute * cjkCharsPerMinute
+	return (chars + perMinute - 1) / perMinute
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
@@ -0,0 +1,85 @@
+package reading
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContentSanitizer_Clean(t *testing.T) {
+	s, err := NewContentSanitizer([]string{
+		`(?s)Subscribe to our newsletter.*$`,
+		`Share on \w+`,
+		"",
+	})
+	if err != nil {
+		t.Fatalf("NewContentSanitizer() error = %v", err)
+	}
+
+	got := s.Clean("Body text. Share on Twitter\n\nSubscribe to our newsletter\nfooter links")
+	if got != "Body text." {
+		t.Fatalf("Clean() = %q, want %q", got, "Body text.")
+	}
+
+	var disabled *ContentSanitizer
+	if got := disabled.Clean(" keep "); got != " keep " {
+		t.Fatalf("nil sanitizer should keep text, got %q", got)
+	}
+}
+
+func TestNewContentSanitizer_InvalidPattern(t *testing.T) {
+	s, err := NewContentSanitizer([]string{"(unclosed", "ads"})
+	if err == nil {
+		t.Fatal("expected error for invalid pattern")
+	}
+	if got := s.Clean("no ads here"); got != "no  here" {
+		t.Fatalf("valid patterns should still apply, got %q", got)
+	}
+}
+
+func TestMeasureText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    TextLength
+		minutes int
+	}{
+		{name: "empty", text: "  ", want: TextLength{}, minutes: 0},
+		{name: "words", text: "Hello, world — it's 2026.", want: TextLength{Words: 4}, minutes: 1},
+		{name: "cjk", text: "日本語の記事です。", want: TextLength{CJKChars: 8}, minutes: 1},
+		{name: "mixed", text: "Go言語 入門", want: TextLength{Words: 1, CJKChars: 4}, minutes: 1},
+		{name: "long", text: strings.Repeat("word ", 401), want: TextLength{Words: 401}, minutes: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MeasureText(tt.text)
+			if got != tt.want {
+				t.Fatalf("MeasureText(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+			if minutes := got.ReadingMinutes(); minutes != tt.minutes {
+				t.Fatalf("ReadingMinutes() = %d, want %d", minutes, tt.minutes)
+			}
+		})
+	}
+}
+
+func TestContentFilter_Filters(t *testing.T) {
+	filter := ContentFilter{MinLength: 12, FeedMinLength: map[string]int{"quiet": 0}}
+	tests := []struct {
+		name string
+		item *HistoryItem
+		want bool
+	}{
+		{name: "short", item: &HistoryItem{Title: "Links", Description: "<p>  here </p>"}, want: true},
+		{name: "long enough", item: &HistoryItem{Title: "Weekly", Description: "notes &amp; more"}},
+		{name: "feed override", item: &HistoryItem{Title: "Hi", FeedURL: "quiet"}},
+		{name: "nil", item: nil},
+	}
+	for _, tt := range tests {
+		if got := filter.Filters(tt.item); got != tt.want {
+			t.Errorf("%s: Filters() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if (ContentFilter{}).Filters(&HistoryItem{Title: "x"}) {
+		t.Error("a zero filter should keep every article")
+	}
+}
@@ -12,6 +12,9 @@ const NewsURL = "internal://news"
 // BookmarksURL is the special URL used to represent the filtered "Bookmarks" view.
 const BookmarksURL = "internal://bookmarks"
 
+// DismissedURL is the special URL used to represent the "Dismissed" view.
+const DismissedURL = "internal://dismissed"
+
 const (
 	// ArticleKind is the default history item kind.
 	ArticleKind = "article"
@@ -22,7 +25,7 @@ const (
 // IsVirtualFeedURL returns true when the URL is one of the built-in feed tabs.
 func IsVirtualFeedURL(url string) bool {
 	switch url {
-	case AllFeedsURL, NewsURL, BookmarksURL:
+	case AllFeedsURL, NewsURL, BookmarksURL, DismissedURL:
 		return true
 	default:
 		return false
@@ -40,6 +43,19 @@ type Item struct {
 	Date        time.Time
 	FeedTitle   string
 	FeedURL     string
+	Categories  []string
+}
+
+// HistoryGUID returns the key the item is stored under in history: its GUID,
+// or its link or title when the feed omits one.
+func (it Item) HistoryGUID() string {
+	if it.GUID != "" {
+		return it.GUID
+	}
+	if it.Link != "" {
+		return it.Link
+	}
+	return it.Title
 }
 
 // Feed represents a parsed RSS feed.
@@ -47,4 +63,6 @@ type Feed struct {
 	Title string
 	Items []Item
 	URL   string
+	// MovedTo is the final URL when the feed permanently redirected (HTTP 301/308).
+	MovedTo string
 }
@@ -2,8 +2,11 @@
 package reading
 
 import (
+	"maps"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -20,6 +23,8 @@ type HistoryItem struct {
 	Date        time.Time `json:"date"`
 	FeedTitle   string    `json:"feed_title"`
 	FeedURL     string    `json:"feed_url"`
+	// FeedCategories are publisher-provided categories, kept apart from AI tags.
+	FeedCategories []string `json:"feed_categories,omitempty"`
 
 	IsRead       bool      `json:"is_read"`
 	SavedAt      time.Time `json:"saved_at"`
@@ -27,13 +32,26 @@ type HistoryItem struct {
 	AISummary    string    `json:"ai_summary,omitempty"`
 	AITags       []string  `json:"ai_tags,omitempty"`
 	AIUpdatedAt  time.Time `json:"ai_updated_at"`
-	DigestDate   string    `json:"digest_date,omitempty"`
-	RelatedGUIDs []string  `json:"related_guids,omitempty"`
-	BodyHydrated bool      `json:"-"`
+	OpenCount    int       `json:"open_count,omitempty"`
+	// SnoozedUntil hides the article from lists until WakeSnoozed passes it.
+	SnoozedUntil time.Time `json:"snoozed_until"`
+	// IsDismissed hides the article everywhere but the Dismissed view, even
+	// when its feed is fetched again.
+	IsDismissed bool `json:"is_dismissed,omitempty"`
+	// ScrollOffset is the reading pane line the article was last left at.
+	ScrollOffset int      `json:"scroll_offset,omitempty"`
+	DigestDate   string   `json:"digest_date,omitempty"`
+	RelatedGUIDs []string `json:"related_guids,omitempty"`
+	// RelatedTitles holds translated titles of related articles keyed by GUID.
+	RelatedTitles map[string]string `json:"related_titles,omitempty"`
+	BodyHydrated  bool              `json:"-"`
 }
 
-// History holds cached items keyed by GUID.
+// History holds cached items keyed by GUID. Its methods are safe to call
+// from several goroutines, so background work can read it while a fetch
+// merges into it.
 type History struct {
+	mu    sync.RWMutex
 	items map[string]*HistoryItem
 }
 
@@ -45,43 +63,69 @@ func NewHistory(items map[string]*HistoryItem) *History {
 	return new(History{items: items})
 }
 
-// Items returns the underlying map for read-only iteration.
+// Items returns the underlying map. It is not synchronized: use it only
+// where nothing else touches the history, and Snapshot everywhere else.
 func (h *History) Items() map[string]*HistoryItem {
 	return h.items
 }
 
-// Snapshot returns a slice snapshot of all items.
+// Snapshot returns copies of all items taken under the read lock, so callers
+// can iterate or hand them to another goroutine while merges continue.
 func (h *History) Snapshot() []*HistoryItem {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 	items := make([]*HistoryItem, 0, len(h.items))
 	for _, v := range h.items {
 		if v == nil {
 			continue
 		}
-		items = append(items, v)
+		items = append(items, v.clone())
 	}
 	return items
 }
 
+// Clone returns an independent history holding a Snapshot of the items.
+func (h *History) Clone() *History {
+	if h == nil {
+		return NewHistory(nil)
+	}
+	items := h.Snapshot()
+	cloned := make(map[string]*HistoryItem, len(items))
+	for _, item := range items {
+		cloned[item.GUID] = item
+	}
+	return NewHistory(cloned)
+}
+
+func (h *HistoryItem) clone() *HistoryItem {
+	c := *h
+	c.FeedCategories = slices.Clone(h.FeedCategories)
+	c.AITags = slices.Clone(h.AITags)
+	c.RelatedGUIDs = slices.Clone(h.RelatedGUIDs)
+	c.RelatedTitles = maps.Clone(h.RelatedTitles)
+	return &c
+}
+
 // MergeFeed merges a fetched feed into history.
+// Merging only adds or updates items: articles missing from the feed are kept,
+// and empty fetched fields never blank cached ones, so an empty or partial
+// refresh leaves history intact. Dismissed articles are left untouched so
+// they stay hidden however often the feed lists them again.
 func (h *History) MergeFeed(feed *Feed, savedAt time.Time) []*HistoryItem {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	if feed == nil {
 		return nil
 	}
 	changed := make([]*HistoryItem, 0, len(feed.Items))
 	for _, it := range feed.Items {
-		guid := it.GUID
-		if guid == "" {
-			guid = it.Link
-		}
-		if guid == "" {
-			guid = it.Title
-		}
+		guid := it.HistoryGUID()
 		if strings.TrimSpace(guid) == "" {
 			continue
 		}
 
 		if existing, exists := h.items[guid]; exists {
-			if existing == nil || existing.kind() == NewsDigestKind {
+			if existing == nil || existing.kind() == NewsDigestKind || existing.IsDismissed {
 				continue
 			}
 			if mergeFetchedArticle(existing, it, savedAt) {
@@ -91,19 +135,20 @@ func (h *History) MergeFeed(feed *Feed, savedAt time.Time) []*HistoryItem {
 		}
 
 		newItem := &HistoryItem{
-			GUID:         guid,
-			Kind:         ArticleKind,
-			Title:        it.Title,
-			Description:  it.Description,
-			Content:      it.Content,
-			Link:         it.Link,
-			Published:    it.Published,
-			Date:         it.Date,
-			FeedTitle:    it.FeedTitle,
-			FeedURL:      it.FeedURL,
-			IsRead:       false,
-			SavedAt:      savedAt,
-			BodyHydrated: true,
+			GUID:           guid,
+			Kind:           ArticleKind,
+			Title:          it.Title,
+			Description:    it.Description,
+			Content:        it.Content,
+			Link:           it.Link,
+			Published:      it.Published,
+			Date:           it.Date,
+			FeedTitle:      it.FeedTitle,
+			FeedURL:        it.FeedURL,
+			FeedCategories: append([]string(nil), it.Categories...),
+			IsRead:         false,
+			SavedAt:        savedAt,
+			BodyHydrated:   true,
 		}
 		h.items[guid] = newItem
 		changed = append(changed, newItem)
@@ -111,6 +156,16 @@ func (h *History) MergeFeed(feed *Feed, savedAt time.Time) []*HistoryItem {
 	return changed
 }
 
+// keepsFullText reports whether existing holds a fuller body, such as text
+// extracted from the article page, than the excerpt fetched carries.
+func keepsFullText(existing *HistoryItem, fetched Item) bool {
+	content := strings.TrimSpace(existing.Content)
+	if content == "" || content == strings.TrimSpace(existing.Description) {
+		return false
+	}
+	return strings.TrimSpace(fetched.Content) == strings.TrimSpace(fetched.Description)
+}
+
 func mergeFetchedArticle(existing *HistoryItem, fetched Item, savedAt time.Time) bool {
 	if existing == nil {
 		return false
@@ -129,7 +184,7 @@ func mergeFetchedArticle(existing *HistoryItem, fetched Item, savedAt time.Time)
 		existing.Description = fetched.Description
 		changed = true
 	}
-	if fetched.Content != existing.Content {
+	if fetched.Content != "" && fetched.Content != existing.Content && !keepsFullText(existing, fetched) {
 		existing.Content = fetched.Content
 		changed = true
 	}
@@ -153,6 +208,10 @@ func mergeFetchedArticle(existing *HistoryItem, fetched Item, savedAt time.Time)
 		existing.FeedURL = fetched.FeedURL
 		changed = true
 	}
+	if len(fetched.Categories) > 0 && !slices.Equal(fetched.Categories, existing.FeedCategories) {
+		existing.FeedCategories = append([]string(nil), fetched.Categories...)
+		changed = true
+	}
 	if !savedAt.IsZero() && !savedAt.Equal(existing.SavedAt) {
 		existing.SavedAt = savedAt
 		changed = true
@@ -166,6 +225,8 @@ func mergeFetchedArticle(existing *HistoryItem, fetched Item, savedAt time.Time)
 
 // MarkRead marks an item as read. Returns true if it existed.
 func (h *History) MarkRead(guid string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	item, ok := h.items[guid]
 	if !ok || item == nil {
 		return false
@@ -174,14 +235,78 @@ func (h *History) MarkRead(guid string) bool {
 	return true
 }
 
+// MarkFeedRead marks every unread article of feedURL read and returns their
+// GUIDs in order. Digests and hidden articles are left alone, matching what
+// UnreadCountByFeed counts.
+func (h *History) MarkFeedRead(feedURL string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var guids []string
+	for guid, item := range h.items {
+		if item == nil || item.FeedURL != feedURL || item.kind() == NewsDigestKind || item.IsRead || item.IsHidden() {
+			continue
+		}
+		item.IsRead = true
+		guids = append(guids, guid)
+	}
+	sort.Strings(guids)
+	return guids
+}
+
+// MoveFeed points every item of the feed at from to the feed at to, as when
+// the feed moved permanently, and returns how many items changed.
+func (h *History) MoveFeed(from, to string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	moved := 0
+	for _, item := range h.items {
+		if item == nil || item.FeedURL != from {
+			continue
+		}
+		item.FeedURL = to
+		moved++
+	}
+	return moved
+}
+
+// IncrementOpenCount records one more open of an item and returns the new count.
+func (h *History) IncrementOpenCount(guid string) (int, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	item, ok := h.items[guid]
+	if !ok || item == nil {
+		return 0, false
+	}
+	item.OpenCount++
+	return item.OpenCount, true
+}
+
+// SetScrollOffset records where the reading pane was left in an item. It
+// reports false when the item is unknown or already at that offset.
+func (h *History) SetScrollOffset(guid string, offset int) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	item, ok := h.items[guid]
+	offset = max(offset, 0)
+	if !ok || item == nil || item.ScrollOffset == offset {
+		return false
+	}
+	item.ScrollOffset = offset
+	return true
+}
+
 // Item returns a history item by GUID.
 func (h *History) Item(guid string) (*HistoryItem, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 	item, ok := h.items[guid]
 	return item, ok
 }
 
 // ToggleBookmark returns true if the item exists and the specific item's state was toggled.
 func (h *History) ToggleBookmark(guid string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	item, ok := h.items[guid]
 	if !ok || item == nil {
 		return false
@@ -192,6 +317,8 @@ func (h *History) ToggleBookmark(guid string) bool {
 
 // SetInsight sets AI-generated insight fields for an item.
 func (h *History) SetInsight(guid, summary string, tags []string, updatedAt time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	item, ok := h.items[guid]
 	if !ok || item == nil {
 		return false
@@ -207,32 +334,121 @@ func (h *History) UpsertItem(item *HistoryItem) {
 	if h == nil || item == nil || strings.TrimSpace(item.GUID) == "" {
 		return
 	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	if item.kind() == NewsDigestKind {
 		item.BodyHydrated = true
 	}
 	h.items[item.GUID] = item
 }
 
-// BookmarkedItems returns all bookmarked items.
+// Snooze hides an article from lists until WakeSnoozed is called with a time
+// at or after until. Returns true if the article exists.
+func (h *History) Snooze(guid string, until time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	item, ok := h.items[guid]
+	if !ok || item == nil || item.kind() == NewsDigestKind || until.IsZero() {
+		return false
+	}
+	item.SnoozedUntil = until
+	return true
+}
+
+// WakeSnoozed brings back articles whose snooze ended at or before now,
+// marking them unread, and returns the articles it changed.
+func (h *History) WakeSnoozed(now time.Time) []*HistoryItem {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var woken []*HistoryItem
+	for _, item := range h.items {
+		if !item.IsSnoozed() || now.Before(item.SnoozedUntil) {
+			continue
+		}
+		item.SnoozedUntil = time.Time{}
+		item.IsRead = false
+		woken = append(woken, item)
+	}
+	return woken
+}
+
+// IsSnoozed reports whether the item is hidden from lists by a snooze.
+func (h *HistoryItem) IsSnoozed() bool {
+	return h != nil && !h.SnoozedUntil.IsZero()
+}
+
+// IsHidden reports whether the item is left out of lists because it is
+// snoozed or dismissed.
+func (h *HistoryItem) IsHidden() bool {
+	return h.IsSnoozed() || (h != nil && h.IsDismissed)
+}
+
+// SetDismissed dismisses or restores an article. Returns true if the article
+// exists and its flag changed.
+func (h *History) SetDismissed(guid string, dismissed bool) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	item, ok := h.items[guid]
+	if !ok || item == nil || item.kind() == NewsDigestKind || item.IsDismissed == dismissed {
+		return false
+	}
+	item.IsDismissed = dismissed
+	return true
+}
+
+// DismissedItems returns all dismissed articles.
+func (h *History) DismissedItems() []*HistoryItem {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.dismissedItems()
+}
+
+func (h *History) dismissedItems() []*HistoryItem {
+	items := make([]*HistoryItem, 0)
+	for _, hItem := range h.items {
+		if hItem != nil && hItem.IsDismissed && hItem.kind() != NewsDigestKind {
+			items = append(items, hItem)
+		}
+	}
+	return items
+}
+
+// BookmarkedItems returns all bookmarked items that are not hidden.
 func (h *History) BookmarkedItems() []*HistoryItem {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.bookmarkedItems()
+}
+
+func (h *History) bookmarkedItems() []*HistoryItem {
 	items := make([]*HistoryItem, 0)
 	for _, hItem := range h.items {
-		if hItem != nil && hItem.IsBookmarked {
+		if hItem != nil && hItem.IsBookmarked && !hItem.IsHidden() {
 			items = append(items, hItem)
 		}
 	}
 	return items
 }
 
-// ItemsByFeed returns history items filtered by feed URL.
+// ItemsByFeed returns history items filtered by feed URL. Snoozed and
+// dismissed articles are left out except in the Dismissed view.
 func (h *History) ItemsByFeed(feedURL string) []*HistoryItem {
-	if feedURL == BookmarksURL {
-		return h.BookmarkedItems()
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.itemsByFeed(feedURL)
+}
+
+func (h *History) itemsByFeed(feedURL string) []*HistoryItem {
+	switch feedURL {
+	case BookmarksURL:
+		return h.bookmarkedItems()
+	case DismissedURL:
+		return h.dismissedItems()
 	}
 
 	items := make([]*HistoryItem, 0, len(h.items))
 	for _, hItem := range h.items {
-		if hItem == nil || hItem.kind() == NewsDigestKind {
+		if hItem == nil || hItem.kind() == NewsDigestKind || hItem.IsHidden() {
 			continue
 		}
 		if feedURL == AllFeedsURL || feedURL == NewsURL || hItem.FeedURL == feedURL {
@@ -242,8 +458,102 @@ func (h *History) ItemsByFeed(feedURL string) []*HistoryItem {
 	return items
 }
 
+// UnreadCountByFeed returns the number of unread articles per feed URL,
+// not counting snoozed or dismissed ones. Feeds without unread articles are
+// absent.
+func (h *History) UnreadCountByFeed() map[string]int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	counts := make(map[string]int)
+	for _, item := range h.items {
+		if item == nil || item.kind() == NewsDigestKind || item.IsRead || item.IsHidden() {
+			continue
+		}
+		counts[item.FeedURL]++
+	}
+	return counts
+}
+
+// TagCounts returns how many articles carry each AI tag. Tags are compared
+// case-insensitively and keyed in lower case; digests are not counted.
+func (h *History) TagCounts() map[string]int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	counts := make(map[string]int)
+	for _, item := range h.items {
+		if item == nil || item.kind() == NewsDigestKind {
+			continue
+		}
+		seen := make(map[string]bool, len(item.AITags))
+		for _, tag := range item.AITags {
+			key := strings.ToLower(strings.TrimSpace(tag))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			counts[key]++
+		}
+	}
+	return counts
+}
+
+// NewestUnreadItemByFeed returns the most recent unread article per feed
+// URL, not counting snoozed or dismissed ones, with AllFeedsURL and
+// BookmarksURL holding the newest of those views. Items without a published
+// date fall back to their saved time.
+func (h *History) NewestUnreadItemByFeed() map[string]*HistoryItem {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	newest := make(map[string]*HistoryItem)
+	dates := make(map[string]time.Time)
+	keep := func(feedURL string, item *HistoryItem, date time.Time) {
+		if newest[feedURL] == nil || date.After(dates[feedURL]) {
+			newest[feedURL], dates[feedURL] = item, date
+		}
+	}
+	for _, item := range h.items {
+		if item == nil || item.IsRead || item.IsHidden() {
+			continue
+		}
+		date := historySortDate(item, time.Local)
+		if item.IsBookmarked {
+			keep(BookmarksURL, item, date)
+		}
+		if item.kind() == NewsDigestKind {
+			continue
+		}
+		keep(item.FeedURL, item, date)
+		keep(AllFeedsURL, item, date)
+	}
+	return newest
+}
+
+// LatestItemDateByFeed returns the newest article date per feed URL, not
+// counting snoozed or dismissed ones, with AllFeedsURL holding the newest
+// overall. Items without a published date fall back to their saved time.
+func (h *History) LatestItemDateByFeed() map[string]time.Time {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	latest := make(map[string]time.Time)
+	for _, item := range h.items {
+		if item == nil || item.kind() == NewsDigestKind || item.IsHidden() {
+			continue
+		}
+		date := historySortDate(item, time.Local)
+		if date.After(latest[item.FeedURL]) {
+			latest[item.FeedURL] = date
+		}
+		if date.After(latest[AllFeedsURL]) {
+			latest[AllFeedsURL] = date
+		}
+	}
+	return latest
+}
+
 // DigestItemsByDate returns all digest items for the specified date key.
 func (h *History) DigestItemsByDate(dateKey string) []*HistoryItem {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 	items := make([]*HistoryItem, 0)
 	for _, hItem := range h.items {
 		if hItem == nil || hItem.kind() != NewsDigestKind || digestDateKey(hItem, time.Local) != dateKey {
@@ -271,6 +581,8 @@ func (h *History) DigestItemsByDate(dateKey string) []*HistoryItem {
 // DigestItems returns all digest items sorted by digest_date (desc),
 // then by article date (desc), then by GUID (asc).
 func (h *History) DigestItems() []*HistoryItem {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 	items := make([]*HistoryItem, 0)
 	for _, hItem := range h.items {
 		if hItem == nil || hItem.kind() != NewsDigestKind {
@@ -309,6 +621,8 @@ func (h *History) DigestItems() []*HistoryItem {
 // ReplaceDigestItemsByDate upserts digest items for the date while keeping
 // previously generated items for the same date.
 func (h *History) ReplaceDigestItemsByDate(dateKey string, items []*HistoryItem) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	for _, item := range items {
 		if item == nil || item.GUID == "" {
 			continue
@@ -320,8 +634,11 @@ func (h *History) ReplaceDigestItemsByDate(dateKey string, items []*HistoryItem)
 	}
 }
 
-// TodayArticleItems returns today's article items in reverse-chronological order.
+// TodayArticleItems returns today's article items in reverse-chronological
+// order, leaving out snoozed and dismissed ones.
 func (h *History) TodayArticleItems(dateKey string, feeds []string, loc *time.Location) []*HistoryItem {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 	allowedFeeds := make(map[string]struct{}, len(feeds))
 	for _, feed := range feeds {
 		if feed == "" {
@@ -332,7 +649,7 @@ func (h *History) TodayArticleItems(dateKey string, feeds []string, loc *time.Lo
 
 	items := make([]*HistoryItem, 0)
 	for _, hItem := range h.items {
-		if hItem == nil || hItem.kind() == NewsDigestKind {
+		if hItem == nil || hItem.kind() == NewsDigestKind || hItem.IsHidden() {
 			continue
 		}
 		if len(allowedFeeds) > 0 {
@@ -400,6 +717,8 @@ func (h *HistoryItem) kind() string {
 
 // RelatedItems resolves RelatedGUIDs to article items while preserving GUID order.
 func (h *History) RelatedItems(digest *HistoryItem) []*HistoryItem {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 	if digest == nil || len(digest.RelatedGUIDs) == 0 {
 		return nil
 	}
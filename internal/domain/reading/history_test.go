@@ -1,6 +1,8 @@
 package reading
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 )
@@ -101,6 +103,54 @@ func TestHistory_ItemsByFeed(t *testing.T) {
 	}
 }
 
+func TestHistory_LatestItemDateByFeed(t *testing.T) {
+	older := time.Date(2026, 2, 13, 9, 0, 0, 0, time.UTC)
+	newer := time.Date(2026, 2, 14, 9, 0, 0, 0, time.UTC)
+	h := NewHistory(map[string]*HistoryItem{
+		"a": {GUID: "a", FeedURL: "feed1", Date: older},
+		"b": {GUID: "b", FeedURL: "feed1", Date: newer},
+		"c": {GUID: "c", FeedURL: "feed2", SavedAt: older},
+		"d": {GUID: "d", Kind: NewsDigestKind, FeedURL: "feed1", Date: newer.Add(time.Hour)},
+		"e": {GUID: "e", FeedURL: "feed2", Date: newer, IsDismissed: true},
+	})
+
+	latest := h.LatestItemDateByFeed()
+	if got := latest["feed1"]; !got.Equal(newer) {
+		t.Fatalf("latest[feed1] = %v, want %v", got, newer)
+	}
+	if got := latest["feed2"]; !got.Equal(older) {
+		t.Fatalf("latest[feed2] = %v, want %v without the dismissed article", got, older)
+	}
+	if got := latest[AllFeedsURL]; !got.Equal(newer) {
+		t.Fatalf("latest[AllFeedsURL] = %v, want %v", got, newer)
+	}
+	if _, ok := latest["missing"]; ok {
+		t.Fatal("latest should have no date for an unknown feed")
+	}
+}
+
+func TestHistory_NewestUnreadItemByFeed(t *testing.T) {
+	older := time.Date(2026, 2, 13, 9, 0, 0, 0, time.UTC)
+	newer := time.Date(2026, 2, 14, 9, 0, 0, 0, time.UTC)
+	h := NewHistory(map[string]*HistoryItem{
+		"a": {GUID: "a", FeedURL: "feed1", Date: older, IsBookmarked: true},
+		"b": {GUID: "b", FeedURL: "feed1", Date: newer, IsRead: true},
+		"c": {GUID: "c", FeedURL: "feed2", SavedAt: older.Add(time.Hour)},
+		"d": {GUID: "d", Kind: NewsDigestKind, FeedURL: "feed1", Date: newer},
+		"e": {GUID: "e", FeedURL: "feed2", Date: newer, IsDismissed: true},
+	})
+
+	newest := h.NewestUnreadItemByFeed()
+	for feedURL, want := range map[string]string{"feed1": "a", "feed2": "c", AllFeedsURL: "c", BookmarksURL: "a"} {
+		if got := newest[feedURL]; got == nil || got.GUID != want {
+			t.Fatalf("newest[%s] = %+v, want %s", feedURL, got, want)
+		}
+	}
+	if _, ok := newest[NewsURL]; ok {
+		t.Fatal("news digests should not be previewed")
+	}
+}
+
 func TestHistory_DigestItemsAndReplace(t *testing.T) {
 	h := NewHistory(map[string]*HistoryItem{
 		"d_old_1": {
@@ -262,6 +312,20 @@ func TestHistory_TodayArticleItems(t *testing.T) {
 			FeedURL: "feed3",
 			Date:    time.Date(2026, 2, 14, 13, 0, 0, 0, loc),
 		},
+		"snoozed_today": {
+			GUID:         "snoozed_today",
+			Kind:         ArticleKind,
+			FeedURL:      "feed1",
+			Date:         time.Date(2026, 2, 14, 14, 0, 0, 0, loc),
+			SnoozedUntil: time.Date(2026, 2, 15, 9, 0, 0, 0, loc),
+		},
+		"dismissed_today": {
+			GUID:        "dismissed_today",
+			Kind:        ArticleKind,
+			FeedURL:     "feed2",
+			Date:        time.Date(2026, 2, 14, 15, 0, 0, 0, loc),
+			IsDismissed: true,
+		},
 	})
 
 	got := h.TodayArticleItems("2026-02-14", []string{"feed1", "feed2"}, loc)
@@ -338,3 +402,264 @@ func TestHistory_SetInsight(t *testing.T) {
 		t.Fatal("SetInsight should return false for missing item")
 	}
 }
+
+func TestHistory_MergeFeedCategories(t *testing.T) {
+	now := time.Date(2026, 2, 5, 12, 0, 0, 0, time.UTC)
+	h := NewHistory(map[string]*HistoryItem{
+		"old": {GUID: "old", Kind: ArticleKind, AITags: []string{"ai"}},
+	})
+
+	h.MergeFeed(&Feed{Items: []Item{
+		{GUID: "new", Title: "New", Categories: []string{"Go"}},
+		{GUID: "old", Title: "Old", Categories: []string{"Release"}},
+	}}, now)
+
+	item, _ := h.Item("new")
+	if len(item.FeedCategories) != 1 || item.FeedCategories[0] != "Go" || len(item.AITags) != 0 {
+		t.Fatalf("new item categories = %#v, tags = %#v", item.FeedCategories, item.AITags)
+	}
+	item, _ = h.Item("old")
+	if len(item.FeedCategories) != 1 || item.FeedCategories[0] != "Release" {
+		t.Fatalf("existing item categories = %#v, want [Release]", item.FeedCategories)
+	}
+	if len(item.AITags) != 1 || item.AITags[0] != "ai" {
+		t.Fatalf("AI tags should be untouched, got %#v", item.AITags)
+	}
+}
+
+func TestHistory_UnreadCountByFeed(t *testing.T) {
+	h := NewHistory(map[string]*HistoryItem{
+		"a1":     {GUID: "a1", FeedURL: "a", Kind: ArticleKind},
+		"a2":     {GUID: "a2", FeedURL: "a", Kind: ArticleKind},
+		"b1":     {GUID: "b1", FeedURL: "b", Kind: ArticleKind, IsRead: true},
+		"digest": {GUID: "digest", FeedURL: "a", Kind: NewsDigestKind},
+	})
+
+	counts := h.UnreadCountByFeed()
+	if len(counts) != 1 || counts["a"] != 2 {
+		t.Fatalf("UnreadCountByFeed() = %#v, want map[a:2]", counts)
+	}
+}
+
+func TestHistory_MarkFeedRead(t *testing.T) {
+	h := NewHistory(map[string]*HistoryItem{
+		"a2":     {GUID: "a2", FeedURL: "a", Kind: ArticleKind},
+		"a1":     {GUID: "a1", FeedURL: "a", Kind: ArticleKind},
+		"a-read": {GUID: "a-read", FeedURL: "a", Kind: ArticleKind, IsRead: true},
+		"a-gone": {GUID: "a-gone", FeedURL: "a", Kind: ArticleKind, IsDismissed: true},
+		"b1":     {GUID: "b1", FeedURL: "b", Kind: ArticleKind},
+		"digest": {GUID: "digest", FeedURL: "a", Kind: NewsDigestKind},
+	})
+
+	if got := h.MarkFeedRead("a"); len(got) != 2 || got[0] != "a1" || got[1] != "a2" {
+		t.Fatalf("MarkFeedRead() = %v, want [a1 a2]", got)
+	}
+	if counts := h.UnreadCountByFeed(); counts["a"] != 0 || counts["b"] != 1 {
+		t.Fatalf("UnreadCountByFeed() = %#v, want only b unread", counts)
+	}
+	if item, _ := h.Item("a-gone"); item.IsRead {
+		t.Fatal("hidden articles should be left alone")
+	}
+}
+
+func TestHistory_MergeFeedKeepsCachedItemsOnEmptyRefresh(t *testing.T) {
+	now := time.Date(2026, 2, 5, 12, 0, 0, 0, time.UTC)
+	h := NewHistory(map[string]*HistoryItem{
+		"a": {GUID: "a", Kind: ArticleKind, Title: "A", Content: "cached body"},
+	})
+
+	if changed := h.MergeFeed(&Feed{}, now); len(changed) != 0 {
+		t.Fatalf("empty refresh changed %d items, want 0", len(changed))
+	}
+	h.MergeFeed(&Feed{Items: []Item{{GUID: "a"}}}, now)
+
+	item, ok := h.Item("a")
+	if !ok || item.Title != "A" || item.Content != "cached body" {
+		t.Fatalf("cached item should be intact, got %#v", item)
+	}
+}
+
+func TestHistory_MergeFeedKeepsExtractedText(t *testing.T) {
+	now := time.Date(2026, 2, 5, 12, 0, 0, 0, time.UTC)
+	h := NewHistory(map[string]*HistoryItem{
+		"a": {GUID: "a", Kind: ArticleKind, Title: "A", Description: "Teaser", Content: "The whole article"},
+	})
+
+	h.MergeFeed(&Feed{Items: []Item{{GUID: "a", Title: "A", Description: "Teaser", Content: "Teaser"}}}, now)
+	if item, _ := h.Item("a"); item.Content != "The whole article" {
+		t.Fatalf("an excerpt refresh replaced the extracted text: %q", item.Content)
+	}
+
+	h.MergeFeed(&Feed{Items: []Item{{GUID: "a", Title: "A", Description: "Teaser", Content: "Updated full post"}}}, now)
+	if item, _ := h.Item("a"); item.Content != "Updated full post" {
+		t.Fatalf("full content from the feed should still update the body, got %q", item.Content)
+	}
+}
+
+func TestHistory_SnoozeHidesUntilWoken(t *testing.T) {
+	now := time.Date(2026, 2, 14, 9, 0, 0, 0, time.UTC)
+	h := NewHistory(map[string]*HistoryItem{
+		"a1":     {GUID: "a1", FeedURL: "a", Kind: ArticleKind, IsRead: true, IsBookmarked: true},
+		"a2":     {GUID: "a2", FeedURL: "a", Kind: ArticleKind},
+		"digest": {GUID: "digest", FeedURL: NewsURL, Kind: NewsDigestKind},
+	})
+
+	if !h.Snooze("a1", now.Add(time.Hour)) {
+		t.Fatal("Snooze() should snooze an existing article")
+	}
+	if h.Snooze("digest", now.Add(time.Hour)) || h.Snooze("missing", now.Add(time.Hour)) {
+		t.Fatal("Snooze() should ignore digests and unknown items")
+	}
+	if items := h.ItemsByFeed("a"); len(items) != 1 || items[0].GUID != "a2" {
+		t.Fatalf("ItemsByFeed() = %#v, want only a2", items)
+	}
+	if items := h.ItemsByFeed(BookmarksURL); len(items) != 0 {
+		t.Fatalf("snoozed bookmark should be hidden, got %#v", items)
+	}
+
+	if woken := h.WakeSnoozed(now.Add(59 * time.Minute)); len(woken) != 0 {
+		t.Fatalf("WakeSnoozed() before the snooze ends = %#v", woken)
+	}
+	woken := h.WakeSnoozed(now.Add(time.Hour))
+	if len(woken) != 1 || woken[0].GUID != "a1" {
+		t.Fatalf("WakeSnoozed() = %#v, want a1", woken)
+	}
+	if woken[0].IsSnoozed() || woken[0].IsRead {
+		t.Fatalf("woken article should be visible and unread: %#v", woken[0])
+	}
+	if counts := h.UnreadCountByFeed(); counts["a"] != 2 {
+		t.Fatalf("UnreadCountByFeed() = %#v, want a:2", counts)
+	}
+}
+
+func TestHistory_DismissHidesAcrossRefetch(t *testing.T) {
+	now := time.Date(2026, 2, 14, 9, 0, 0, 0, time.UTC)
+	h := NewHistory(map[string]*HistoryItem{
+		"a1":     {GUID: "a1", Title: "Old", FeedURL: "a", Kind: ArticleKind, IsBookmarked: true, Published: "2026-02-14"},
+		"a2":     {GUID: "a2", FeedURL: "a", Kind: ArticleKind},
+		"digest": {GUID: "digest", FeedURL: NewsURL, Kind: NewsDigestKind},
+	})
+
+	if !h.SetDismissed("a1", true) {
+		t.Fatal("SetDismissed() should dismiss an existing article")
+	}
+	if h.SetDismissed("a1", true) || h.SetDismissed("digest", true) || h.SetDismissed("missing", true) {
+		t.Fatal("SetDismissed() should ignore unchanged flags, digests and unknown items")
+	}
+	for _, url := range []string{"a", AllFeedsURL, BookmarksURL} {
+		for _, item := range h.ItemsByFeed(url) {
+			if item.GUID == "a1" {
+				t.Fatalf("dismissed article listed in %s", url)
+			}
+		}
+	}
+	if counts := h.UnreadCountByFeed(); counts["a"] != 1 {
+		t.Fatalf("UnreadCountByFeed() = %#v, want a:1", counts)
+	}
+	if items := h.TodayArticleItems("2026-02-14", nil, time.UTC); len(items) != 0 {
+		t.Fatalf("TodayArticleItems() = %#v, want dismissed article left out", items)
+	}
+
+	changed := h.MergeFeed(&Feed{Items: []Item{{GUID: "a1", Title: "New", FeedURL: "a"}}}, now)
+	if len(changed) != 0 {
+		t.Fatalf("MergeFeed() changed %#v, want dismissed article untouched", changed)
+	}
+	if items := h.ItemsByFeed(DismissedURL); len(items) != 1 || items[0].GUID != "a1" || !items[0].IsDismissed {
+		t.Fatalf("Dismissed view = %#v, want a1", items)
+	}
+
+	if !h.SetDismissed("a1", false) {
+		t.Fatal("SetDismissed(false) should restore the article")
+	}
+	if items := h.ItemsByFeed("a"); len(items) != 2 {
+		t.Fatalf("restored article should be listed again, got %#v", items)
+	}
+}
+
+func TestHistory_TagCounts(t *testing.T) {
+	h := NewHistory(map[string]*HistoryItem{
+		"a1":     {GUID: "a1", Kind: ArticleKind, AITags: []string{"Go", "AI", "go"}},
+		"a2":     {GUID: "a2", Kind: ArticleKind, AITags: []string{"go", " "}},
+		"a3":     {GUID: "a3", Kind: ArticleKind},
+		"digest": {GUID: "digest", Kind: NewsDigestKind, AITags: []string{"go"}},
+	})
+
+	counts := h.TagCounts()
+	if len(counts) != 2 || counts["go"] != 2 || counts["ai"] != 1 {
+		t.Fatalf("TagCounts() = %#v, want go:2 ai:1", counts)
+	}
+}
+
+func TestHistory_SnapshotCopiesItems(t *testing.T) {
+	h := NewHistory(map[string]*HistoryItem{
+		"a": {GUID: "a", Title: "Original", AITags: []string{"go"}},
+	})
+
+	snapshot := h.Snapshot()
+	if len(snapshot) != 1 {
+		t.Fatalf("Snapshot() returned %d items, want 1", len(snapshot))
+	}
+	snapshot[0].Title = "Changed"
+	snapshot[0].AITags[0] = "rust"
+
+	clone := h.Clone()
+	clone.MarkRead("a")
+
+	item, _ := h.Item("a")
+	if item.Title != "Original" || item.AITags[0] != "go" || item.IsRead {
+		t.Fatalf("item = %+v, snapshot and clone must not write through", item)
+	}
+}
+
+func TestHistory_SnapshotDuringMerge(t *testing.T) {
+	h := NewHistory(nil)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 500 {
+			h.MergeFeed(&Feed{Items: []Item{{GUID: fmt.Sprintf("g%d", i), FeedURL: "f"}}}, time.Now())
+		}
+	}()
+	for range 500 {
+		_ = h.Snapshot()
+		_ = h.UnreadCountByFeed()
+	}
+	wg.Wait()
+
+	if got := len(h.Snapshot()); got != 500 {
+		t.Fatalf("Snapshot() returned %d items, want 500", got)
+	}
+}
+
+// TestHistory_ConcurrentMergeAndListing is meant to run under -race: one
+// goroutine merges, upserts and marks read while another lists.
+func TestHistory_ConcurrentMergeAndListing(t *testing.T) {
+	h := NewHistory(nil)
+	const rounds = 200
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range rounds {
+			guid := fmt.Sprintf("a%d", i)
+			h.MergeFeed(&Feed{Items: []Item{{GUID: guid, FeedURL: "f"}}}, time.Now())
+			h.MarkRead(guid)
+			h.UpsertItem(&HistoryItem{GUID: fmt.Sprintf("d%d", i), Kind: NewsDigestKind, DigestDate: "2026-01-02"})
+		}
+	}()
+	for range rounds {
+		_ = h.ItemsByFeed(AllFeedsURL)
+		_ = h.ItemsByFeed("f")
+		_ = h.DigestItems()
+		_, _ = h.Item("a0")
+	}
+	wg.Wait()
+
+	if got := len(h.ItemsByFeed("f")); got != rounds {
+		t.Fatalf("ItemsByFeed() returned %d items, want %d", got, rounds)
+	}
+	if got := len(h.DigestItemsByDate("2026-01-02")); got != rounds {
+		t.Fatalf("DigestItemsByDate() returned %d items, want %d", got, rounds)
+	}
+}
@@ -0,0 +1,115 @@
+package subscription
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+)
+
+// OPMLFolderSeparator joins the names of nested OPML folders.
+const OPMLFolderSeparator = " / "
+
+type opmlDocument struct {
+	XMLName xml.Name      `xml:"opml"`
+	Body    []opmlOutline `xml:"body>outline"`
+}
+
+type opmlOutline struct {
+	XMLURL   string        `xml:"xmlUrl,attr"`
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr"`
+	Category string        `xml:"category,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// name returns the outline's label, preferring text over title.
+func (o opmlOutline) name() string {
+	if text := strings.Join(strings.Fields(o.Text), " "); text != "" {
+		return text
+	}
+	return strings.Join(strings.Fields(o.Title), " ")
+}
+
+// OPMLFeed is one feed outline of an OPML document.
+type OPMLFeed struct {
+	URL string
+	// Title is the outline's text (or title) attribute; empty when it only
+	// repeats the URL.
+	Title string
+	// Folder names the enclosing folder outlines, outermost first, joined
+	// with OPMLFolderSeparator. Empty for feeds at the top level.
+	Folder string
+	// Categories lists the entries of the outline's category attribute,
+	// e.g. "/Tech/Go,News" gives "Tech/Go" and "News".
+	Categories []string
+}
+
+// ParseOPML returns the feed URLs (xmlUrl attributes) of an OPML document in
+// document order, including outlines nested in folders. Outlines without a
+// feed URL are skipped; the URLs themselves are not validated.
+func ParseOPML(r io.Reader) ([]string, error) {
+	feeds, err := ParseOPMLFeeds(r)
+	if err != nil {
+		return nil, err
+	}
+	urls := make([]string, 0, len(feeds))
+	for _, feed := range feeds {
+		urls = append(urls, feed.URL)
+	}
+	return urls, nil
+}
+
+// ParseOPMLFeeds is ParseOPML keeping each feed's title, folder and
+// categories.
+func ParseOPMLFeeds(r io.Reader) ([]OPMLFeed, error) {
+	var doc opmlDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	var feeds []OPMLFeed
+	var walk func([]opmlOutline, []string)
+	walk = func(outlines []opmlOutline, folders []string) {
+		for _, outline := range outlines {
+			url := strings.TrimSpace(outline.XMLURL)
+			if url == "" {
+				nested := folders
+				if name := outline.name(); name != "" {
+					nested = append(slices.Clip(folders), name)
+				}
+				walk(outline.Outlines, nested)
+				continue
+			}
+			title := outline.name()
+			if title == url {
+				title = ""
+			}
+			feeds = append(feeds, OPMLFeed{
+				URL:        url,
+				Title:      title,
+				Folder:     strings.Join(folders, OPMLFolderSeparator),
+				Categories: parseOPMLCategories(outline.Category),
+			})
+			walk(outline.Outlines, folders)
+		}
+	}
+	walk(doc.Body, nil)
+	if len(feeds) == 0 {
+		return nil, errors.New("opml has no feed outlines")
+	}
+	return feeds, nil
+}
+
+// parseOPMLCategories splits a category attribute into its comma-separated
+// entries without surrounding slashes, dropping empty and repeated ones.
+func parseOPMLCategories(value string) []string {
+	var categories []string
+	for entry := range strings.SplitSeq(value, ",") {
+		entry = strings.Trim(strings.TrimSpace(entry), "/")
+		if entry != "" && !slices.Contains(categories, entry) {
+			categories = append(categories, entry)
+		}
+	}
+	return categories
+}
@@ -0,0 +1,63 @@
+package subscription
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseOPML(t *testing.T) {
+	doc := `<?xml version="1.0"?>
+<opml version="2.0">
+  <head><title>Subscriptions</title></head>
+  <body>
+    <outline text="Go Blog" type="rss" xmlUrl="https://go.dev/blog/feed.atom"/>
+    <outline text="Tech">
+      <outline text="HN" type="rss" xmlUrl=" https://news.ycombinator.com/rss "/>
+      <outline text="Folder note"/>
+    </outline>
+  </body>
+</opml>`
+
+	urls, err := ParseOPML(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ParseOPML() error = %v", err)
+	}
+	if len(urls) != 2 || urls[0] != "https://go.dev/blog/feed.atom" || urls[1] != "https://news.ycombinator.com/rss" {
+		t.Fatalf("ParseOPML() = %#v", urls)
+	}
+
+	if _, err := ParseOPML(strings.NewReader(`<opml><body><outline text="empty"/></body></opml>`)); err == nil {
+		t.Fatal("ParseOPML() should fail without feed outlines")
+	}
+	if _, err := ParseOPML(strings.NewReader("not xml")); err == nil {
+		t.Fatal("ParseOPML() should fail on malformed input")
+	}
+}
+
+func TestParseOPMLFeeds(t *testing.T) {
+	doc := `<opml version="2.0">
+  <body>
+    <outline text="Go Blog" xmlUrl="https://go.dev/blog/feed.atom" category="/Programming/Go, News,News"/>
+    <outline title="Tech">
+      <outline text="Japan">
+        <outline xmlUrl="https://example.jp/rss" text="https://example.jp/rss"/>
+      </outline>
+      <outline text="Hacker   News" xmlUrl="https://news.ycombinator.com/rss"/>
+    </outline>
+  </body>
+</opml>`
+
+	feeds, err := ParseOPMLFeeds(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ParseOPMLFeeds() error = %v", err)
+	}
+	want := []OPMLFeed{
+		{URL: "https://go.dev/blog/feed.atom", Title: "Go Blog", Categories: []string{"Programming/Go", "News"}},
+		{URL: "https://example.jp/rss", Folder: "Tech / Japan"},
+		{URL: "https://news.ycombinator.com/rss", Title: "Hacker News", Folder: "Tech"},
+	}
+	if !reflect.DeepEqual(feeds, want) {
+		t.Fatalf("ParseOPMLFeeds() = %#v, want %#v", feeds, want)
+	}
+}
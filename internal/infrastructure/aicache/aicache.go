@@ -0,0 +1,245 @@
+// Package aicache keeps AI summaries and tags of articles in a JSON sidecar
+// file, so the history database only holds what the feeds provided.
+package aicache
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/infrastructure/history"
+)
+
+// FileName is the sidecar file created next to the history database.
+const FileName = "ai_cache.json"
+
+// PathFor returns the sidecar path for the history database at historyFile.
+func PathFor(historyFile string) string {
+	return filepath.Join(filepath.Dir(historyFile), FileName)
+}
+
+type insight struct {
+	Summary   string    `json:"summary"`
+	Tags      []string  `json:"tags,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Repository is a history repository that keeps article insights in the
+// sidecar instead of the database. Reads merge them back into the items;
+// every other operation goes to the wrapped Manager.
+type Repository struct {
+	*history.Manager
+
+	mu       sync.Mutex
+	path     string
+	insights map[string]insight
+	loaded   bool
+}
+
+// NewRepository wraps manager, storing article insights in the file at path.
+func NewRepository(manager *history.Manager, path string) *Repository {
+	return &Repository{Manager: manager, path: path}
+}
+
+// LoadMetadata loads history items with their insights from the sidecar.
+// Insights still stored in the database are moved to the sidecar first and
+// cleared from the database once the sidecar is saved.
+func (r *Repository) LoadMetadata() (map[string]*reading.HistoryItem, error) {
+	items, err := r.Manager.LoadMetadata()
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	moved := false
+	var stale []string
+	for guid, item := range items {
+		if !storesInsight(item) {
+			continue
+		}
+		if strings.TrimSpace(item.AISummary) != "" || len(item.AITags) > 0 {
+			stale = append(stale, guid)
+		}
+		if _, ok := r.insights[guid]; !ok && strings.TrimSpace(item.AISummary) != "" {
+			r.insights[guid] = insight{Summary: item.AISummary, Tags: slices.Clone(item.AITags), UpdatedAt: item.AIUpdatedAt}
+			moved = true
+		}
+		r.apply(item)
+	}
+	if moved {
+		if err := r.save(); err != nil {
+			return nil, err
+		}
+	}
+	if len(stale) > 0 {
+		if err := r.Manager.ClearInsights(stale); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// LoadByGUID loads one fully hydrated item with its insight.
+func (r *Repository) LoadByGUID(guid string) (*reading.HistoryItem, error) {
+	item, err := r.Manager.LoadByGUID(guid)
+	if err != nil || item == nil {
+		return item, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	r.apply(item)
+	return item, nil
+}
+
+// LoadTodayArticles loads today's articles with their insights.
+func (r *Repository) LoadTodayArticles(dateKey string, feeds []string, limit int, loc *time.Location) ([]*reading.HistoryItem, error) {
+	items, err := r.Manager.LoadTodayArticles(dateKey, feeds, limit, loc)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		r.apply(item)
+	}
+	return items, nil
+}
+
+// Upsert saves items without their article insights, which live in the
+// sidecar. Digests keep theirs, since they are AI output as a whole.
+func (r *Repository) Upsert(items []*reading.HistoryItem) error {
+	stripped := make([]*reading.HistoryItem, 0, len(items))
+	for _, item := range items {
+		if item == nil || !storesInsight(item) {
+			stripped = append(stripped, item)
+			continue
+		}
+		c := *item
+		c.AISummary = ""
+		c.AITags = nil
+		c.AIUpdatedAt = time.Time{}
+		stripped = append(stripped, &c)
+	}
+	return r.Manager.Upsert(stripped)
+}
+
+// SetInsight stores an article's insight in the sidecar.
+func (r *Repository) SetInsight(guid, summary string, tags []string, updatedAt time.Time) error {
+	guid = strings.TrimSpace(guid)
+	if guid == "" {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.load(); err != nil {
+		return err
+	}
+	r.insights[guid] = insight{Summary: summary, Tags: slices.Clone(tags), UpdatedAt: updatedAt}
+	return r.save()
+}
+
+// ClearAll deletes every history row and every stored insight.
+func (r *Repository) ClearAll() error {
+	if err := r.Manager.ClearAll(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.insights = make(map[string]insight)
+	r.loaded = true
+	return r.save()
+}
+
+// ClearUnbookmarked deletes the history rows ClearUnbookmarked drops from
+// the database, along with their insights.
+func (r *Repository) ClearUnbookmarked() error {
+	if err := r.Manager.ClearUnbookmarked(); err != nil {
+		return err
+	}
+	kept, err := r.Manager.LoadMetadata()
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.load(); err != nil {
+		return err
+	}
+	for guid := range r.insights {
+		if _, ok := kept[guid]; !ok {
+			delete(r.insights, guid)
+		}
+	}
+	return r.save()
+}
+
+// storesInsight reports whether the item's insight belongs in the sidecar.
+func storesInsight(item *reading.HistoryItem) bool {
+	return item != nil && item.Kind != reading.NewsDigestKind
+}
+
+func (r *Repository) apply(item *reading.HistoryItem) {
+	if !storesInsight(item) {
+		return
+	}
+	stored, ok := r.insights[item.GUID]
+	if !ok {
+		return
+	}
+	item.AISummary = stored.Summary
+	item.AITags = slices.Clone(stored.Tags)
+	item.AIUpdatedAt = stored.UpdatedAt
+}
+
+func (r *Repository) load() error {
+	if r.loaded {
+		return nil
+	}
+	r.insights = make(map[string]insight)
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		r.loaded = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &r.insights); err != nil {
+		return err
+	}
+	r.loaded = true
+	return nil
+}
+
+// save writes the sidecar through a temporary file so a crash never leaves
+// it half written.
+func (r *Repository) save() error {
+	data, err := json.MarshalIndent(r.insights, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0750); err != nil {
+		return err
+	}
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.path)
+}
@@ -0,0 +1,117 @@
+package aicache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/infrastructure/history"
+)
+
+func newTestRepository(t *testing.T) (*Repository, string) {
+	t.Helper()
+	dir := t.TempDir()
+	manager := history.NewManager(filepath.Join(dir, "history.db"))
+	t.Cleanup(func() { _ = manager.Close() })
+	return NewRepository(manager, PathFor(filepath.Join(dir, "history.db"))), dir
+}
+
+func TestRepository_SetInsightKeepsDatabasePure(t *testing.T) {
+	repo, dir := newTestRepository(t)
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := repo.Upsert([]*reading.HistoryItem{{GUID: "a", Kind: reading.ArticleKind, Title: "A", Content: "Body"}}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if err := repo.SetInsight("a", "Summary", []string{"go"}, updatedAt); err != nil {
+		t.Fatalf("SetInsight failed: %v", err)
+	}
+
+	stored, err := repo.Manager.LoadByGUID("a")
+	if err != nil || stored.AISummary != "" {
+		t.Fatalf("database item = %+v, %v; want no AI summary", stored, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, FileName)); err != nil {
+		t.Fatalf("sidecar missing: %v", err)
+	}
+
+	reopened := NewRepository(repo.Manager, filepath.Join(dir, FileName))
+	meta, err := reopened.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if got := meta["a"]; got.AISummary != "Summary" || len(got.AITags) != 1 || !got.AIUpdatedAt.Equal(updatedAt) {
+		t.Fatalf("merged item = %+v, want the sidecar insight", got)
+	}
+	item, err := reopened.LoadByGUID("a")
+	if err != nil || item.AISummary != "Summary" {
+		t.Fatalf("LoadByGUID() = %+v, %v; want the sidecar insight", item, err)
+	}
+
+	// Saving the merged item again must not copy the insight into the database.
+	if err := reopened.Upsert([]*reading.HistoryItem{item}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if stored, _ := repo.Manager.LoadByGUID("a"); stored.AISummary != "" {
+		t.Fatalf("database summary = %q after upsert, want none", stored.AISummary)
+	}
+}
+
+func TestRepository_MovesExistingInsightsOutOfDatabase(t *testing.T) {
+	repo, _ := newTestRepository(t)
+	items := []*reading.HistoryItem{
+		{GUID: "a", Kind: reading.ArticleKind, AISummary: "Old summary"},
+		{GUID: "d", Kind: reading.NewsDigestKind, AISummary: "Digest"},
+	}
+	if err := repo.Manager.Upsert(items); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	meta, err := repo.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if meta["a"].AISummary != "Old summary" || meta["d"].AISummary != "Digest" {
+		t.Fatalf("summaries = %q, %q; want both kept", meta["a"].AISummary, meta["d"].AISummary)
+	}
+	if _, ok := repo.insights["a"]; !ok {
+		t.Fatal("the article summary should move to the sidecar")
+	}
+	if _, ok := repo.insights["d"]; ok {
+		t.Fatal("digests should stay in the database")
+	}
+	stored, err := repo.Manager.LoadMetadata()
+	if err != nil {
+		t.Fatalf("Manager.LoadMetadata failed: %v", err)
+	}
+	if stored["a"].AISummary != "" || stored["d"].AISummary != "Digest" {
+		t.Fatalf("database summaries = %q, %q; want the moved one cleared", stored["a"].AISummary, stored["d"].AISummary)
+	}
+}
+
+func TestRepository_ClearUnbookmarkedDropsInsights(t *testing.T) {
+	repo, _ := newTestRepository(t)
+	items := []*reading.HistoryItem{
+		{GUID: "kept", Kind: reading.ArticleKind, IsBookmarked: true},
+		{GUID: "gone", Kind: reading.ArticleKind},
+	}
+	if err := repo.Upsert(items); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	for _, guid := range []string{"kept", "gone"} {
+		if err := repo.SetInsight(guid, "Summary", nil, time.Now()); err != nil {
+			t.Fatalf("SetInsight failed: %v", err)
+		}
+	}
+
+	if err := repo.ClearUnbookmarked(); err != nil {
+		t.Fatalf("ClearUnbookmarked failed: %v", err)
+	}
+	if _, ok := repo.insights["gone"]; ok {
+		t.Fatal("insights of cleared articles should be dropped")
+	}
+	if _, ok := repo.insights["kept"]; !ok {
+		t.Fatal("insights of bookmarked articles should stay")
+	}
+}
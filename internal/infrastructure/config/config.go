@@ -2,15 +2,22 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/alecthomas/kong"
 	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/domain/reading"
 	"github.com/tesso57/reazy/internal/domain/subscription"
+	"github.com/tesso57/reazy/internal/infrastructure/aicache"
 	"gopkg.in/yaml.v3"
 )
 
@@ -18,6 +25,10 @@ import (
 type Store struct {
 	Settings   settings.Settings
 	configPath string
+	// batching defers saves until Batch returns; dirty records that one
+	// was deferred.
+	batching bool
+	dirty    bool
 }
 
 // Load loads the configuration from the specified path or default location.
@@ -59,11 +70,55 @@ func Load(customPath ...string) (*Store, error) {
 	}
 
 	store.Settings = cfg
-	if groups, err := loadFeedGroupsFromConfig(configPath); err != nil {
+	structured, err := loadStructuredConfig(configPath)
+	if err != nil {
 		return nil, err
-	} else if groups != nil {
-		store.Settings.FeedGroups = groups
 	}
+	if structured.FeedGroups != nil {
+		store.Settings.FeedGroups = structured.FeedGroups
+	}
+	store.Settings.FeedGroupingCache = structured.FeedGroupingCache
+	store.Settings.NewsSelection = structured.NewsSelection
+	sanitizer, err := reading.NewContentSanitizer(structured.ContentStripPatterns)
+	if err != nil {
+		return nil, fmt.Errorf("content_strip_patterns: %w", err)
+	}
+	store.Settings.ContentStripPatterns = structured.ContentStripPatterns
+	store.Settings.ContentSanitizer = sanitizer
+	if err := settings.ValidateSectionHeaderFormat(store.Settings.SectionHeaderFormat); err != nil {
+		return nil, fmt.Errorf("section_header_format: %w", err)
+	}
+	if err := settings.ValidateBuiltinTabs(store.Settings.BuiltinTabs); err != nil {
+		return nil, fmt.Errorf("builtin_tabs: %w", err)
+	}
+	if err := settings.ValidateGroupSort(store.Settings.GroupSort); err != nil {
+		return nil, fmt.Errorf("group_sort: %w", err)
+	}
+	if err := settings.ValidateIcons(store.Settings.Icons); err != nil {
+		return nil, fmt.Errorf("icons: %w", err)
+	}
+	if err := settings.ValidateOpenAction(store.Settings.DefaultOpenAction); err != nil {
+		return nil, fmt.Errorf("default_open_action: %w", err)
+	}
+	if err := settings.ValidateGroupingStrategy(store.Settings.Grouping.Strategy); err != nil {
+		return nil, fmt.Errorf("grouping.strategy: %w", err)
+	}
+	if _, err := settings.ParseArticleAge(store.Settings.MaxArticleAge); err != nil {
+		return nil, fmt.Errorf("max_article_age: %w", err)
+	}
+	if threshold := store.Settings.NewsDigest.MergeThreshold; threshold < 0 || threshold > 1 {
+		return nil, fmt.Errorf("news_digest.merge_threshold: must be between 0 and 1, got %v", threshold)
+	}
+	network, _, err := settings.ParseControlSocket(store.Settings.ControlSocket)
+	if err != nil {
+		return nil, fmt.Errorf("control_socket: %w", err)
+	}
+	if network == "tcp" && strings.TrimSpace(store.Settings.ControlToken) == "" {
+		return nil, errors.New("control_token: required when control_socket is a localhost:port address")
+	}
+	store.Settings.Grouping.Keywords = structured.Grouping.Keywords
+	store.Settings.FeedOptions = normalizeFeedOptions(structured.FeedOptions)
+	store.Settings.OPMLHeaders = structured.OPMLHeaders
 	store.Settings.Feeds = normalizeFeeds(store.Settings.Feeds)
 	store.Settings.FeedGroups = normalizeFeedGroups(store.Settings.FeedGroups)
 	store.Settings.HistoryFile = normalizeHistoryPath(store.Settings.HistoryFile)
@@ -98,6 +153,19 @@ func normalizeFeeds(feeds []string) []string {
 	return normalized
 }
 
+func normalizeFeedOptions(options map[string]settings.FeedOptions) map[string]settings.FeedOptions {
+	if len(options) == 0 {
+		return nil
+	}
+	normalized := make(map[string]settings.FeedOptions, len(options))
+	for url, opts := range options {
+		if url = strings.TrimSpace(url); url != "" {
+			normalized[url] = opts
+		}
+	}
+	return normalized
+}
+
 func normalizeFeedGroups(groups []subscription.FeedGroup) []subscription.FeedGroup {
 	if len(groups) == 0 {
 		return groups
@@ -121,23 +189,34 @@ func normalizeFeedGroups(groups []subscription.FeedGroup) []subscription.FeedGro
 	return normalized
 }
 
-func loadFeedGroupsFromConfig(configPath string) ([]subscription.FeedGroup, error) {
+// structuredConfig holds config sections that kong cannot resolve as flags.
+type structuredConfig struct {
+	FeedGroups           []subscription.FeedGroup        `yaml:"feed_groups"`
+	FeedGroupingCache    *settings.FeedGroupingCache     `yaml:"feed_grouping_cache"`
+	NewsSelection        *settings.NewsSelection         `yaml:"news_selection"`
+	ContentStripPatterns []string                        `yaml:"content_strip_patterns"`
+	FeedOptions          map[string]settings.FeedOptions `yaml:"feed_options"`
+	OPMLHeaders          map[string]map[string]string    `yaml:"opml_headers"`
+	Grouping             struct {
+		Keywords map[string][]string `yaml:"keywords"`
+	} `yaml:"grouping"`
+}
+
+func loadStructuredConfig(configPath string) (structuredConfig, error) {
+	var raw structuredConfig
 	f, err := os.Open(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, nil
+			return raw, nil
 		}
-		return nil, err
+		return raw, err
 	}
 	defer func() { _ = f.Close() }()
 
-	var raw struct {
-		FeedGroups []subscription.FeedGroup `yaml:"feed_groups"`
-	}
 	if err := yaml.NewDecoder(f).Decode(&raw); err != nil && err != io.EOF {
-		return nil, err
+		return raw, err
 	}
-	return raw.FeedGroups, nil
+	return raw, nil
 }
 
 func defaultDataHome() string {
@@ -233,6 +312,78 @@ func (s *Store) ReplaceFeedGroups(groups []subscription.FeedGroup, ungrouped []s
 	return s.Save()
 }
 
+// LoadFeedGroupingCache returns the stored AI grouping cache.
+func (s *Store) LoadFeedGroupingCache() (usecase.FeedGroupingCache, error) {
+	cache := s.Settings.FeedGroupingCache
+	if cache == nil {
+		return usecase.FeedGroupingCache{}, nil
+	}
+	return usecase.FeedGroupingCache{
+		InputHash: cache.InputHash,
+		Groups:    normalizeFeedGroups(cache.Groups),
+		Ungrouped: normalizeFeeds(cache.Ungrouped),
+	}, nil
+}
+
+// SaveFeedGroupingCache stores the AI grouping cache and saves the configuration.
+func (s *Store) SaveFeedGroupingCache(cache usecase.FeedGroupingCache) error {
+	s.Settings.FeedGroupingCache = &settings.FeedGroupingCache{
+		InputHash: cache.InputHash,
+		Groups:    normalizeFeedGroups(cache.Groups),
+		Ungrouped: normalizeFeeds(cache.Ungrouped),
+	}
+	return s.Save()
+}
+
+// LoadFeedMetadata returns the titles and tags set in feed_options.
+func (s *Store) LoadFeedMetadata() (map[string]usecase.FeedMetadata, error) {
+	metadata := make(map[string]usecase.FeedMetadata)
+	for url, opts := range s.Settings.FeedOptions {
+		if opts.Title != "" || len(opts.Tags) > 0 {
+			metadata[url] = usecase.FeedMetadata{Title: opts.Title, Tags: slices.Clone(opts.Tags)}
+		}
+	}
+	return metadata, nil
+}
+
+// SaveFeedMetadata sets the title and tags of the given feeds in
+// feed_options, keeping their other options, and saves the configuration.
+// Empty metadata clears them, dropping options left with nothing set.
+func (s *Store) SaveFeedMetadata(metadata map[string]usecase.FeedMetadata) error {
+	if s.Settings.FeedOptions == nil {
+		s.Settings.FeedOptions = make(map[string]settings.FeedOptions, len(metadata))
+	}
+	for url, meta := range metadata {
+		opts := s.Settings.FeedOptions[url]
+		opts.Title = strings.TrimSpace(meta.Title)
+		opts.Tags = nil
+		if len(meta.Tags) > 0 {
+			opts.Tags = slices.Clone(meta.Tags)
+		}
+		if opts.IsZero() {
+			delete(s.Settings.FeedOptions, url)
+			continue
+		}
+		s.Settings.FeedOptions[url] = opts
+	}
+	return s.Save()
+}
+
+// SaveShowAISummary stores the AI summary visibility and saves the configuration.
+func (s *Store) SaveShowAISummary(show bool) error {
+	s.Settings.ShowAISummaryDefault = show
+	return s.Save()
+}
+
+// SaveNewsSelection stores the News topic opened last and saves the configuration.
+func (s *Store) SaveNewsSelection(selection usecase.NewsSelection) error {
+	s.Settings.NewsSelection = &settings.NewsSelection{
+		Date: selection.DateKey,
+		GUID: selection.GUID,
+	}
+	return s.Save()
+}
+
 // Add appends a new feed URL and saves the configuration.
 func (s *Store) Add(url string) error {
 	s.Settings.Feeds = append(s.Settings.Feeds, url)
@@ -264,8 +415,77 @@ func (s *Store) Remove(index int) error {
 	return s.Save()
 }
 
+// UpdateFeedURL replaces a subscribed feed URL in place, keeping its group,
+// position and per-feed options.
+func (s *Store) UpdateFeedURL(oldURL, newURL string) error {
+	for groupIndex := range s.Settings.FeedGroups {
+		feeds := s.Settings.FeedGroups[groupIndex].Feeds
+		if idx := slices.Index(feeds, oldURL); idx >= 0 {
+			feeds[idx] = newURL
+			s.moveFeedOptions(oldURL, newURL)
+			return s.Save()
+		}
+	}
+	if idx := slices.Index(s.Settings.Feeds, oldURL); idx >= 0 {
+		s.Settings.Feeds[idx] = newURL
+		s.moveFeedOptions(oldURL, newURL)
+		return s.Save()
+	}
+	return fmt.Errorf("feed is not subscribed: %s", oldURL)
+}
+
+func (s *Store) moveFeedOptions(oldURL, newURL string) {
+	opts, ok := s.Settings.FeedOptions[oldURL]
+	if !ok {
+		return
+	}
+	delete(s.Settings.FeedOptions, oldURL)
+	s.Settings.FeedOptions[newURL] = opts
+}
+
+// Batch runs fn as one transaction: saves are deferred and the
+// configuration is written once if fn changed it, so multi-step changes such
+// as an import save a single time. If fn fails, the settings are rolled back
+// and nothing is written. Nested calls join the outer batch.
+func (s *Store) Batch(fn func() error) error {
+	if s.batching {
+		return fn()
+	}
+	before := cloneSubscriptions(s.Settings)
+	s.batching = true
+	err := fn()
+	s.batching = false
+	if err != nil {
+		s.Settings = before
+		s.dirty = false
+		return err
+	}
+	if !s.dirty {
+		return nil
+	}
+	s.dirty = false
+	return s.Save()
+}
+
+// cloneSubscriptions copies settings deeply enough that the store's
+// in-place edits of feeds, groups and feed options don't reach the copy.
+func cloneSubscriptions(current settings.Settings) settings.Settings {
+	clone := current
+	clone.Feeds = slices.Clone(current.Feeds)
+	clone.FeedGroups = slices.Clone(current.FeedGroups)
+	for i := range clone.FeedGroups {
+		clone.FeedGroups[i].Feeds = slices.Clone(clone.FeedGroups[i].Feeds)
+	}
+	clone.FeedOptions = maps.Clone(current.FeedOptions)
+	return clone
+}
+
 // Save writes the current settings to the config file.
 func (s *Store) Save() error {
+	if s.batching {
+		s.dirty = true
+		return nil
+	}
 	f, err := os.Create(s.configPath)
 	if err != nil {
 		return err
@@ -274,3 +494,80 @@ func (s *Store) Save() error {
 
 	return yaml.NewEncoder(f).Encode(s.Settings)
 }
+
+// Paths lists the files the application uses with the loaded settings.
+type Paths struct {
+	Config  string `yaml:"config"`
+	History string `yaml:"history"`
+	DataDir string `yaml:"data_dir"`
+	// AICache is only used when ai.separate_store is enabled.
+	AICache string `yaml:"ai_cache,omitempty"`
+}
+
+// Paths returns the absolute paths in use after defaults and path rewriting.
+func (s *Store) Paths() Paths {
+	paths := Paths{
+		Config:  absPath(s.configPath),
+		History: absPath(s.Settings.HistoryFile),
+	}
+	paths.DataDir = filepath.Dir(paths.History)
+	if s.Settings.AI.SeparateStore {
+		paths.AICache = aicache.PathFor(paths.History)
+	}
+	return paths
+}
+
+// redactOPMLHeaders returns headers with every value replaced by a mask.
+func redactOPMLHeaders(headers map[string]map[string]string) map[string]map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	redacted := make(map[string]map[string]string, len(headers))
+	for host, values := range headers {
+		redacted[host] = make(map[string]string, len(values))
+		for name := range values {
+			redacted[host][name] = "********"
+		}
+	}
+	return redacted
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// Show writes the resolved settings and the paths in use to w, formatted
+// as "yaml" or "json". OPML header values are masked since they usually
+// hold credentials.
+func (s *Store) Show(w io.Writer, format string) error {
+	shown := s.Settings
+	shown.OPMLHeaders = redactOPMLHeaders(shown.OPMLHeaders)
+	resolved := struct {
+		Paths    Paths             `yaml:"paths"`
+		Settings settings.Settings `yaml:"settings"`
+	}{Paths: s.Paths(), Settings: shown}
+
+	data, err := yaml.Marshal(resolved)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "", "yaml":
+		_, err = w.Write(data)
+		return err
+	case "json":
+		// Round-trip through YAML so JSON keys match the config file.
+		var values map[string]any
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(values)
+	default:
+		return fmt.Errorf("unknown format %q (want yaml or json)", format)
+	}
+}
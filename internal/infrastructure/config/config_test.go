@@ -1,10 +1,18 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/application/usecase"
 	"github.com/tesso57/reazy/internal/domain/subscription"
 )
 
@@ -49,6 +57,27 @@ func TestLoad_Defaults(t *testing.T) {
 	if store.Settings.Codex.TimeoutSeconds != 30 {
 		t.Errorf("Expected default Codex.TimeoutSeconds 30, got %d", store.Settings.Codex.TimeoutSeconds)
 	}
+	if got := strings.Join(store.Settings.MarkReadViews, ","); got != "all,news,dismissed,feeds" {
+		t.Errorf("Expected default MarkReadViews 'all,news,dismissed,feeds', got %q", got)
+	}
+	if store.Settings.NewsDigest.MaxTopics != 20 || store.Settings.NewsDigest.MaxTopicArticles != 10 {
+		t.Errorf("Expected default NewsDigest limits 20/10, got %+v", store.Settings.NewsDigest)
+	}
+	if store.Settings.NewsDigest.MergeThreshold != 0.8 {
+		t.Errorf("Expected default NewsDigest.MergeThreshold 0.8, got %v", store.Settings.NewsDigest.MergeThreshold)
+	}
+	if !store.Settings.NewsDigest.FallbackToToday {
+		t.Error("Expected default NewsDigest.FallbackToToday true")
+	}
+	if !store.Settings.ResolveRelativeLinks {
+		t.Error("Expected default ResolveRelativeLinks true")
+	}
+	if store.Settings.Icons != settings.IconsEmoji {
+		t.Errorf("Expected default Icons %q, got %q", settings.IconsEmoji, store.Settings.Icons)
+	}
+	if store.Settings.ShutdownTimeoutSeconds != 3 {
+		t.Errorf("Expected default ShutdownTimeoutSeconds 3, got %d", store.Settings.ShutdownTimeoutSeconds)
+	}
 	if filepath.Base(store.Settings.HistoryFile) != "history.db" {
 		t.Errorf("Expected default history db path, got %q", store.Settings.HistoryFile)
 	}
@@ -189,6 +218,147 @@ func TestLoad_NormalizesFeeds(t *testing.T) {
 	}
 }
 
+func TestLoad_SectionHeaderFormat(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("section_header_format: \"── {label} ──\"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if store.Settings.SectionHeaderFormat != "── {label} ──" {
+		t.Fatalf("SectionHeaderFormat = %q", store.Settings.SectionHeaderFormat)
+	}
+
+	if err := os.WriteFile(configPath, []byte("section_header_format: \"{date}\"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "section_header_format") {
+		t.Fatalf("expected invalid format error, got %v", err)
+	}
+}
+
+func TestLoad_BuiltinTabs(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("builtin_tabs:\n  - bookmarks\n  - all\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := store.Settings.EnabledBuiltinTabs(); len(got) != 2 || got[0] != "bookmarks" || got[1] != "all" {
+		t.Fatalf("BuiltinTabs = %#v, want [bookmarks all]", got)
+	}
+
+	if err := os.WriteFile(configPath, []byte("builtin_tabs:\n  - starred\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "builtin_tabs") {
+		t.Fatalf("expected unknown tab error, got %v", err)
+	}
+}
+
+func TestLoad_RejectsUnknownOpenAction(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("default_open_action: brwoser\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "default_open_action") {
+		t.Fatalf("expected unknown action error, got %v", err)
+	}
+}
+
+func TestLoad_RejectsUnknownGroupingStrategy(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("grouping:\n  strategy: heuristics\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "grouping.strategy") {
+		t.Fatalf("expected unknown strategy error, got %v", err)
+	}
+}
+
+func TestLoad_ControlTokenRequiredForTCP(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("control_socket: localhost:7373\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "control_token") {
+		t.Fatalf("expected missing token error, got %v", err)
+	}
+
+	body := "control_socket: localhost:7373\ncontrol_token: secret\nhistory_file: " + filepath.Join(dir, "history.db") + "\n"
+	if err := os.WriteFile(configPath, []byte(body), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if store.Settings.ControlToken != "secret" {
+		t.Fatalf("ControlToken = %q", store.Settings.ControlToken)
+	}
+}
+
+func TestLoad_ContentStripPatterns(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	content := `content_strip_patterns:
+  - "(?s)Subscribe to our newsletter.*$"
+  - "Share on (Twitter|Facebook){1,2}"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := store.Settings.ContentStripPatterns; len(got) != 2 || got[1] != "Share on (Twitter|Facebook){1,2}" {
+		t.Fatalf("unexpected patterns: %#v", got)
+	}
+	if got := store.Settings.ContentSanitizer.Clean("Read more. Share on Twitter"); got != "Read more." {
+		t.Fatalf("loaded sanitizer Clean() = %q, want the patterns compiled at load", got)
+	}
+
+	if err := os.WriteFile(configPath, []byte("content_strip_patterns:\n  - \"(unclosed\"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "content_strip_patterns") {
+		t.Fatalf("expected invalid pattern error, got %v", err)
+	}
+}
+
+func TestLoad_GroupingKeywords(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	content := `grouping:
+  strategy: heuristic
+  keywords:
+    Go:
+      - golang
+      - go.dev
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !store.Settings.GroupsHeuristically() {
+		t.Fatalf("strategy = %q, want heuristic", store.Settings.Grouping.Strategy)
+	}
+	if got := store.Settings.Grouping.Keywords["Go"]; len(got) != 2 || got[1] != "go.dev" {
+		t.Fatalf("unexpected keywords: %#v", store.Settings.Grouping.Keywords)
+	}
+}
+
 func TestLoad_FeedGroups(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "config.yaml")
@@ -328,3 +498,420 @@ func TestStore_ReplaceFeedGroups(t *testing.T) {
 		t.Fatalf("reloaded groups = %#v", reloaded.Settings.FeedGroups)
 	}
 }
+
+func TestStore_FeedGroupingCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	cache, err := store.LoadFeedGroupingCache()
+	if err != nil {
+		t.Fatalf("LoadFeedGroupingCache failed: %v", err)
+	}
+	if cache.InputHash != "" {
+		t.Fatalf("initial cache hash = %q, want empty", cache.InputHash)
+	}
+
+	err = store.SaveFeedGroupingCache(usecase.FeedGroupingCache{
+		InputHash: "abc",
+		Groups: []subscription.FeedGroup{
+			{Name: "Tech", Feeds: []string{"https://example.com/tech.xml"}},
+		},
+		Ungrouped: []string{"https://example.com/misc.xml"},
+	})
+	if err != nil {
+		t.Fatalf("SaveFeedGroupingCache failed: %v", err)
+	}
+
+	reloaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	cache, err = reloaded.LoadFeedGroupingCache()
+	if err != nil {
+		t.Fatalf("LoadFeedGroupingCache failed: %v", err)
+	}
+	if cache.InputHash != "abc" || len(cache.Groups) != 1 || cache.Groups[0].Name != "Tech" {
+		t.Fatalf("reloaded cache = %#v", cache)
+	}
+	if len(cache.Ungrouped) != 1 || cache.Ungrouped[0] != "https://example.com/misc.xml" {
+		t.Fatalf("reloaded ungrouped = %#v", cache.Ungrouped)
+	}
+}
+
+func TestStore_UpdateFeedURL(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	content := `feed_groups:
+  - name: GroupA
+    feeds:
+      - https://example.com/a.xml
+feeds:
+  - https://example.com/b.xml
+  - https://example.com/c.xml
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := store.UpdateFeedURL("https://example.com/a.xml", "https://example.org/a.xml"); err != nil {
+		t.Fatalf("UpdateFeedURL grouped failed: %v", err)
+	}
+	if err := store.UpdateFeedURL("https://example.com/b.xml", "https://example.org/b.xml"); err != nil {
+		t.Fatalf("UpdateFeedURL ungrouped failed: %v", err)
+	}
+	if err := store.UpdateFeedURL("https://example.com/missing.xml", "https://example.org/x.xml"); err == nil {
+		t.Fatal("UpdateFeedURL should fail for unknown feeds")
+	}
+
+	reloaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	listed, err := reloaded.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	want := []string{
+		"https://example.org/a.xml",
+		"https://example.org/b.xml",
+		"https://example.com/c.xml",
+	}
+	if len(listed) != len(want) {
+		t.Fatalf("listed = %#v, want %#v", listed, want)
+	}
+	for i, got := range listed {
+		if got != want[i] {
+			t.Fatalf("listed[%d] = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestStore_SaveShowAISummary(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !store.Settings.ShowAISummaryDefault {
+		t.Fatal("AI summary should be shown by default")
+	}
+
+	if err := store.SaveShowAISummary(false); err != nil {
+		t.Fatalf("SaveShowAISummary failed: %v", err)
+	}
+	reloaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if reloaded.Settings.ShowAISummaryDefault {
+		t.Fatal("hidden AI summary should persist across loads")
+	}
+}
+
+func TestStore_SaveNewsSelection(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if store.Settings.NewsDigest.RememberSelection || store.Settings.NewsSelection != nil {
+		t.Fatal("News selection should not be remembered by default")
+	}
+
+	if err := store.SaveNewsSelection(usecase.NewsSelection{DateKey: "2026-10-17", GUID: "digest-2"}); err != nil {
+		t.Fatalf("SaveNewsSelection failed: %v", err)
+	}
+	reloaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	want := settings.NewsSelection{Date: "2026-10-17", GUID: "digest-2"}
+	if got := reloaded.Settings.NewsSelection; got == nil || *got != want {
+		t.Fatalf("NewsSelection = %+v, want %+v", got, want)
+	}
+}
+
+func TestStore_FeedMetadata(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `feeds:
+  - https://example.com/a.xml
+feed_options:
+  https://example.com/a.xml:
+    full_text: true
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := store.SaveFeedMetadata(map[string]usecase.FeedMetadata{
+		"https://example.com/a.xml": {Title: " Example ", Tags: []string{"Tech"}},
+	}); err != nil {
+		t.Fatalf("SaveFeedMetadata failed: %v", err)
+	}
+
+	reloaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	opts := reloaded.Settings.FeedOptions["https://example.com/a.xml"]
+	if !opts.FullText || opts.Title != "Example" || len(opts.Tags) != 1 || opts.Tags[0] != "Tech" {
+		t.Fatalf("feed options = %+v, want full text kept with title and tags", opts)
+	}
+	metadata, err := reloaded.LoadFeedMetadata()
+	if err != nil || metadata["https://example.com/a.xml"].Title != "Example" {
+		t.Fatalf("LoadFeedMetadata() = %#v, %v", metadata, err)
+	}
+	if titles := reloaded.Settings.FeedTitles(); titles["https://example.com/a.xml"] != "Example" {
+		t.Fatalf("FeedTitles() = %#v", titles)
+	}
+}
+
+func TestStore_FeedMetadataClearDropsEmptyOptions(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `feeds:
+  - https://example.com/a.xml
+  - https://example.com/b.xml
+feed_options:
+  https://example.com/a.xml:
+    full_text: true
+    title: A
+  https://example.com/b.xml:
+    title: B
+    tags: [Tech]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := store.SaveFeedMetadata(map[string]usecase.FeedMetadata{
+		"https://example.com/a.xml": {},
+		"https://example.com/b.xml": {},
+	}); err != nil {
+		t.Fatalf("SaveFeedMetadata failed: %v", err)
+	}
+
+	reloaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	options := reloaded.Settings.FeedOptions
+	if len(options) != 1 || !options["https://example.com/a.xml"].FullText || options["https://example.com/a.xml"].Title != "" {
+		t.Fatalf("feed options = %+v, want only a's full text option left", options)
+	}
+}
+
+func TestStore_BatchSavesOnce(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("feeds: []\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	err = store.Batch(func() error {
+		if err := store.Add("https://example.com/a.xml"); err != nil {
+			return err
+		}
+		if err := store.Add("https://example.com/b.xml"); err != nil {
+			return err
+		}
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return err
+		}
+		if strings.Contains(string(data), "example.com") {
+			t.Errorf("config saved during the batch:\n%s", data)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+
+	reloaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if feeds, _ := reloaded.List(); len(feeds) != 2 {
+		t.Fatalf("feeds = %v, want both saved after the batch", feeds)
+	}
+}
+
+func TestStore_BatchRollsBackOnError(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := "feeds:\n  - https://example.com/a.xml\nfeed_groups:\n  - name: Tech\n    feeds:\n      - https://example.com/t.xml\n"
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	wantErr := errors.New("boom")
+	err = store.Batch(func() error {
+		if err := store.Add("https://example.com/b.xml"); err != nil {
+			return err
+		}
+		if err := store.Remove(0); err != nil {
+			return err
+		}
+		if err := store.SaveFeedMetadata(map[string]usecase.FeedMetadata{"https://example.com/b.xml": {Title: "B"}}); err != nil {
+			return err
+		}
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Batch error = %v, want %v", err, wantErr)
+	}
+
+	want := []string{"https://example.com/t.xml", "https://example.com/a.xml"}
+	if feeds, _ := store.List(); !slices.Equal(feeds, want) {
+		t.Fatalf("feeds in memory = %v, want rollback to %v", feeds, want)
+	}
+	if _, ok := store.Settings.FeedOptions["https://example.com/b.xml"]; ok {
+		t.Fatal("feed options of the failed batch were kept")
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(data) != content {
+		t.Fatalf("config written by a failed batch:\n%s", data)
+	}
+}
+
+func TestLoad_FeedOptions(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `feeds:
+  - https://example.com/a.xml
+  - https://example.com/b.xml
+feed_options:
+  " https://example.com/a.xml ":
+    full_text: true
+  https://example.com/b.xml:
+    full_text: false
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	fullText := store.Settings.FullTextFeeds()
+	if len(fullText) != 1 || !fullText["https://example.com/a.xml"] {
+		t.Fatalf("FullTextFeeds() = %#v, want only a.xml", fullText)
+	}
+
+	if err := store.UpdateFeedURL("https://example.com/a.xml", "https://example.org/a.xml"); err != nil {
+		t.Fatalf("UpdateFeedURL failed: %v", err)
+	}
+	reloaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if !reloaded.Settings.FeedOptions["https://example.org/a.xml"].FullText {
+		t.Fatalf("feed options should follow a moved feed, got %#v", reloaded.Settings.FeedOptions)
+	}
+}
+
+func TestLoad_OPMLHeaders(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `opml_headers:
+  reader.example.com:
+    Authorization: Bearer secret
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := map[string]map[string]string{"reader.example.com": {"Authorization": "Bearer secret"}}
+	if !reflect.DeepEqual(store.Settings.OPMLHeaders, want) {
+		t.Fatalf("OPMLHeaders = %#v, want %#v", store.Settings.OPMLHeaders, want)
+	}
+
+	var out bytes.Buffer
+	if err := store.Show(&out, "yaml"); err != nil {
+		t.Fatalf("Show failed: %v", err)
+	}
+	if strings.Contains(out.String(), "secret") || !strings.Contains(out.String(), "Authorization") {
+		t.Fatalf("Show should mask OPML header values:\n%s", out.String())
+	}
+	if store.Settings.OPMLHeaders["reader.example.com"]["Authorization"] != "Bearer secret" {
+		t.Fatal("Show should not change the stored headers")
+	}
+}
+
+func TestStore_Show(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	content := "feeds:\n  - https://example.com/rss\nhistory_file: " + filepath.Join(tmpDir, "history.jsonl") + "\nai:\n  separate_store: true\n"
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	store, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	paths := store.Paths()
+	if paths.Config != configPath || paths.History != filepath.Join(tmpDir, "history.db") || paths.DataDir != tmpDir {
+		t.Fatalf("Paths() = %+v, want the rewritten history path", paths)
+	}
+	if paths.AICache != filepath.Join(tmpDir, "ai_cache.json") {
+		t.Fatalf("AICache = %q, want the sidecar next to the history", paths.AICache)
+	}
+
+	var out strings.Builder
+	if err := store.Show(&out, "yaml"); err != nil {
+		t.Fatalf("Show(yaml) failed: %v", err)
+	}
+	for _, want := range []string{"paths:", "history: " + paths.History, "settings:", "- https://example.com/rss"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("yaml output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := store.Show(&out, "json"); err != nil {
+		t.Fatalf("Show(json) failed: %v", err)
+	}
+	var decoded struct {
+		Paths    map[string]string `json:"paths"`
+		Settings struct {
+			Feeds []string `json:"feeds"`
+		} `json:"settings"`
+	}
+	if err := json.Unmarshal([]byte(out.String()), &decoded); err != nil {
+		t.Fatalf("json output does not parse: %v\n%s", err, out.String())
+	}
+	if decoded.Paths["history"] != paths.History || len(decoded.Settings.Feeds) != 1 {
+		t.Fatalf("json output = %+v", decoded)
+	}
+
+	if err := store.Show(&out, "toml"); err == nil {
+		t.Fatal("Show(toml) should fail")
+	}
+}
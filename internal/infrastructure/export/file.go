@@ -0,0 +1,65 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// maxNameAttempts bounds the numbered names tried when an export file with
+// the same timestamp already exists.
+const maxNameAttempts = 100
+
+// FileWriter implements usecase.ExportWriter by saving each export as a new
+// reazy-export-YYYYMMDD-HHMMSS.md file.
+type FileWriter struct {
+	// Dir receives the files; empty uses the current directory.
+	Dir string
+	Now func() time.Time
+}
+
+// WriteExport saves markdown to a new file in Dir and returns its absolute
+// path. Existing files are never overwritten.
+func (w FileWriter) WriteExport(markdown string) (string, error) {
+	dir := w.Dir
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("create export directory: %w", err)
+	}
+	now := time.Now
+	if w.Now != nil {
+		now = w.Now
+	}
+	base := "reazy-export-" + now().Format("20060102-150405")
+	for attempt := 1; attempt <= maxNameAttempts; attempt++ {
+		name := base + ".md"
+		if attempt > 1 {
+			name = fmt.Sprintf("%s-%d.md", base, attempt)
+		}
+		path := filepath.Join(dir, name)
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := file.WriteString(markdown); err != nil {
+			_ = file.Close()
+			return "", err
+		}
+		if err := file.Close(); err != nil {
+			return "", err
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		return path, nil
+	}
+	return "", fmt.Errorf("export file %s.md already exists", base)
+}
@@ -0,0 +1,37 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileWriter_WriteExport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "exports")
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	w := FileWriter{Dir: dir, Now: func() time.Time { return now }}
+
+	first, err := w.WriteExport("# one\n")
+	if err != nil {
+		t.Fatalf("WriteExport() error = %v", err)
+	}
+	if want := filepath.Join(dir, "reazy-export-20260304-050607.md"); first != want {
+		t.Fatalf("path = %q, want %q", first, want)
+	}
+	second, err := w.WriteExport("# two\n")
+	if err != nil {
+		t.Fatalf("WriteExport() error = %v", err)
+	}
+	if want := filepath.Join(dir, "reazy-export-20260304-050607-2.md"); second != want {
+		t.Fatalf("second path = %q, want %q", second, want)
+	}
+
+	data, err := os.ReadFile(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "# one\n" {
+		t.Fatalf("first export was overwritten: %q", data)
+	}
+}
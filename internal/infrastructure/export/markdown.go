@@ -0,0 +1,260 @@
+// Package export writes selected articles out as Markdown.
+package export
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Converter implements usecase.MarkdownConverter.
+type Converter struct{}
+
+// ToMarkdown converts an article body to Markdown.
+func (Converter) ToMarkdown(body string) string {
+	return HTMLToMarkdown(body)
+}
+
+// HTMLToMarkdown converts an HTML article body to Markdown: paragraphs,
+// headings, lists, quotes, code, links, images and emphasis are kept, and
+// scripts, styles and unknown markup are dropped. A body without markup is
+// returned trimmed.
+func HTMLToMarkdown(body string) string {
+	body = strings.TrimSpace(body)
+	if !strings.Contains(body, "<") {
+		return body
+	}
+	root := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(body), root)
+	if err != nil {
+		return body
+	}
+	for _, node := range nodes {
+		root.AppendChild(node)
+	}
+	return strings.Join(blocks(root), "\n\n")
+}
+
+// blocks renders the children of parent as Markdown blocks. Runs of inline
+// content between block elements become paragraphs.
+func blocks(parent *html.Node) []string {
+	var out []string
+	var para strings.Builder
+	flush := func() {
+		if text := collapseLines(para.String()); text != "" {
+			out = append(out, text)
+		}
+		para.Reset()
+	}
+	for node := parent.FirstChild; node != nil; node = node.NextSibling {
+		if node.Type == html.ElementNode && isBlock(node.DataAtom) {
+			flush()
+			if block := renderBlock(node); block != "" {
+				out = append(out, block)
+			}
+			continue
+		}
+		para.WriteString(inline(node))
+	}
+	flush()
+	return out
+}
+
+func isBlock(tag atom.Atom) bool {
+	switch tag {
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Main, atom.Header, atom.Footer, atom.Figure,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6,
+		atom.Ul, atom.Ol, atom.Blockquote, atom.Pre, atom.Hr,
+		atom.Table, atom.Thead, atom.Tbody, atom.Tfoot, atom.Tr,
+		atom.Script, atom.Style, atom.Noscript, atom.Head, atom.Nav, atom.Form:
+		return true
+	}
+	return false
+}
+
+func renderBlock(node *html.Node) string {
+	switch node.DataAtom {
+	case atom.Script, atom.Style, atom.Noscript, atom.Head, atom.Nav, atom.Form:
+		return ""
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		text := strings.Join(strings.Fields(inlineChildren(node)), " ")
+		if text == "" {
+			return ""
+		}
+		level := int(node.Data[1] - '0')
+		return strings.Repeat("#", level) + " " + text
+	case atom.Ul, atom.Ol:
+		return renderList(node, node.DataAtom == atom.Ol)
+	case atom.Blockquote:
+		inner := strings.Join(blocks(node), "\n\n")
+		if inner == "" {
+			return ""
+		}
+		lines := strings.Split(inner, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		return strings.Join(lines, "\n")
+	case atom.Pre:
+		text := strings.Trim(textContent(node), "\n")
+		if strings.TrimSpace(text) == "" {
+			return ""
+		}
+		return "```\n" + text + "\n```"
+	case atom.Hr:
+		return "---"
+	case atom.Tr:
+		var cells []string
+		for cell := node.FirstChild; cell != nil; cell = cell.NextSibling {
+			if cell.Type == html.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+				cells = append(cells, strings.Join(strings.Fields(inlineChildren(cell)), " "))
+			}
+		}
+		return strings.TrimSpace(strings.Join(cells, " | "))
+	case atom.Table, atom.Thead, atom.Tbody, atom.Tfoot:
+		return strings.Join(blocks(node), "\n")
+	default:
+		return strings.Join(blocks(node), "\n\n")
+	}
+}
+
+func renderList(node *html.Node, ordered bool) string {
+	var lines []string
+	number := 1
+	for item := node.FirstChild; item != nil; item = item.NextSibling {
+		if item.Type != html.ElementNode || item.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if ordered {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+		indent := strings.Repeat(" ", len(marker))
+		for i, line := range strings.Split(strings.Join(blocks(item), "\n"), "\n") {
+			switch {
+			case i == 0:
+				line = marker + line
+			case line != "":
+				line = indent + line
+			}
+			lines = append(lines, strings.TrimRight(line, " "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func inline(node *html.Node) string {
+	if node.Type == html.TextNode {
+		return collapseSpace(node.Data)
+	}
+	if node.Type != html.ElementNode {
+		return ""
+	}
+	switch node.DataAtom {
+	case atom.Br:
+		return "\n"
+	case atom.Script, atom.Style:
+		return ""
+	case atom.Img:
+		src := attr(node, "src")
+		if src == "" {
+			return ""
+		}
+		return "![" + attr(node, "alt") + "](" + src + ")"
+	case atom.A:
+		text := strings.TrimSpace(inlineChildren(node))
+		href := attr(node, "href")
+		switch {
+		case href == "" || strings.HasPrefix(href, "#"):
+			return text
+		case text == "":
+			return "<" + href + ">"
+		default:
+			return "[" + text + "](" + href + ")"
+		}
+	case atom.Strong, atom.B:
+		return wrap(inlineChildren(node), "**")
+	case atom.Em, atom.I:
+		return wrap(inlineChildren(node), "_")
+	case atom.Code:
+		return wrap(textContent(node), "`")
+	}
+	return inlineChildren(node)
+}
+
+func inlineChildren(node *html.Node) string {
+	var b strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(inline(child))
+	}
+	return b.String()
+}
+
+// collapseSpace turns each whitespace run in text into one space, keeping a
+// space at either end so words in neighbouring nodes stay apart.
+func collapseSpace(text string) string {
+	if text == "" {
+		return ""
+	}
+	collapsed := strings.Join(strings.Fields(text), " ")
+	if collapsed == "" {
+		return " "
+	}
+	if strings.TrimLeft(text, " \t\r\n") != text {
+		collapsed = " " + collapsed
+	}
+	if strings.TrimRight(text, " \t\r\n") != text {
+		collapsed += " "
+	}
+	return collapsed
+}
+
+// wrap surrounds text with marker, keeping outer spaces outside it.
+func wrap(text, marker string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	lead := text[:strings.Index(text, trimmed)]
+	trail := text[len(lead)+len(trimmed):]
+	return lead + marker + trimmed + marker + trail
+}
+
+func textContent(node *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+	return b.String()
+}
+
+func attr(node *html.Node, name string) string {
+	for _, a := range node.Attr {
+		if a.Key == name {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
+
+// collapseLines squeezes the spaces on every line of an inline run and
+// drops blank lines.
+func collapseLines(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
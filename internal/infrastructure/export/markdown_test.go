@@ -0,0 +1,45 @@
+package export
+
+import "testing"
+
+func TestHTMLToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "plain text is kept",
+			body: "  Just text, no markup.  ",
+			want: "Just text, no markup.",
+		},
+		{
+			name: "paragraphs and inline markup",
+			body: `<p>First <b>bold</b> and <em>soft</em>
+   words with <a href="https://example.com/x">a link</a>.</p><p>Second <code>x := 1</code></p>`,
+			want: "First **bold** and _soft_ words with [a link](https://example.com/x).\n\nSecond `x := 1`",
+		},
+		{
+			name: "headings, lists and quotes",
+			body: `<h2>Notes</h2><ul><li>One</li><li>Two<ol><li>Nested</li></ol></li></ul><blockquote><p>Quoted</p><p>Twice</p></blockquote>`,
+			want: "## Notes\n\n- One\n- Two\n  1. Nested\n\n> Quoted\n>\n> Twice",
+		},
+		{
+			name: "code blocks keep their layout",
+			body: "<pre><code>func main() {\n\tprintln()\n}</code></pre>",
+			want: "```\nfunc main() {\n\tprintln()\n}\n```",
+		},
+		{
+			name: "scripts dropped, images and breaks kept",
+			body: `<script>track()</script><div>Line one<br>Line two <img src="https://example.com/a.png" alt="chart"></div><hr>`,
+			want: "Line one\nLine two ![chart](https://example.com/a.png)\n\n---",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTMLToMarkdown(tt.body); got != tt.want {
+				t.Fatalf("HTMLToMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package feed
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// feedAcceptEncoding is requested explicitly, which turns off net/http's
+// transparent gzip handling, so decodeResponse has to undo both encodings.
+const feedAcceptEncoding = "gzip, deflate"
+
+// decodeResponse replaces a gzip or deflate encoded body with its decoded
+// bytes, and converts a body whose charset is only named in the Content-Type
+// header to UTF-8. Charsets declared in the XML itself are left to the parser.
+// Redirect hops and empty bodies are passed through untouched, since there is
+// nothing to decode and gzip rejects an empty stream.
+func decodeResponse(resp *http.Response) error {
+	if isRedirect(resp) || resp.Body == nil || resp.Body == http.NoBody || resp.ContentLength == 0 {
+		return nil
+	}
+	body := resp.Body
+	// The length may be unknown, so peek to catch an empty body as well.
+	buffered := bufio.NewReader(body)
+	if _, err := buffered.Peek(1); err != nil {
+		resp.Body = readCloser{Reader: buffered, close: body.Close}
+		return nil
+	}
+	decoded, err := decompress(resp.Header.Get("Content-Encoding"), buffered)
+	if err != nil {
+		return err
+	}
+	if decoded != nil {
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	} else {
+		decoded = buffered
+	}
+
+	converted, err := convertCharset(resp.Header.Get("Content-Type"), decoded)
+	if err != nil {
+		return err
+	}
+	resp.Body = readCloser{Reader: converted, close: body.Close}
+	return nil
+}
+
+// isRedirect reports whether the client will follow resp to another URL
+// instead of reading its body.
+func isRedirect(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return resp.Header.Get("Location") != ""
+	}
+	return false
+}
+
+// decompress returns a reader decoding body per encoding, or nil when the
+// body isn't compressed in a way we handle.
+func decompress(encoding string, body io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "gzip", "x-gzip":
+		return gzip.NewReader(body)
+	case "deflate":
+		// "deflate" should be zlib-wrapped, but some servers send raw deflate.
+		buffered := bufio.NewReader(body)
+		header, err := buffered.Peek(2)
+		if err == nil && isZlibHeader(header) {
+			return zlib.NewReader(buffered)
+		}
+		return flate.NewReader(buffered), nil
+	}
+	return nil, nil
+}
+
+func isZlibHeader(b []byte) bool {
+	return b[0]&0x0f == 8 && (uint16(b[0])<<8|uint16(b[1]))%31 == 0
+}
+
+// convertCharset decodes body from the Content-Type charset to UTF-8 unless
+// the document declares its own encoding.
+func convertCharset(contentType string, body io.Reader) (io.Reader, error) {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return body, nil
+	}
+	label := strings.TrimSpace(params["charset"])
+	if label == "" || strings.EqualFold(label, "utf-8") || strings.EqualFold(label, "utf8") {
+		return body, nil
+	}
+	buffered := bufio.NewReader(body)
+	head, _ := buffered.Peek(512)
+	if declaresEncoding(head) {
+		return buffered, nil
+	}
+	return charset.NewReaderLabel(label, buffered)
+}
+
+// declaresEncoding reports whether an XML declaration at the start of head
+// names an encoding.
+func declaresEncoding(head []byte) bool {
+	head = bytes.TrimLeft(head, "\ufeff \t\r\n")
+	if !bytes.HasPrefix(head, []byte("<?xml")) {
+		return false
+	}
+	end := bytes.Index(head, []byte("?>"))
+	if end < 0 {
+		end = len(head)
+	}
+	return bytes.Contains(head[:end], []byte("encoding"))
+}
+
+type readCloser struct {
+	io.Reader
+	close func() error
+}
+
+func (r readCloser) Close() error {
+	return r.close()
+}
@@ -0,0 +1,142 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxArticleBytes bounds how much of an article page is read.
+const maxArticleBytes = 5 << 20
+
+// articleTimeout limits how long downloading an article page may take.
+const articleTimeout = 20 * time.Second
+
+// ErrNoArticleText is returned when a page has no readable article text.
+var ErrNoArticleText = errors.New("no article text found")
+
+// HTTPClient is exposed for testing.
+// It performs article page requests for ExtractArticle.
+var HTTPClient = &http.Client{Timeout: articleTimeout}
+
+// ExtractArticle downloads an article page and returns its readable text:
+// paragraphs, headings, list items and quotes from the page's <article>
+// (or <main>, or <body>), separated by blank lines.
+func ExtractArticle(ctx context.Context, link string) (string, error) {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return "", errors.New("article link is empty")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "Reazy/1.0")
+	req.Header.Set("Accept", "text/html, application/xhtml+xml;q=0.9, */*;q=0.5")
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", fmt.Errorf("fetch article: %s", resp.Status)
+	}
+
+	doc, err := html.Parse(io.LimitReader(resp.Body, maxArticleBytes))
+	if err != nil {
+		return "", err
+	}
+	text := articleText(articleRoot(doc))
+	if text == "" {
+		return "", ErrNoArticleText
+	}
+	return text, nil
+}
+
+// ArticleExtractor implements usecase.ArticleExtractor.
+type ArticleExtractor struct{}
+
+// ExtractArticle returns the readable text of the article at link.
+func (ArticleExtractor) ExtractArticle(ctx context.Context, link string) (string, error) {
+	return ExtractArticle(ctx, link)
+}
+
+func articleRoot(doc *html.Node) *html.Node {
+	for _, tag := range []atom.Atom{atom.Article, atom.Main, atom.Body} {
+		if node := findElement(doc, tag); node != nil {
+			return node
+		}
+	}
+	return doc
+}
+
+func findElement(node *html.Node, tag atom.Atom) *html.Node {
+	if node.Type == html.ElementNode && node.DataAtom == tag {
+		return node
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if found := findElement(child, tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func articleText(root *html.Node) string {
+	var blocks []string
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode {
+			switch node.DataAtom {
+			case atom.Script, atom.Style, atom.Noscript, atom.Nav, atom.Header, atom.Footer, atom.Aside, atom.Form, atom.Button:
+				return
+			case atom.P, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Li, atom.Blockquote:
+				if text := strings.Join(strings.Fields(nodeText(node)), " "); text != "" {
+					blocks = append(blocks, text)
+				}
+				return
+			case atom.Pre:
+				if text := strings.TrimSpace(nodeText(node)); text != "" {
+					blocks = append(blocks, text)
+				}
+				return
+			}
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(root)
+	return strings.Join(blocks, "\n\n")
+}
+
+func nodeText(node *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			b.WriteString(n.Data)
+		case n.Type == html.ElementNode && (n.DataAtom == atom.Script || n.DataAtom == atom.Style):
+			return
+		case n.Type == html.ElementNode && n.DataAtom == atom.Br:
+			b.WriteString("\n")
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+	return b.String()
+}
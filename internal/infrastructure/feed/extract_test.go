@@ -0,0 +1,59 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExtractArticle(t *testing.T) {
+	var gotUA string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUA = r.Header.Get("User-Agent")
+		switch r.URL.Path {
+		case "/article":
+			_, _ = w.Write([]byte(`<html><head><style>p{}</style></head><body>
+<nav><p>Home | About</p></nav>
+<article>
+  <h1>Robots   Run Amok</h1>
+  <p>First <b>paragraph</b>
+     continues.</p>
+  <script>track()</script>
+  <ul><li>One</li><li>Two</li></ul>
+  <aside><p>Related links</p></aside>
+</article>
+<footer><p>Copyright</p></footer>
+</body></html>`))
+		case "/empty":
+			_, _ = w.Write([]byte(`<html><body><nav><p>Menu</p></nav></body></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	text, err := ExtractArticle(context.Background(), server.URL+"/article")
+	if err != nil {
+		t.Fatalf("ExtractArticle failed: %v", err)
+	}
+	want := "Robots Run Amok\n\nFirst paragraph continues.\n\nOne\n\nTwo"
+	if text != want {
+		t.Fatalf("ExtractArticle() = %q, want %q", text, want)
+	}
+	if gotUA != "Reazy/1.0" {
+		t.Errorf("User-Agent = %q, want Reazy/1.0", gotUA)
+	}
+
+	if _, err := (ArticleExtractor{}).ExtractArticle(context.Background(), server.URL+"/empty"); !errors.Is(err, ErrNoArticleText) {
+		t.Fatalf("empty page error = %v, want ErrNoArticleText", err)
+	}
+	if _, err := ExtractArticle(context.Background(), server.URL+"/missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("missing page error = %v, want 404", err)
+	}
+	if _, err := ExtractArticle(context.Background(), "  "); err == nil {
+		t.Fatal("ExtractArticle should reject an empty link")
+	}
+}
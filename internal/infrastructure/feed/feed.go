@@ -17,6 +17,8 @@ import (
 
 const feedAcceptHeader = "application/atom+xml, application/rss+xml, application/feed+json, application/xml;q=0.9, text/xml;q=0.8, */*;q=0.5"
 
+// acceptTransport asks for feed content types and compressed bodies, and
+// hands the parser a decoded body.
 type acceptTransport struct {
 	base http.RoundTripper
 }
@@ -30,7 +32,18 @@ func (t acceptTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if clone.Header.Get("Accept") == "" {
 		clone.Header.Set("Accept", feedAcceptHeader)
 	}
-	return base.RoundTrip(clone)
+	if clone.Header.Get("Accept-Encoding") == "" {
+		clone.Header.Set("Accept-Encoding", feedAcceptEncoding)
+	}
+	resp, err := base.RoundTrip(clone)
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeResponse(resp); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
 }
 
 // ParserFunc is exposed for testing.
@@ -40,10 +53,56 @@ var ParserFunc = defaultParser
 func defaultParser(ctx context.Context, url string) (*gofeed.Feed, error) {
 	fp := gofeed.NewParser()
 	fp.UserAgent = "Reazy/1.0"
-	fp.Client = &http.Client{Transport: acceptTransport{base: http.DefaultTransport}}
+	fp.Client = &http.Client{
+		Transport:     acceptTransport{base: http.DefaultTransport},
+		CheckRedirect: trackRedirect,
+	}
 	return fp.ParseURLWithContext(url, ctx)
 }
 
+// maxRedirects matches the net/http default redirect limit.
+const maxRedirects = 10
+
+// redirectTracker records where a fetch was redirected and whether every
+// hop was permanent.
+type redirectTracker struct {
+	finalURL  string
+	permanent bool
+}
+
+type redirectTrackerKey struct{}
+
+func withRedirectTracker(ctx context.Context, tracker *redirectTracker) context.Context {
+	return context.WithValue(ctx, redirectTrackerKey{}, tracker)
+}
+
+func trackRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return errors.New("stopped after 10 redirects")
+	}
+	tracker, ok := req.Context().Value(redirectTrackerKey{}).(*redirectTracker)
+	if !ok || tracker == nil {
+		return nil
+	}
+	permanent := req.Response != nil &&
+		(req.Response.StatusCode == http.StatusMovedPermanently || req.Response.StatusCode == http.StatusPermanentRedirect)
+	if len(via) == 1 {
+		tracker.permanent = permanent
+	} else {
+		tracker.permanent = tracker.permanent && permanent
+	}
+	tracker.finalURL = req.URL.String()
+	return nil
+}
+
+// movedTo returns the permanent redirect target for url, if any.
+func (t *redirectTracker) movedTo(url string) string {
+	if t == nil || !t.permanent || t.finalURL == "" || t.finalURL == url {
+		return ""
+	}
+	return t.finalURL
+}
+
 // Fetch parses a feed from the given URL.
 func Fetch(url string) (*reading.Feed, error) {
 	return FetchWithTimeout(url, 10*time.Second)
@@ -69,18 +128,35 @@ func FetchWithContext(ctx context.Context, url string) (*reading.Feed, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	parsed, err := ParserFunc(ctx, url)
+	tracker := &redirectTracker{}
+	parsed, err := ParserFunc(withRedirectTracker(ctx, tracker), url)
 	if err != nil {
 		return nil, err
 	}
 
+	if parsed == nil {
+		return nil, errors.New("feed parser returned no feed")
+	}
+	f := mapFeed(parsed, url)
+	f.MovedTo = tracker.movedTo(url)
+	return f, nil
+}
+
+// mapFeed converts a parsed feed into the reading model. Feeds come from
+// anywhere, so nil items are skipped and every text field is cleaned and
+// clamped before it reaches history or the terminal.
+func mapFeed(parsed *gofeed.Feed, url string) *reading.Feed {
+	feedTitle := cleanText(parsed.Title, maxShortTextBytes)
 	f := new(reading.Feed{
-		Title: parsed.Title,
+		Title: feedTitle,
 		URL:   url,
-		Items: make([]reading.Item, len(parsed.Items)),
+		Items: make([]reading.Item, 0, len(parsed.Items)),
 	})
 
-	for i, item := range parsed.Items {
+	for _, item := range parsed.Items {
+		if item == nil {
+			continue
+		}
 		pub := item.Published
 		if pub == "" {
 			pub = item.Updated
@@ -92,30 +168,67 @@ func FetchWithContext(ctx context.Context, url string) (*reading.Feed, error) {
 			date = *item.UpdatedParsed
 		}
 
-		f.Items[i] = reading.Item{
-			GUID:        item.GUID,
-			Title:       item.Title,
-			Link:        item.Link,
-			Published:   pub,
-			Description: item.Description,
-			Content:     item.Content,
+		mapped := reading.Item{
+			GUID:        strings.TrimSpace(cleanText(item.GUID, maxShortTextBytes)),
+			Title:       cleanText(item.Title, maxShortTextBytes),
+			Link:        strings.TrimSpace(cleanText(item.Link, maxShortTextBytes)),
+			Published:   cleanText(pub, maxShortTextBytes),
+			Description: cleanText(item.Description, maxBodyTextBytes),
+			Content:     cleanText(item.Content, maxBodyTextBytes),
 			Date:        date,
-			FeedTitle:   parsed.Title,
+			FeedTitle:   feedTitle,
 			FeedURL:     url,
+			Categories:  normalizeCategories(item.Categories),
 		}
+		// Items without a GUID are keyed by link, then title, so the same
+		// entry keeps its identity across refreshes.
+		mapped.GUID = strings.TrimSpace(mapped.HistoryGUID())
+		f.Items = append(f.Items, mapped)
 	}
+	return f
+}
 
-	return f, nil
+// normalizeCategories trims category labels and drops empty or duplicate ones,
+// keeping the publisher's order.
+func normalizeCategories(categories []string) []string {
+	if len(categories) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(categories))
+	seen := make(map[string]struct{}, len(categories))
+	for _, category := range categories {
+		if len(out) == maxCategories {
+			break
+		}
+		category = strings.Join(strings.Fields(cleanText(category, maxShortTextBytes)), " ")
+		key := strings.ToLower(category)
+		if category == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, category)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
 }
 
-// FetchAll parses multiple feeds concurrently and aggregates items.
-func FetchAll(urls []string, opt usecase.FeedFetchOptions) (*reading.Feed, usecase.FeedFetchReport, error) {
+// FetchAll parses multiple feeds concurrently and aggregates items. Canceling
+// ctx abandons the feeds still in flight.
+func FetchAll(ctx context.Context, urls []string, opt usecase.FeedFetchOptions) (*reading.Feed, usecase.FeedFetchReport, error) {
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	var allItems []reading.Item
 	report := usecase.FeedFetchReport{Requested: len(urls)}
 
-	batchCtx := context.Background()
+	batchCtx := ctx
+	if batchCtx == nil {
+		batchCtx = context.Background()
+	}
 	var batchCancel context.CancelFunc
 	if opt.BatchTimeout > 0 {
 		batchCtx, batchCancel = context.WithTimeout(batchCtx, opt.BatchTimeout)
@@ -141,16 +254,29 @@ func FetchAll(urls []string, opt usecase.FeedFetchOptions) (*reading.Feed, useca
 			mu.Lock()
 			defer mu.Unlock()
 
-			if err == nil && f != nil {
+			progress := usecase.FeedFetchProgress{URL: url, Err: err}
+			switch {
+			case err == nil && f != nil:
 				report.Succeeded++
 				allItems = append(allItems, f.Items...)
-				return
-			}
-			if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+				if f.MovedTo != "" {
+					if report.Moved == nil {
+						report.Moved = make(map[string]string)
+					}
+					report.Moved[url] = f.MovedTo
+				}
+			case errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled):
 				report.TimedOut++
-				return
+				progress.TimedOut = true
+			default:
+				report.Failed++
+				if progress.Err == nil {
+					progress.Err = errors.New("feed returned no content")
+				}
+			}
+			if opt.OnProgress != nil {
+				opt.OnProgress(progress)
 			}
-			report.Failed++
 		})
 	}
 	wg.Wait()
@@ -170,12 +296,12 @@ func FetchAll(urls []string, opt usecase.FeedFetchOptions) (*reading.Feed, useca
 // Fetcher implements the usecase.FeedFetcher interface.
 type Fetcher struct{}
 
-// Fetch fetches a single feed.
-func (Fetcher) Fetch(url string) (*reading.Feed, error) {
-	return Fetch(url)
+// Fetch fetches a single feed, giving up when ctx is done.
+func (Fetcher) Fetch(ctx context.Context, url string) (*reading.Feed, error) {
+	return FetchWithContext(ctx, url)
 }
 
 // FetchAll fetches and aggregates multiple feeds.
-func (Fetcher) FetchAll(urls []string, opt usecase.FeedFetchOptions) (*reading.Feed, usecase.FeedFetchReport, error) {
-	return FetchAll(urls, opt)
+func (Fetcher) FetchAll(ctx context.Context, urls []string, opt usecase.FeedFetchOptions) (*reading.Feed, usecase.FeedFetchReport, error) {
+	return FetchAll(ctx, urls, opt)
 }
@@ -1,13 +1,20 @@
 package feed
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/mmcdole/gofeed"
 	"github.com/tesso57/reazy/internal/application/usecase"
@@ -66,6 +73,100 @@ func TestDefaultParserHeaders(t *testing.T) {
 	}
 }
 
+func TestDefaultParserDecodesEncodedResponses(t *testing.T) {
+	const rss = `<rss version="2.0"><channel><title>Encoded</title><item><title>Café</title><link>https://example.com/a</link></item></channel></rss>`
+	compress := func(newWriter func(io.Writer) io.WriteCloser) []byte {
+		var buf bytes.Buffer
+		w := newWriter(&buf)
+		_, _ = io.WriteString(w, rss)
+		_ = w.Close()
+		return buf.Bytes()
+	}
+	latin1 := []byte(strings.ReplaceAll(rss, "é", "\xe9"))
+
+	tests := []struct {
+		name        string
+		encoding    string
+		contentType string
+		body        []byte
+	}{
+		{name: "gzip", encoding: "gzip", body: compress(func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) })},
+		{name: "zlib deflate", encoding: "deflate", body: compress(func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) })},
+		{name: "raw deflate", encoding: "deflate", body: compress(func(w io.Writer) io.WriteCloser {
+			fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+			return fw
+		})},
+		{name: "header charset", contentType: "application/rss+xml; charset=ISO-8859-1", body: latin1},
+		{name: "declared charset", contentType: "application/rss+xml; charset=utf-8", body: append([]byte(`<?xml version="1.0" encoding="ISO-8859-1"?>`), latin1...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotAcceptEncoding string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAcceptEncoding = r.Header.Get("Accept-Encoding")
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				_, _ = w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			parsed, err := defaultParser(context.Background(), server.URL)
+			if err != nil {
+				t.Fatalf("default parser failed: %v", err)
+			}
+			if !strings.Contains(gotAcceptEncoding, "gzip") || !strings.Contains(gotAcceptEncoding, "deflate") {
+				t.Errorf("Accept-Encoding = %q, want gzip and deflate", gotAcceptEncoding)
+			}
+			if len(parsed.Items) != 1 || parsed.Items[0].Title != "Café" {
+				t.Fatalf("items = %+v, want the decoded item", parsed.Items)
+			}
+		})
+	}
+}
+
+func TestDefaultParserSkipsDecodingRedirectsAndEmptyBodies(t *testing.T) {
+	const rss = `<rss version="2.0"><channel><title>Moved</title><item><title>Item</title><link>https://example.com/a</link></item></channel></rss>`
+	var gzipped bytes.Buffer
+	w := gzip.NewWriter(&gzipped)
+	_, _ = io.WriteString(w, rss)
+	_ = w.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, _ *http.Request) {
+		// An empty gzip-labelled redirect body must not fail the fetch.
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Location", "/feed")
+		w.WriteHeader(http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/feed", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(gzipped.Bytes())
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	parsed, err := defaultParser(context.Background(), server.URL+"/old")
+	if err != nil {
+		t.Fatalf("default parser failed: %v", err)
+	}
+	if parsed.Title != "Moved" {
+		t.Fatalf("title = %q, want the redirected feed", parsed.Title)
+	}
+
+	for _, resp := range []*http.Response{
+		{StatusCode: http.StatusOK, Header: http.Header{"Content-Encoding": {"gzip"}}, Body: io.NopCloser(strings.NewReader("")), ContentLength: -1},
+		{StatusCode: http.StatusFound, Header: http.Header{"Content-Encoding": {"gzip"}, "Location": {"/feed"}}, Body: io.NopCloser(strings.NewReader("not gzip")), ContentLength: -1},
+	} {
+		if err := decodeResponse(resp); err != nil {
+			t.Fatalf("decodeResponse(%d) error = %v, want the body passed through", resp.StatusCode, err)
+		}
+	}
+}
+
 func TestFetch(t *testing.T) {
 	// Restore original parser after test
 	defer func() {
@@ -127,6 +228,64 @@ func TestFetch(t *testing.T) {
 		}
 	})
 
+	t.Run("Categories", func(t *testing.T) {
+		mockFeed := &gofeed.Feed{
+			Title: "Test Feed",
+			Items: []*gofeed.Item{
+				{Title: "Item 1", Link: "http://link1.com", Categories: []string{" Go ", "go", "", "Release  Notes"}},
+				{Title: "Item 2", Link: "http://link2.com"},
+			},
+		}
+		ParserFunc = func(_ context.Context, _ string) (*gofeed.Feed, error) {
+			return mockFeed, nil
+		}
+
+		f, err := Fetch("http://example.com")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got := f.Items[0].Categories; len(got) != 2 || got[0] != "Go" || got[1] != "Release Notes" {
+			t.Errorf("Expected normalized categories [Go Release Notes], got %#v", got)
+		}
+		if f.Items[1].Categories != nil {
+			t.Errorf("Expected no categories, got %#v", f.Items[1].Categories)
+		}
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		mockFeed := &gofeed.Feed{
+			Title: "Bad\x1b[2J Feed",
+			Items: []*gofeed.Item{
+				nil,
+				{GUID: "  ", Link: "http://link1.com", Title: "Invalid \xff byte"},
+				{Title: "Only a title", Content: strings.Repeat("あ", maxBodyTextBytes)},
+			},
+		}
+		ParserFunc = func(_ context.Context, _ string) (*gofeed.Feed, error) {
+			return mockFeed, nil
+		}
+
+		f, err := Fetch("http://example.com")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if f.Title != "Bad[2J Feed" {
+			t.Errorf("Expected control characters stripped from title, got %q", f.Title)
+		}
+		if len(f.Items) != 2 {
+			t.Fatalf("Expected nil item to be skipped, got %d items", len(f.Items))
+		}
+		if f.Items[0].GUID != "http://link1.com" || f.Items[0].Title != "Invalid \ufffd byte" {
+			t.Errorf("Expected link GUID and repaired title, got %q / %q", f.Items[0].GUID, f.Items[0].Title)
+		}
+		if f.Items[1].GUID != "Only a title" {
+			t.Errorf("Expected title GUID, got %q", f.Items[1].GUID)
+		}
+		if content := f.Items[1].Content; len(content) > maxBodyTextBytes || !utf8.ValidString(content) {
+			t.Errorf("Expected content clamped on a character boundary, got %d bytes", len(content))
+		}
+	})
+
 	t.Run("Failure", func(t *testing.T) {
 		ParserFunc = func(_ context.Context, _ string) (*gofeed.Feed, error) {
 			return nil, gofeed.HTTPError{StatusCode: 404, Status: "Not Found"}
@@ -188,7 +347,7 @@ func TestFetchAll(t *testing.T) {
 	}
 
 	urls := []string{"site1", "site2", "error_site"}
-	f, report, err := FetchAll(urls, usecase.FeedFetchOptions{
+	f, report, err := FetchAll(context.Background(), urls, usecase.FeedFetchOptions{
 		PerFeedTimeout: 5 * time.Second,
 		BatchTimeout:   5 * time.Second,
 	})
@@ -212,3 +371,148 @@ func TestFetchAll(t *testing.T) {
 		t.Errorf("Expected title 'All Feeds', got '%s'", f.Title)
 	}
 }
+
+func TestFetchAllReportsProgress(t *testing.T) {
+	originalParser := ParserFunc
+	defer func() { ParserFunc = originalParser }()
+
+	ParserFunc = func(_ context.Context, url string) (*gofeed.Feed, error) {
+		if url == "ok" {
+			return &gofeed.Feed{Title: "OK"}, nil
+		}
+		return nil, fmt.Errorf("network error")
+	}
+
+	var mu sync.Mutex
+	got := map[string]usecase.FeedFetchProgress{}
+	_, _, err := FetchAll(context.Background(), []string{"ok", "bad"}, usecase.FeedFetchOptions{
+		OnProgress: func(p usecase.FeedFetchProgress) {
+			mu.Lock()
+			defer mu.Unlock()
+			got[p.URL] = p
+		},
+	})
+	if err != nil {
+		t.Fatalf("FetchAll failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected one progress event per feed, got %#v", got)
+	}
+	if got["ok"].Err != nil || got["ok"].TimedOut {
+		t.Fatalf("unexpected progress for ok feed: %#v", got["ok"])
+	}
+	if got["bad"].Err == nil {
+		t.Fatalf("expected error progress for bad feed: %#v", got["bad"])
+	}
+}
+
+func TestFetchWithContextDetectsPermanentRedirect(t *testing.T) {
+	const body = `<?xml version="1.0"?><rss version="2.0"><channel><title>Moved</title></channel></rss>`
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/older", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/older", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusPermanentRedirect)
+	})
+	mux.HandleFunc("/temp", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusFound)
+	})
+	mux.HandleFunc("/mixed", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/temp", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(body))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	original := ParserFunc
+	ParserFunc = defaultParser
+	defer func() { ParserFunc = original }()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/old", want: server.URL + "/new"},
+		{path: "/temp", want: ""},
+		{path: "/mixed", want: ""},
+		{path: "/new", want: ""},
+	}
+	for _, tt := range tests {
+		f, err := FetchWithContext(context.Background(), server.URL+tt.path)
+		if err != nil {
+			t.Fatalf("FetchWithContext(%s) failed: %v", tt.path, err)
+		}
+		if f.MovedTo != tt.want {
+			t.Errorf("FetchWithContext(%s).MovedTo = %q, want %q", tt.path, f.MovedTo, tt.want)
+		}
+		if f.URL != server.URL+tt.path {
+			t.Errorf("FetchWithContext(%s).URL = %q, want subscribed URL", tt.path, f.URL)
+		}
+	}
+}
+
+func TestFetcherFetchHonorsContext(t *testing.T) {
+	original := ParserFunc
+	defer func() { ParserFunc = original }()
+	ParserFunc = func(ctx context.Context, _ string) (*gofeed.Feed, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := (Fetcher{}).Fetch(ctx, "https://example.com/rss"); err != context.Canceled {
+		t.Fatalf("Fetch() error = %v, want context.Canceled", err)
+	}
+	_, report, err := (Fetcher{}).FetchAll(ctx, []string{"https://example.com/rss"}, usecase.FeedFetchOptions{})
+	if err != nil {
+		t.Fatalf("FetchAll() error = %v", err)
+	}
+	if report.TimedOut != 1 {
+		t.Fatalf("FetchAll() report = %+v, want canceled feed counted as timed out", report)
+	}
+}
+
+func FuzzFetchMapping(f *testing.F) {
+	originalParser := ParserFunc
+	f.Cleanup(func() { ParserFunc = originalParser })
+
+	f.Add([]byte(`<rss version="2.0"><channel><title>T</title><item><title>A</title><link>https://example.com/a</link><pubDate>Mon, 02 Jan 2006 15:04:05 MST</pubDate></item></channel></rss>`))
+	f.Add([]byte(`<feed xmlns="http://www.w3.org/2005/Atom"><title>T</title><entry><id> </id><title>B</title></entry></feed>`))
+	f.Add([]byte(`{"version":"https://jsonfeed.org/version/1.1","title":"T","items":[{"id":"1","content_text":"x"}]}`))
+	f.Add([]byte("<rss><channel><item><title>\x00\xff\x1b]0;x\x07</title></item></channel></rss>"))
+
+	parser := gofeed.NewParser()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ParserFunc = func(_ context.Context, _ string) (*gofeed.Feed, error) {
+			return parser.Parse(bytes.NewReader(data))
+		}
+		feed, err := FetchWithContext(context.Background(), "https://example.com/feed")
+		if err != nil {
+			return
+		}
+		if !utf8.ValidString(feed.Title) || len(feed.Title) > maxShortTextBytes {
+			t.Fatalf("feed title not cleaned: %q", feed.Title)
+		}
+		for _, item := range feed.Items {
+			for _, field := range []string{item.GUID, item.Title, item.Link, item.Published, item.Description, item.Content} {
+				if !utf8.ValidString(field) || strings.ContainsRune(field, 0x1b) || len(field) > maxBodyTextBytes {
+					t.Fatalf("item field not cleaned: %q", field)
+				}
+			}
+			if item.GUID != strings.TrimSpace(item.GUID) {
+				t.Fatalf("GUID %q has surrounding space", item.GUID)
+			}
+			if item.GUID == "" && (item.Link != "" || item.Title != "") {
+				t.Fatalf("item %+v has no GUID despite a link or title", item)
+			}
+			if len(item.Categories) > maxCategories {
+				t.Fatalf("%d categories kept", len(item.Categories))
+			}
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxOPMLBytes bounds how much of a hosted subscription list is read.
+const maxOPMLBytes = 5 << 20
+
+const opmlAcceptHeader = "text/x-opml, application/xml;q=0.9, text/xml;q=0.9, */*;q=0.1"
+
+// opmlTimeout limits how long downloading a subscription list may take.
+const opmlTimeout = 30 * time.Second
+
+// OPMLFetcher implements usecase.OPMLFetcher over HTTP.
+type OPMLFetcher struct {
+	// Client performs the request; nil uses one that follows at most 10
+	// redirects.
+	Client *http.Client
+	// Headers holds extra request headers, such as Authorization, keyed by
+	// the host they are sent to.
+	Headers map[string]map[string]string
+}
+
+// FetchOPML downloads the subscription list at link. Credentials in the URL
+// are sent as basic auth and the headers configured for its host are added;
+// both are dropped on a redirect to a different host. Responses that are not
+// XML, such as a sign-in page, are rejected before parsing.
+func (f OPMLFetcher) FetchOPML(ctx context.Context, link string) ([]byte, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, opmlTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSpace(link), nil)
+	if err != nil {
+		return nil, redactURLError(err)
+	}
+	req.Header.Set("User-Agent", "Reazy/1.0")
+	req.Header.Set("Accept", opmlAcceptHeader)
+	var configured []string
+	for host, headers := range f.Headers {
+		if !strings.EqualFold(host, req.URL.Hostname()) {
+			continue
+		}
+		for name, value := range headers {
+			req.Header.Set(name, value)
+			configured = append(configured, name)
+		}
+	}
+
+	resp, err := f.client(configured).Do(req)
+	if err != nil {
+		return nil, redactURLError(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, errors.New(resp.Status)
+	}
+	if err := checkOPMLContentType(resp.Header.Get("Content-Type")); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxOPMLBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxOPMLBytes {
+		return nil, fmt.Errorf("opml is larger than %d MB", maxOPMLBytes>>20)
+	}
+	return data, nil
+}
+
+// client returns the HTTP client for one download. net/http only drops
+// Authorization and cookies on a redirect to another domain, so the client
+// also removes the configured headers once a redirect leaves the host.
+func (f OPMLFetcher) client(configured []string) *http.Client {
+	client := &http.Client{
+		Transport:     acceptTransport{base: http.DefaultTransport},
+		CheckRedirect: trackRedirect,
+	}
+	if f.Client != nil {
+		copied := *f.Client
+		client = &copied
+	}
+	check := client.CheckRedirect
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if check != nil {
+			if err := check(req, via); err != nil {
+				return err
+			}
+		} else if len(via) >= maxRedirects {
+			return errors.New("stopped after 10 redirects")
+		}
+		if !strings.EqualFold(req.URL.Hostname(), via[0].URL.Hostname()) {
+			for _, name := range configured {
+				req.Header.Del(name)
+			}
+		}
+		return nil
+	}
+	return client
+}
+
+// checkOPMLContentType accepts XML media types and the generic ones static
+// hosts often serve OPML files with.
+func checkOPMLContentType(contentType string) error {
+	if strings.TrimSpace(contentType) == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("unexpected content type %q", contentType)
+	}
+	switch {
+	case mediaType == "text/x-opml", mediaType == "application/xml", mediaType == "text/xml",
+		strings.HasSuffix(mediaType, "+xml"),
+		mediaType == "text/plain", mediaType == "application/octet-stream":
+		return nil
+	}
+	return fmt.Errorf("unexpected content type %q, want OPML", mediaType)
+}
+
+// redactURLError removes credentials from the URL a request error quotes.
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	if parsed, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+		urlErr.URL = parsed.Redacted()
+	}
+	return urlErr
+}
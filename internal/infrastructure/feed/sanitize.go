@@ -0,0 +1,40 @@
+package feed
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxShortTextBytes bounds single-line fields such as titles and links.
+	maxShortTextBytes = 4 << 10
+	// maxBodyTextBytes bounds an item's description or content.
+	maxBodyTextBytes = 1 << 20
+	// maxCategories bounds how many categories one item keeps.
+	maxCategories = 32
+)
+
+// cleanText makes feed text safe to store and display: invalid UTF-8 is
+// replaced, control characters other than tabs and line breaks are removed
+// (they could drive the terminal), and the result is cut to at most limit
+// bytes on a character boundary.
+func cleanText(s string, limit int) string {
+	s = strings.ToValidUTF8(s, "�")
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			return r
+		case r < 0x20 || r == 0x7f || (r >= 0x80 && r < 0xa0):
+			return -1
+		}
+		return r
+	}, s)
+	if len(s) <= limit {
+		return s
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
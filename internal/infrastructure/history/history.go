@@ -4,6 +4,7 @@ package history
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -19,16 +20,21 @@ import (
 
 // Manager handles loading and saving history.
 type Manager struct {
-	mu      sync.RWMutex
-	path    string
-	db      *sql.DB
-	once    sync.Once
-	initErr error
+	mu         sync.RWMutex
+	path       string
+	legacyPath string
+	db         *sql.DB
+	once       sync.Once
+	initErr    error
+	closed     bool
 }
 
+// errClosed is returned by operations on a closed Manager.
+var errClosed = errors.New("history db is closed")
+
 // NewManager creates a new history manager.
 func NewManager(path string) *Manager {
-	return &Manager{path: resolveDBPath(path)}
+	return &Manager{path: resolveDBPath(path), legacyPath: resolveLegacyPath(path)}
 }
 
 func resolveDBPath(path string) string {
@@ -43,6 +49,9 @@ func resolveDBPath(path string) string {
 }
 
 func (m *Manager) dbConn() (*sql.DB, error) {
+	if m.closed {
+		return nil, errClosed
+	}
 	m.once.Do(func() {
 		dir := filepath.Dir(m.path)
 		if err := os.MkdirAll(dir, 0750); err != nil {
@@ -70,6 +79,21 @@ func (m *Manager) dbConn() (*sql.DB, error) {
 	return m.db, nil
 }
 
+// Close waits for the running operation to finish and closes the database.
+// Later operations fail; closing again is a no-op.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+	if m.db == nil {
+		return nil
+	}
+	return m.db.Close()
+}
+
 func initDB(db *sql.DB) error {
 	pragmas := []string{
 		"PRAGMA journal_mode=WAL;",
@@ -103,11 +127,23 @@ func initDB(db *sql.DB) error {
 			digest_date TEXT,
 			related_guids TEXT
 		);`,
+	}
+	for _, stmt := range schema {
+		if _, err := db.Exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	if err := runMigrations(db, migrations); err != nil {
+		return err
+	}
+
+	indexes := []string{
 		`CREATE INDEX IF NOT EXISTS idx_history_feed_kind_date ON history_items (feed_url, kind, date DESC, saved_at DESC);`,
 		`CREATE INDEX IF NOT EXISTS idx_history_bookmarked_kind_date ON history_items (is_bookmarked, kind, date DESC, saved_at DESC);`,
 		`CREATE INDEX IF NOT EXISTS idx_history_kind_digest_date ON history_items (kind, digest_date);`,
 	}
-	for _, stmt := range schema {
+	for _, stmt := range indexes {
 		if _, err := db.Exec(stmt); err != nil {
 			return err
 		}
@@ -131,7 +167,8 @@ func (m *Manager) LoadMetadata() (map[string]*reading.HistoryItem, error) {
 		       link, published, date, feed_title, feed_url,
 		       is_read, saved_at, is_bookmarked,
 		       ai_summary, ai_tags, ai_updated_at,
-		       digest_date, related_guids
+		       digest_date, related_guids, feed_categories, related_titles, open_count,
+		       snoozed_until, is_dismissed, scroll_offset
 		FROM history_items`, reading.NewsDigestKind)
 	if err != nil {
 		return nil, err
@@ -181,7 +218,8 @@ func (m *Manager) LoadByGUID(guid string) (*reading.HistoryItem, error) {
 		       link, published, date, feed_title, feed_url,
 		       is_read, saved_at, is_bookmarked,
 		       ai_summary, ai_tags, ai_updated_at,
-		       digest_date, related_guids
+		       digest_date, related_guids, feed_categories, related_titles, open_count,
+		       snoozed_until, is_dismissed, scroll_offset
 		FROM history_items WHERE guid = ?`, guid)
 	item, err := scanHistoryItem(row)
 	if err == sql.ErrNoRows {
@@ -196,7 +234,9 @@ func (m *Manager) LoadByGUID(guid string) (*reading.HistoryItem, error) {
 	return item, nil
 }
 
-// Upsert inserts or updates the given items.
+// Upsert inserts or updates the given items. Empty or excerpt-only content
+// never replaces a fuller stored body, so refreshing a feed keeps article
+// text extracted earlier.
 func (m *Manager) Upsert(items []*reading.HistoryItem) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -218,13 +258,19 @@ func (m *Manager) Upsert(items []*reading.HistoryItem) error {
 			link, published, date, feed_title, feed_url,
 			is_read, saved_at, is_bookmarked,
 			ai_summary, ai_tags, ai_updated_at,
-			digest_date, related_guids
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			digest_date, related_guids, feed_categories, related_titles, open_count,
+			snoozed_until, is_dismissed, scroll_offset
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(guid) DO UPDATE SET
 			kind = excluded.kind,
 			title = excluded.title,
 			description = excluded.description,
-			content = excluded.content,
+			content = CASE
+				WHEN excluded.content = '' THEN history_items.content
+				WHEN excluded.content = excluded.description
+					AND history_items.content NOT IN ('', history_items.description) THEN history_items.content
+				ELSE excluded.content
+			END,
 			link = excluded.link,
 			published = excluded.published,
 			date = excluded.date,
@@ -237,7 +283,9 @@ func (m *Manager) Upsert(items []*reading.HistoryItem) error {
 			ai_tags = excluded.ai_tags,
 			ai_updated_at = excluded.ai_updated_at,
 			digest_date = excluded.digest_date,
-			related_guids = excluded.related_guids`)
+			related_guids = excluded.related_guids,
+			feed_categories = excluded.feed_categories,
+			related_titles = excluded.related_titles`)
 	if err != nil {
 		return err
 	}
@@ -260,6 +308,39 @@ func (m *Manager) SetRead(guid string, isRead bool) error {
 	return m.updateBoolField("is_read", guid, isRead)
 }
 
+// SetReadMany updates read state for several items in one transaction.
+func (m *Manager) SetReadMany(guids []string, isRead bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	db, err := m.dbConn()
+	if err != nil {
+		return err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.Prepare("UPDATE history_items SET is_read = ? WHERE guid = ?")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, guid := range guids {
+		guid = strings.TrimSpace(guid)
+		if guid == "" {
+			continue
+		}
+		if _, err := stmt.Exec(boolToInt(isRead), guid); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 // SetBookmark updates bookmark state for one item.
 func (m *Manager) SetBookmark(guid string, isBookmarked bool) error {
 	return m.updateBoolField("is_bookmarked", guid, isBookmarked)
@@ -309,6 +390,39 @@ func (m *Manager) SetInsight(guid, summary string, tags []string, updatedAt time
 	return err
 }
 
+// ClearInsights removes the AI fields of several items in one transaction.
+func (m *Manager) ClearInsights(guids []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	db, err := m.dbConn()
+	if err != nil {
+		return err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.Prepare("UPDATE history_items SET ai_summary = '', ai_tags = '[]', ai_updated_at = '' WHERE guid = ?")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, guid := range guids {
+		guid = strings.TrimSpace(guid)
+		if guid == "" {
+			continue
+		}
+		if _, err := stmt.Exec(guid); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 // ReplaceDigestItemsByDate upserts digest rows for the specified date while
 // keeping previously generated rows for the same date.
 func (m *Manager) ReplaceDigestItemsByDate(dateKey string, items []*reading.HistoryItem) error {
@@ -336,8 +450,9 @@ func (m *Manager) ReplaceDigestItemsByDate(dateKey string, items []*reading.Hist
 			link, published, date, feed_title, feed_url,
 			is_read, saved_at, is_bookmarked,
 			ai_summary, ai_tags, ai_updated_at,
-			digest_date, related_guids
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			digest_date, related_guids, feed_categories, related_titles, open_count,
+			snoozed_until, is_dismissed, scroll_offset
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(guid) DO UPDATE SET
 			kind = excluded.kind,
 			title = excluded.title,
@@ -355,7 +470,9 @@ func (m *Manager) ReplaceDigestItemsByDate(dateKey string, items []*reading.Hist
 			ai_tags = excluded.ai_tags,
 			ai_updated_at = excluded.ai_updated_at,
 			digest_date = excluded.digest_date,
-			related_guids = excluded.related_guids`)
+			related_guids = excluded.related_guids,
+			feed_categories = excluded.feed_categories,
+			related_titles = excluded.related_titles`)
 	if err != nil {
 		return err
 	}
@@ -377,6 +494,182 @@ func (m *Manager) ReplaceDigestItemsByDate(dateKey string, items []*reading.Hist
 	return tx.Commit()
 }
 
+// Snooze stores when a snoozed item should reappear; a zero until clears it.
+func (m *Manager) Snooze(guid string, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	guid = strings.TrimSpace(guid)
+	if guid == "" {
+		return nil
+	}
+	db, err := m.dbConn()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("UPDATE history_items SET snoozed_until = ? WHERE guid = ?", timeToText(until), guid)
+	return err
+}
+
+// SetDismissed stores whether an item is dismissed. Upserts never change the
+// flag, so re-fetching a dismissed article keeps it hidden.
+func (m *Manager) SetDismissed(guid string, dismissed bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	guid = strings.TrimSpace(guid)
+	if guid == "" {
+		return nil
+	}
+	db, err := m.dbConn()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("UPDATE history_items SET is_dismissed = ? WHERE guid = ?", boolToInt(dismissed), guid)
+	return err
+}
+
+// IncrementOpenCount adds one to the stored open count of an item.
+func (m *Manager) IncrementOpenCount(guid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	guid = strings.TrimSpace(guid)
+	if guid == "" {
+		return nil
+	}
+	db, err := m.dbConn()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("UPDATE history_items SET open_count = open_count + 1 WHERE guid = ?", guid)
+	return err
+}
+
+// SetScrollOffset stores how far the reading pane was scrolled in an item.
+func (m *Manager) SetScrollOffset(guid string, offset int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	guid = strings.TrimSpace(guid)
+	if guid == "" {
+		return nil
+	}
+	db, err := m.dbConn()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("UPDATE history_items SET scroll_offset = ? WHERE guid = ?", max(offset, 0), guid)
+	return err
+}
+
+// MoveFeedURL rewrites the feed URL of every item of a moved feed.
+func (m *Manager) MoveFeedURL(from, to string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	db, err := m.dbConn()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("UPDATE history_items SET feed_url = ? WHERE feed_url = ?", to, from)
+	return err
+}
+
+// Selections of the rows the clear operations delete, shared with their
+// count-only previews so a preview never disagrees with the real run.
+const (
+	clearAllWhere          = "1 = 1"
+	clearUnbookmarkedWhere = "is_bookmarked = 0 AND is_dismissed = 0"
+)
+
+// ClearAll deletes every history row while keeping the schema.
+func (m *Manager) ClearAll() error {
+	return m.clearItems(clearAllWhere)
+}
+
+// ClearUnbookmarked deletes every history row except bookmarked items and
+// dismissed ones, which must stay hidden when their feed is fetched again.
+func (m *Manager) ClearUnbookmarked() error {
+	return m.clearItems(clearUnbookmarkedWhere)
+}
+
+// CountClearAll reports how many rows ClearAll would delete and the titles of
+// up to limit of the newest, without deleting anything.
+func (m *Manager) CountClearAll(limit int) (int, []string, error) {
+	return m.countItems(clearAllWhere, limit)
+}
+
+// CountClearUnbookmarked is the count-only variant of ClearUnbookmarked.
+func (m *Manager) CountClearUnbookmarked(limit int) (int, []string, error) {
+	return m.countItems(clearUnbookmarkedWhere, limit)
+}
+
+func (m *Manager) clearItems(where string) error {
+	_, err := m.deleteItems(where)
+	return err
+}
+
+func (m *Manager) deleteItems(where string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	db, err := m.dbConn()
+	if err != nil {
+		return 0, err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec("DELETE FROM history_items WHERE " + where)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (m *Manager) countItems(where string, limit int) (int, []string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	db, err := m.dbConn()
+	if err != nil {
+		return 0, nil, err
+	}
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM history_items WHERE " + where).Scan(&count); err != nil {
+		return 0, nil, err
+	}
+	if limit <= 0 || count == 0 {
+		return count, nil, nil
+	}
+
+	rows, err := db.Query(
+		"SELECT COALESCE(title, '') FROM history_items WHERE "+where+" ORDER BY date DESC, saved_at DESC LIMIT ?",
+		limit,
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	titles := make([]string, 0, min(count, limit))
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			return 0, nil, err
+		}
+		titles = append(titles, title)
+	}
+	return count, titles, rows.Err()
+}
+
 // LoadTodayArticles loads today's article rows with full body for digest generation.
 func (m *Manager) LoadTodayArticles(dateKey string, feeds []string, limit int, loc *time.Location) ([]*reading.HistoryItem, error) {
 	m.mu.RLock()
@@ -393,9 +686,10 @@ func (m *Manager) LoadTodayArticles(dateKey string, feeds []string, limit int, l
 		       link, published, date, feed_title, feed_url,
 		       is_read, saved_at, is_bookmarked,
 		       ai_summary, ai_tags, ai_updated_at,
-		       digest_date, related_guids
+		       digest_date, related_guids, feed_categories, related_titles, open_count,
+		       snoozed_until, is_dismissed, scroll_offset
 		FROM history_items
-		WHERE kind != ?`)
+		WHERE kind != ? AND is_dismissed = 0`)
 	args := make([]any, 0, len(feeds)+2)
 	args = append(args, reading.NewsDigestKind)
 
@@ -456,43 +750,54 @@ type scanner interface {
 }
 
 func scanHistoryItem(src scanner) (*reading.HistoryItem, error) {
+	// Text columns are nullable in databases migrated from older schemas.
 	var (
-		guid, kind, title, desc, content, link   string
-		published, dateText, feedTitle, feedURL  string
-		savedAtText, aiSummary, aiTagsJSON       string
-		aiUpdatedAtText, digestDate, relatedJSON string
-		isRead, isBookmarked                     int
+		guid, kind, title, desc, content, link   sql.NullString
+		published, dateText, feedTitle, feedURL  sql.NullString
+		savedAtText, aiSummary, aiTagsJSON       sql.NullString
+		aiUpdatedAtText, digestDate, relatedJSON sql.NullString
+		categoriesJSON, relatedTitlesJSON        sql.NullString
+		snoozedUntilText                         sql.NullString
+		isRead, isBookmarked, openCount          int
+		isDismissed, scrollOffset                int
 	)
 	if err := src.Scan(
 		&guid, &kind, &title, &desc, &content,
 		&link, &published, &dateText, &feedTitle, &feedURL,
 		&isRead, &savedAtText, &isBookmarked,
 		&aiSummary, &aiTagsJSON, &aiUpdatedAtText,
-		&digestDate, &relatedJSON,
+		&digestDate, &relatedJSON, &categoriesJSON, &relatedTitlesJSON,
+		&openCount, &snoozedUntilText, &isDismissed, &scrollOffset,
 	); err != nil {
 		return nil, err
 	}
 
 	item := &reading.HistoryItem{
-		GUID:         guid,
-		Kind:         kind,
-		Title:        title,
-		Description:  desc,
-		Content:      content,
-		Link:         link,
-		Published:    published,
-		Date:         parseTime(dateText),
-		FeedTitle:    feedTitle,
-		FeedURL:      feedURL,
-		IsRead:       isRead != 0,
-		SavedAt:      parseTime(savedAtText),
-		IsBookmarked: isBookmarked != 0,
-		AISummary:    aiSummary,
-		AITags:       unmarshalStringSlice(aiTagsJSON),
-		AIUpdatedAt:  parseTime(aiUpdatedAtText),
-		DigestDate:   digestDate,
-		RelatedGUIDs: unmarshalStringSlice(relatedJSON),
-		BodyHydrated: strings.TrimSpace(content) != "",
+		GUID:           guid.String,
+		Kind:           kind.String,
+		Title:          title.String,
+		Description:    desc.String,
+		Content:        content.String,
+		Link:           link.String,
+		Published:      published.String,
+		Date:           parseTime(dateText.String),
+		FeedTitle:      feedTitle.String,
+		FeedURL:        feedURL.String,
+		IsRead:         isRead != 0,
+		SavedAt:        parseTime(savedAtText.String),
+		IsBookmarked:   isBookmarked != 0,
+		AISummary:      aiSummary.String,
+		AITags:         unmarshalStringSlice(aiTagsJSON.String),
+		AIUpdatedAt:    parseTime(aiUpdatedAtText.String),
+		OpenCount:      openCount,
+		SnoozedUntil:   parseTime(snoozedUntilText.String),
+		IsDismissed:    isDismissed != 0,
+		ScrollOffset:   scrollOffset,
+		DigestDate:     digestDate.String,
+		RelatedGUIDs:   unmarshalStringSlice(relatedJSON.String),
+		FeedCategories: unmarshalStringSlice(categoriesJSON.String),
+		RelatedTitles:  unmarshalStringMap(relatedTitlesJSON.String),
+		BodyHydrated:   strings.TrimSpace(content.String) != "",
 	}
 	if strings.TrimSpace(item.Kind) == "" {
 		item.Kind = reading.ArticleKind
@@ -505,7 +810,7 @@ func scanHistoryItem(src scanner) (*reading.HistoryItem, error) {
 
 func upsertArgs(item *reading.HistoryItem) []any {
 	if item == nil {
-		return make([]any, 18)
+		return make([]any, 24)
 	}
 	kind := strings.TrimSpace(item.Kind)
 	if kind == "" {
@@ -533,6 +838,12 @@ func upsertArgs(item *reading.HistoryItem) []any {
 		timeToText(item.AIUpdatedAt),
 		item.DigestDate,
 		marshalStringSlice(item.RelatedGUIDs),
+		marshalStringSlice(item.FeedCategories),
+		marshalStringMap(item.RelatedTitles),
+		item.OpenCount,
+		timeToText(item.SnoozedUntil),
+		boolToInt(item.IsDismissed),
+		item.ScrollOffset,
 	}
 }
 
@@ -589,6 +900,29 @@ func unmarshalStringSlice(payload string) []string {
 	return out
 }
 
+func marshalStringMap(items map[string]string) string {
+	if len(items) == 0 {
+		return "{}"
+	}
+	payload, err := json.Marshal(items)
+	if err != nil {
+		return "{}"
+	}
+	return string(payload)
+}
+
+func unmarshalStringMap(payload string) map[string]string {
+	payload = strings.TrimSpace(payload)
+	if payload == "" {
+		return nil
+	}
+	var out map[string]string
+	if err := json.Unmarshal([]byte(payload), &out); err != nil || len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
 func itemSortDate(item *reading.HistoryItem, loc *time.Location) time.Time {
 	if item == nil {
 		return time.Time{}
@@ -3,6 +3,7 @@ package history
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -17,19 +18,21 @@ func TestManager_UpsertAndLoad(t *testing.T) {
 	now := time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC)
 	items := []*reading.HistoryItem{
 		{
-			GUID:         "id1",
-			Kind:         reading.ArticleKind,
-			Title:        "Title 1",
-			Description:  "Desc 1",
-			Content:      "Body 1",
-			FeedURL:      "feed1",
-			FeedTitle:    "Feed 1",
-			Date:         now,
-			SavedAt:      now,
-			IsRead:       false,
-			IsBookmarked: true,
-			AITags:       []string{"go", "rss"},
-			RelatedGUIDs: []string{"x", "y"},
+			GUID:           "id1",
+			Kind:           reading.ArticleKind,
+			Title:          "Title 1",
+			Description:    "Desc 1",
+			Content:        "Body 1",
+			FeedURL:        "feed1",
+			FeedTitle:      "Feed 1",
+			Date:           now,
+			SavedAt:        now,
+			IsRead:         false,
+			IsBookmarked:   true,
+			AITags:         []string{"go", "rss"},
+			RelatedGUIDs:   []string{"x", "y"},
+			FeedCategories: []string{"Release"},
+			RelatedTitles:  map[string]string{"x": "翻訳タイトル"},
 		},
 	}
 
@@ -70,6 +73,47 @@ func TestManager_UpsertAndLoad(t *testing.T) {
 	if len(full.RelatedGUIDs) != 2 || full.RelatedGUIDs[1] != "y" {
 		t.Fatalf("RelatedGUIDs not round-tripped: %#v", full.RelatedGUIDs)
 	}
+	if len(full.FeedCategories) != 1 || full.FeedCategories[0] != "Release" {
+		t.Fatalf("FeedCategories not round-tripped: %#v", full.FeedCategories)
+	}
+	if len(full.AITags) != 2 {
+		t.Fatalf("feed categories should not leak into AI tags: %#v", full.AITags)
+	}
+	if full.RelatedTitles["x"] != "翻訳タイトル" {
+		t.Fatalf("RelatedTitles not round-tripped: %#v", full.RelatedTitles)
+	}
+}
+
+func TestManager_UpsertKeepsFullerContent(t *testing.T) {
+	m := NewManager(filepath.Join(t.TempDir(), "history.db"))
+	full := &reading.HistoryItem{GUID: "a", Kind: reading.ArticleKind, Title: "A", Description: "Teaser", Content: "The whole article"}
+	if err := m.Upsert([]*reading.HistoryItem{full}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	// A refresh saves the article from metadata, which carries no body, or
+	// with the feed's excerpt as its content.
+	for _, content := range []string{"", "Teaser"} {
+		refreshed := &reading.HistoryItem{GUID: "a", Kind: reading.ArticleKind, Title: "A (updated)", Description: "Teaser", Content: content}
+		if err := m.Upsert([]*reading.HistoryItem{refreshed}); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+		got, err := m.LoadByGUID("a")
+		if err != nil {
+			t.Fatalf("LoadByGUID failed: %v", err)
+		}
+		if got.Content != "The whole article" || got.Title != "A (updated)" {
+			t.Fatalf("content %q replaced the stored body: %#v", content, got)
+		}
+	}
+
+	full.Content = "Rewritten article"
+	if err := m.Upsert([]*reading.HistoryItem{full}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if got, _ := m.LoadByGUID("a"); got.Content != "Rewritten article" {
+		t.Fatalf("full content should replace the body, got %q", got.Content)
+	}
 }
 
 func TestManager_Setters(t *testing.T) {
@@ -109,6 +153,280 @@ func TestManager_Setters(t *testing.T) {
 	}
 }
 
+func TestManager_SetReadMany(t *testing.T) {
+	m := NewManager(filepath.Join(t.TempDir(), "history.db"))
+	if err := m.Upsert([]*reading.HistoryItem{
+		{GUID: "id1", Kind: reading.ArticleKind},
+		{GUID: "id2", Kind: reading.ArticleKind},
+		{GUID: "id3", Kind: reading.ArticleKind},
+	}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if err := m.SetReadMany([]string{"id1", " ", "id3"}, true); err != nil {
+		t.Fatalf("SetReadMany failed: %v", err)
+	}
+
+	meta, err := m.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if !meta["id1"].IsRead || meta["id2"].IsRead || !meta["id3"].IsRead {
+		t.Fatalf("read = %v/%v/%v, want id1 and id3 read", meta["id1"].IsRead, meta["id2"].IsRead, meta["id3"].IsRead)
+	}
+}
+
+func TestManager_IncrementOpenCount(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewManager(filepath.Join(tmpDir, "history.db"))
+
+	item := &reading.HistoryItem{GUID: "id1", Kind: reading.ArticleKind, Title: "Title"}
+	if err := m.Upsert([]*reading.HistoryItem{item}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	for range 2 {
+		if err := m.IncrementOpenCount("id1"); err != nil {
+			t.Fatalf("IncrementOpenCount failed: %v", err)
+		}
+	}
+	item.Title = "Updated"
+	if err := m.Upsert([]*reading.HistoryItem{item}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	meta, err := m.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if got := meta["id1"].OpenCount; got != 2 {
+		t.Fatalf("OpenCount = %d, want 2 (upsert must not reset it)", got)
+	}
+}
+
+func TestManager_SetScrollOffset(t *testing.T) {
+	m := NewManager(filepath.Join(t.TempDir(), "history.db"))
+
+	item := &reading.HistoryItem{GUID: "id1", Kind: reading.ArticleKind, Title: "Title"}
+	if err := m.Upsert([]*reading.HistoryItem{item}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if err := m.SetScrollOffset("id1", 42); err != nil {
+		t.Fatalf("SetScrollOffset failed: %v", err)
+	}
+	item.Title = "Updated"
+	if err := m.Upsert([]*reading.HistoryItem{item}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	meta, err := m.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if got := meta["id1"].ScrollOffset; got != 42 {
+		t.Fatalf("ScrollOffset = %d, want 42 (upsert must not reset it)", got)
+	}
+}
+
+func TestManager_MoveFeedURL(t *testing.T) {
+	m := NewManager(filepath.Join(t.TempDir(), "history.db"))
+
+	items := []*reading.HistoryItem{
+		{GUID: "a", Kind: reading.ArticleKind, FeedURL: "http://old.example.com/rss"},
+		{GUID: "b", Kind: reading.ArticleKind, FeedURL: "http://other.example.com/rss"},
+	}
+	if err := m.Upsert(items); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if err := m.MoveFeedURL("http://old.example.com/rss", "https://new.example.com/rss"); err != nil {
+		t.Fatalf("MoveFeedURL failed: %v", err)
+	}
+
+	meta, err := m.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if meta["a"].FeedURL != "https://new.example.com/rss" || meta["b"].FeedURL != "http://other.example.com/rss" {
+		t.Fatalf("feed URLs = %q, %q; want only the moved feed rewritten", meta["a"].FeedURL, meta["b"].FeedURL)
+	}
+}
+
+func TestManager_Close(t *testing.T) {
+	m := NewManager(filepath.Join(t.TempDir(), "history.db"))
+	if err := m.Upsert([]*reading.HistoryItem{{GUID: "id1", Kind: reading.ArticleKind}}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := m.Close(); err != nil {
+		t.Fatalf("second Close should be a no-op: %v", err)
+	}
+	if err := m.SetRead("id1", true); err == nil {
+		t.Fatal("writes after Close should fail")
+	}
+}
+
+func TestManager_Snooze(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewManager(filepath.Join(tmpDir, "history.db"))
+
+	item := &reading.HistoryItem{GUID: "id1", Kind: reading.ArticleKind, Title: "Title"}
+	if err := m.Upsert([]*reading.HistoryItem{item}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	until := time.Date(2026, 2, 15, 8, 0, 0, 0, time.UTC)
+	if err := m.Snooze("id1", until); err != nil {
+		t.Fatalf("Snooze failed: %v", err)
+	}
+	item.Title = "Updated"
+	if err := m.Upsert([]*reading.HistoryItem{item}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	meta, err := m.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if got := meta["id1"].SnoozedUntil; !got.Equal(until) {
+		t.Fatalf("SnoozedUntil = %v, want %v (upsert must not reset it)", got, until)
+	}
+
+	if err := m.Snooze("id1", time.Time{}); err != nil {
+		t.Fatalf("Snooze clear failed: %v", err)
+	}
+	loaded, err := m.LoadByGUID("id1")
+	if err != nil {
+		t.Fatalf("LoadByGUID failed: %v", err)
+	}
+	if !loaded.SnoozedUntil.IsZero() {
+		t.Fatalf("SnoozedUntil = %v, want cleared", loaded.SnoozedUntil)
+	}
+}
+
+func TestManager_SetDismissed(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewManager(filepath.Join(tmpDir, "history.db"))
+
+	now := time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC)
+	item := &reading.HistoryItem{GUID: "id1", Kind: reading.ArticleKind, Title: "Title", FeedURL: "feed", Date: now, SavedAt: now}
+	if err := m.Upsert([]*reading.HistoryItem{item}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if err := m.SetDismissed("id1", true); err != nil {
+		t.Fatalf("SetDismissed failed: %v", err)
+	}
+	item.Title = "Updated"
+	if err := m.Upsert([]*reading.HistoryItem{item}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if err := m.ClearUnbookmarked(); err != nil {
+		t.Fatalf("ClearUnbookmarked failed: %v", err)
+	}
+
+	meta, err := m.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if meta["id1"] == nil || !meta["id1"].IsDismissed {
+		t.Fatalf("dismissed item = %#v, want kept and dismissed (upsert and clear must not drop it)", meta["id1"])
+	}
+	today, err := m.LoadTodayArticles("2026-02-14", nil, 0, time.UTC)
+	if err != nil {
+		t.Fatalf("LoadTodayArticles failed: %v", err)
+	}
+	if len(today) != 0 {
+		t.Fatalf("LoadTodayArticles() = %#v, want dismissed item left out", today)
+	}
+
+	if err := m.SetDismissed("id1", false); err != nil {
+		t.Fatalf("SetDismissed restore failed: %v", err)
+	}
+	loaded, err := m.LoadByGUID("id1")
+	if err != nil {
+		t.Fatalf("LoadByGUID failed: %v", err)
+	}
+	if loaded.IsDismissed {
+		t.Fatal("IsDismissed should be cleared after restoring")
+	}
+}
+
+func TestManager_Clear(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewManager(filepath.Join(tmpDir, "history.db"))
+
+	now := time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC)
+	if err := m.Upsert([]*reading.HistoryItem{
+		{GUID: "plain", Kind: reading.ArticleKind, SavedAt: now},
+		{GUID: "saved", Kind: reading.ArticleKind, SavedAt: now, IsBookmarked: true},
+	}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	if err := m.ClearUnbookmarked(); err != nil {
+		t.Fatalf("ClearUnbookmarked failed: %v", err)
+	}
+	items, err := m.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if len(items) != 1 || items["saved"] == nil {
+		t.Fatalf("ClearUnbookmarked should keep only bookmarks, got %#v", items)
+	}
+
+	if err := m.ClearAll(); err != nil {
+		t.Fatalf("ClearAll failed: %v", err)
+	}
+	items, err = m.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("ClearAll should remove every row, got %d", len(items))
+	}
+
+	if err := m.Upsert([]*reading.HistoryItem{{GUID: "again", Kind: reading.ArticleKind, SavedAt: now}}); err != nil {
+		t.Fatalf("Upsert after ClearAll failed: %v", err)
+	}
+}
+
+func TestManager_CountClear(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewManager(filepath.Join(tmpDir, "history.db"))
+
+	now := time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC)
+	if err := m.Upsert([]*reading.HistoryItem{
+		{GUID: "old", Kind: reading.ArticleKind, Title: "Old", Date: now.Add(-2 * time.Hour), SavedAt: now},
+		{GUID: "new", Kind: reading.ArticleKind, Title: "New", Date: now, SavedAt: now},
+		{GUID: "saved", Kind: reading.ArticleKind, Title: "Saved", Date: now.Add(time.Hour), SavedAt: now, IsBookmarked: true},
+		{GUID: "hidden", Kind: reading.ArticleKind, Title: "Hidden", Date: now.Add(-time.Hour), SavedAt: now, IsDismissed: true},
+	}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	count, titles, err := m.CountClearUnbookmarked(1)
+	if err != nil {
+		t.Fatalf("CountClearUnbookmarked failed: %v", err)
+	}
+	if count != 2 || !reflect.DeepEqual(titles, []string{"New"}) {
+		t.Fatalf("CountClearUnbookmarked(1) = %d, %v; want 2, [New]", count, titles)
+	}
+
+	count, titles, err = m.CountClearAll(0)
+	if err != nil {
+		t.Fatalf("CountClearAll failed: %v", err)
+	}
+	if count != 4 || titles != nil {
+		t.Fatalf("CountClearAll(0) = %d, %v; want 4 without examples", count, titles)
+	}
+
+	items, err := m.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("counting should not delete rows, got %d left", len(items))
+	}
+}
+
 func TestManager_ReplaceDigestItemsByDate(t *testing.T) {
 	tmpDir := t.TempDir()
 	m := NewManager(filepath.Join(tmpDir, "history.db"))
@@ -210,3 +528,116 @@ func TestManager_JsonlPathResolvesToDB(t *testing.T) {
 		t.Fatalf("expected sqlite db at %s: %v", dbPath, err)
 	}
 }
+
+func TestManager_ImportLegacyJSONL(t *testing.T) {
+	tmpDir := t.TempDir()
+	legacy := `{"guid":"a","kind":"article","title":"Old A","is_read":true}
+not json
+{"guid":"","title":"no guid"}
+
+{"guid":"b","title":"Old B"}
+{"guid":"a","kind":"article","title":"Newer A","is_bookmarked":true}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "history.jsonl"), []byte(legacy), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	m := NewManager(filepath.Join(tmpDir, "history.db"))
+
+	count, err := m.ImportLegacyJSONL()
+	if err != nil {
+		t.Fatalf("ImportLegacyJSONL failed: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("imported %d items, want 2", count)
+	}
+	a, err := m.LoadByGUID("a")
+	if err != nil || a == nil {
+		t.Fatalf("LoadByGUID(a) = %v, %v", a, err)
+	}
+	if a.Title != "Newer A" || !a.IsBookmarked || a.IsRead {
+		t.Fatalf("later line should win, got %#v", a)
+	}
+	b, err := m.LoadByGUID("b")
+	if err != nil || b == nil || b.Kind != reading.ArticleKind {
+		t.Fatalf("LoadByGUID(b) = %#v, %v; want article kind", b, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "history.jsonl.imported")); err != nil {
+		t.Fatalf("legacy file should be renamed after the import: %v", err)
+	}
+
+	// Clearing history must not bring the legacy items back.
+	if err := m.ClearAll(); err != nil {
+		t.Fatalf("ClearAll failed: %v", err)
+	}
+	count, err = m.ImportLegacyJSONL()
+	if err != nil || count != 0 {
+		t.Fatalf("import after clearing = %d, %v; want 0, nil", count, err)
+	}
+}
+
+func TestManager_ImportLegacyJSONLSkipsWhenMissingOrPopulated(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonlPath := filepath.Join(tmpDir, "history.jsonl")
+	m := NewManager(jsonlPath)
+
+	if count, err := m.ImportLegacyJSONL(); err != nil || count != 0 {
+		t.Fatalf("import without legacy file = %d, %v; want 0, nil", count, err)
+	}
+
+	if err := m.Upsert([]*reading.HistoryItem{{GUID: "existing", Kind: reading.ArticleKind}}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if err := os.WriteFile(jsonlPath, []byte(`{"guid":"old"}`+"\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if count, err := m.ImportLegacyJSONL(); err != nil || count != 0 {
+		t.Fatalf("import into populated db = %d, %v; want 0, nil", count, err)
+	}
+	if item, _ := m.LoadByGUID("old"); item != nil {
+		t.Fatalf("legacy item should not be imported into a populated db, got %#v", item)
+	}
+}
+
+func TestManager_ImportJSONLMergesIntoPopulatedDB(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewManager(filepath.Join(tmpDir, "history.db"))
+	if err := m.Upsert([]*reading.HistoryItem{{GUID: "existing", Kind: reading.ArticleKind}}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	oldPath := filepath.Join(tmpDir, "moved", "old.jsonl")
+	if err := os.MkdirAll(filepath.Dir(oldPath), 0o750); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	old := `{"guid":"x","title":"X","feed_url":"https://example.com/feed","is_read":true}
+{"guid":
+{"title":"missing guid"}
+{"guid":"y","title":"Y"}
+`
+	if err := os.WriteFile(oldPath, []byte(old), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	imported, skipped, err := m.ImportJSONL(oldPath)
+	if err != nil {
+		t.Fatalf("ImportJSONL failed: %v", err)
+	}
+	if imported != 2 || skipped != 2 {
+		t.Fatalf("ImportJSONL = %d imported, %d skipped; want 2, 2", imported, skipped)
+	}
+	items, err := m.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if len(items) != 3 || items["existing"] == nil {
+		t.Fatalf("import should merge with existing rows, got %d items", len(items))
+	}
+	if x := items["x"]; x == nil || !x.IsRead || x.FeedURL != "https://example.com/feed" {
+		t.Fatalf("legacy fields not mapped, got %#v", x)
+	}
+
+	if _, _, err := m.ImportJSONL(filepath.Join(tmpDir, "missing.jsonl")); err == nil {
+		t.Fatal("ImportJSONL should fail for a missing file")
+	}
+}
@@ -0,0 +1,144 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+)
+
+// legacyFileName is the JSONL history file used before the SQLite store.
+const legacyFileName = "history.jsonl"
+
+// importedSuffix is appended to a legacy JSONL file once it was imported,
+// so it isn't imported again after history is cleared.
+const importedSuffix = ".imported"
+
+// maxLegacyLineSize bounds one JSONL record; article bodies can be large.
+const maxLegacyLineSize = 16 << 20
+
+// resolveLegacyPath returns the JSONL history file that predates the
+// database at path: the configured path itself when it names a .jsonl file,
+// otherwise history.jsonl next to the database.
+func resolveLegacyPath(path string) string {
+	trimmed := strings.TrimSpace(path)
+	if trimmed == "" {
+		return ""
+	}
+	if strings.EqualFold(filepath.Ext(trimmed), ".jsonl") {
+		return trimmed
+	}
+	return filepath.Join(filepath.Dir(trimmed), legacyFileName)
+}
+
+// ImportLegacyJSONL copies a legacy JSONL history into the database, once:
+// it does nothing when the legacy file is missing or the database already
+// has items, and renames the file with an .imported suffix afterwards. Later
+// lines win for repeated GUIDs, and malformed lines are skipped. It returns
+// how many items were imported, even when the rename fails.
+func (m *Manager) ImportLegacyJSONL() (int, error) {
+	if m.legacyPath == "" {
+		return 0, nil
+	}
+	f, err := os.Open(m.legacyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	empty, err := m.isEmpty()
+	if err != nil || !empty {
+		return 0, err
+	}
+
+	items, _, err := parseLegacyJSONL(f)
+	if err != nil {
+		return 0, err
+	}
+	if err := m.Upsert(items); err != nil {
+		return 0, err
+	}
+	m.optimizeAfter(int64(len(items)))
+	_ = f.Close()
+	if err := os.Rename(m.legacyPath, m.legacyPath+importedSuffix); err != nil {
+		return len(items), fmt.Errorf("mark legacy history imported: %w", err)
+	}
+	return len(items), nil
+}
+
+// ImportJSONL upserts every valid item of a legacy JSONL history file into
+// the database, whether or not it already has items. Each line is validated
+// on its own; malformed lines and lines without a GUID are counted as
+// skipped.
+func (m *Manager) ImportJSONL(path string) (imported, skipped int, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	items, skipped, err := parseLegacyJSONL(f)
+	if err != nil {
+		return 0, skipped, err
+	}
+	if err := m.Upsert(items); err != nil {
+		return 0, skipped, err
+	}
+	m.optimizeAfter(int64(len(items)))
+	return len(items), skipped, nil
+}
+
+func (m *Manager) isEmpty() (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	db, err := m.dbConn()
+	if err != nil {
+		return false, err
+	}
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM history_items").Scan(&count); err != nil {
+		return false, err
+	}
+	return count == 0, nil
+}
+
+func parseLegacyJSONL(f *os.File) ([]*reading.HistoryItem, int, error) {
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLegacyLineSize)
+
+	byGUID := make(map[string]int)
+	var items []*reading.HistoryItem
+	skipped := 0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var item reading.HistoryItem
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			skipped++
+			continue
+		}
+		item.GUID = strings.TrimSpace(item.GUID)
+		if item.GUID == "" {
+			skipped++
+			continue
+		}
+		if idx, ok := byGUID[item.GUID]; ok {
+			items[idx] = &item
+			continue
+		}
+		byGUID[item.GUID] = len(items)
+		items = append(items, &item)
+	}
+	return items, skipped, scanner.Err()
+}
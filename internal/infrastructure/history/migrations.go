@@ -0,0 +1,182 @@
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+)
+
+// migration is one ordered schema change applied on top of the base table.
+// Versions must be unique and increasing; apply must be idempotent so a
+// partially upgraded database can be migrated again safely.
+type migration struct {
+	version int
+	name    string
+	apply   func(tx *sql.Tx) error
+}
+
+// migrations lists schema changes in the order they are applied.
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "add kind, AI and digest columns",
+		apply: func(tx *sql.Tx) error {
+			columns := []struct{ name, definition string }{
+				{"kind", "TEXT NOT NULL DEFAULT ''"},
+				{"ai_summary", "TEXT"},
+				{"ai_tags", "TEXT"},
+				{"ai_updated_at", "TEXT"},
+				{"digest_date", "TEXT"},
+				{"related_guids", "TEXT"},
+			}
+			for _, column := range columns {
+				if err := addColumnIfMissing(tx, "history_items", column.name, column.definition); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		version: 2,
+		name:    "backfill empty kind as article",
+		apply: func(tx *sql.Tx) error {
+			_, err := tx.Exec(
+				"UPDATE history_items SET kind = ? WHERE kind IS NULL OR TRIM(kind) = ''",
+				reading.ArticleKind,
+			)
+			return err
+		},
+	},
+	{
+		version: 3,
+		name:    "add feed categories column",
+		apply: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, "history_items", "feed_categories", "TEXT")
+		},
+	},
+	{
+		version: 4,
+		name:    "add related titles column",
+		apply: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, "history_items", "related_titles", "TEXT")
+		},
+	},
+	{
+		version: 5,
+		name:    "add open count column",
+		apply: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, "history_items", "open_count", "INTEGER NOT NULL DEFAULT 0")
+		},
+	},
+	{
+		version: 6,
+		name:    "add snoozed until column",
+		apply: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, "history_items", "snoozed_until", "TEXT")
+		},
+	},
+	{
+		version: 7,
+		name:    "add dismissed column",
+		apply: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, "history_items", "is_dismissed", "INTEGER NOT NULL DEFAULT 0")
+		},
+	},
+	{
+		version: 8,
+		name:    "add scroll offset column",
+		apply: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, "history_items", "scroll_offset", "INTEGER NOT NULL DEFAULT 0")
+		},
+	},
+}
+
+func runMigrations(db *sql.DB, steps []migration) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	);`); err != nil {
+		return err
+	}
+
+	current, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+	for _, step := range steps {
+		if step.version <= current {
+			continue
+		}
+		if err := applyMigration(db, step); err != nil {
+			return fmt.Errorf("history migration %d (%s): %w", step.version, step.name, err)
+		}
+		current = step.version
+	}
+	return nil
+}
+
+func applyMigration(db *sql.DB, step migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := step.apply(tx); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(
+		"INSERT INTO schema_version (version, applied_at) VALUES (?, ?)",
+		step.version,
+		timeToText(time.Now()),
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func schemaVersion(db *sql.DB) (int, error) {
+	var version int
+	err := db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version)
+	return version, err
+}
+
+func addColumnIfMissing(tx *sql.Tx, table, column, definition string) error {
+	rows, err := tx.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	exists := false
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			columnType string
+			notNull    int
+			defaultVal sql.NullString
+			primaryKey int
+		)
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultVal, &primaryKey); err != nil {
+			return err
+		}
+		if strings.EqualFold(name, column) {
+			exists = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	_ = rows.Close()
+	if exists {
+		return nil
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
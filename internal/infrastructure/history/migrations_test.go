@@ -0,0 +1,156 @@
+package history
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+)
+
+func TestInitDB_MigratesV0Database(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+
+	legacy, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("open legacy db: %v", err)
+	}
+	if _, err := legacy.Exec(`CREATE TABLE history_items (
+		guid TEXT PRIMARY KEY,
+		title TEXT,
+		description TEXT,
+		content TEXT,
+		link TEXT,
+		published TEXT,
+		date TEXT,
+		feed_title TEXT,
+		feed_url TEXT,
+		is_read INTEGER NOT NULL DEFAULT 0,
+		saved_at TEXT,
+		is_bookmarked INTEGER NOT NULL DEFAULT 0
+	);`); err != nil {
+		t.Fatalf("create legacy table: %v", err)
+	}
+	if _, err := legacy.Exec(`INSERT INTO history_items (guid, title, feed_url, is_read) VALUES ('old', 'Old item', 'feed1', 1)`); err != nil {
+		t.Fatalf("insert legacy row: %v", err)
+	}
+	_ = legacy.Close()
+
+	m := NewManager(path)
+	items, err := m.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata after migration failed: %v", err)
+	}
+	old := items["old"]
+	if old == nil || old.Title != "Old item" || !old.IsRead {
+		t.Fatalf("legacy row not preserved: %#v", old)
+	}
+	if old.Kind != reading.ArticleKind {
+		t.Fatalf("legacy row kind = %q, want %q", old.Kind, reading.ArticleKind)
+	}
+	if err := m.SetInsight("old", "summary", []string{"go"}, old.SavedAt); err != nil {
+		t.Fatalf("SetInsight on migrated row failed: %v", err)
+	}
+	if err := m.Upsert([]*reading.HistoryItem{{GUID: "new", Kind: reading.ArticleKind, RelatedGUIDs: []string{"old"}}}); err != nil {
+		t.Fatalf("Upsert after migration failed: %v", err)
+	}
+
+	db, err := m.dbConn()
+	if err != nil {
+		t.Fatalf("dbConn failed: %v", err)
+	}
+	version, err := schemaVersion(db)
+	if err != nil {
+		t.Fatalf("schemaVersion failed: %v", err)
+	}
+	if want := migrations[len(migrations)-1].version; version != want {
+		t.Fatalf("schema version = %d, want %d", version, want)
+	}
+
+	// Re-running the full migration list must be a no-op.
+	if err := runMigrations(db, migrations); err != nil {
+		t.Fatalf("re-running migrations failed: %v", err)
+	}
+}
+
+func TestRunMigrations_IdempotentSteps(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	if err := initDB(db); err != nil {
+		t.Fatalf("initDB failed: %v", err)
+	}
+
+	// Forget recorded versions; steps must tolerate already-applied changes.
+	if _, err := db.Exec("DELETE FROM schema_version"); err != nil {
+		t.Fatalf("reset schema_version: %v", err)
+	}
+	if err := runMigrations(db, migrations); err != nil {
+		t.Fatalf("runMigrations on current schema failed: %v", err)
+	}
+	version, err := schemaVersion(db)
+	if err != nil {
+		t.Fatalf("schemaVersion failed: %v", err)
+	}
+	if want := migrations[len(migrations)-1].version; version != want {
+		t.Fatalf("schema version = %d, want %d", version, want)
+	}
+}
+
+func TestInitDB_BackfillsEmptyKind(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	m := NewManager(path)
+	now := time.Date(2026, 2, 14, 9, 0, 0, 0, time.Local)
+	if err := m.Upsert([]*reading.HistoryItem{
+		{GUID: "a1", FeedURL: "feed1", Date: now, SavedAt: now, Content: "body"},
+		{GUID: "a2", FeedURL: "feed1", Date: now, SavedAt: now, Content: "body"},
+	}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if err := m.ReplaceDigestItemsByDate("2026-02-14", []*reading.HistoryItem{
+		{GUID: "d1", Title: "Digest", Date: now, SavedAt: now},
+	}); err != nil {
+		t.Fatalf("ReplaceDigestItemsByDate failed: %v", err)
+	}
+
+	// Simulate rows written before kind was populated.
+	db, err := m.dbConn()
+	if err != nil {
+		t.Fatalf("dbConn failed: %v", err)
+	}
+	if _, err := db.Exec("UPDATE history_items SET kind = '' WHERE guid IN ('a1', 'a2')"); err != nil {
+		t.Fatalf("blank kind: %v", err)
+	}
+	if _, err := db.Exec("DELETE FROM schema_version WHERE version >= 2"); err != nil {
+		t.Fatalf("reset schema_version: %v", err)
+	}
+	if err := runMigrations(db, migrations); err != nil {
+		t.Fatalf("runMigrations failed: %v", err)
+	}
+
+	var blank int
+	if err := db.QueryRow("SELECT COUNT(*) FROM history_items WHERE kind = ''").Scan(&blank); err != nil {
+		t.Fatalf("count blank kinds: %v", err)
+	}
+	if blank != 0 {
+		t.Fatalf("rows with blank kind = %d, want 0", blank)
+	}
+
+	articles, err := m.LoadTodayArticles("2026-02-14", []string{"feed1"}, 60, time.Local)
+	if err != nil {
+		t.Fatalf("LoadTodayArticles failed: %v", err)
+	}
+	if len(articles) != 2 {
+		t.Fatalf("LoadTodayArticles len = %d, want 2", len(articles))
+	}
+	var digests int
+	if err := db.QueryRow("SELECT COUNT(*) FROM history_items WHERE kind = ?", reading.NewsDigestKind).Scan(&digests); err != nil {
+		t.Fatalf("count digests: %v", err)
+	}
+	if digests != 1 {
+		t.Fatalf("digest rows = %d, want 1", digests)
+	}
+}
@@ -0,0 +1,60 @@
+package history
+
+import "database/sql"
+
+// autoOptimizeRows is how many rows an import must touch before the
+// database is optimized right after it. Clearing leaves optimizing to the
+// caller, so it can run in the background.
+const autoOptimizeRows = 1000
+
+// Optimize refreshes the query planner statistics, rebuilds the indexes and
+// compacts the database file. It returns the database size in bytes before
+// and after.
+func (m *Manager) Optimize() (before, after int64, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	db, err := m.dbConn()
+	if err != nil {
+		return 0, 0, err
+	}
+	return optimize(db)
+}
+
+// optimizeAfter runs Optimize when rows reached autoOptimizeRows. It is best
+// effort: the change it follows already succeeded, so failures are dropped.
+func (m *Manager) optimizeAfter(rows int64) {
+	if rows < autoOptimizeRows {
+		return
+	}
+	_, _, _ = m.Optimize()
+}
+
+func optimize(db *sql.DB) (before, after int64, err error) {
+	if before, err = databaseSize(db); err != nil {
+		return 0, 0, err
+	}
+	// VACUUM cannot run inside a transaction, so each statement runs alone.
+	for _, stmt := range []string{"ANALYZE", "REINDEX", "VACUUM"} {
+		if _, err := db.Exec(stmt); err != nil {
+			return before, 0, err
+		}
+	}
+	if after, err = databaseSize(db); err != nil {
+		return before, 0, err
+	}
+	return before, after, nil
+}
+
+// databaseSize reports the size of the database pages in bytes. Unlike the
+// file size it does not lag behind a VACUUM waiting in the WAL.
+func databaseSize(db *sql.DB) (int64, error) {
+	var pages, pageSize int64
+	if err := db.QueryRow("PRAGMA page_count").Scan(&pages); err != nil {
+		return 0, err
+	}
+	if err := db.QueryRow("PRAGMA page_size").Scan(&pageSize); err != nil {
+		return 0, err
+	}
+	return pages * pageSize, nil
+}
@@ -0,0 +1,71 @@
+package history
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+)
+
+func seedItems(t *testing.T, m *Manager, n int) {
+	t.Helper()
+	now := time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC)
+	body := strings.Repeat("body ", 200)
+	items := make([]*reading.HistoryItem, n)
+	for i := range items {
+		items[i] = &reading.HistoryItem{GUID: fmt.Sprintf("id%d", i), Kind: reading.ArticleKind, Content: body, SavedAt: now}
+	}
+	if err := m.Upsert(items); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+}
+
+func currentSize(t *testing.T, m *Manager) int64 {
+	t.Helper()
+	db, err := m.dbConn()
+	if err != nil {
+		t.Fatalf("dbConn failed: %v", err)
+	}
+	size, err := databaseSize(db)
+	if err != nil {
+		t.Fatalf("databaseSize failed: %v", err)
+	}
+	return size
+}
+
+func TestManager_Optimize(t *testing.T) {
+	m := NewManager(filepath.Join(t.TempDir(), "history.db"))
+	seedItems(t, m, 200)
+	if _, err := m.deleteItems(clearAllWhere); err != nil {
+		t.Fatalf("deleteItems failed: %v", err)
+	}
+
+	before, after, err := m.Optimize()
+	if err != nil {
+		t.Fatalf("Optimize failed: %v", err)
+	}
+	if before <= 0 || after >= before {
+		t.Fatalf("Optimize sizes = %d -> %d, want the freed pages reclaimed", before, after)
+	}
+	if err := m.Upsert([]*reading.HistoryItem{{GUID: "again", Kind: reading.ArticleKind}}); err != nil {
+		t.Fatalf("Upsert after Optimize failed: %v", err)
+	}
+}
+
+func TestManager_ClearLeavesOptimizingToTheCaller(t *testing.T) {
+	m := NewManager(filepath.Join(t.TempDir(), "history.db"))
+	seedItems(t, m, autoOptimizeRows)
+	full := currentSize(t, m)
+	if err := m.ClearAll(); err != nil {
+		t.Fatalf("ClearAll failed: %v", err)
+	}
+	if got := currentSize(t, m); got != full {
+		t.Fatalf("size after a large clear = %d, want %d until Optimize runs", got, full)
+	}
+	if _, after, err := m.Optimize(); err != nil || after >= full {
+		t.Fatalf("Optimize after clear = %d, %v; want below %d", after, err, full)
+	}
+}
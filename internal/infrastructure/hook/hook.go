@@ -0,0 +1,143 @@
+// Package hook runs user-configured commands when reazy events happen.
+package hook
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tesso57/reazy/internal/application/usecase"
+)
+
+const (
+	defaultMaxPerMinute = 10
+	defaultTimeout      = 30 * time.Second
+	rateWindow          = time.Minute
+)
+
+// Config controls how the new-article command is run.
+type Config struct {
+	// Command is a shell command line; empty disables the hook.
+	Command string
+	// MaxPerMinute bounds how many times Command runs in any minute;
+	// articles beyond it are skipped. Zero or less uses 10.
+	MaxPerMinute int
+	// Timeout stops a run that takes longer. Zero uses 30 seconds.
+	Timeout time.Duration
+}
+
+// Runner runs command through the shell with env added to the environment
+// and args as its positional parameters.
+type Runner func(ctx context.Context, command string, env, args []string) error
+
+// NewItemCommand implements usecase.NewItemHook by running a shell command
+// once per new article. The command runs in the background with the article
+// in REAZY_* environment variables, and its title and link as $1 and $2.
+type NewItemCommand struct {
+	config Config
+	run    Runner
+	now    func() time.Time
+
+	mu     sync.Mutex
+	recent []time.Time
+	wg     sync.WaitGroup
+}
+
+// NewNewItemCommand creates a hook running cfg.Command, or returns nil when
+// no command is configured.
+func NewNewItemCommand(cfg Config) *NewItemCommand {
+	return NewNewItemCommandWithRunner(cfg, defaultRunner, time.Now)
+}
+
+// NewNewItemCommandWithRunner creates a hook with a custom runner and clock
+// for tests.
+func NewNewItemCommandWithRunner(cfg Config, runner Runner, now func() time.Time) *NewItemCommand {
+	cfg.Command = strings.TrimSpace(cfg.Command)
+	if cfg.Command == "" {
+		return nil
+	}
+	if cfg.MaxPerMinute <= 0 {
+		cfg.MaxPerMinute = defaultMaxPerMinute
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if runner == nil {
+		runner = defaultRunner
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &NewItemCommand{config: cfg, run: runner, now: now}
+}
+
+// NotifyNewItems starts the command for each event the rate limit allows and
+// returns without waiting. Failed runs are ignored.
+func (h *NewItemCommand) NotifyNewItems(events []usecase.NewItemEvent) {
+	if h == nil {
+		return
+	}
+	for _, event := range events {
+		if !h.allow() {
+			return
+		}
+		h.wg.Go(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), h.config.Timeout)
+			defer cancel()
+			_ = h.run(ctx, h.config.Command, eventEnv(event), []string{event.Title, event.Link})
+		})
+	}
+}
+
+// Wait blocks until every started command has finished.
+func (h *NewItemCommand) Wait() {
+	if h == nil {
+		return
+	}
+	h.wg.Wait()
+}
+
+// allow reports whether another run fits in the rate limit and records it.
+func (h *NewItemCommand) allow() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.now()
+	recent := h.recent[:0]
+	for _, started := range h.recent {
+		if now.Sub(started) < rateWindow {
+			recent = append(recent, started)
+		}
+	}
+	h.recent = recent
+	if len(h.recent) >= h.config.MaxPerMinute {
+		return false
+	}
+	h.recent = append(h.recent, now)
+	return true
+}
+
+func eventEnv(event usecase.NewItemEvent) []string {
+	return []string{
+		"REAZY_GUID=" + event.GUID,
+		"REAZY_TITLE=" + event.Title,
+		"REAZY_LINK=" + event.Link,
+		"REAZY_PUBLISHED=" + event.Published,
+		"REAZY_FEED_TITLE=" + event.FeedTitle,
+		"REAZY_FEED_URL=" + event.FeedURL,
+	}
+}
+
+func defaultRunner(ctx context.Context, command string, env, args []string) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command) //nolint:gosec
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", append([]string{"-c", command, "reazy"}, args...)...) //nolint:gosec
+	}
+	cmd.Env = append(os.Environ(), env...)
+	return cmd.Run()
+}
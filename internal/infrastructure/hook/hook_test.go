@@ -0,0 +1,80 @@
+package hook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tesso57/reazy/internal/application/usecase"
+)
+
+func TestNewNewItemCommandWithoutCommand(t *testing.T) {
+	if h := NewNewItemCommand(Config{Command: "  "}); h != nil {
+		t.Fatalf("NewNewItemCommand() = %v, want nil without a command", h)
+	}
+	var h *NewItemCommand
+	h.NotifyNewItems([]usecase.NewItemEvent{{GUID: "a"}})
+	h.Wait()
+}
+
+func TestNotifyNewItemsPassesEventAndLimitsRate(t *testing.T) {
+	var mu sync.Mutex
+	var calls [][]string
+	runner := func(_ context.Context, command string, env, args []string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if command != "notify" {
+			t.Errorf("command = %q", command)
+		}
+		calls = append(calls, append(env, args...))
+		return nil
+	}
+	now := time.Unix(1000, 0)
+	h := NewNewItemCommandWithRunner(Config{Command: "notify", MaxPerMinute: 2}, runner, func() time.Time { return now })
+
+	h.NotifyNewItems([]usecase.NewItemEvent{
+		{GUID: "a", Title: "First", Link: "https://example.com/a", FeedURL: "https://example.com/feed"},
+		{GUID: "b", Title: "Second"},
+		{GUID: "c", Title: "Third"},
+	})
+	h.Wait()
+	if len(calls) != 2 {
+		t.Fatalf("runs = %d, want 2 within the rate limit", len(calls))
+	}
+	first := calls[slices.IndexFunc(calls, func(call []string) bool { return slices.Contains(call, "REAZY_GUID=a") })]
+	for _, want := range []string{"REAZY_TITLE=First", "REAZY_LINK=https://example.com/a", "REAZY_FEED_URL=https://example.com/feed", "First", "https://example.com/a"} {
+		if !slices.Contains(first, want) {
+			t.Errorf("run is missing %q: %v", want, first)
+		}
+	}
+
+	now = now.Add(rateWindow)
+	h.NotifyNewItems([]usecase.NewItemEvent{{GUID: "d", Title: "Fourth"}})
+	h.Wait()
+	if len(calls) != 3 {
+		t.Fatalf("runs = %d, want the limit to reset after a minute", len(calls))
+	}
+}
+
+func TestDefaultRunnerRunsShellCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+	h := NewNewItemCommand(Config{Command: `printf '%s|%s|%s' "$REAZY_FEED_TITLE" "$1" "$2" > ` + out})
+	h.NotifyNewItems([]usecase.NewItemEvent{{Title: "Go 1.26", Link: "https://go.dev/blog", FeedTitle: "Go Blog"}})
+	h.Wait()
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("command did not run: %v", err)
+	}
+	if got, want := string(data), "Go Blog|Go 1.26|https://go.dev/blog"; got != want {
+		t.Fatalf("command output = %q, want %q", got, want)
+	}
+}
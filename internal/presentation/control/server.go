@@ -0,0 +1,348 @@
+// Package control serves a small local HTTP API so scripts and status bars
+// can read unread counts, trigger a refresh, and mark or bookmark articles.
+package control
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/domain/reading"
+)
+
+// HistorySource runs functions on the history the control API reads and
+// changes, one call at a time. A running TUI provides its own history this
+// way, so both always show the same state.
+type HistorySource interface {
+	// ReadHistory runs fn, which only reads history.
+	ReadHistory(ctx context.Context, fn func(*reading.History) error) error
+	// UpdateHistory runs fn, which may change history, and then lets the
+	// owner show the change.
+	UpdateHistory(ctx context.Context, fn func(*reading.History) error) error
+}
+
+// Server answers control API requests with the application services.
+type Server struct {
+	Subscriptions *usecase.SubscriptionService
+	Reading       *usecase.ReadingService
+	// History, when set, is where requests read and change history.
+	// Without it each request loads history from persistence, and requests
+	// are handled one at a time.
+	History HistorySource
+	// Token, when set, must be sent by every request as
+	// "Authorization: Bearer <token>". Serving over TCP requires it.
+	Token string
+
+	mu sync.Mutex
+}
+
+// statusError carries the HTTP status an error should be reported with.
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e statusError) Error() string { return e.err.Error() }
+func (e statusError) Unwrap() error { return e.err }
+
+// NewServer constructs a Server.
+func NewServer(subscriptions *usecase.SubscriptionService, readingSvc *usecase.ReadingService) *Server {
+	return &Server{Subscriptions: subscriptions, Reading: readingSvc}
+}
+
+// FeedUnread is the unread count of one subscribed feed.
+type FeedUnread struct {
+	URL    string `json:"url"`
+	Unread int    `json:"unread"`
+}
+
+// UnreadResponse is the body of GET /unread.
+type UnreadResponse struct {
+	Total int          `json:"total"`
+	Feeds []FeedUnread `json:"feeds"`
+}
+
+// RefreshResponse is the body of POST /refresh.
+type RefreshResponse struct {
+	Requested int `json:"requested"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	TimedOut  int `json:"timed_out"`
+	New       int `json:"new"`
+}
+
+// ArticleResponse is the body of the article endpoints.
+type ArticleResponse struct {
+	GUID       string `json:"guid"`
+	Read       bool   `json:"read"`
+	Bookmarked bool   `json:"bookmarked"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler returns the HTTP handler serving the control API:
+//
+//	GET  /unread                        unread counts per subscribed feed
+//	POST /refresh                       fetch every feed and save new articles
+//	POST /articles/{guid}/read          mark an article read
+//	POST /articles/{guid}/bookmark      toggle an article's bookmark
+//
+// Requests sent by a browser (those with an Origin header) are rejected, and
+// over TCP so are requests whose Host is not a loopback name, so web pages
+// cannot reach the API through the browser or DNS rebinding. When Token is
+// set, requests without it get 401.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /unread", s.handleUnread)
+	mux.HandleFunc("POST /refresh", s.handleRefresh)
+	mux.HandleFunc("POST /articles/{guid}/read", s.handleMarkRead)
+	mux.HandleFunc("POST /articles/{guid}/bookmark", s.handleToggleBookmark)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Origin") != "" {
+			writeError(w, http.StatusForbidden, errors.New("cross-origin requests are not allowed"))
+			return
+		}
+		if overTCP(r) && !isLoopbackHost(r.Host) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("host %q is not allowed", r.Host))
+			return
+		}
+		if !s.authorized(r) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("missing or wrong control token"))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// authorized reports whether r carries the configured token, or whether no
+// token is configured.
+func (s *Server) authorized(r *http.Request) bool {
+	if s.Token == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) == 1
+}
+
+// overTCP reports whether r arrived on a TCP listener rather than a unix
+// socket, whose clients may send any Host.
+func overTCP(r *http.Request) bool {
+	_, ok := r.Context().Value(http.LocalAddrContextKey).(*net.TCPAddr)
+	return ok
+}
+
+// isLoopbackHost reports whether a Host header names localhost or a loopback
+// address.
+func isLoopbackHost(host string) bool {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Listen opens the listener for a control_socket value (see
+// settings.ParseControlSocket). A leading "~/" in a socket path is expanded
+// to the home directory, a stale socket file is replaced, and the new socket
+// is only accessible to the current user.
+func Listen(value string) (net.Listener, error) {
+	network, address, err := settings.ParseControlSocket(value)
+	if err != nil {
+		return nil, err
+	}
+	if network == "" {
+		return nil, errors.New("control socket is not configured")
+	}
+	if network == "tcp" {
+		return net.Listen(network, address)
+	}
+	if rest, ok := strings.CutPrefix(address, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			address = filepath.Join(home, rest)
+		}
+	}
+	if info, err := os.Lstat(address); err == nil && info.Mode()&fs.ModeSocket != 0 {
+		if err := os.Remove(address); err != nil {
+			return nil, err
+		}
+	}
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(address, 0o600); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// Serve handles requests on listener until ctx is canceled. It refuses a
+// TCP listener while no Token is set, since any local user could reach it.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	if _, ok := listener.Addr().(*net.TCPAddr); ok && s.Token == "" {
+		_ = listener.Close()
+		return errors.New("a control token is required to serve on a TCP address")
+	}
+	server := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// withHistory runs fn on History, or on history freshly loaded from
+// persistence when no History is set. update tells whether fn may change it.
+func (s *Server) withHistory(ctx context.Context, update bool, fn func(*reading.History) error) error {
+	if s.History != nil && update {
+		return s.History.UpdateHistory(ctx, fn)
+	}
+	if s.History != nil {
+		return s.History.ReadHistory(ctx, fn)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	history, err := s.Reading.LoadHistoryMetadata()
+	if err != nil {
+		return err
+	}
+	return fn(history)
+}
+
+func (s *Server) handleUnread(w http.ResponseWriter, r *http.Request) {
+	var response UnreadResponse
+	err := s.withHistory(r.Context(), false, func(history *reading.History) error {
+		feeds, err := s.Subscriptions.List()
+		if err != nil {
+			return err
+		}
+		counts := history.UnreadCountByFeed()
+		response = UnreadResponse{Feeds: make([]FeedUnread, 0, len(feeds))}
+		for _, feed := range feeds {
+			response.Feeds = append(response.Feeds, FeedUnread{URL: feed, Unread: counts[feed]})
+			response.Total += counts[feed]
+		}
+		return nil
+	})
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleRefresh fetches every feed outside withHistory, so a TUI sharing
+// its history stays responsive, and only merges the result inside it.
+func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	var feeds []string
+	err := s.withHistory(r.Context(), false, func(*reading.History) error {
+		var err error
+		feeds, err = s.Subscriptions.List()
+		return err
+	})
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	feed, report, err := s.Reading.FetchFeed(r.Context(), reading.AllFeedsURL, feeds)
+	if feed == nil && err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	var added int
+	err = s.withHistory(r.Context(), true, func(history *reading.History) error {
+		merged, err := s.Reading.MergeHistory(history, feed)
+		if err != nil {
+			return fmt.Errorf("save articles: %w", err)
+		}
+		added = len(merged.Added)
+		return nil
+	})
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, RefreshResponse{
+		Requested: report.Requested,
+		Succeeded: report.Succeeded,
+		Failed:    report.Failed,
+		TimedOut:  report.TimedOut,
+		New:       added,
+	})
+}
+
+func (s *Server) handleMarkRead(w http.ResponseWriter, r *http.Request) {
+	s.updateArticle(w, r, s.Reading.MarkRead)
+}
+
+func (s *Server) handleToggleBookmark(w http.ResponseWriter, r *http.Request) {
+	s.updateArticle(w, r, s.Reading.ToggleBookmark)
+}
+
+func (s *Server) updateArticle(w http.ResponseWriter, r *http.Request, update func(*reading.History, string) error) {
+	guid := r.PathValue("guid")
+	var response ArticleResponse
+	err := s.withHistory(r.Context(), true, func(history *reading.History) error {
+		if item, ok := history.Item(guid); !ok || item == nil || item.Kind == reading.NewsDigestKind {
+			return statusError{status: http.StatusNotFound, err: fmt.Errorf("article %q not found", guid)}
+		}
+		if err := update(history, guid); err != nil {
+			return err
+		}
+		item, _ := history.Item(guid)
+		response = ArticleResponse{GUID: guid, Read: item.IsRead, Bookmarked: item.IsBookmarked}
+		return nil
+	})
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// errorStatus returns the status carried by err, or 500.
+func errorStatus(err error) int {
+	var withStatus statusError
+	if errors.As(err, &withStatus) {
+		return withStatus.status
+	}
+	return http.StatusInternalServerError
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,338 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/domain/reading"
+)
+
+type stubSubscriptionRepo struct {
+	feeds []string
+}
+
+func (s *stubSubscriptionRepo) List() ([]string, error) { return s.feeds, nil }
+func (s *stubSubscriptionRepo) Add(url string) error {
+	s.feeds = append(s.feeds, url)
+	return nil
+}
+func (s *stubSubscriptionRepo) Remove(int) error { return nil }
+
+type stubHistoryRepo struct {
+	usecase.HistoryRepository
+	items map[string]*reading.HistoryItem
+}
+
+func (s *stubHistoryRepo) LoadMetadata() (map[string]*reading.HistoryItem, error) {
+	items := make(map[string]*reading.HistoryItem, len(s.items))
+	for guid, item := range s.items {
+		copied := *item
+		items[guid] = &copied
+	}
+	return items, nil
+}
+
+func (s *stubHistoryRepo) Upsert(items []*reading.HistoryItem) error {
+	for _, item := range items {
+		copied := *item
+		s.items[item.GUID] = &copied
+	}
+	return nil
+}
+
+func (s *stubHistoryRepo) SetRead(guid string, isRead bool) error {
+	s.items[guid].IsRead = isRead
+	return nil
+}
+
+func (s *stubHistoryRepo) SetBookmark(guid string, isBookmarked bool) error {
+	s.items[guid].IsBookmarked = isBookmarked
+	return nil
+}
+
+type stubFeedFetcher struct {
+	feed   *reading.Feed
+	report usecase.FeedFetchReport
+}
+
+func (s *stubFeedFetcher) Fetch(context.Context, string) (*reading.Feed, error) {
+	return s.feed, nil
+}
+
+func (s *stubFeedFetcher) FetchAll(context.Context, []string, usecase.FeedFetchOptions) (*reading.Feed, usecase.FeedFetchReport, error) {
+	return s.feed, s.report, nil
+}
+
+func newTestServer(feeds []string, items map[string]*reading.HistoryItem, fetcher *stubFeedFetcher) (*Server, *stubHistoryRepo) {
+	historyRepo := &stubHistoryRepo{items: items}
+	subs := usecase.NewSubscriptionService(&stubSubscriptionRepo{feeds: feeds})
+	readingSvc := usecase.NewReadingService(fetcher, historyRepo, func() time.Time {
+		return time.Date(2026, 2, 14, 9, 0, 0, 0, time.UTC)
+	})
+	return NewServer(subs, readingSvc), historyRepo
+}
+
+func doRequest(t *testing.T, handler http.Handler, method, path string, body any) int {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+	if body != nil {
+		if err := json.NewDecoder(recorder.Body).Decode(body); err != nil {
+			t.Fatalf("%s %s: decode response: %v", method, path, err)
+		}
+	}
+	return recorder.Code
+}
+
+func TestServer_Unread(t *testing.T) {
+	server, _ := newTestServer(
+		[]string{"http://example.com/a", "http://example.com/b"},
+		map[string]*reading.HistoryItem{
+			"a1": {GUID: "a1", FeedURL: "http://example.com/a", Kind: reading.ArticleKind},
+			"a2": {GUID: "a2", FeedURL: "http://example.com/a", Kind: reading.ArticleKind},
+			"b1": {GUID: "b1", FeedURL: "http://example.com/b", Kind: reading.ArticleKind, IsRead: true},
+			"x1": {GUID: "x1", FeedURL: "http://example.com/removed", Kind: reading.ArticleKind},
+			"d1": {GUID: "d1", FeedURL: reading.NewsURL, Kind: reading.NewsDigestKind},
+		},
+		&stubFeedFetcher{},
+	)
+
+	var got UnreadResponse
+	if code := doRequest(t, server.Handler(), http.MethodGet, "/unread", &got); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	want := UnreadResponse{Total: 2, Feeds: []FeedUnread{
+		{URL: "http://example.com/a", Unread: 2},
+		{URL: "http://example.com/b", Unread: 0},
+	}}
+	if got.Total != want.Total || len(got.Feeds) != 2 || got.Feeds[0] != want.Feeds[0] || got.Feeds[1] != want.Feeds[1] {
+		t.Fatalf("unread = %#v, want %#v", got, want)
+	}
+}
+
+func TestServer_RefreshSavesNewArticles(t *testing.T) {
+	server, historyRepo := newTestServer(
+		[]string{"http://example.com/a"},
+		map[string]*reading.HistoryItem{},
+		&stubFeedFetcher{
+			feed: &reading.Feed{Title: "All Feeds", URL: reading.AllFeedsURL, Items: []reading.Item{
+				{GUID: "new", Title: "New", FeedURL: "http://example.com/a"},
+			}},
+			report: usecase.FeedFetchReport{Requested: 1, Succeeded: 1},
+		},
+	)
+
+	var got RefreshResponse
+	if code := doRequest(t, server.Handler(), http.MethodPost, "/refresh", &got); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if got != (RefreshResponse{Requested: 1, Succeeded: 1, New: 1}) {
+		t.Fatalf("refresh = %#v", got)
+	}
+	if _, ok := historyRepo.items["new"]; !ok {
+		t.Fatal("refreshed article should be saved to history")
+	}
+}
+
+func TestServer_ArticleUpdates(t *testing.T) {
+	server, historyRepo := newTestServer(nil, map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", FeedURL: "http://example.com/a", Kind: reading.ArticleKind},
+	}, &stubFeedFetcher{})
+	handler := server.Handler()
+
+	var got ArticleResponse
+	if code := doRequest(t, handler, http.MethodPost, "/articles/a1/read", &got); code != http.StatusOK {
+		t.Fatalf("read status = %d", code)
+	}
+	if !got.Read || !historyRepo.items["a1"].IsRead {
+		t.Fatalf("article should be marked read: %#v", got)
+	}
+
+	if code := doRequest(t, handler, http.MethodPost, "/articles/a1/bookmark", &got); code != http.StatusOK || !got.Bookmarked {
+		t.Fatalf("bookmark status = %d, response = %#v", code, got)
+	}
+	if code := doRequest(t, handler, http.MethodPost, "/articles/a1/bookmark", &got); code != http.StatusOK || got.Bookmarked {
+		t.Fatalf("second bookmark should toggle off: status = %d, response = %#v", code, got)
+	}
+
+	if code := doRequest(t, handler, http.MethodPost, "/articles/missing/read", nil); code != http.StatusNotFound {
+		t.Fatalf("unknown article status = %d, want 404", code)
+	}
+	if code := doRequest(t, handler, http.MethodGet, "/articles/a1/read", nil); code != http.StatusMethodNotAllowed {
+		t.Fatalf("GET on a POST endpoint status = %d, want 405", code)
+	}
+}
+
+func TestListenAndServe_UnixSocket(t *testing.T) {
+	server, _ := newTestServer([]string{"http://example.com/a"}, map[string]*reading.HistoryItem{}, &stubFeedFetcher{})
+	socket := filepath.Join(t.TempDir(), "reazy.sock")
+	listener, err := Listen(socket)
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(ctx, listener) }()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	resp, err := client.Get("http://reazy/unread")
+	if err != nil {
+		t.Fatalf("GET /unread over the socket: %v", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	// The socket path can be listened on again after shutdown.
+	listener, err = Listen(socket)
+	if err != nil {
+		t.Fatalf("Listen() over a stale socket error = %v", err)
+	}
+	_ = listener.Close()
+}
+
+func TestListen_RejectsNonLoopbackAddress(t *testing.T) {
+	if _, err := Listen("0.0.0.0:7373"); err == nil {
+		t.Fatal("Listen() should reject non-loopback addresses")
+	}
+	if _, err := Listen(""); err == nil {
+		t.Fatal("Listen() should fail when no control socket is configured")
+	}
+}
+
+func TestServer_RejectsBrowserAndForeignHostRequests(t *testing.T) {
+	server, _ := newTestServer([]string{"http://example.com/a"}, map[string]*reading.HistoryItem{}, &stubFeedFetcher{})
+	server.Token = "secret"
+	listener, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(ctx, listener) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Fatalf("Serve() error = %v", err)
+		}
+	}()
+
+	addr := listener.Addr().String()
+	_, port, _ := net.SplitHostPort(addr)
+	for _, tc := range []struct {
+		name   string
+		host   string
+		origin string
+		token  string
+		want   int
+	}{
+		{name: "loopback address", host: addr, token: "secret", want: http.StatusOK},
+		{name: "localhost", host: "localhost:" + port, token: "secret", want: http.StatusOK},
+		{name: "browser origin", host: addr, origin: "https://evil.example", token: "secret", want: http.StatusForbidden},
+		{name: "rebound host", host: "evil.example:" + port, token: "secret", want: http.StatusForbidden},
+		{name: "missing token", host: addr, want: http.StatusUnauthorized},
+		{name: "wrong token", host: addr, token: "guess", want: http.StatusUnauthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "http://"+addr+"/unread", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Host = tc.host
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("GET /unread: %v", err)
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+			if resp.StatusCode != tc.want {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tc.want)
+			}
+		})
+	}
+}
+
+func TestServe_RequiresTokenOnTCP(t *testing.T) {
+	server, _ := newTestServer(nil, map[string]*reading.HistoryItem{}, &stubFeedFetcher{})
+	listener, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	if err := server.Serve(context.Background(), listener); err == nil {
+		t.Fatal("Serve() should refuse a TCP listener without a token")
+	}
+}
+
+// sharedHistory is a HistorySource holding one in-memory history, like a
+// running TUI.
+type sharedHistory struct {
+	history *reading.History
+	reads   int
+	updates int
+}
+
+func (s *sharedHistory) ReadHistory(_ context.Context, fn func(*reading.History) error) error {
+	s.reads++
+	return fn(s.history)
+}
+
+func (s *sharedHistory) UpdateHistory(_ context.Context, fn func(*reading.History) error) error {
+	s.updates++
+	return fn(s.history)
+}
+
+func TestServer_UsesSharedHistory(t *testing.T) {
+	server, historyRepo := newTestServer([]string{"http://example.com/a"}, map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", FeedURL: "http://example.com/a", Kind: reading.ArticleKind},
+	}, &stubFeedFetcher{})
+	// The shared history already knows a2, which persistence has not seen.
+	shared := &sharedHistory{history: reading.NewHistory(map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", FeedURL: "http://example.com/a", Kind: reading.ArticleKind},
+		"a2": {GUID: "a2", FeedURL: "http://example.com/a", Kind: reading.ArticleKind},
+	})}
+	server.History = shared
+	handler := server.Handler()
+
+	var unread UnreadResponse
+	if code := doRequest(t, handler, http.MethodGet, "/unread", &unread); code != http.StatusOK || unread.Total != 2 {
+		t.Fatalf("status = %d, unread = %#v; want the shared history's 2 unread", code, unread)
+	}
+	var article ArticleResponse
+	if code := doRequest(t, handler, http.MethodPost, "/articles/a1/read", &article); code != http.StatusOK || !article.Read {
+		t.Fatalf("status = %d, article = %#v", code, article)
+	}
+	if item, _ := shared.history.Item("a1"); !item.IsRead {
+		t.Fatal("marking read should change the shared history")
+	}
+	if !historyRepo.items["a1"].IsRead {
+		t.Fatal("marking read should still be persisted")
+	}
+	if shared.reads != 1 || shared.updates != 1 {
+		t.Fatalf("reads = %d, updates = %d; want one of each", shared.reads, shared.updates)
+	}
+}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+func TestAskAIShowsAnswerInDetailView(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com"},
+		KeyMap: settings.KeyMapConfig{AskAI: "E", Back: "esc"},
+	}
+	m := newTestModelWithInsightGenerator(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{}, &stubInsightGenerator{
+		answer: "Rust adoption keeps growing.",
+	})
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = tm.(*Model)
+	m.state.Session = state.DetailView
+	m.state.ArticleList.SetItems([]list.Item{
+		&presenter.Item{RawTitle: "Article", TitleText: "1. Article", GUID: "guid-1", Content: "Article body", BodyHydrated: true},
+	})
+	m.state.ArticleList.Select(0)
+
+	m, _ = typeKeys(m, "E")
+	if m.state.Session != state.AskAIView {
+		t.Fatalf("session = %v, want the ask dialog", m.state.Session)
+	}
+	if props := m.buildModalProps(); !props.Visible || !strings.Contains(props.Body, "Ask about this article") {
+		t.Fatalf("modal = %+v, want the question prompt", props)
+	}
+
+	m, _ = typeKeys(m, "Why?")
+	tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+	if m.state.Session != state.DetailView || cmd == nil {
+		t.Fatalf("session = %v, cmd = %v; want the question sent from the detail view", m.state.Session, cmd)
+	}
+
+	var answer update.ArticleAnswerMsg
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		for _, c := range batch {
+			if msg, ok := c().(update.ArticleAnswerMsg); ok {
+				answer = msg
+			}
+		}
+	}
+	if answer.GUID != "guid-1" || answer.Question != "Why?" {
+		t.Fatalf("answer msg = %+v, want the question about guid-1", answer)
+	}
+
+	tm, _ = m.Update(answer)
+	m = tm.(*Model)
+	if !strings.Contains(m.state.DetailContent, "Q: Why?") || !strings.Contains(m.state.DetailContent, "Rust adoption keeps growing.") {
+		t.Fatalf("detail content = %q, want the question and answer", m.state.DetailContent)
+	}
+
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = tm.(*Model)
+	if m.state.Session != state.DetailView || m.state.AskAnswer != "" {
+		t.Fatalf("session = %v, answer = %q; esc should return to the article first", m.state.Session, m.state.AskAnswer)
+	}
+	if !strings.Contains(m.state.DetailContent, "Article body") {
+		t.Fatalf("detail content = %q, want the article again", m.state.DetailContent)
+	}
+}
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+func TestClearHistoryDialog(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/1"},
+		KeyMap: settings.KeyMapConfig{ClearHistory: "X"},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"plain": {GUID: "plain", Title: "Plain", FeedURL: "http://example.com/1"},
+		"saved": {GUID: "saved", Title: "Saved", FeedURL: "http://example.com/1", IsBookmarked: true},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	press := func(r rune) tea.Cmd {
+		tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = tm.(*Model)
+		return cmd
+	}
+
+	// Cancel on the first step.
+	press('X')
+	if m.state.Session != state.ClearHistoryView {
+		t.Fatal("Should switch to ClearHistoryView on 'X'")
+	}
+	press('n')
+	if m.state.Session != state.FeedView || len(historyRepo.items) != 2 {
+		t.Fatal("'n' should cancel without clearing history")
+	}
+
+	// Cancel on the second step.
+	press('X')
+	press('y')
+	if !m.state.ClearHistoryConfirmed {
+		t.Fatal("first 'y' should only advance to the final confirmation")
+	}
+	if len(historyRepo.items) != 2 {
+		t.Fatal("history should not be cleared before the second confirmation")
+	}
+	if p := m.state.ClearHistoryPreview; p == nil || p.Count != 2 {
+		t.Fatalf("final confirmation should preview every item, got %+v", p)
+	}
+	if body := m.buildModalProps().Body; !strings.Contains(body, "2 items would be deleted") || !strings.Contains(body, "- Saved") {
+		t.Fatalf("final confirmation should list what goes, got %q", body)
+	}
+	press('n')
+	if m.state.Session != state.FeedView || len(historyRepo.items) != 2 {
+		t.Fatal("'n' on the final confirmation should cancel")
+	}
+
+	// Keep bookmarks.
+	press('X')
+	press('b')
+	if p := m.state.ClearHistoryPreview; p == nil || p.Count != 1 || p.Examples[0] != "Plain" {
+		t.Fatalf("preview should leave bookmarks out, got %+v", p)
+	}
+	press('y')
+	if m.state.Session != state.FeedView {
+		t.Fatal("Should return to FeedView after clearing")
+	}
+	if len(historyRepo.items) != 1 || historyRepo.items["saved"] == nil {
+		t.Fatalf("bookmarks should be kept, got %#v", historyRepo.items)
+	}
+	if _, ok := m.state.History.Item("plain"); ok {
+		t.Fatal("in-memory history should be reloaded after clearing")
+	}
+	if _, ok := m.state.History.Item("saved"); !ok {
+		t.Fatal("in-memory history should be reloaded after clearing")
+	}
+	if m.state.StatusMessage != "History cleared (bookmarks kept)" {
+		t.Fatalf("unexpected status message: %q", m.state.StatusMessage)
+	}
+
+	// Clear everything.
+	press('X')
+	press('y')
+	optimize := press('y')
+	if len(historyRepo.items) != 0 || len(m.state.History.Items()) != 0 {
+		t.Fatal("history should be empty after clearing all")
+	}
+	// The database is compacted by a background command, not inside Update.
+	if optimize == nil || historyRepo.optimized != 0 {
+		t.Fatalf("clearing should leave optimizing to a command, optimized %d times", historyRepo.optimized)
+	}
+	optimize()
+	if historyRepo.optimized != 1 {
+		t.Fatalf("optimize command ran Optimize %d times, want 1", historyRepo.optimized)
+	}
+	if m.state.StatusMessage != "History cleared" {
+		t.Fatalf("unexpected status message: %q", m.state.StatusMessage)
+	}
+}
@@ -3,15 +3,38 @@ package header
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tesso57/reazy/internal/presentation/tui/glyph"
+	"github.com/tesso57/reazy/internal/presentation/tui/metrics"
+	"github.com/tesso57/reazy/internal/presentation/tui/textutil"
 )
 
+// metaSeparator divides the detail metadata fields on one row.
+const metaSeparator = " │ "
+
 // Props defines the properties for the header component.
 type Props struct {
-	Visible   bool
-	Link      string
+	Visible bool
+	Link    string
+	// Preview replaces the link line with the newest unread article title of
+	// the highlighted feed.
+	Preview   string
 	FeedTitle string
+	Updated   string
+	// Length describes the open article's size, e.g. "1,240 words · ~6 min".
+	Length string
+	// Glyphs labels the lines; the zero Set uses glyph.Emoji.
+	Glyphs glyph.Set
+
+	// Detail replaces the title line with the open article's metadata:
+	// feed, Date, Length and AI side by side when Width allows, stacked on
+	// two lines otherwise (see metrics.DetailHeaderLines).
+	Detail bool
+	Date   string
+	AI     string
+	Width  int
 }
 
 // Render renders the header component.
@@ -19,7 +42,51 @@ func Render(p Props) string {
 	if !p.Visible {
 		return ""
 	}
+	if p.Detail {
+		return renderDetail(p)
+	}
+	titleLine := p.FeedTitle
+	if p.Updated != "" {
+		titleLine = fmt.Sprintf("%s  (updated %s)", p.FeedTitle, p.Updated)
+	}
+	if p.Length != "" {
+		titleLine = fmt.Sprintf("%s  (%s)", titleLine, p.Length)
+	}
+	glyphs := p.Glyphs.OrDefault()
+	firstLine := glyphs.Link + " " + p.Link
+	if p.Preview != "" {
+		firstLine = glyphs.Preview + " " + p.Preview
+	}
 	return lipgloss.NewStyle().
 		Foreground(lipgloss.Color("240")).
-		Render(fmt.Sprintf("🔗 %s\n🏷️  %s", p.Link, p.FeedTitle))
+		Render(fmt.Sprintf("%s\n%s %s", firstLine, glyphs.Feed, titleLine))
+}
+
+func renderDetail(p Props) string {
+	glyphs := p.Glyphs.OrDefault()
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	linkLine := glyphs.Link + " " + p.Link
+	feedLabel := glyphs.Feed + " "
+	var meta []string
+	for _, field := range []string{p.Date, p.Length, p.AI} {
+		if field != "" {
+			meta = append(meta, field)
+		}
+	}
+
+	if metrics.DetailHeaderLines(p.Width) > metrics.HeaderLines {
+		feedLine := feedLabel + textutil.Truncate(p.FeedTitle, p.Width-lipgloss.Width(feedLabel))
+		metaLine := textutil.Truncate(strings.Join(meta, " · "), p.Width)
+		return style.Render(strings.Join([]string{linkLine, feedLine, metaLine}, "\n"))
+	}
+
+	metaWidth := 0
+	cells := make([]string, 0, 2*len(meta))
+	for _, field := range meta {
+		cells = append(cells, metaSeparator, field)
+		metaWidth += lipgloss.Width(metaSeparator) + lipgloss.Width(field)
+	}
+	feedWidth := p.Width - lipgloss.Width(feedLabel) - metaWidth
+	row := lipgloss.JoinHorizontal(lipgloss.Top, append([]string{feedLabel + textutil.Truncate(p.FeedTitle, feedWidth)}, cells...)...)
+	return style.Render(linkLine + "\n" + textutil.Truncate(row, p.Width))
 }
@@ -3,6 +3,10 @@ package header
 import (
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tesso57/reazy/internal/presentation/tui/glyph"
+	"github.com/tesso57/reazy/internal/presentation/tui/metrics"
 )
 
 func TestRender(t *testing.T) {
@@ -24,6 +28,30 @@ func TestRender(t *testing.T) {
 			wantFeed: "Example Feed",
 			wantVis:  true,
 		},
+		{
+			name: "VisibleWithUpdated",
+			props: Props{
+				Visible:   true,
+				Link:      "http://example.com",
+				FeedTitle: "Example Feed",
+				Updated:   "3h ago",
+			},
+			wantLink: "http://example.com",
+			wantFeed: "Example Feed  (updated 3h ago)",
+			wantVis:  true,
+		},
+		{
+			name: "VisibleWithLength",
+			props: Props{
+				Visible:   true,
+				Link:      "http://example.com",
+				FeedTitle: "Example Feed",
+				Length:    "1,240 words · ~7 min",
+			},
+			wantLink: "http://example.com",
+			wantFeed: "Example Feed  (1,240 words · ~7 min)",
+			wantVis:  true,
+		},
 		{
 			name: "Hidden",
 			props: Props{
@@ -55,3 +83,92 @@ func TestRender(t *testing.T) {
 		})
 	}
 }
+
+func TestRender_PreviewReplacesLink(t *testing.T) {
+	got := Render(Props{
+		Visible:   true,
+		Link:      "http://example.com",
+		Preview:   "Newest unread story",
+		FeedTitle: "Example Feed",
+	})
+	if !strings.Contains(got, "📰 Newest unread story") {
+		t.Errorf("Render() = %q, want preview line", got)
+	}
+	if strings.Contains(got, "http://example.com") {
+		t.Errorf("Render() = %q, preview should replace the link line", got)
+	}
+}
+
+func TestRender_ASCIIGlyphs(t *testing.T) {
+	got := Render(Props{
+		Visible:   true,
+		Link:      "http://example.com",
+		FeedTitle: "Example Feed",
+		Glyphs:    glyph.ASCII,
+	})
+	if !strings.Contains(got, "[L] http://example.com") || !strings.Contains(got, "[F] Example Feed") {
+		t.Errorf("Render() = %q, want ASCII labels", got)
+	}
+	if strings.Contains(got, "🔗") {
+		t.Errorf("Render() = %q, should not draw emoji", got)
+	}
+}
+
+func TestRender_DetailMetadata(t *testing.T) {
+	props := Props{
+		Visible:   true,
+		Detail:    true,
+		Link:      "http://example.com/a",
+		FeedTitle: "Example Feed",
+		Date:      "2026-02-14 09:30",
+		Length:    "1,240 words · ~7 min",
+		AI:        "AI: summarized",
+	}
+	fields := []string{"Example Feed", "2026-02-14 09:30", "1,240 words · ~7 min", "AI: summarized"}
+
+	for _, tt := range []struct {
+		name  string
+		width int
+		lines int
+	}{
+		{name: "Wide", width: 100, lines: metrics.HeaderLines},
+		{name: "Narrow", width: 60, lines: metrics.StackedDetailHeaderLines},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			props.Width = tt.width
+			got := Render(props)
+			lines := strings.Split(got, "\n")
+			if len(lines) != tt.lines || len(lines) != metrics.DetailHeaderLines(tt.width) {
+				t.Fatalf("Render() = %q, want %d lines", got, tt.lines)
+			}
+			for _, line := range lines[1:] {
+				if w := lipgloss.Width(line); w > tt.width {
+					t.Errorf("line %q is %d columns, want at most %d", line, w, tt.width)
+				}
+			}
+			for _, field := range fields {
+				if !strings.Contains(got, field) {
+					t.Errorf("Render() = %q, missing %q", got, field)
+				}
+			}
+		})
+	}
+}
+
+func TestRender_DetailMetadataTruncatesFeedTitleOnOneRow(t *testing.T) {
+	got := Render(Props{
+		Visible:   true,
+		Detail:    true,
+		FeedTitle: strings.Repeat("Very long feed title ", 10),
+		Date:      "2026-02-14 09:30",
+		AI:        "AI: none",
+		Width:     80,
+	})
+	lines := strings.Split(got, "\n")
+	if len(lines) != 2 || lipgloss.Width(lines[1]) > 80 {
+		t.Fatalf("Render() = %q, want one metadata row within 80 columns", got)
+	}
+	if !strings.Contains(lines[1], "...") || !strings.Contains(lines[1], "AI: none") {
+		t.Fatalf("metadata row = %q, want a truncated feed title and every field", lines[1])
+	}
+}
@@ -20,6 +20,26 @@ const (
 	Quit
 	// DeleteFeed shows the delete feed confirmation dialog.
 	DeleteFeed
+	// ClearHistory shows the clear history confirmation dialog.
+	ClearHistory
+	// FetchProgress shows per-feed progress of a bulk refresh.
+	FetchProgress
+	// MoveFeed asks whether to follow a permanent feed redirect.
+	MoveFeed
+	// ImportFeeds asks for the OPML file to import.
+	ImportFeeds
+	// ImportSummary reviews the result of a feed import.
+	ImportSummary
+	// Snooze asks how long to snooze an article.
+	Snooze
+	// AskAI asks for a question about the open article.
+	AskAI
+	// MarkFeedRead confirms marking every article of a feed read.
+	MarkFeedRead
+	// TagStats lists the most common AI tags.
+	TagStats
+	// EditGroup asks for a group name to move a feed to or rename a group.
+	EditGroup
 )
 
 // Props defines the properties for the modal component.
@@ -40,7 +60,7 @@ func Render(p Props) string {
 	borderColor := lipgloss.Color("63") // Default (Help)
 	var content string
 
-	if p.Kind == AddFeed {
+	if p.Kind == AddFeed || p.Kind == ImportFeeds || p.Kind == AskAI || p.Kind == EditGroup {
 		borderColor = lipgloss.Color("205")
 		// For AddFeed, Body usually contains the full dialog content constructed in container
 		// containing title, input view, etc.
@@ -61,14 +81,22 @@ func Render(p Props) string {
 			BorderForeground(borderColor).
 			Padding(1, 2).
 			Render(p.Body)
-	} else if p.Kind == DeleteFeed {
-		// Delete Feed Confirmation
+	} else if p.Kind == DeleteFeed || p.Kind == ClearHistory {
+		// Delete Feed / Clear History Confirmation
 		borderColor = lipgloss.Color("196") // Red for Delete
 		content = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(borderColor).
 			Padding(1, 2).
 			Render(p.Body)
+	} else if p.Kind == FetchProgress || p.Kind == MoveFeed || p.Kind == ImportSummary || p.Kind == Snooze || p.Kind == MarkFeedRead || p.Kind == TagStats {
+		// Bulk refresh progress / Feed move prompt / Import summary / Snooze / Mark feed read / Tag stats
+		borderColor = lipgloss.Color("205")
+		content = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(borderColor).
+			Padding(1, 2).
+			Render(p.Body)
 	} else {
 		// Help
 		content = lipgloss.NewStyle().
@@ -13,10 +13,15 @@ type Props struct {
 	Height int
 	Title  string
 	Active bool
+	// Hidden leaves the sidebar out so the main pane can use the whole window.
+	Hidden bool
 }
 
 // Render renders the sidebar component.
 func Render(p Props) string {
+	if p.Hidden {
+		return ""
+	}
 	sidebarStyle := lipgloss.NewStyle().
 		Width(p.Width).
 		Height(p.Height).
@@ -59,3 +59,9 @@ func TestRender_TruncatesTitleOnNarrowWidth(t *testing.T) {
 		t.Fatalf("expected title not to wrap into second word, got: %q", got)
 	}
 }
+
+func TestRenderHidden(t *testing.T) {
+	if got := Render(Props{View: "FEED LIST", Width: 20, Height: 10, Hidden: true}); got != "" {
+		t.Fatalf("Render() = %q, want nothing for a hidden sidebar", got)
+	}
+}
@@ -3,17 +3,23 @@ package tui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/tesso57/reazy/internal/domain/reading"
 	"github.com/tesso57/reazy/internal/presentation/tui/components/header"
 	main_view "github.com/tesso57/reazy/internal/presentation/tui/components/main"
 	"github.com/tesso57/reazy/internal/presentation/tui/components/modal"
 	"github.com/tesso57/reazy/internal/presentation/tui/components/sidebar"
+	"github.com/tesso57/reazy/internal/presentation/tui/glyph"
 	"github.com/tesso57/reazy/internal/presentation/tui/metrics"
 	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
 	"github.com/tesso57/reazy/internal/presentation/tui/state"
 	"github.com/tesso57/reazy/internal/presentation/tui/textutil"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
 	"github.com/tesso57/reazy/internal/presentation/tui/view"
 )
 
@@ -24,6 +30,7 @@ func (m *Model) buildProps() view.Props {
 		Main:    m.buildMainProps(),
 		Modal:   m.buildModalProps(),
 		Footer:  m.buildFooterProps(),
+		Focus:   update.FocusLayout(m.state),
 	}
 }
 
@@ -32,14 +39,17 @@ func (m *Model) buildSidebarProps() sidebar.Props {
 		View:   m.state.FeedList.View(),
 		Width:  m.state.FeedList.Width(),
 		Height: m.state.FeedList.Height(),
-		Active: m.state.Session == state.FeedView,
+		Active: m.state.Session == state.FeedView || m.state.Session == state.ManageFeedsView,
 		Title:  "Reazy Feeds",
+		Hidden: update.DetailFullScreen(m.state),
 	}
 }
 
 func (m *Model) buildHeaderProps() header.Props {
 	visible := headerVisible(m.state)
-	var link, feedTitle string
+	var link, preview, feedTitle, updated, length, date, aiStatus string
+	detail := visible && m.state.Session == state.DetailView
+	availableWidth := headerWidth(m.state)
 
 	if visible {
 		var currentItem *presenter.Item
@@ -54,12 +64,6 @@ func (m *Model) buildHeaderProps() header.Props {
 		}
 
 		if currentItem != nil {
-			// Truncate logic
-			sidebarWidth := m.state.Width / 3
-			mainWidth := m.state.Width - sidebarWidth - metrics.SidebarRightBorderWidth
-			// Main view has 1 padding left. Header has "🔗 " prefix (~3 chars).
-			// Safe buffer: metrics.HeaderWidthPadding.
-			availableWidth := mainWidth - metrics.HeaderWidthPadding
 			// Date-section items don't have article URLs; keep header rows stable by
 			// falling back to the selected feed URL.
 			if currentItem.IsSectionHeader() {
@@ -75,12 +79,29 @@ func (m *Model) buildHeaderProps() header.Props {
 				}
 			} else {
 				link = headerLine(currentItem.Link, availableWidth)
+				if m.state.Session == state.FeedView {
+					updated = feedFreshness(m.state.FeedLatestDates, currentItem.Link, time.Now())
+					if m.state.FeedPreview {
+						preview = headerLine(feedPreview(m.state.FeedPreviews, currentItem.Link), availableWidth)
+					}
+				}
+				if detail {
+					// The header fits the metadata row to the width itself.
+					length = articleLengthLabel(currentItem, m.state.ContentSanitizer)
+					date = articleDateLabel(m.state.History, currentItem)
+					aiStatus = articleAIStatus(currentItem)
+				}
+				titleWidth := availableWidth
+				if updated != "" {
+					// Reserve room for "  (updated ...)".
+					titleWidth -= len(updated) + len("  (updated )")
+				}
 				// For feed items, title is usually formatted index + title.
 				// But header Props expects "FeedTitle".
 				// In feedList item, we don't store FeedTitle explicitly?
 				// The item struct has feedTitle field.
 				// Let's check model.go logic.
-				feedTitle = headerLine(currentItem.FeedTitleText, availableWidth)
+				feedTitle = headerLine(currentItem.FeedTitleText, titleWidth)
 				// If feedTitle is empty (e.g. initial item for feedList doesn't populate feedTitle?),
 				// use Title.
 				if feedTitle == "" {
@@ -88,7 +109,7 @@ func (m *Model) buildHeaderProps() header.Props {
 					// We can use link as title if feedTitle is missing.
 					// Or m.currentFeed.Title if available and matches?
 					// Simple fallback:
-					feedTitle = headerLine(currentItem.TitleText, availableWidth)
+					feedTitle = headerLine(currentItem.TitleText, titleWidth)
 				}
 			}
 		}
@@ -97,20 +118,80 @@ func (m *Model) buildHeaderProps() header.Props {
 	return header.Props{
 		Visible:   visible,
 		Link:      link,
+		Preview:   preview,
 		FeedTitle: feedTitle,
+		Updated:   updated,
+		Length:    length,
+		Glyphs:    m.state.Glyphs,
+		Detail:    detail,
+		Date:      date,
+		AI:        aiStatus,
+		Width:     availableWidth,
 	}
 }
 
+// headerWidth returns the columns header lines may use beside the sidebar,
+// or across the window when the detail view hides it.
+func headerWidth(s *state.ModelState) int {
+	mainWidth := metrics.MainWidth(s.Width)
+	if update.DetailFullScreen(s) {
+		mainWidth = s.Width
+	}
+	// Main view has 1 padding left. Header has a glyph prefix (up to 4 chars).
+	// Safe buffer: metrics.HeaderWidthPadding.
+	return mainWidth - metrics.HeaderWidthPadding
+}
+
+// articleDateLabel formats when an article was published, falling back to
+// the feed's raw date text.
+func articleDateLabel(history *reading.History, item *presenter.Item) string {
+	if stored, ok := history.Item(item.GUID); ok && stored != nil && !stored.Date.IsZero() {
+		return stored.Date.In(time.Local).Format("2006-01-02 15:04")
+	}
+	return textutil.SingleLine(item.Published)
+}
+
+// articleAIStatus tells whether the article has an AI summary yet.
+func articleAIStatus(item *presenter.Item) string {
+	if strings.TrimSpace(item.AISummary) == "" {
+		return "AI: none"
+	}
+	return "AI: summarized"
+}
+
+// articleLengthLabel describes a hydrated article's length and reading time,
+// counting characters instead of words for mostly CJK text.
+func articleLengthLabel(item *presenter.Item, sanitizer *reading.ContentSanitizer) string {
+	if item == nil || !item.BodyHydrated {
+		return ""
+	}
+	body := sanitizer.Clean(item.Content)
+	if strings.TrimSpace(body) == "" {
+		body = sanitizer.Clean(item.Desc)
+	}
+	length := reading.MeasureText(body)
+	minutes := length.ReadingMinutes()
+	if minutes == 0 {
+		return ""
+	}
+	count := pluralize(length.Words, "word")
+	if length.CJKChars > length.Words {
+		count = pluralize(length.CJKChars, "char")
+	}
+	return fmt.Sprintf("%s · ~%d min", count, minutes)
+}
+
 func (m *Model) buildMainProps() main_view.Props {
 	var body string
 	switch {
 	case m.state.Loading:
-		message := loadingMessage(m.state)
-		body = fmt.Sprintf("\n\n   %s %s", m.state.Spinner.View(), message)
+		body = loadingBody(m.state.Spinner.View(), loadingMessage(m.state), m.state.ArticleList.Width())
 	case m.state.Session == state.DetailView:
 		body = m.state.Viewport.View()
 	case m.state.Session == state.NewsTopicView:
 		body = buildNewsTopicBody(m.state)
+	case m.state.Session == state.ManageFeedsView:
+		body = buildManageFeedsBody(m.state, m.state.ArticleList.Width(), time.Now())
 	case m.state.Session == state.ArticleView || m.state.Session == state.FeedView:
 		body = m.state.ArticleList.View()
 	default:
@@ -123,6 +204,9 @@ func (m *Model) buildMainProps() main_view.Props {
 	headerHeight := 0
 	if headerVisible(m.state) {
 		headerHeight = metrics.HeaderLines
+		if m.state.Session == state.DetailView {
+			headerHeight = metrics.DetailHeaderLines(headerWidth(m.state))
+		}
 	}
 
 	return main_view.Props{
@@ -139,8 +223,9 @@ func (m *Model) buildModalProps() modal.Props {
 			Visible: true,
 			Kind:    modal.AddFeed,
 			Body: fmt.Sprintf(
-				"Enter Feed URL:\n\n%s\n\n(esc to cancel)",
+				"Enter Feed URL:\n\n%s\n\n%s(esc to cancel)",
 				m.state.TextInput.View(),
+				addFeedHint(m.state),
 			),
 			Width:  m.state.Width,
 			Height: m.state.Height,
@@ -164,6 +249,112 @@ func (m *Model) buildModalProps() modal.Props {
 			Height:  m.state.Height,
 		}
 	}
+	if m.state.Session == state.ClearHistoryView {
+		return modal.Props{
+			Visible: true,
+			Kind:    modal.ClearHistory,
+			Body:    clearHistoryModalBody(m.state.ClearHistoryConfirmed, m.state.ClearHistoryKeepBookmarks, m.state.ClearHistoryPreview),
+			Width:   m.state.Width,
+			Height:  m.state.Height,
+		}
+	}
+	if m.state.Session == state.MoveFeedView && len(m.state.PendingFeedMoves) > 0 {
+		move := m.state.PendingFeedMoves[0]
+		return modal.Props{
+			Visible: true,
+			Kind:    modal.MoveFeed,
+			Body:    fmt.Sprintf("This feed has moved permanently:\n\n%s\n→ %s\n\nUpdate the subscription? (y/n)", move.From, move.To),
+			Width:   m.state.Width,
+			Height:  m.state.Height,
+		}
+	}
+	if m.state.Session == state.GroupEditView && m.state.GroupEdit != nil {
+		prompt := fmt.Sprintf("Move %s to group (empty for none):", m.state.GroupEdit.FeedURL)
+		if m.state.GroupEdit.Kind == state.RenameFeedGroup {
+			prompt = fmt.Sprintf("Rename group %s to:", m.state.GroupEdit.Group)
+		}
+		return modal.Props{
+			Visible: true,
+			Kind:    modal.EditGroup,
+			Body:    fmt.Sprintf("%s\n\n%s\n\n(esc to cancel)", prompt, m.state.TextInput.View()),
+			Width:   m.state.Width,
+			Height:  m.state.Height,
+		}
+	}
+	if m.state.Session == state.ImportFeedsView {
+		source := "OPML file"
+		if m.subscriptions.DownloadsOPML() {
+			source = "OPML file or URL"
+		}
+		return modal.Props{
+			Visible: true,
+			Kind:    modal.ImportFeeds,
+			Body: fmt.Sprintf(
+				"Import feeds from %s:\n\n%s\n\n(esc to cancel)",
+				source,
+				m.state.TextInput.View(),
+			),
+			Width:  m.state.Width,
+			Height: m.state.Height,
+		}
+	}
+	if m.state.Session == state.ImportSummaryView && m.state.FeedImport != nil {
+		return modal.Props{
+			Visible: true,
+			Kind:    modal.ImportSummary,
+			Body:    importSummaryModalBody(m.state.FeedImport, m.state.Glyphs, m.state.Width, m.state.Height),
+			Width:   m.state.Width,
+			Height:  m.state.Height,
+		}
+	}
+	if m.state.Session == state.SnoozeView {
+		return modal.Props{
+			Visible: true,
+			Kind:    modal.Snooze,
+			Body:    "Snooze this article until:\n\n1  later today (3 hours)\n2  tomorrow morning\n3  next Monday morning\n\n(esc to cancel)",
+			Width:   m.state.Width,
+			Height:  m.state.Height,
+		}
+	}
+	if m.state.Session == state.MarkFeedReadView {
+		return modal.Props{
+			Visible: true,
+			Kind:    modal.MarkFeedRead,
+			Body:    fmt.Sprintf("Mark all %d unread articles read?\n\n%s\n\n(y/n)", m.state.MarkReadFeedCount, m.state.MarkReadFeedURL),
+			Width:   m.state.Width,
+			Height:  m.state.Height,
+		}
+	}
+	if m.state.Session == state.TagStatsView {
+		return modal.Props{
+			Visible: true,
+			Kind:    modal.TagStats,
+			Body:    tagStatsModalBody(m.state.TagStats),
+			Width:   m.state.Width,
+			Height:  m.state.Height,
+		}
+	}
+	if m.state.Session == state.AskAIView {
+		return modal.Props{
+			Visible: true,
+			Kind:    modal.AskAI,
+			Body: fmt.Sprintf(
+				"Ask about this article:\n\n%s\n\n(enter to ask, esc to cancel)",
+				m.state.AskInput.View(),
+			),
+			Width:  m.state.Width,
+			Height: m.state.Height,
+		}
+	}
+	if m.state.FetchProgress != nil {
+		return modal.Props{
+			Visible: true,
+			Kind:    modal.FetchProgress,
+			Body:    fetchProgressModalBody(m.state.FetchProgress, m.state.Glyphs, m.state.Width, m.state.Height),
+			Width:   m.state.Width,
+			Height:  m.state.Height,
+		}
+	}
 	if m.state.Help.ShowAll {
 		return modal.Props{
 			Visible: true,
@@ -176,9 +367,169 @@ func (m *Model) buildModalProps() modal.Props {
 	return modal.Props{Visible: false}
 }
 
+// addFeedHint describes the validation state of the URL being added.
+func addFeedHint(st *state.ModelState) string {
+	switch st.AddFeedStatus {
+	case state.AddFeedChecking:
+		return "Checking feed…\n\n"
+	case state.AddFeedInvalid:
+		return fmt.Sprintf("Not a valid feed: %s\nEdit the URL and press enter to retry.\n\n", textutil.SingleLine(st.AddFeedError))
+	default:
+		return ""
+	}
+}
+
+func tagStatsModalBody(tags []state.TagCount) string {
+	if len(tags) == 0 {
+		return "No AI tags yet. Summarize articles (s) to tag them.\n\n(esc to close)"
+	}
+	var b strings.Builder
+	b.WriteString("Top AI tags\n\n")
+	for i, tag := range tags {
+		fmt.Fprintf(&b, "%2d  %s (%d)\n", (i+1)%10, tag.Tag, tag.Count)
+	}
+	b.WriteString("\n(1-9, 0 to browse a tag, esc to close)")
+	return b.String()
+}
+
+func clearHistoryModalBody(confirmed, keepBookmarks bool, preview *state.ClearPreview) string {
+	if !confirmed {
+		return "Clear reading history?\n\n(y = clear all, b = keep bookmarks, n = cancel)"
+	}
+	question := "Delete all history including bookmarks?"
+	if keepBookmarks {
+		question = "Delete all history except bookmarks and dismissed articles?"
+	}
+	lines := []string{"This cannot be undone.", question}
+	if preview != nil {
+		lines = append(lines, "", clearPreviewSummary(preview.Count))
+		for _, title := range preview.Examples {
+			if title = strings.TrimSpace(title); title == "" {
+				title = "(untitled)"
+			}
+			lines = append(lines, "  - "+title)
+		}
+		if more := preview.Count - len(preview.Examples); more > 0 && len(preview.Examples) > 0 {
+			lines = append(lines, fmt.Sprintf("  … and %d more", more))
+		}
+	}
+	return strings.Join(append(lines, "", "(y/n)"), "\n")
+}
+
+func clearPreviewSummary(count int) string {
+	switch count {
+	case 0:
+		return "Nothing would be deleted."
+	case 1:
+		return "1 item would be deleted:"
+	}
+	return fmt.Sprintf("%d items would be deleted:", count)
+}
+
+// fetchProgressModalBody lists every feed of a bulk refresh with its state,
+// eliding the tail when the terminal is too short to show them all.
+func fetchProgressModalBody(p *state.FetchProgress, glyphs glyph.Set, width, height int) string {
+	glyphs = glyphs.OrDefault()
+	title := fmt.Sprintf("Refreshing feeds (%d/%d)", p.Done(), len(p.Feeds))
+	if p.Group != "" {
+		title = fmt.Sprintf("Refreshing %s (%d/%d)", p.Group, p.Done(), len(p.Feeds))
+	}
+	if p.FollowUp != "" {
+		title += ", " + p.FollowUp
+	}
+	lines := []string{title, ""}
+	lineWidth := max(width-12, 20)
+	maxRows := len(p.Feeds)
+	if height > 0 {
+		maxRows = max(height-10, 3)
+	}
+	for idx, feed := range p.Feeds {
+		if idx == maxRows && len(p.Feeds) > maxRows {
+			lines = append(lines, fmt.Sprintf("… and %d more", len(p.Feeds)-maxRows))
+			break
+		}
+		mark := glyphs.Pending
+		line := feed
+		switch p.States[feed] {
+		case state.FeedProgressSucceeded:
+			mark = glyphs.Succeeded
+		case state.FeedProgressFailed:
+			mark = glyphs.Failed
+			if reason := p.Errors[feed]; reason != "" {
+				line = fmt.Sprintf("%s (%s)", feed, textutil.SingleLine(reason))
+			}
+		}
+		lines = append(lines, textutil.Truncate(mark+" "+line, lineWidth))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// importSummaryModalBody lists the feeds an import added and skipped,
+// sharing the available rows between the sections.
+func importSummaryModalBody(summary *state.FeedImportSummary, glyphs glyph.Set, width, height int) string {
+	lineWidth := max(width-12, 20)
+	maxRows := len(summary.Added) + len(summary.Duplicates) + len(summary.Invalid)
+	if height > 0 {
+		maxRows = max((height-14)/3, 2)
+	}
+	lines := []string{fmt.Sprintf("Added %d feeds", len(summary.Added))}
+	section := func(mark string, feeds []string) {
+		for idx, feed := range feeds {
+			if idx == maxRows && len(feeds) > maxRows {
+				lines = append(lines, fmt.Sprintf("  … and %d more", len(feeds)-maxRows))
+				break
+			}
+			lines = append(lines, textutil.Truncate(fmt.Sprintf("  %s %s", mark, feed), lineWidth))
+		}
+	}
+	section("+", summary.Added)
+	if len(summary.Duplicates) > 0 {
+		lines = append(lines, "", fmt.Sprintf("Skipped %d already subscribed", len(summary.Duplicates)))
+		section("=", summary.Duplicates)
+	}
+	if len(summary.Invalid) > 0 {
+		lines = append(lines, "", fmt.Sprintf("Skipped %d invalid", len(summary.Invalid)))
+		section(glyphs.OrDefault().Failed, summary.Invalid)
+	}
+	if len(summary.Added) > 0 {
+		lines = append(lines, "", "(u = undo import, enter = close)")
+	} else {
+		lines = append(lines, "", "(enter = close)")
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (m *Model) buildFooterProps() string {
+	if update.FooterHidden(m.state) {
+		return ""
+	}
 	helpText := state.FooterHelpText(m.state.Help, m.state.Keys)
-	return state.FooterText(m.state.Session, m.state.Loading, m.state.AIStatus, m.state.StatusMessage, helpText)
+	statusMessage := m.state.StatusMessage
+	if search := detailSearchStatus(m.state); search != "" {
+		statusMessage = search
+	}
+	aiStatus := m.state.AIStatus
+	if total := m.tokens.Total(); total > 0 && strings.TrimSpace(aiStatus) != "" {
+		aiStatus += fmt.Sprintf(" · ~%s tokens this session", formatCount(total))
+	}
+	return state.FooterText(m.state.Session, m.state.Loading, aiStatus, statusMessage, helpText)
+}
+
+// detailSearchStatus describes the in-article search prompt or its matches.
+func detailSearchStatus(st *state.ModelState) string {
+	if st.Session != state.DetailView {
+		return ""
+	}
+	if st.DetailSearching {
+		return "/" + st.DetailSearchQuery
+	}
+	if st.DetailSearchQuery == "" {
+		return ""
+	}
+	if len(st.DetailSearchMatches) == 0 {
+		return fmt.Sprintf("Search %q: no matches", st.DetailSearchQuery)
+	}
+	return fmt.Sprintf("Search %q: %d/%d (n/N to jump)", st.DetailSearchQuery, st.DetailSearchIndex+1, len(st.DetailSearchMatches))
 }
 
 func headerVisible(st *state.ModelState) bool {
@@ -186,8 +537,10 @@ func headerVisible(st *state.ModelState) bool {
 		return false
 	}
 	switch st.Session {
-	case state.FeedView, state.DetailView:
+	case state.FeedView:
 		return true
+	case state.DetailView:
+		return !update.FocusLayout(st)
 	case state.ArticleView:
 		if item, ok := st.ArticleList.SelectedItem().(*presenter.Item); ok && item != nil {
 			if item.IsNewsDigest() {
@@ -205,6 +558,24 @@ func headerVisible(st *state.ModelState) bool {
 	}
 }
 
+// feedFreshness returns how long ago the newest article of a feed was published.
+func feedFreshness(latestDates map[string]time.Time, feedURL string, now time.Time) string {
+	if feedURL == "" || feedURL == reading.NewsURL || feedURL == reading.BookmarksURL || feedURL == reading.DismissedURL {
+		return ""
+	}
+	latest, ok := latestDates[feedURL]
+	if !ok {
+		return ""
+	}
+	return textutil.RelativeTime(latest, now)
+}
+
+// feedPreview returns the title of the newest unread article of a feed, so
+// the feed view can offer a peek without opening it.
+func feedPreview(previews map[string]string, feedURL string) string {
+	return textutil.StripBidiControls(previews[feedURL])
+}
+
 func headerLine(text string, width int) string {
 	return textutil.Truncate(textutil.SingleLine(text), width)
 }
@@ -227,14 +598,90 @@ func buildNewsTopicBody(st *state.ModelState) string {
 	}
 
 	return fmt.Sprintf(
-		"%s\n----------------------------------------\n%s%s\nRelated Articles\n%s",
+		"%s\n----------------------------------------\n%s%s\nRelated Articles%s%s\n%s",
 		title,
 		tags,
 		summary,
+		newsTopicCoverage(st),
+		translatedTitlesMarker(st),
 		st.ArticleList.View(),
 	)
 }
 
+// newsTopicCoverage reports how many source articles and distinct feeds the
+// open digest topic was built from, e.g. " (5 articles from 3 feeds)".
+func newsTopicCoverage(st *state.ModelState) string {
+	if st.History == nil {
+		return ""
+	}
+	digest, ok := st.History.Item(st.NewsTopicDigestGUID)
+	if !ok {
+		return ""
+	}
+	related := st.History.RelatedItems(digest)
+	if len(related) == 0 {
+		return ""
+	}
+	feeds := make(map[string]struct{}, len(related))
+	for _, item := range related {
+		feeds[strings.TrimSpace(item.FeedURL)] = struct{}{}
+	}
+	return fmt.Sprintf(" (%s from %s)", pluralize(len(related), "article"), pluralize(len(feeds), "feed"))
+}
+
+func translatedTitlesMarker(st *state.ModelState) string {
+	if !st.ShowTranslatedTitles || len(st.NewsTopicRelatedTitles) == 0 {
+		return ""
+	}
+	return " [translated]"
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%s %ss", formatCount(n), noun)
+}
+
+// formatCount renders n with thousands separators, e.g. 1240 as "1,240".
+func formatCount(n int) string {
+	if n < 0 {
+		return "-" + formatCount(-n)
+	}
+	digits := strconv.Itoa(n)
+	var b strings.Builder
+	for idx, digit := range digits {
+		if idx > 0 && (len(digits)-idx)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+	return b.String()
+}
+
+// loadingBody renders the spinner and message, wrapping the message under
+// itself so no line is wider than width. Width zero leaves it unwrapped.
+func loadingBody(spinner, message string, width int) string {
+	prefix := fmt.Sprintf("   %s ", spinner)
+	if width <= 0 {
+		return "\n\n" + prefix + message
+	}
+	available := width - ansi.StringWidth(prefix)
+	if available < 1 {
+		return "\n\n" + textutil.Truncate(prefix+message, width)
+	}
+	lines := strings.Split(ansi.Wrap(message, available, ""), "\n")
+	indent := strings.Repeat(" ", ansi.StringWidth(prefix))
+	for idx := range lines {
+		if idx == 0 {
+			lines[idx] = prefix + lines[idx]
+			continue
+		}
+		lines[idx] = indent + lines[idx]
+	}
+	return "\n\n" + strings.Join(lines, "\n")
+}
+
 func loadingMessage(st *state.ModelState) string {
 	if st == nil {
 		return "Loading..."
@@ -257,3 +704,70 @@ func loadingMessage(st *state.ModelState) string {
 		return "Loading..."
 	}
 }
+
+// manageFeedsFixedWidth is the width taken by the marker, group, count and
+// fetched columns plus their separators in the Manage Feeds table.
+const manageFeedsFixedWidth = 2 + 12 + 1 + 6 + 1 + 6 + 2 + 10 + 2
+
+func buildManageFeedsBody(st *state.ModelState, width int, now time.Time) string {
+	if st == nil {
+		return ""
+	}
+	rows := presenter.BuildFeedOverviewRows(st.History, st.Feeds, st.FeedGroups)
+	title := fmt.Sprintf(
+		"Manage Feeds (%s delete, %s move to group, %s rename group, %s back)",
+		st.Keys.DeleteFeed.Help().Key,
+		st.Keys.MoveToGroup.Help().Key,
+		st.Keys.RenameGroup.Help().Key,
+		st.Keys.Back.Help().Key,
+	)
+	if len(rows) == 0 {
+		return title + "\n\nNo subscriptions yet."
+	}
+
+	selectedURL := ""
+	if item, ok := st.FeedList.SelectedItem().(*presenter.Item); ok && item != nil {
+		selectedURL = item.Link
+	}
+	nameWidth := max((width-manageFeedsFixedWidth)/2, 10)
+	errWidth := max(width-manageFeedsFixedWidth-nameWidth, 10)
+
+	lines := make([]string, 0, len(rows)+3)
+	lines = append(lines, title, "", fmt.Sprintf(
+		"  %s %s %6s %6s  %s  %s",
+		padCell("NAME", nameWidth), padCell("GROUP", 12), "TOTAL", "UNREAD", padCell("FETCHED", 10), "ERROR",
+	))
+	for _, row := range rows {
+		marker := "  "
+		if row.URL == selectedURL {
+			marker = "> "
+		}
+		group := row.Group
+		if group == "" {
+			group = "-"
+		}
+		fetched, lastErr := "-", ""
+		if status, ok := st.FeedFetchStatus[row.URL]; ok {
+			if ago := textutil.RelativeTime(status.FetchedAt, now); ago != "" {
+				fetched = ago
+			}
+			lastErr = textutil.Truncate(textutil.SingleLine(status.Err), errWidth)
+		}
+		lines = append(lines, strings.TrimRight(fmt.Sprintf(
+			"%s%s %s %6d %6d  %s  %s",
+			marker,
+			padCell(textutil.SingleLine(row.Name), nameWidth),
+			padCell(textutil.SingleLine(group), 12),
+			row.Total,
+			row.Unread,
+			padCell(fetched, 10),
+			lastErr,
+		), " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func padCell(text string, width int) string {
+	text = textutil.Truncate(text, width)
+	return text + strings.Repeat(" ", max(width-lipgloss.Width(text), 0))
+}
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+func newScrollTestModel(keyMap settings.KeyMapConfig) *Model {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/feed"}, KeyMap: keyMap}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = tm.(*Model)
+	m.state.Session = state.DetailView
+	m.state.Viewport.SetContent(strings.Repeat("line\n", 200))
+	return m
+}
+
+func TestDetailViewScrollKeys(t *testing.T) {
+	m := newScrollTestModel(settings.KeyMapConfig{})
+	half := m.state.Viewport.Height / 2
+
+	m, _ = typeKeys(m, "d")
+	if m.state.Viewport.YOffset != half {
+		t.Fatalf("YOffset = %d after d, want half a page (%d)", m.state.Viewport.YOffset, half)
+	}
+	m, _ = typeKeys(m, "j")
+	if m.state.Viewport.YOffset != half+1 {
+		t.Fatalf("YOffset = %d after j, want one more line", m.state.Viewport.YOffset)
+	}
+	m, _ = typeKeys(m, "G")
+	if !m.state.Viewport.AtBottom() {
+		t.Fatalf("YOffset = %d after G, want the bottom", m.state.Viewport.YOffset)
+	}
+	m, _ = typeKeys(m, "u")
+	if m.state.Viewport.AtBottom() {
+		t.Fatal("u should scroll half a page up")
+	}
+	m, _ = typeKeys(m, "g")
+	if !m.state.Viewport.AtTop() {
+		t.Fatalf("YOffset = %d after g, want the top", m.state.Viewport.YOffset)
+	}
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	if m = tm.(*Model); m.state.Viewport.YOffset != half {
+		t.Fatalf("YOffset = %d after ctrl+d, want half a page", m.state.Viewport.YOffset)
+	}
+}
+
+func TestDetailViewScrollKeysFollowConfig(t *testing.T) {
+	m := newScrollTestModel(settings.KeyMapConfig{HalfPageDown: "n", Down: "e"})
+
+	m, _ = typeKeys(m, "e")
+	if m.state.Viewport.YOffset != 1 {
+		t.Fatalf("YOffset = %d after the configured down key, want 1", m.state.Viewport.YOffset)
+	}
+	m, _ = typeKeys(m, "d")
+	if m.state.Viewport.YOffset != 1 {
+		t.Fatal("d should no longer scroll once half_page_down is remapped")
+	}
+	m, _ = typeKeys(m, "n")
+	if m.state.Viewport.YOffset != 1+m.state.Viewport.Height/2 {
+		t.Fatalf("YOffset = %d after the configured half page key", m.state.Viewport.YOffset)
+	}
+}
+
+func pressKey(m *Model, keyType tea.KeyType) *Model {
+	tm, _ := m.Update(tea.KeyMsg{Type: keyType})
+	return tm.(*Model)
+}
+
+func openListedArticle(m *Model, guid string) *Model {
+	for idx, listItem := range m.state.ArticleList.Items() {
+		if item, ok := listItem.(*presenter.Item); ok && item.GUID == guid {
+			m.state.ArticleList.Select(idx)
+		}
+	}
+	return pressKey(m, tea.KeyEnter)
+}
+
+func TestDetailViewRestoresScrollWhenReopeningArticle(t *testing.T) {
+	now := time.Now()
+	body := strings.Repeat("paragraph\n\n", 100)
+	cfg := settings.Settings{Feeds: []string{"http://example.com/feed"}}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", Title: "First", Content: body, FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: now, BodyHydrated: true},
+		"a2": {GUID: "a2", Title: "Second", Content: body, FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: now.Add(-time.Second), BodyHydrated: true},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = tm.(*Model)
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: "http://example.com/feed"}
+	presenter.ApplyArticleList(&m.state.ArticleList, m.state.History, "http://example.com/feed", presenter.ArticleListOptions{})
+
+	m = openListedArticle(m, "a1")
+	m, _ = typeKeys(m, "d")
+	left := m.state.Viewport.YOffset
+	if left == 0 {
+		t.Fatal("d should scroll the article")
+	}
+	m = pressKey(m, tea.KeyEsc)
+	if got := historyRepo.items["a1"].ScrollOffset; got != left {
+		t.Fatalf("saved offset = %d, want %d", got, left)
+	}
+
+	m = openListedArticle(m, "a2")
+	if m.state.Viewport.YOffset != 0 {
+		t.Fatalf("YOffset = %d for another article, want the top", m.state.Viewport.YOffset)
+	}
+	m = pressKey(m, tea.KeyEsc)
+	m = openListedArticle(m, "a1")
+	if m.state.Viewport.YOffset != left {
+		t.Fatalf("YOffset = %d when reopening, want %d", m.state.Viewport.YOffset, left)
+	}
+}
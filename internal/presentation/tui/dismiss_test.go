@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+func TestDismissHidesArticleAndDismissedViewRestoresIt(t *testing.T) {
+	now := time.Now()
+	cfg := settings.Settings{
+		Feeds:       []string{"http://example.com/a"},
+		KeyMap:      settings.KeyMapConfig{Dismiss: "D"},
+		BuiltinTabs: []string{settings.BuiltinTabAll, settings.BuiltinTabDismissed},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", Title: "Newer", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now},
+		"a2": {GUID: "a2", Title: "Older", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now.Add(-time.Hour)},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	if index := presenter.BuiltinTabIndex(cfg.BuiltinTabs, reading.DismissedURL); index != 1 {
+		t.Fatalf("Dismissed tab index = %d, want 1", index)
+	}
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: reading.AllFeedsURL}
+	presenter.ApplyArticleList(&m.state.ArticleList, m.state.History, reading.AllFeedsURL, presenter.ArticleListOptions{})
+	m.state.ArticleList.Select(1)
+
+	m, _ = typeKeys(m, "D")
+	if guids := articleListGUIDs(m); len(guids) != 1 || guids[0] != "a2" {
+		t.Fatalf("article list = %v, want only a2", guids)
+	}
+	if m.state.StatusMessage != "Dismissed" {
+		t.Fatalf("status = %q", m.state.StatusMessage)
+	}
+
+	m.state.CurrentFeed = &reading.Feed{URL: reading.DismissedURL}
+	presenter.ApplyArticleList(&m.state.ArticleList, m.state.History, reading.DismissedURL, presenter.ArticleListOptions{})
+	if m.state.ArticleList.Title != "Dismissed" {
+		t.Fatalf("title = %q", m.state.ArticleList.Title)
+	}
+	if guids := articleListGUIDs(m); len(guids) != 1 || guids[0] != "a1" {
+		t.Fatalf("dismissed list = %v, want a1", guids)
+	}
+	m.state.ArticleList.Select(1)
+
+	m, _ = typeKeys(m, "D")
+	if guids := articleListGUIDs(m); len(guids) != 0 {
+		t.Fatalf("dismissed list = %v, want empty after restoring", guids)
+	}
+	if m.state.StatusMessage != "Restored" {
+		t.Fatalf("status = %q", m.state.StatusMessage)
+	}
+	if len(m.state.History.ItemsByFeed(reading.AllFeedsURL)) != 2 {
+		t.Fatal("restored article should be listed in All Feeds again")
+	}
+}
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+func TestOpenExpandsCollapsedDuplicateTitles(t *testing.T) {
+	now := time.Now()
+	cfg := settings.Settings{
+		Feeds:                   []string{"http://a.example/feed", "http://b.example/feed"},
+		CollapseDuplicateTitles: true,
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a": {GUID: "a", Title: "Big News", FeedURL: "http://a.example/feed", Kind: reading.ArticleKind, Date: now},
+		"b": {GUID: "b", Title: "Big news.", FeedURL: "http://b.example/feed", Kind: reading.ArticleKind, Date: now.Add(-time.Minute)},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: reading.AllFeedsURL}
+	presenter.ApplyArticleList(&m.state.ArticleList, m.state.History, reading.AllFeedsURL, update.ArticleListOptions(m.state))
+
+	if got := len(m.state.ArticleList.Items()); got != 2 {
+		t.Fatalf("len(items) = %d, want a header and one collapsed row", got)
+	}
+
+	m = openListedArticle(m, "a")
+	if m.state.Session != state.ArticleView {
+		t.Fatalf("Session = %v, opening a collapsed row should expand it in place", m.state.Session)
+	}
+	if got := len(m.state.ArticleList.Items()); got != 3 {
+		t.Fatalf("len(items) = %d, want both sources listed", got)
+	}
+	if item, ok := m.state.ArticleList.SelectedItem().(*presenter.Item); !ok || item.GUID != "a" {
+		t.Fatalf("selection = %+v, want the row that was opened", item)
+	}
+
+	m = openListedArticle(m, "b")
+	if m.state.Session != state.DetailView {
+		t.Fatalf("Session = %v, want an expanded source to open", m.state.Session)
+	}
+}
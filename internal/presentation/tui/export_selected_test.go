@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+type recordingExportWriter struct {
+	markdown string
+}
+
+func (w *recordingExportWriter) WriteExport(markdown string) (string, error) {
+	w.markdown = markdown
+	return "/tmp/reazy-export.md", nil
+}
+
+func TestExportSelectedWritesPickedArticles(t *testing.T) {
+	now := time.Now()
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/a"},
+		KeyMap: settings.KeyMapConfig{ToggleSelect: "V", ExportSelected: "Y"},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", Title: "Newer", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now, Content: "newer body"},
+		"a2": {GUID: "a2", Title: "Older", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now.Add(-time.Hour), Content: "older body"},
+		"a3": {GUID: "a3", Title: "Oldest", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now.Add(-2 * time.Hour)},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	writer := &recordingExportWriter{}
+	m.reading.Exports = writer
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: reading.AllFeedsURL}
+	update.ApplyArticleList(m.state, reading.AllFeedsURL)
+
+	m.state.ArticleList.Select(2) // a2
+	m, _ = typeKeys(m, "V")
+	m.state.ArticleList.Select(1) // a1
+	m, _ = typeKeys(m, "V")
+	if len(m.state.Selected) != 2 || m.state.Selected[0] != "a2" {
+		t.Fatalf("Selected = %v, want [a2 a1]", m.state.Selected)
+	}
+
+	// The marks survive a rebuild of the list.
+	update.ApplyArticleList(m.state, reading.AllFeedsURL)
+	var marked []string
+	for _, item := range m.state.ArticleList.Items() {
+		if it, ok := item.(*presenter.Item); ok && it.Selected {
+			marked = append(marked, it.GUID)
+		}
+	}
+	if len(marked) != 2 {
+		t.Fatalf("marked rows = %v, want a1 and a2", marked)
+	}
+
+	m, cmd := typeKeys(m, "Y")
+	if cmd == nil {
+		t.Fatal("export should start a command")
+	}
+	tm, _ := m.Update(cmd())
+	m = tm.(*Model)
+
+	older := strings.Index(writer.markdown, "## Older")
+	newer := strings.Index(writer.markdown, "## Newer")
+	if older < 0 || newer < 0 || older > newer || strings.Contains(writer.markdown, "Oldest") {
+		t.Fatalf("export should hold the picked articles in order:\n%s", writer.markdown)
+	}
+	if m.state.StatusMessage != "Exported 2 articles to /tmp/reazy-export.md" {
+		t.Fatalf("status = %q", m.state.StatusMessage)
+	}
+	if len(m.state.Selected) != 0 {
+		t.Fatalf("exported articles should leave the selection, got %v", m.state.Selected)
+	}
+}
+
+func TestExportSelectedFallsBackToHighlightedArticle(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/a"}}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", Title: "Only", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: time.Now()},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: reading.AllFeedsURL}
+	update.ApplyArticleList(m.state, reading.AllFeedsURL)
+	m.state.ArticleList.Select(1)
+
+	m, cmd := typeKeys(m, "Y")
+	tm, _ := m.Update(cmd())
+	m = tm.(*Model)
+	if m.state.Err == nil || !strings.Contains(m.state.Err.Error(), "export is not available") {
+		t.Fatalf("Err = %v, want the unavailable export error", m.state.Err)
+	}
+
+	writer := &recordingExportWriter{}
+	m.reading.Exports = writer
+	m, cmd = typeKeys(m, "Y")
+	tm, _ = m.Update(cmd())
+	m = tm.(*Model)
+	if !strings.Contains(writer.markdown, "## Only") || m.state.Err != nil {
+		t.Fatalf("export should hold the highlighted article, err=%v:\n%s", m.state.Err, writer.markdown)
+	}
+}
@@ -0,0 +1,339 @@
+package tui
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/domain/subscription"
+	"github.com/tesso57/reazy/internal/presentation/tui/components/modal"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+func TestBulkRefreshProgressOverlay(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/1", "http://example.com/2"},
+		KeyMap: settings.KeyMapConfig{Refresh: "r"},
+	}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: reading.AllFeedsURL, Title: "All Feeds"}
+
+	tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = tm.(*Model)
+	if cmd == nil || m.state.FetchProgress == nil {
+		t.Fatal("refreshing All Feeds should start a bulk refresh with progress")
+	}
+	props := m.buildModalProps()
+	if !props.Visible || props.Kind != modal.FetchProgress {
+		t.Fatalf("expected progress overlay, got %#v", props)
+	}
+	if !strings.Contains(props.Body, "(0/2)") || !strings.Contains(props.Body, "⏳ http://example.com/1") {
+		t.Fatalf("all feeds should start pending:\n%s", props.Body)
+	}
+
+	updates := make(chan usecase.FeedFetchProgress)
+	close(updates)
+	m.Update(update.FetchProgressMsg{Progress: usecase.FeedFetchProgress{URL: "http://example.com/1"}, Updates: updates})
+	m.Update(update.FetchProgressMsg{Progress: usecase.FeedFetchProgress{URL: "http://example.com/2", Err: errors.New("boom")}, Updates: updates})
+	body := m.buildModalProps().Body
+	if !strings.Contains(body, "(2/2)") || !strings.Contains(body, "✓ http://example.com/1") || !strings.Contains(body, "✗ http://example.com/2 (boom)") {
+		t.Fatalf("progress should reflect completed feeds:\n%s", body)
+	}
+
+	m.Update(update.FeedFetchedMsg{URL: reading.AllFeedsURL, Feed: &reading.Feed{URL: reading.AllFeedsURL}})
+	if m.state.FetchProgress != nil || m.buildModalProps().Visible {
+		t.Fatal("overlay should dismiss once the refresh completes")
+	}
+}
+
+func TestAllFeedsTotalFailureKeepsCachedArticles(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/1", "http://example.com/2"}}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"cached": {GUID: "cached", FeedURL: "http://example.com/1", Title: "Cached", Kind: reading.ArticleKind},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+
+	tm, _ := m.Update(update.FeedFetchedMsg{
+		URL:    reading.AllFeedsURL,
+		Feed:   &reading.Feed{URL: reading.AllFeedsURL},
+		Report: usecase.FeedFetchReport{Requested: 2, Failed: 1, TimedOut: 1},
+	})
+	m = tm.(*Model)
+	if !strings.Contains(m.state.StatusMessage, "Couldn't reach any feeds") {
+		t.Fatalf("status = %q, want connection hint", m.state.StatusMessage)
+	}
+	if m.state.Err != nil {
+		t.Fatalf("total failure should not be reported as an error: %v", m.state.Err)
+	}
+	found := false
+	for _, item := range m.state.ArticleList.Items() {
+		if it, ok := item.(*presenter.Item); ok && it.GUID == "cached" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("cached article should stay in the list")
+	}
+
+	tm, _ = m.Update(update.FeedFetchedMsg{
+		URL:    reading.AllFeedsURL,
+		Feed:   &reading.Feed{URL: reading.AllFeedsURL},
+		Report: usecase.FeedFetchReport{Requested: 2, Succeeded: 1, Failed: 1},
+	})
+	m = tm.(*Model)
+	if m.state.StatusMessage != "1 feed failed to load" {
+		t.Fatalf("partial failure status = %q", m.state.StatusMessage)
+	}
+}
+
+func TestEmptyRefreshKeepsCachedArticleList(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/1"}}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a": {GUID: "a", FeedURL: "http://example.com/1", Title: "A", Kind: reading.ArticleKind},
+		"b": {GUID: "b", FeedURL: "http://example.com/1", Title: "B", Kind: reading.ArticleKind},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	m.state.FeedList.Select(3)
+
+	for _, feed := range []*reading.Feed{{URL: "http://example.com/1"}, nil} {
+		tm, _ := m.Update(update.FeedFetchedMsg{
+			URL:    "http://example.com/1",
+			Feed:   feed,
+			Report: usecase.FeedFetchReport{Requested: 1, Succeeded: 1},
+		})
+		m = tm.(*Model)
+		articles := 0
+		for _, item := range m.state.ArticleList.Items() {
+			if it, ok := item.(*presenter.Item); ok && !it.IsSectionHeader() {
+				articles++
+			}
+		}
+		if articles != 2 {
+			t.Fatalf("refresh with feed %#v left %d articles, want 2", feed, articles)
+		}
+	}
+	if m.state.CurrentFeed == nil || m.state.CurrentFeed.URL != "http://example.com/1" {
+		t.Fatalf("current feed = %#v, want the last fetched feed", m.state.CurrentFeed)
+	}
+}
+
+func TestRefreshAllChainsNewsDigestAfterFeeds(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/1", "http://example.com/2"},
+		KeyMap: settings.KeyMapConfig{RefreshAll: "R"},
+	}
+	m := newTestModelWithInsightAndNewsDigestGenerator(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{}, nil, &stubNewsDigestGenerator{})
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	m = tm.(*Model)
+	if cmd == nil || m.state.FetchProgress == nil || !m.state.RefreshAllPending {
+		t.Fatal("refresh all should start a bulk refresh that waits to build the news")
+	}
+	if body := m.buildModalProps().Body; !strings.Contains(body, "Refreshing feeds (0/2), then daily news") {
+		t.Fatalf("progress should announce the news step:\n%s", body)
+	}
+
+	tm, cmd = m.Update(update.FeedFetchedMsg{
+		URL:    reading.AllFeedsURL,
+		Feed:   &reading.Feed{URL: reading.AllFeedsURL},
+		Report: usecase.FeedFetchReport{Requested: 2, Succeeded: 2},
+	})
+	m = tm.(*Model)
+	if cmd == nil || m.state.RefreshAllPending {
+		t.Fatal("the news digest should start once the feeds are merged")
+	}
+	if m.state.AIStatus != "AI: generating daily news..." {
+		t.Fatalf("AI status = %q", m.state.AIStatus)
+	}
+}
+
+func TestRefreshAllWithoutAIOnlyRefreshesFeeds(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/1"},
+		KeyMap: settings.KeyMapConfig{RefreshAll: "R"},
+	}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	m = tm.(*Model)
+	if m.state.FetchProgress == nil || m.state.RefreshAllPending {
+		t.Fatalf("refresh all without AI should only refresh feeds, pending = %v", m.state.RefreshAllPending)
+	}
+	if body := m.buildModalProps().Body; strings.Contains(body, "daily news") {
+		t.Fatalf("progress should not mention news without AI:\n%s", body)
+	}
+}
+
+func TestRefreshReportsNewArticlesPerFeed(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/feed", "https://www.blog.example.jp/rss"}}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"old": {GUID: "old", FeedURL: "http://example.com/feed", Title: "Old", Kind: reading.ArticleKind},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+
+	tm, _ := m.Update(update.FeedFetchedMsg{
+		URL: reading.AllFeedsURL,
+		Feed: &reading.Feed{URL: reading.AllFeedsURL, Items: []reading.Item{
+			{GUID: "old", Title: "Old", FeedURL: "http://example.com/feed"},
+			{GUID: "e1", Title: "E1", FeedURL: "http://example.com/feed"},
+			{GUID: "e2", Title: "E2", FeedURL: "http://example.com/feed"},
+			{GUID: "b1", Title: "B1", FeedURL: "https://www.blog.example.jp/rss"},
+		}},
+		Report: usecase.FeedFetchReport{Requested: 3, Succeeded: 2, Failed: 1},
+	})
+	m = tm.(*Model)
+	want := "1 feed failed to load · example.com: 2 new, blog.example.jp: 1 new"
+	if m.state.StatusMessage != want {
+		t.Fatalf("status = %q, want %q", m.state.StatusMessage, want)
+	}
+
+	tm, _ = m.Update(update.FeedFetchedMsg{
+		URL:  reading.AllFeedsURL,
+		Feed: &reading.Feed{URL: reading.AllFeedsURL, Items: []reading.Item{{GUID: "e1", Title: "E1", FeedURL: "http://example.com/feed"}}},
+	})
+	m = tm.(*Model)
+	if m.state.StatusMessage != "" {
+		t.Fatalf("status = %q, a refresh without new articles should clear it", m.state.StatusMessage)
+	}
+}
+
+func selectFeedByURL(t *testing.T, m *Model, url string) {
+	t.Helper()
+	for idx, listItem := range m.state.FeedList.Items() {
+		if item, ok := listItem.(*presenter.Item); ok && item.Link == url {
+			m.state.FeedList.Select(idx)
+			return
+		}
+	}
+	t.Fatalf("feed %s is not in the sidebar", url)
+}
+
+func TestRefreshGroupFetchesOnlySiblingFeeds(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:      []string{"http://example.com/other"},
+		FeedGroups: []subscription.FeedGroup{{Name: "Tech", Feeds: []string{"http://example.com/go", "http://example.com/rust"}}},
+	}
+	repo := &stubSubscriptionRepo{feeds: cfg.Feeds, groups: cfg.FeedGroups}
+	m := newTestModel(cfg, repo, &stubHistoryRepo{}, &stubFeedFetcher{})
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	selectFeedByURL(t, m, "http://example.com/go")
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: "http://example.com/go"}
+
+	tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	m = tm.(*Model)
+	if cmd == nil || m.state.FetchProgress == nil || m.state.RefreshGroupPending != "Tech" {
+		t.Fatal("refresh group should start a bulk refresh of the group")
+	}
+	if got := m.state.FetchProgress.Feeds; !slices.Equal(got, []string{"http://example.com/go", "http://example.com/rust"}) {
+		t.Fatalf("refreshed feeds = %v, want only the group's feeds", got)
+	}
+	if body := m.buildModalProps().Body; !strings.Contains(body, "Refreshing Tech (0/2)") {
+		t.Fatalf("progress should name the group:\n%s", body)
+	}
+
+	tm, _ = m.Update(update.FeedFetchedMsg{
+		URL: reading.AllFeedsURL,
+		Feed: &reading.Feed{URL: reading.AllFeedsURL, Items: []reading.Item{
+			{GUID: "go-new", Title: "Go news", FeedURL: "http://example.com/go"},
+			{GUID: "rust-new", Title: "Rust news", FeedURL: "http://example.com/rust"},
+		}},
+	})
+	m = tm.(*Model)
+	if m.state.RefreshGroupPending != "" || m.state.Loading || m.state.FetchProgress != nil {
+		t.Fatalf("group refresh should finish, pending = %q loading = %v", m.state.RefreshGroupPending, m.state.Loading)
+	}
+	if guids := articleListGUIDs(m); !slices.Equal(guids, []string{"go-new"}) {
+		t.Fatalf("article list = %v, want the feed relisted with its new article", guids)
+	}
+	if _, ok := m.state.History.Item("rust-new"); !ok {
+		t.Fatal("sibling feed articles should be merged into history")
+	}
+}
+
+func TestRefreshGroupNeedsAGroupedFeed(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/other"}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	selectFeedByURL(t, m, "http://example.com/other")
+
+	tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	m = tm.(*Model)
+	if cmd != nil || m.state.FetchProgress != nil {
+		t.Fatal("an ungrouped feed should not start a refresh")
+	}
+	if m.state.StatusMessage != "example.com is not in a group" {
+		t.Fatalf("status = %q", m.state.StatusMessage)
+	}
+}
+
+func TestLoadingWatchdogStopsHungSpinner(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/feed"}, LoadingTimeoutSeconds: 5}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	start := time.Unix(1000, 0)
+	tick := func(at time.Time) {
+		t.Helper()
+		tm, cmd := m.Update(update.LoadingTickMsg{At: at})
+		m = tm.(*Model)
+		if cmd == nil {
+			t.Fatal("the watchdog should schedule its next check")
+		}
+	}
+
+	tick(start)
+	if !m.state.LoadingSince.IsZero() {
+		t.Fatal("an idle spinner should not be timed")
+	}
+
+	m.state.Session = state.ArticleView
+	update.BeginLoading(m.state, update.LoadingFeeds)
+	tick(start)
+	tick(start.Add(4 * time.Second))
+	if !m.state.Loading || m.state.Err != nil {
+		t.Fatal("loading should go on until the timeout")
+	}
+	// A new loading task restarts the clock even though Loading never cleared.
+	update.BeginLoading(m.state, update.LoadingFeeds)
+	tick(start.Add(5 * time.Second))
+	tick(start.Add(9 * time.Second))
+	if !m.state.Loading {
+		t.Fatal("a restarted task should be timed from its own start")
+	}
+	tick(start.Add(10 * time.Second))
+	if m.state.Loading || m.state.FetchProgress != nil {
+		t.Fatal("the watchdog should clear loading after the timeout")
+	}
+	if m.state.Err == nil || m.state.Err.Error() != "feed fetch timed out; press r to retry" {
+		t.Fatalf("err = %v, want a timeout with a retry hint", m.state.Err)
+	}
+
+	// Outside the article list r does not refetch, so no hint is given.
+	m.state.Session = state.FeedView
+	m.state.Err = nil
+	update.BeginLoading(m.state, update.LoadingFeeds)
+	tick(start)
+	tick(start.Add(5 * time.Second))
+	if m.state.Err == nil || m.state.Err.Error() != "feed fetch timed out" {
+		t.Fatalf("err = %v, want a timeout without a retry hint", m.state.Err)
+	}
+
+	m.state.Err = nil
+	update.BeginLoading(m.state, update.LoadingFeeds)
+	m.state.LoadingTimeout = 0
+	m.state.Err = nil
+	tick(start)
+	tick(start.Add(time.Hour))
+	if !m.state.Loading || m.state.Err != nil {
+		t.Fatal("a zero timeout should never stop loading")
+	}
+}
@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/domain/subscription"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+// requireFilterTyped fails unless the active list is still being filtered
+// with exactly text, i.e. no typed key was taken as an action.
+func requireFilterTyped(t *testing.T, m *Model, model *list.Model, session state.Session, text string) {
+	t.Helper()
+	if m.state.Session != session {
+		t.Fatalf("Session = %v, want %v while typing a filter", m.state.Session, session)
+	}
+	if model.FilterState() != list.Filtering || model.FilterValue() != text {
+		t.Fatalf("filter = %v %q, want %q being typed", model.FilterState(), model.FilterValue(), text)
+	}
+}
+
+func newFeedFilterModel() (*Model, *stubSubscriptionRepo) {
+	cfg := settings.Settings{
+		Feeds:      []string{"http://example.com/rust", "http://example.com/go"},
+		FeedGroups: []subscription.FeedGroup{{Name: "Lang", Feeds: []string{"http://example.com/rust", "http://example.com/go"}}},
+	}
+	subs := &stubSubscriptionRepo{feeds: cfg.Feeds, groups: cfg.FeedGroups}
+	return newTestModel(cfg, subs, &stubHistoryRepo{}, &stubFeedFetcher{}), subs
+}
+
+func TestFeedFilterTakesUndoKey(t *testing.T) {
+	m, subs := newFeedFilterModel()
+	snapshot := &state.FeedGroupingSnapshot{Ungrouped: []string{"http://example.com/rust", "http://example.com/go"}}
+	m.state.FeedGroupingUndo = snapshot
+
+	m, _ = typeKeys(m, "/rust")
+	requireFilterTyped(t, m, &m.state.FeedList, state.FeedView, "rust")
+	if m.state.FeedGroupingUndo != snapshot || len(subs.groups) != 1 {
+		t.Fatalf("undo = %v, groups = %v, want grouping left alone", m.state.FeedGroupingUndo, subs.groups)
+	}
+}
+
+func TestArticleFilterTakesOpenRandomKey(t *testing.T) {
+	m := newRandomPickModel(map[string]*reading.HistoryItem{
+		"u1": {GUID: "u1", Title: "Unread", FeedTitle: "Example", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: time.Now()},
+	}, 1)
+
+	m, _ = typeKeys(m, "/date:today")
+	requireFilterTyped(t, m, &m.state.ArticleList, state.ArticleView, "date:today")
+	if m.state.StatusMessage != "" {
+		t.Fatalf("StatusMessage = %q, want no random pick", m.state.StatusMessage)
+	}
+}
+
+func TestArticleFilterTakesExpandRowKey(t *testing.T) {
+	m := newRandomPickModel(map[string]*reading.HistoryItem{
+		"u1": {GUID: "u1", Title: "Go release", Description: "Notes", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: time.Now()},
+	}, 1)
+
+	m, _ = typeKeys(m, "/release")
+	requireFilterTyped(t, m, &m.state.ArticleList, state.ArticleView, "release")
+	if m.state.ExpandedGUID != "" {
+		t.Fatalf("ExpandedGUID = %q, want no row expanded", m.state.ExpandedGUID)
+	}
+}
+
+func TestFeedFilterTakesUnreadFeedsKey(t *testing.T) {
+	m, _ := newFeedFilterModel()
+
+	m, _ = typeKeys(m, "/Ubuntu")
+	requireFilterTyped(t, m, &m.state.FeedList, state.FeedView, "Ubuntu")
+	if m.state.UnreadFeedsOnly {
+		t.Fatal("typing a filter should not switch to unread feeds only")
+	}
+}
+
+func TestFeedFilterTakesManageFeedsKey(t *testing.T) {
+	m, _ := newFeedFilterModel()
+
+	m, _ = typeKeys(m, "/Mastodon")
+	requireFilterTyped(t, m, &m.state.FeedList, state.FeedView, "Mastodon")
+}
+
+func TestFeedFilterTakesRefreshAllKey(t *testing.T) {
+	m, _ := newFeedFilterModel()
+
+	m, _ = typeKeys(m, "/RSS")
+	requireFilterTyped(t, m, &m.state.FeedList, state.FeedView, "RSS")
+	if m.state.Loading || m.state.FetchProgress != nil {
+		t.Fatalf("loading = %v, progress = %v, want no refresh", m.state.Loading, m.state.FetchProgress)
+	}
+}
+
+func TestFeedFilterTakesRefreshGroupKey(t *testing.T) {
+	m, _ := newFeedFilterModel()
+
+	m, _ = typeKeys(m, "/go")
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	m = tm.(*Model)
+	requireFilterTyped(t, m, &m.state.FeedList, state.FeedView, "go")
+	if m.state.StatusMessage != "" || m.state.RefreshGroupPending != "" {
+		t.Fatalf("status = %q, pending = %q, want no group refresh", m.state.StatusMessage, m.state.RefreshGroupPending)
+	}
+}
+
+func TestFeedFilterTakesClearHistoryKey(t *testing.T) {
+	m, _ := newFeedFilterModel()
+
+	m, _ = typeKeys(m, "/Xbox")
+	requireFilterTyped(t, m, &m.state.FeedList, state.FeedView, "Xbox")
+}
+
+func TestFeedFilterTakesImportFeedsKey(t *testing.T) {
+	m, _ := newFeedFilterModel()
+
+	m, _ = typeKeys(m, "/Infoq")
+	requireFilterTyped(t, m, &m.state.FeedList, state.FeedView, "Infoq")
+}
+
+func TestArticleFilterTakesDismissKey(t *testing.T) {
+	m := newRandomPickModel(map[string]*reading.HistoryItem{
+		"u1": {GUID: "u1", Title: "Dune review", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: time.Now()},
+	}, 1)
+
+	m, _ = typeKeys(m, "/Dune")
+	requireFilterTyped(t, m, &m.state.ArticleList, state.ArticleView, "Dune")
+	if item, _ := m.state.History.Item("u1"); item == nil || item.IsDismissed {
+		t.Fatalf("item = %+v, want it kept", item)
+	}
+}
+
+func TestArticleFilterTakesSnoozeKey(t *testing.T) {
+	m := newRandomPickModel(map[string]*reading.HistoryItem{
+		"u1": {GUID: "u1", Title: "Zig 1.0", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: time.Now()},
+	}, 1)
+
+	m, _ = typeKeys(m, "/Zig")
+	requireFilterTyped(t, m, &m.state.ArticleList, state.ArticleView, "Zig")
+}
+
+func TestArticleFilterTakesSelectAndExportKeys(t *testing.T) {
+	m := newRandomPickModel(map[string]*reading.HistoryItem{
+		"u1": {GUID: "u1", Title: "Vim tips", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: time.Now()},
+	}, 1)
+
+	m, _ = typeKeys(m, "/VimY")
+	requireFilterTyped(t, m, &m.state.ArticleList, state.ArticleView, "VimY")
+	if len(m.state.Selected) != 0 || m.state.StatusMessage != "" {
+		t.Fatalf("selected = %v, status = %q, want nothing selected or exported", m.state.Selected, m.state.StatusMessage)
+	}
+}
+
+func TestArticleFilterTakesSummarizeMissingKey(t *testing.T) {
+	m := newRandomPickModel(map[string]*reading.HistoryItem{
+		"u1": {GUID: "u1", Title: "AI news", Content: "Body", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: time.Now()},
+	}, 1)
+
+	m, _ = typeKeys(m, "/AI")
+	requireFilterTyped(t, m, &m.state.ArticleList, state.ArticleView, "AI")
+	if m.state.SummaryBatch != nil || m.state.StatusMessage != "" {
+		t.Fatalf("batch = %+v, status = %q, want no summary batch", m.state.SummaryBatch, m.state.StatusMessage)
+	}
+}
+
+func TestArticleFilterQualifiersTypedThroughModel(t *testing.T) {
+	now := time.Now()
+	cfg := settings.Settings{Feeds: []string{"http://go.example/feed", "http://rust.example/feed"}}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"go":   {GUID: "go", Title: "Generics", FeedTitle: "Go Blog", FeedURL: "http://go.example/feed", Kind: reading.ArticleKind, Date: now},
+		"rust": {GUID: "rust", Title: "Editions", FeedTitle: "Rust Blog", FeedURL: "http://rust.example/feed", Kind: reading.ArticleKind, Date: now.AddDate(0, 0, -3)},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: reading.AllFeedsURL}
+	update.ApplyArticleList(m.state, reading.AllFeedsURL)
+
+	for _, tt := range []struct {
+		filter string
+		want   []string
+	}{
+		{filter: "feed:rust", want: []string{"rust"}},
+		{filter: "date:today", want: []string{"go"}},
+	} {
+		m, _ = typeKeys(m, "/"+tt.filter)
+		requireFilterTyped(t, m, &m.state.ArticleList, state.ArticleView, tt.filter)
+		// The list matches typed text in a command; apply the typed value
+		// directly to read the result.
+		m.state.ArticleList.SetFilterText(m.state.ArticleList.FilterValue())
+		if got := visibleGUIDs(m); !slices.Equal(got, tt.want) {
+			t.Fatalf("%s shows %v, want %v", tt.filter, got, tt.want)
+		}
+		m.state.ArticleList.ResetFilter()
+	}
+}
@@ -0,0 +1,84 @@
+// Package glyph provides the indicator glyph sets the TUI can draw with.
+package glyph
+
+import "github.com/tesso57/reazy/internal/application/settings"
+
+// Set holds the glyphs used for header labels and list and progress markers.
+type Set struct {
+	// Link labels the header line with the selected article URL.
+	Link string
+	// Preview labels the header line with the highlighted feed's newest title.
+	Preview string
+	// Feed labels the header line with the feed title.
+	Feed string
+	// LastOpened prefixes the most recently opened article.
+	LastOpened string
+	// Selected prefixes articles picked for export.
+	Selected string
+	// Reopened precedes how often an article was opened, e.g. "×3".
+	Reopened string
+	// Pending, Succeeded and Failed mark feeds in progress lists.
+	Pending   string
+	Succeeded string
+	Failed    string
+}
+
+// Emoji is the default set. The feed label keeps a trailing space because
+// many terminals draw the tag emoji wider than its reported width.
+var Emoji = Set{
+	Link:       "🔗",
+	Preview:    "📰",
+	Feed:       "🏷️ ",
+	LastOpened: "·",
+	Selected:   "✔",
+	Reopened:   "×",
+	Pending:    "⏳",
+	Succeeded:  "✓",
+	Failed:     "✗",
+}
+
+// ASCII draws with plain ASCII for terminals and fonts without emoji.
+var ASCII = Set{
+	Link:       "[L]",
+	Preview:    "[N]",
+	Feed:       "[F]",
+	LastOpened: ">",
+	Selected:   "+",
+	Reopened:   "x",
+	Pending:    "..",
+	Succeeded:  "ok",
+	Failed:     "!!",
+}
+
+// NerdFont draws with Nerd Font icons from the Private Use Area.
+var NerdFont = Set{
+	Link:       "\uf0c1",
+	Preview:    "\uf1ea",
+	Feed:       "\uf02b",
+	LastOpened: "\uf105",
+	Selected:   "\uf14a",
+	Reopened:   "×",
+	Pending:    "\uf252",
+	Succeeded:  "\uf00c",
+	Failed:     "\uf00d",
+}
+
+// For returns the set named by an icons setting, falling back to Emoji.
+func For(icons string) Set {
+	switch settings.NormalizeIcons(icons) {
+	case settings.IconsASCII:
+		return ASCII
+	case settings.IconsNerdFont:
+		return NerdFont
+	default:
+		return Emoji
+	}
+}
+
+// OrDefault returns s, or Emoji when s is the zero Set.
+func (s Set) OrDefault() Set {
+	if s == (Set{}) {
+		return Emoji
+	}
+	return s
+}
@@ -0,0 +1,27 @@
+package glyph
+
+import "testing"
+
+func TestFor(t *testing.T) {
+	tests := map[string]Set{
+		"":         Emoji,
+		"emoji":    Emoji,
+		" ASCII ":  ASCII,
+		"nerdfont": NerdFont,
+		"unknown":  Emoji,
+	}
+	for icons, want := range tests {
+		if got := For(icons); got != want {
+			t.Errorf("For(%q) = %+v, want %+v", icons, got, want)
+		}
+	}
+}
+
+func TestOrDefault(t *testing.T) {
+	if got := (Set{}).OrDefault(); got != Emoji {
+		t.Fatalf("zero Set should fall back to Emoji, got %+v", got)
+	}
+	if got := ASCII.OrDefault(); got != ASCII {
+		t.Fatalf("OrDefault() = %+v, want ASCII kept", got)
+	}
+}
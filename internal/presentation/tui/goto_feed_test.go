@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/domain/subscription"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+func typeKeys(m *Model, keys string) (*Model, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, r := range keys {
+		var tm tea.Model
+		tm, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = tm.(*Model)
+	}
+	return m, cmd
+}
+
+func TestGotoFeedOpensFeedByNumber(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds: []string{"http://example.com/a", "http://example.com/b", "http://example.com/c"},
+		FeedGroups: []subscription.FeedGroup{
+			{Name: "Tech", Feeds: []string{"http://example.com/a"}},
+		},
+		KeyMap: settings.KeyMapConfig{GotoFeed: ":"},
+	}
+	repo := &stubSubscriptionRepo{feeds: append([]string(nil), cfg.Feeds...)}
+	m := newTestModel(cfg, repo, &stubHistoryRepo{items: map[string]*reading.HistoryItem{}}, &stubFeedFetcher{})
+
+	m, _ = typeKeys(m, ":5")
+	if !m.state.GotoFeeding || m.state.StatusMessage != "Go to feed: 5" {
+		t.Fatalf("goto feed mode = %v, status = %q", m.state.GotoFeeding, m.state.StatusMessage)
+	}
+	selected, ok := m.state.FeedList.SelectedItem().(*presenter.Item)
+	if !ok || !strings.HasPrefix(selected.TitleText, "5. ") {
+		t.Fatalf("selected = %#v, want the entry numbered 5", selected)
+	}
+
+	tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+	if m.state.Session != state.ArticleView || cmd == nil {
+		t.Fatalf("session = %v, want ArticleView with a fetch command", m.state.Session)
+	}
+	if m.state.GotoFeeding {
+		t.Fatal("goto feed mode should end after opening the feed")
+	}
+}
+
+func TestGotoFeedUnknownNumberStaysInFeedView(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/a"},
+		KeyMap: settings.KeyMapConfig{GotoFeed: ":"},
+	}
+	repo := &stubSubscriptionRepo{feeds: append([]string(nil), cfg.Feeds...)}
+	m := newTestModel(cfg, repo, &stubHistoryRepo{items: map[string]*reading.HistoryItem{}}, &stubFeedFetcher{})
+
+	m, _ = typeKeys(m, ":12")
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = tm.(*Model)
+	if m.state.GotoFeedInput != "1" {
+		t.Fatalf("input after backspace = %q, want 1", m.state.GotoFeedInput)
+	}
+	m, _ = typeKeys(m, "9")
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+	if m.state.Session != state.FeedView || m.state.StatusMessage != "No feed 19" {
+		t.Fatalf("session = %v, status = %q", m.state.Session, m.state.StatusMessage)
+	}
+
+	m, _ = typeKeys(m, ":3")
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = tm.(*Model)
+	if m.state.GotoFeeding || m.state.Session != state.FeedView || m.state.StatusMessage != "" {
+		t.Fatalf("esc should cancel: mode = %v, session = %v, status = %q", m.state.GotoFeeding, m.state.Session, m.state.StatusMessage)
+	}
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+// errProgramStopped is returned by HistoryBridge calls once the TUI quit.
+var errProgramStopped = errors.New("reazy is shutting down")
+
+// HistoryBridge gives code running outside the TUI, such as the control
+// API, the history the TUI shows. Each call is handed to the running
+// program and runs inside Update, so it never races with the UI, and
+// changes are shown right away.
+type HistoryBridge struct {
+	program *tea.Program
+	closed  chan struct{}
+	once    sync.Once
+}
+
+// NewHistoryBridge returns a bridge to program's history.
+func NewHistoryBridge(program *tea.Program) *HistoryBridge {
+	return &HistoryBridge{program: program, closed: make(chan struct{})}
+}
+
+// Close fails calls still waiting and every later call. Call it once the
+// program has exited.
+func (b *HistoryBridge) Close() {
+	b.once.Do(func() { close(b.closed) })
+}
+
+// ReadHistory runs fn, which only reads history, inside the TUI.
+func (b *HistoryBridge) ReadHistory(ctx context.Context, fn func(*reading.History) error) error {
+	return b.run(ctx, false, fn)
+}
+
+// UpdateHistory runs fn inside the TUI and then refreshes its lists.
+func (b *HistoryBridge) UpdateHistory(ctx context.Context, fn func(*reading.History) error) error {
+	return b.run(ctx, true, fn)
+}
+
+func (b *HistoryBridge) run(ctx context.Context, changes bool, fn func(*reading.History) error) error {
+	select {
+	case <-b.closed:
+		return errProgramStopped
+	default:
+	}
+	reply := make(chan error, 1)
+	// Send returns once the program took the message or has stopped.
+	b.program.Send(update.HistoryRequestMsg{Apply: fn, Update: changes, Reply: reply})
+	select {
+	case err := <-reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.closed:
+		return errProgramStopped
+	}
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+func TestHistoryBridgeRunsInsideTheProgram(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/a"}}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", Title: "One", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: time.Now()},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: "http://example.com/a"}
+	presenter.ApplyArticleList(&m.state.ArticleList, m.state.History, "http://example.com/a", presenter.ArticleListOptions{})
+
+	program := tea.NewProgram(m, tea.WithInput(nil), tea.WithOutput(io.Discard), tea.WithoutRenderer())
+	bridge := NewHistoryBridge(program)
+	done := make(chan error, 1)
+	go func() {
+		_, err := program.Run()
+		done <- err
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := bridge.UpdateHistory(ctx, func(history *reading.History) error {
+		return m.reading.MarkRead(history, "a1")
+	})
+	if err != nil {
+		t.Fatalf("UpdateHistory() error = %v", err)
+	}
+	var listedRead bool
+	err = bridge.ReadHistory(ctx, func(*reading.History) error {
+		// Runs inside Update, after the list was rebuilt.
+		item, ok := m.state.ArticleList.Items()[1].(*presenter.Item)
+		listedRead = ok && item.Read
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadHistory() error = %v", err)
+	}
+	if !listedRead {
+		t.Fatal("the article list should show the change made through the bridge")
+	}
+
+	program.Quit()
+	if err := <-done; err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	bridge.Close()
+	if err := bridge.ReadHistory(ctx, func(*reading.History) error { return nil }); !errors.Is(err, errProgramStopped) {
+		t.Fatalf("ReadHistory() after Close error = %v, want errProgramStopped", err)
+	}
+}
@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/presentation/tui/components/modal"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+func writeTestOPML(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "feeds.opml")
+	doc := `<opml version="2.0"><body>
+<outline text="Existing" xmlUrl="http://example.com/rss"/>
+<outline text="Go" xmlUrl="https://go.dev/blog/feed.atom"/>
+<outline text="Broken" xmlUrl="feed://example.com"/>
+</body></opml>`
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func typeText(m *Model, text string) *Model {
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+	return tm.(*Model)
+}
+
+func TestImportFeedsShowsSummaryAndUndoes(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/rss"},
+		KeyMap: settings.KeyMapConfig{ImportFeeds: "I"},
+	}
+	repo := &stubSubscriptionRepo{feeds: append([]string(nil), cfg.Feeds...)}
+	m := newTestModel(cfg, repo, &stubHistoryRepo{}, &stubFeedFetcher{})
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	m = typeText(m, "I")
+	if m.state.Session != state.ImportFeedsView || m.buildModalProps().Kind != modal.ImportFeeds {
+		t.Fatalf("session = %v, want the import prompt", m.state.Session)
+	}
+	m = typeText(m, writeTestOPML(t))
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+
+	if m.state.Session != state.ImportSummaryView {
+		t.Fatalf("session = %v, want ImportSummaryView (err %v)", m.state.Session, m.state.Err)
+	}
+	props := m.buildModalProps()
+	for _, want := range []string{"Added 1 feeds", "+ https://go.dev/blog/feed.atom", "= http://example.com/rss", "✗ feed://example.com", "u = undo import"} {
+		if !strings.Contains(props.Body, want) {
+			t.Fatalf("summary should contain %q:\n%s", want, props.Body)
+		}
+	}
+	if len(m.state.Feeds) != 2 || len(repo.feeds) != 2 {
+		t.Fatalf("feeds = %#v, repo = %#v, want the imported feed added", m.state.Feeds, repo.feeds)
+	}
+
+	m = typeText(m, "u")
+	if m.state.Session != state.FeedView || m.state.FeedImport != nil {
+		t.Fatalf("undo should close the summary, session = %v", m.state.Session)
+	}
+	if len(m.state.Feeds) != 1 || len(repo.feeds) != 1 || repo.feeds[0] != "http://example.com/rss" {
+		t.Fatalf("undo should keep only the original feed: state=%#v repo=%#v", m.state.Feeds, repo.feeds)
+	}
+	if !strings.Contains(m.state.StatusMessage, "Import undone") {
+		t.Fatalf("status = %q", m.state.StatusMessage)
+	}
+}
+
+func TestImportFeedsKeepsFoldersAndTitles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feeds.opml")
+	doc := `<opml version="2.0"><body>
+<outline text="Tech"><outline text="Go Blog" xmlUrl="https://go.dev/blog/feed.atom"/></outline>
+</body></opml>`
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/rss"},
+		KeyMap: settings.KeyMapConfig{ImportFeeds: "I"},
+	}
+	repo := &stubSubscriptionRepo{feeds: append([]string(nil), cfg.Feeds...)}
+	m := newTestModel(cfg, repo, &stubHistoryRepo{}, &stubFeedFetcher{})
+
+	m = typeText(m, "I")
+	m = typeText(m, path)
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+
+	if len(m.state.FeedGroups) != 1 || m.state.FeedGroups[0].Name != "Tech" {
+		t.Fatalf("groups = %#v, want the OPML folder as a group", m.state.FeedGroups)
+	}
+	var titles []string
+	for _, listItem := range m.state.FeedList.Items() {
+		titles = append(titles, listItem.(*presenter.Item).TitleText)
+	}
+	if !slices.ContainsFunc(titles, func(title string) bool { return strings.HasSuffix(title, ". Go Blog") }) {
+		t.Fatalf("sidebar = %q, want the outline title in place of the URL", titles)
+	}
+}
+
+func TestImportFeedsReportsUnreadableFile(t *testing.T) {
+	cfg := settings.Settings{KeyMap: settings.KeyMapConfig{ImportFeeds: "I"}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{}, &stubHistoryRepo{}, &stubFeedFetcher{})
+
+	m = typeText(m, "I")
+	m = typeText(m, filepath.Join(t.TempDir(), "missing.opml"))
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+	if m.state.Session != state.FeedView || m.state.Err == nil {
+		t.Fatalf("session = %v, err = %v; want feed view with an error", m.state.Session, m.state.Err)
+	}
+}
+
+type stubOPMLFetcher struct {
+	data []byte
+	err  error
+}
+
+func (f *stubOPMLFetcher) FetchOPML(context.Context, string) ([]byte, error) {
+	return f.data, f.err
+}
+
+func TestImportFeedsFromURL(t *testing.T) {
+	const listURL = "https://reader.example.com/subscriptions.opml"
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/rss"},
+		KeyMap: settings.KeyMapConfig{ImportFeeds: "I"},
+	}
+	repo := &stubSubscriptionRepo{feeds: append([]string(nil), cfg.Feeds...)}
+	m := newTestModel(cfg, repo, &stubHistoryRepo{}, &stubFeedFetcher{})
+	fetcher := &stubOPMLFetcher{data: []byte(`<opml version="2.0"><body><outline text="Go" xmlUrl="https://go.dev/blog/feed.atom"/></body></opml>`)}
+	m.subscriptions.OPMLFetcher = fetcher
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	m = typeText(m, "I")
+	m = typeText(m, listURL)
+	tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+	if cmd == nil || m.state.Session != state.FeedView || len(repo.feeds) != 1 {
+		t.Fatalf("entering a URL should start a download without subscribing yet, session = %v", m.state.Session)
+	}
+	msg, ok := cmd().(update.OPMLDownloadedMsg)
+	if !ok {
+		t.Fatalf("command should download the OPML list")
+	}
+	tm, _ = m.Update(msg)
+	m = tm.(*Model)
+	if m.state.Session != state.ImportSummaryView || m.state.Err != nil {
+		t.Fatalf("session = %v, want ImportSummaryView (err %v)", m.state.Session, m.state.Err)
+	}
+	if !slices.Equal(repo.feeds, []string{"http://example.com/rss", "https://go.dev/blog/feed.atom"}) {
+		t.Fatalf("repo feeds = %#v, want the downloaded feed added", repo.feeds)
+	}
+
+	m.state.Session = state.FeedView
+	fetcher.err = context.DeadlineExceeded
+	m = typeText(m, "I")
+	m = typeText(m, listURL)
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	tm, _ = m.Update(cmd())
+	m = tm.(*Model)
+	if m.state.Err == nil || m.state.Session != state.FeedView {
+		t.Fatalf("a failed download should report an error, session = %v", m.state.Session)
+	}
+}
@@ -15,14 +15,39 @@ const (
 	Quit
 	ToggleHelp
 	AddFeed
+	ImportFeeds
 	DeleteFeed
 	GroupFeeds
+	Undo
+	ClearHistory
+	ManageFeeds
+	MoveToGroup
+	RenameGroup
 	Open
 	Back
 	Refresh
 	Bookmark
 	Summarize
 	ToggleSummary
+	ToggleTitles
+	ExpandRow
+	ToggleUnreadFeeds
+	FetchFullText
+	GotoFeed
+	Snooze
+	Dismiss
+	RefreshAll
+	RefreshGroup
+	SummarizeMissing
+	AskAI
+	NavBack
+	NavForward
+	MarkFeedRead
+	OpenRandom
+	ToggleFocus
+	ToggleSelect
+	ExportSelected
+	TagStats
 )
 
 // Intent represents a parsed user intent.
@@ -39,22 +64,72 @@ func FromKeyMsg(msg tea.KeyMsg, keys state.KeyMap) Intent {
 		return Intent{Type: ToggleHelp}
 	case key.Matches(msg, keys.AddFeed):
 		return Intent{Type: AddFeed}
+	case key.Matches(msg, keys.ImportFeeds):
+		return Intent{Type: ImportFeeds}
 	case key.Matches(msg, keys.DeleteFeed):
 		return Intent{Type: DeleteFeed}
 	case key.Matches(msg, keys.GroupFeeds):
 		return Intent{Type: GroupFeeds}
+	case key.Matches(msg, keys.Undo):
+		return Intent{Type: Undo}
+	case key.Matches(msg, keys.ClearHistory):
+		return Intent{Type: ClearHistory}
+	case key.Matches(msg, keys.ManageFeeds):
+		return Intent{Type: ManageFeeds}
+	case key.Matches(msg, keys.MoveToGroup):
+		return Intent{Type: MoveToGroup}
+	case key.Matches(msg, keys.RenameGroup):
+		return Intent{Type: RenameGroup}
 	case key.Matches(msg, keys.Right) || key.Matches(msg, keys.Open):
 		return Intent{Type: Open}
 	case key.Matches(msg, keys.Left) || key.Matches(msg, keys.Back):
 		return Intent{Type: Back}
 	case key.Matches(msg, keys.Refresh):
 		return Intent{Type: Refresh}
+	case key.Matches(msg, keys.RefreshAll):
+		return Intent{Type: RefreshAll}
+	case key.Matches(msg, keys.RefreshGroup):
+		return Intent{Type: RefreshGroup}
 	case key.Matches(msg, keys.Bookmark):
 		return Intent{Type: Bookmark}
 	case key.Matches(msg, keys.Summarize):
 		return Intent{Type: Summarize}
 	case key.Matches(msg, keys.ToggleSummary) || msg.String() == "S":
 		return Intent{Type: ToggleSummary}
+	case key.Matches(msg, keys.ToggleTitles):
+		return Intent{Type: ToggleTitles}
+	case key.Matches(msg, keys.ExpandRow):
+		return Intent{Type: ExpandRow}
+	case key.Matches(msg, keys.UnreadFeeds):
+		return Intent{Type: ToggleUnreadFeeds}
+	case key.Matches(msg, keys.FetchFullText):
+		return Intent{Type: FetchFullText}
+	case key.Matches(msg, keys.GotoFeed):
+		return Intent{Type: GotoFeed}
+	case key.Matches(msg, keys.Snooze):
+		return Intent{Type: Snooze}
+	case key.Matches(msg, keys.Dismiss):
+		return Intent{Type: Dismiss}
+	case key.Matches(msg, keys.SummarizeMissing):
+		return Intent{Type: SummarizeMissing}
+	case key.Matches(msg, keys.AskAI):
+		return Intent{Type: AskAI}
+	case key.Matches(msg, keys.NavBack):
+		return Intent{Type: NavBack}
+	case key.Matches(msg, keys.NavForward):
+		return Intent{Type: NavForward}
+	case key.Matches(msg, keys.MarkFeedRead):
+		return Intent{Type: MarkFeedRead}
+	case key.Matches(msg, keys.OpenRandom):
+		return Intent{Type: OpenRandom}
+	case key.Matches(msg, keys.ToggleFocus):
+		return Intent{Type: ToggleFocus}
+	case key.Matches(msg, keys.ToggleSelect):
+		return Intent{Type: ToggleSelect}
+	case key.Matches(msg, keys.ExportSelected):
+		return Intent{Type: ExportSelected}
+	case key.Matches(msg, keys.TagStats):
+		return Intent{Type: TagStats}
 	default:
 		return Intent{Type: None}
 	}
@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/subscription"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+func TestManageFeedsView(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds: []string{"http://example.com/1", "http://example.com/2"},
+		KeyMap: settings.KeyMapConfig{
+			Down:        "j",
+			Back:        "esc",
+			DeleteFeed:  "x",
+			ManageFeeds: "M",
+		},
+	}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+
+	press := func(msg tea.KeyMsg) {
+		tm, _ := m.Update(msg)
+		m = tm.(*Model)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	if m.state.Session != state.ManageFeedsView {
+		t.Fatalf("session = %v, want manage feeds view", m.state.Session)
+	}
+
+	m.state.FeedList.Select(3)
+	update.HandleFeedFetchedMsg(m.state, update.FeedFetchedMsg{URL: "http://example.com/2", Err: errors.New("boom")}, m.deps())
+	body := buildManageFeedsBody(m.state, 120, time.Now())
+	if !strings.Contains(body, "> http://example.com/1") {
+		t.Fatalf("selected feed should be marked:\n%s", body)
+	}
+	if !strings.Contains(body, "boom") {
+		t.Fatalf("last fetch error should be shown:\n%s", body)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if m.state.Session != state.ManageFeedsView || m.state.FeedList.Index() != 4 {
+		t.Fatalf("down should move the row cursor, session=%v index=%d", m.state.Session, m.state.FeedList.Index())
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if m.state.Session != state.DeleteFeedView {
+		t.Fatalf("session = %v, want delete confirmation", m.state.Session)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if m.state.Session != state.ManageFeedsView {
+		t.Fatalf("delete should return to manage feeds view, got %v", m.state.Session)
+	}
+	if len(m.state.Feeds) != 1 || m.state.Feeds[0] != "http://example.com/1" {
+		t.Fatalf("unexpected feeds after delete: %#v", m.state.Feeds)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.state.Session != state.FeedView {
+		t.Fatalf("esc should return to feed view, got %v", m.state.Session)
+	}
+}
+
+func TestManageFeedsMovesFeedsAndRenamesGroups(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds: []string{"http://example.com/loose"},
+		FeedGroups: []subscription.FeedGroup{
+			{Name: "Tech", Feeds: []string{"http://example.com/a", "http://example.com/b"}},
+			{Name: "News", Feeds: []string{"http://example.com/c"}},
+		},
+		KeyMap: settings.KeyMapConfig{
+			ManageFeeds: "M",
+			MoveToGroup: "v",
+			RenameGroup: "n",
+		},
+	}
+	repo := &stubSubscriptionRepo{feeds: []string{"http://example.com/loose"}, groups: cfg.FeedGroups}
+	m := newTestModel(cfg, repo, &stubHistoryRepo{}, &stubFeedFetcher{})
+	m.state.FeedGroupingUndo = &state.FeedGroupingSnapshot{}
+	m, _ = typeKeys(m, "M")
+
+	edit := func(key, name string) {
+		t.Helper()
+		m, _ = typeKeys(m, key)
+		if m.state.Session != state.GroupEditView {
+			t.Fatalf("session = %v, want the group edit dialog", m.state.Session)
+		}
+		m.state.TextInput.SetValue(name)
+		m = pressKey(m, tea.KeyEnter)
+		if m.state.Session != state.ManageFeedsView {
+			t.Fatalf("session = %v, want manage feeds view", m.state.Session)
+		}
+	}
+
+	selectFeedByURL(t, m, "http://example.com/c")
+	edit("v", "Tech")
+	if want := []subscription.FeedGroup{{Name: "Tech", Feeds: []string{"http://example.com/a", "http://example.com/b", "http://example.com/c"}}}; !reflect.DeepEqual(repo.groups, want) {
+		t.Fatalf("groups after move = %#v, want %#v (empty News dropped)", repo.groups, want)
+	}
+	if m.state.FeedGroupingUndo != nil {
+		t.Fatal("a manual group edit should drop the grouping undo")
+	}
+
+	selectFeedByURL(t, m, "http://example.com/loose")
+	edit("v", "Blogs")
+	if len(repo.groups) != 2 || repo.groups[1].Name != "Blogs" || len(repo.feeds) != 0 {
+		t.Fatalf("moving an ungrouped feed should create the group: groups=%#v feeds=%#v", repo.groups, repo.feeds)
+	}
+
+	selectFeedByURL(t, m, "http://example.com/a")
+	edit("n", "Dev")
+	if repo.groups[0].Name != "Dev" || len(repo.groups[0].Feeds) != 3 {
+		t.Fatalf("groups after rename = %#v", repo.groups)
+	}
+	if len(m.state.Feeds) != 4 {
+		t.Fatalf("feeds = %#v, want every subscription kept", m.state.Feeds)
+	}
+
+	selectFeedByURL(t, m, "http://example.com/a")
+	edit("v", "")
+	if !slices.Equal(repo.feeds, []string{"http://example.com/a"}) {
+		t.Fatalf("an empty group name should ungroup the feed, ungrouped = %#v", repo.feeds)
+	}
+}
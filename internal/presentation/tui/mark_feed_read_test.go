@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+func newMarkFeedReadModel(t *testing.T, unread int) *Model {
+	t.Helper()
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/a", "http://example.com/b"},
+		KeyMap: settings.KeyMapConfig{MarkFeedRead: "m"},
+	}
+	items := map[string]*reading.HistoryItem{
+		"b1": {GUID: "b1", FeedURL: "http://example.com/b", Kind: reading.ArticleKind, Date: time.Now()},
+	}
+	for i := range unread {
+		guid := fmt.Sprintf("a%d", i)
+		items[guid] = &reading.HistoryItem{GUID: guid, FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: time.Now()}
+	}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{items: items}, &stubFeedFetcher{})
+	for i, listItem := range m.state.FeedList.Items() {
+		if item, ok := listItem.(*presenter.Item); ok && item.Link == "http://example.com/a" {
+			m.state.FeedList.Select(i)
+			return m
+		}
+	}
+	t.Fatal("feed a is not listed")
+	return nil
+}
+
+func TestMarkFeedReadMarksSmallFeedsDirectly(t *testing.T) {
+	m := newMarkFeedReadModel(t, 2)
+
+	m, _ = typeKeys(m, "m")
+	if m.state.Session != state.FeedView {
+		t.Fatalf("Session = %v, want no confirmation for 2 unread", m.state.Session)
+	}
+	if m.state.StatusMessage != "example.com: marked 2 articles read" {
+		t.Fatalf("StatusMessage = %q", m.state.StatusMessage)
+	}
+	counts := m.state.History.UnreadCountByFeed()
+	if counts["http://example.com/a"] != 0 || counts["http://example.com/b"] != 1 {
+		t.Fatalf("unread counts = %#v, want only feed b unread", counts)
+	}
+
+	m, _ = typeKeys(m, "m")
+	if m.state.StatusMessage != "example.com: no unread articles" {
+		t.Fatalf("StatusMessage = %q, want nothing left to mark", m.state.StatusMessage)
+	}
+}
+
+func TestMarkFeedReadConfirmsLargeFeeds(t *testing.T) {
+	m := newMarkFeedReadModel(t, 20)
+
+	m, _ = typeKeys(m, "m")
+	if m.state.Session != state.MarkFeedReadView || m.state.MarkReadFeedCount != 20 {
+		t.Fatalf("Session = %v, count = %d; want a confirmation for 20 unread", m.state.Session, m.state.MarkReadFeedCount)
+	}
+	if props := m.buildModalProps(); props.Body == "" {
+		t.Fatal("confirmation modal should have a body")
+	}
+
+	m, _ = typeKeys(m, "n")
+	if m.state.Session != state.FeedView || m.state.History.UnreadCountByFeed()["http://example.com/a"] != 20 {
+		t.Fatal("declining should keep the articles unread")
+	}
+
+	m, _ = typeKeys(m, "my")
+	if m.state.Session != state.FeedView || m.state.MarkReadFeedURL != "" {
+		t.Fatalf("Session = %v, want the feed view after confirming", m.state.Session)
+	}
+	if n := m.state.History.UnreadCountByFeed()["http://example.com/a"]; n != 0 {
+		t.Fatalf("unread = %d, want every article read", n)
+	}
+	if m.state.StatusMessage != "example.com: marked 20 articles read" {
+		t.Fatalf("StatusMessage = %q", m.state.StatusMessage)
+	}
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+func visibleGUIDs(m *Model) []string {
+	var guids []string
+	for _, listItem := range m.state.ArticleList.VisibleItems() {
+		if item, ok := listItem.(*presenter.Item); ok && !item.IsSectionHeader() {
+			guids = append(guids, item.GUID)
+		}
+	}
+	return guids
+}
+
+func TestFilterFindsArticlesOlderThanMaxAge(t *testing.T) {
+	now := time.Now()
+	cfg := settings.Settings{
+		Feeds:         []string{"http://example.com/a"},
+		MaxArticleAge: "30d",
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"fresh": {GUID: "fresh", Title: "Fresh story", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now.Add(-time.Hour)},
+		"stale": {GUID: "stale", Title: "Archived story", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now.Add(-60 * 24 * time.Hour)},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = tm.(*Model)
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: "http://example.com/a"}
+	update.ApplyArticleList(m.state, "http://example.com/a")
+	if got := visibleGUIDs(m); !slices.Equal(got, []string{"fresh"}) {
+		t.Fatalf("visible = %v, want the old article hidden", got)
+	}
+
+	m, _ = typeKeys(m, "/")
+	if got := visibleGUIDs(m); !slices.Equal(got, []string{"fresh", "stale"}) {
+		t.Fatalf("visible while filtering = %v, want every article", got)
+	}
+	m.state.ArticleList.SetFilterText("archived")
+	if got := visibleGUIDs(m); !slices.Equal(got, []string{"stale"}) {
+		t.Fatalf("filtered = %v, want the old article found", got)
+	}
+
+	m, _ = typeKeys(m, "/jj")
+	if m.state.ArticleList.FilterState() != list.Unfiltered {
+		t.Fatalf("filter state = %v, want cleared", m.state.ArticleList.FilterState())
+	}
+	if got := visibleGUIDs(m); !slices.Equal(got, []string{"fresh"}) {
+		t.Fatalf("visible = %v, want the old article hidden again", got)
+	}
+}
@@ -8,6 +8,46 @@ const (
 	SidebarRightBorderWidth = 1
 	NewsTopicSummaryLines   = 8
 
+	// DetailMetaRowMinWidth is the header width from which the detail view
+	// shows its article metadata on one row instead of stacked lines.
+	DetailMetaRowMinWidth = 72
+	// StackedDetailHeaderLines is the detail header height below
+	// DetailMetaRowMinWidth.
+	StackedDetailHeaderLines = 3
+
 	ItemRightPadding  = 1
 	ItemSafetyPadding = 1
 )
+
+// ExpandedRowLines returns how many description lines an expanded article
+// row adds, given the configured count; at least one.
+func ExpandedRowLines(configured int) int {
+	return max(configured, 1)
+}
+
+// SidebarWidth returns the sidebar width in a window width columns wide.
+func SidebarWidth(width int) int {
+	return width / 3
+}
+
+// MainWidth returns the main pane width beside the sidebar in a window
+// width columns wide.
+func MainWidth(width int) int {
+	return width - SidebarWidth(width) - SidebarRightBorderWidth
+}
+
+// DetailFullScreen reports whether the detail view hides the sidebar in a
+// window width columns wide, because the main pane beside it would be
+// narrower than minReadingWidth. A minReadingWidth of 0 never hides it.
+func DetailFullScreen(width, minReadingWidth int) bool {
+	return minReadingWidth > 0 && MainWidth(width) < minReadingWidth
+}
+
+// DetailHeaderLines returns the detail view header height for a header
+// width columns wide.
+func DetailHeaderLines(width int) int {
+	if width < DetailMetaRowMinWidth {
+		return StackedDetailHeaderLines
+	}
+	return HeaderLines
+}
@@ -1,7 +1,15 @@
 package tui
 
 import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -12,6 +20,7 @@ import (
 	"github.com/tesso57/reazy/internal/application/usecase"
 	"github.com/tesso57/reazy/internal/domain/reading"
 	"github.com/tesso57/reazy/internal/domain/subscription"
+	"github.com/tesso57/reazy/internal/presentation/tui/glyph"
 	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
 	"github.com/tesso57/reazy/internal/presentation/tui/state"
 	"github.com/tesso57/reazy/internal/presentation/tui/update"
@@ -19,14 +28,23 @@ import (
 	listview "github.com/tesso57/reazy/internal/presentation/tui/view/list"
 )
 
+// autoSummarizeConcurrency caps AI summaries running at once when articles
+// are summarized on open.
+const autoSummarizeConcurrency = 1
+
 // Model represents the main application state.
 type Model struct {
+	ctx           context.Context
+	cancel        context.CancelFunc
 	settings      settings.Settings
 	subscriptions *usecase.SubscriptionService
 	reading       *usecase.ReadingService
 	insights      *usecase.InsightService
 	newsDigests   *usecase.NewsDigestService
 	feedGrouping  *usecase.FeedGroupingService
+	tokens        *usecase.TokenMeter
+	saves         sync.WaitGroup
+	random        *rand.Rand
 	state         *state.ModelState
 }
 
@@ -39,15 +57,18 @@ func NewModel(cfg settings.Settings, subscriptions *usecase.SubscriptionService,
 		usecase.NewInsightService(nil, nil),
 		usecase.NewNewsDigestService(nil, nil, nil),
 		usecase.NewFeedGroupingService(nil),
+		nil,
 	)
 }
 
 // NewModelWithInsights creates a new application model with AI insights support.
 func NewModelWithInsights(cfg settings.Settings, subscriptions *usecase.SubscriptionService, readingSvc *usecase.ReadingService, insightSvc *usecase.InsightService) *Model {
-	return NewModelWithServices(cfg, subscriptions, readingSvc, insightSvc, usecase.NewNewsDigestService(nil, nil, nil), usecase.NewFeedGroupingService(nil))
+	return NewModelWithServices(cfg, subscriptions, readingSvc, insightSvc, usecase.NewNewsDigestService(nil, nil, nil), usecase.NewFeedGroupingService(nil), nil)
 }
 
-// NewModelWithServices creates a new application model with all optional AI services.
+// NewModelWithServices creates a new application model with all optional AI
+// services. tokens is the meter the services report their prompts to, shown
+// in the footer; nil shows no estimate.
 func NewModelWithServices(
 	cfg settings.Settings,
 	subscriptions *usecase.SubscriptionService,
@@ -55,32 +76,52 @@ func NewModelWithServices(
 	insightSvc *usecase.InsightService,
 	newsDigestSvc *usecase.NewsDigestService,
 	feedGroupingSvc *usecase.FeedGroupingService,
+	tokens *usecase.TokenMeter,
 ) *Model {
+	if cfg.AI.AutoSummarizeOnOpen && insightSvc != nil {
+		// Browsing quickly must not start one AI process per opened article.
+		insightSvc.LimitConcurrency(autoSummarizeConcurrency)
+	}
+	st := newModelState(cfg, readingSvc)
+	if newsDigestSvc != nil {
+		newsDigestSvc.Sanitizer = st.ContentSanitizer
+	}
+	st.NewsShowsToday = cfg.NewsDigest.FallbackToToday && !newsDigestSvc.Enabled()
+	ctx, cancel := context.WithCancel(context.Background())
 	return new(Model{
+		ctx:           ctx,
+		cancel:        cancel,
 		settings:      cfg,
 		subscriptions: subscriptions,
 		reading:       readingSvc,
 		insights:      insightSvc,
 		newsDigests:   newsDigestSvc,
 		feedGrouping:  feedGroupingSvc,
-		state:         newModelState(cfg, readingSvc),
+		tokens:        tokens,
+		state:         st,
 	})
 }
 
 // Init initializes the model.
 func (m *Model) Init() tea.Cmd {
-	return tea.Batch(m.state.Spinner.Tick, textinput.Blink)
+	cmds := []tea.Cmd{m.state.Spinner.Tick, textinput.Blink, func() tea.Msg { return update.SnoozeTickMsg{} }, update.LoadingTickCmd()}
+	if m.settings.NewsDigest.GenerateOnStartup {
+		cmds = append(cmds, update.StartupNewsDigestCmd(m.state, m.deps()))
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update handles messages and updates the model state.
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
+	prevFilter := m.state.ArticleList.FilterState()
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		cmd, handled := update.HandleKeyMsg(m.state, msg, m.deps())
 		if handled {
+			update.SyncArticleAgeCutoff(m.state, prevFilter)
 			update.UpdateListSizes(m.state)
 			return m, cmd
 		}
@@ -88,14 +129,30 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		update.HandleWindowSize(m.state, msg)
 	case update.FeedFetchedMsg:
 		cmds = append(cmds, update.HandleFeedFetchedMsg(m.state, msg, m.deps()))
+	case update.SnoozeTickMsg:
+		cmds = append(cmds, update.HandleSnoozeTickMsg(m.state, m.deps()))
+	case update.LoadingTickMsg:
+		cmds = append(cmds, update.HandleLoadingTickMsg(m.state, msg))
+	case update.FeedValidatedMsg:
+		update.HandleFeedValidatedMsg(m.state, msg, m.deps())
+	case update.OPMLDownloadedMsg:
+		update.HandleOPMLDownloadedMsg(m.state, msg, m.deps())
+	case update.FetchProgressMsg:
+		cmds = append(cmds, update.HandleFetchProgressMsg(m.state, msg))
 	case update.NewsDigestGeneratedMsg:
 		update.HandleNewsDigestGeneratedMsg(m.state, msg, m.deps())
 	case update.FeedGroupingCompletedMsg:
 		update.HandleFeedGroupingCompletedMsg(m.state, msg)
 	case update.InsightGeneratedMsg:
-		update.HandleInsightGeneratedMsg(m.state, msg, m.deps())
+		cmds = append(cmds, update.HandleInsightGeneratedMsg(m.state, msg, m.deps()))
+	case update.ArticleAnswerMsg:
+		update.HandleArticleAnswerMsg(m.state, msg)
 	case update.ArticleDetailLoadedMsg:
 		cmds = append(cmds, update.HandleArticleDetailLoadedMsg(m.state, msg, m.deps()))
+	case update.ArticlesExportedMsg:
+		update.HandleArticlesExportedMsg(m.state, msg)
+	case update.HistoryRequestMsg:
+		update.HandleHistoryRequestMsg(m.state, msg)
 	}
 
 	if m.state.Loading {
@@ -106,7 +163,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m.state.Session {
 	case state.FeedView:
 		prevIdx := m.state.FeedList.Index()
-		m.state.FeedList, cmd = m.state.FeedList.Update(msg)
+		if update.WrapListNavigation(m.state, &m.state.FeedList, msg) {
+			cmd = nil
+		} else {
+			m.state.FeedList, cmd = m.state.FeedList.Update(msg)
+		}
 		if m.state.FeedList.Index() != prevIdx {
 			m.state.Err = nil
 			if i, ok := m.state.FeedList.SelectedItem().(*presenter.Item); ok {
@@ -116,23 +177,36 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, tea.Batch(cmds...)
 				}
 
-				presenter.ApplyArticleList(&m.state.ArticleList, m.state.History, i.Link)
+				update.ApplyArticleList(m.state, i.Link)
 				update.UpdateListSizes(m.state)
 
 				if len(m.state.ArticleList.Items()) == 0 {
-					m.state.Loading = true
-					cmds = append(cmds, tea.Batch(m.state.Spinner.Tick, update.FetchFeedCmd(m.reading, i.Link, m.state.Feeds)))
+					update.BeginLoading(m.state, update.LoadingFeeds)
+					cmds = append(cmds, tea.Batch(m.state.Spinner.Tick, update.FetchFeedCmd(m.ctx, m.reading, i.Link, m.state.Feeds)))
 				} else {
 					m.state.Loading = false
 				}
 			}
 		}
 		cmds = append(cmds, cmd)
-	case state.ArticleView:
-		m.state.ArticleList, cmd = m.state.ArticleList.Update(msg)
+	case state.ArticleView, state.NewsTopicView:
+		prevIdx := m.state.ArticleList.Index()
+		if update.WrapListNavigation(m.state, &m.state.ArticleList, msg) {
+			cmd = nil
+		} else {
+			m.state.ArticleList, cmd = m.state.ArticleList.Update(msg)
+		}
+		if m.state.ArticleList.Index() != prevIdx {
+			update.CollapseExpandedRow(m.state)
+		}
+		update.SyncArticleAgeCutoff(m.state, prevFilter)
 		cmds = append(cmds, cmd)
-	case state.NewsTopicView:
-		m.state.ArticleList, cmd = m.state.ArticleList.Update(msg)
+	case state.ManageFeedsView:
+		if update.WrapListNavigation(m.state, &m.state.FeedList, msg) {
+			cmd = nil
+		} else {
+			m.state.FeedList, cmd = m.state.FeedList.Update(msg)
+		}
 		cmds = append(cmds, cmd)
 	case state.DetailView:
 		m.state.Viewport, cmd = m.state.Viewport.Update(msg)
@@ -147,6 +221,12 @@ func (m *Model) View() string {
 	return view.Render(m.buildProps())
 }
 
+// ShowStatus adds msg to the status bar, e.g. to report startup work done
+// before the model was built.
+func (m *Model) ShowStatus(msg string) {
+	m.state.StatusMessage = update.JoinStatus(msg, m.state.StatusMessage)
+}
+
 func (m *Model) deps() update.Deps {
 	return update.Deps{
 		Subscriptions: m.subscriptions,
@@ -155,37 +235,99 @@ func (m *Model) deps() update.Deps {
 		NewsDigests:   m.newsDigests,
 		FeedGrouping:  m.feedGrouping,
 		OpenBrowser:   openBrowser,
+		Context:       m.ctx,
+		CancelFetches: m.cancel,
+		Saves:         &m.saves,
+		Rand:          m.random,
 	}
 }
 
 func newModelState(cfg settings.Settings, readingSvc *usecase.ReadingService) *state.ModelState {
+	// Settings built without loading a config file carry only the patterns.
+	sanitizer := cfg.ContentSanitizer
+	if sanitizer == nil {
+		// Invalid patterns are rejected when the config is loaded; keep the valid ones.
+		sanitizer, _ = reading.NewContentSanitizer(cfg.ContentStripPatterns)
+	}
 	st := new(state.ModelState{
-		Session:             state.FeedView,
-		FeedList:            newFeedList(cfg),
-		ArticleList:         newArticleList(),
-		TextInput:           newTextInput(),
-		Viewport:            newViewport(),
-		Help:                help.New(),
-		Spinner:             newSpinner(),
-		Keys:                state.NewKeyMap(cfg.KeyMap),
-		History:             loadHistory(readingSvc),
-		Feeds:               append([]string(nil), cfg.FlattenedFeeds()...),
-		FeedGroups:          cloneFeedGroups(cfg.FeedGroups),
-		ShowAISummary:       true,
-		DetailParentSession: state.ArticleView,
+		Session:                  state.FeedView,
+		FeedList:                 newFeedList(cfg),
+		ArticleList:              newArticleList(cfg),
+		TextInput:                newTextInput(),
+		AskInput:                 newAskInput(),
+		Viewport:                 newViewport(),
+		Help:                     help.New(),
+		Spinner:                  newSpinner(),
+		Keys:                     state.NewKeyMap(cfg.KeyMap),
+		History:                  loadHistory(readingSvc),
+		Feeds:                    append([]string(nil), cfg.FlattenedFeeds()...),
+		FeedGroups:               cloneFeedGroups(cfg.FeedGroups),
+		BuiltinTabs:              cfg.EnabledBuiltinTabs(),
+		ShowAISummary:            cfg.ShowAISummaryDefault,
+		ReadingWidth:             cfg.ReadingWidth,
+		MinReadingWidth:          cfg.MinReadingWidth,
+		PageSize:                 cfg.PageSize,
+		WrapListNavigation:       cfg.WrapListNavigation,
+		FilterExitEsc:            cfg.ExitsFilterWithEsc(),
+		MarkReadViews:            markReadViews(cfg),
+		FullTextFeeds:            cfg.FullTextFeeds(),
+		FeedTitles:               cfg.FeedTitles(),
+		SectionHeaderFormat:      cfg.SectionHeaderFormat,
+		GroupSort:                cfg.GroupSort,
+		FeedTagMaxChars:          cfg.FeedTagMaxChars,
+		ExpandRowLines:           cfg.ExpandRowLines,
+		MaxArticleAge:            cfg.ArticleMaxAge(),
+		CollapseDuplicateTitles:  cfg.CollapseDuplicateTitles,
+		BookmarksIgnoreReadDim:   cfg.Bookmarks.IgnoreReadDim,
+		RememberNewsSelection:    cfg.NewsDigest.RememberSelection,
+		FeedPreview:              cfg.FeedPreview,
+		OpenInBrowser:            cfg.OpensInBrowser(),
+		ContentSanitizer:         sanitizer,
+		ResolveRelativeLinks:     cfg.ResolveRelativeLinks,
+		Glyphs:                   glyph.For(cfg.Icons),
+		AIFallback:               cfg.AI.FallbackWhenUnavailable,
+		AutoSummarizeOnOpen:      cfg.AI.AutoSummarizeOnOpen,
+		PreserveManualGroups:     cfg.Grouping.PreserveManual,
+		MinGroupingFeeds:         cfg.Grouping.MinFeeds,
+		HeuristicGrouping:        cfg.GroupsHeuristically(),
+		FollowPermanentRedirects: cfg.FollowPermanentRedirects,
+		ValidateNewFeeds:         cfg.ValidateNewFeeds,
+		ShutdownTimeout:          time.Duration(cfg.ShutdownTimeoutSeconds) * time.Second,
+		LoadingTimeout:           time.Duration(cfg.LoadingTimeoutSeconds) * time.Second,
+		DetailParentSession:      state.ArticleView,
 	})
 
+	st.StatusMessage = keyConflictStatus(st.Keys)
+	if cfg.NewsSelection != nil {
+		st.NewsSelectionDate = cfg.NewsSelection.Date
+		st.NewsSelectionGUID = cfg.NewsSelection.GUID
+	}
+
 	st.FeedList.KeyMap.PrevPage = st.Keys.UpPage
 	st.FeedList.KeyMap.NextPage = st.Keys.DownPage
 	st.ArticleList.KeyMap.PrevPage = st.Keys.UpPage
 	st.ArticleList.KeyMap.NextPage = st.Keys.DownPage
+	st.Viewport.KeyMap = detailViewportKeyMap(st.Keys)
 
-	presenter.ApplyFeedList(&st.FeedList, st.Feeds, st.FeedGroups)
-	presenter.ApplyArticleList(&st.ArticleList, st.History, reading.AllFeedsURL)
+	presenter.ApplyFilteredFeedList(&st.FeedList, st.Feeds, st.FeedGroups, st.BuiltinTabs, nil, st.GroupSort, st.History.UnreadCountByFeed(), st.FeedTitles)
+	update.RefreshFeedHeaders(st)
+	initialURL := ""
+	if item, ok := st.FeedList.SelectedItem().(*presenter.Item); ok {
+		initialURL = item.Link
+	}
+	update.ApplyArticleList(st, initialURL)
 
 	return st
 }
 
+func markReadViews(cfg settings.Settings) map[string]bool {
+	views := make(map[string]bool)
+	for _, view := range []string{settings.MarkReadViewAll, settings.MarkReadViewNews, settings.MarkReadViewBookmarks, settings.MarkReadViewDismissed, settings.MarkReadViewFeeds} {
+		views[view] = cfg.MarksReadOnOpen(view)
+	}
+	return views
+}
+
 func cloneFeedGroups(groups []subscription.FeedGroup) []subscription.FeedGroup {
 	if len(groups) == 0 {
 		return nil
@@ -209,9 +351,12 @@ func newFeedList(cfg settings.Settings) list.Model {
 	return l
 }
 
-func newArticleList() list.Model {
-	l := list.New([]list.Item{}, listview.NewArticleDelegate(), 0, 0)
+func newArticleList(cfg settings.Settings) list.Model {
+	delegate := listview.NewArticleDelegate(glyph.For(cfg.Icons))
+	delegate.PeekLines = cfg.ExpandRowLines
+	l := list.New([]list.Item{}, delegate, 0, 0)
 	l.Title = "Articles"
+	l.Filter = presenter.ArticleFilter
 	l.SetShowTitle(false)
 	l.SetShowHelp(false)
 	l.DisableQuitKeybindings()
@@ -227,6 +372,15 @@ func newTextInput() textinput.Model {
 	return ti
 }
 
+func newAskInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "What are the key takeaways?"
+	ti.Focus()
+	ti.CharLimit = 300
+	ti.Width = 40
+	return ti
+}
+
 func newSpinner() spinner.Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
@@ -242,6 +396,32 @@ func newViewport() viewport.Model {
 	return vp
 }
 
+// detailViewportKeyMap scrolls the reading pane with the configured keys.
+// The arrow keys keep scrolling by line, and the list page keys scroll half
+// a page like the viewport's own ctrl+u/ctrl+d.
+func detailViewportKeyMap(keys state.KeyMap) viewport.KeyMap {
+	km := viewport.DefaultKeyMap()
+	km.Up = key.NewBinding(key.WithKeys(slices.Concat([]string{"up"}, keys.Up.Keys())...))
+	km.Down = key.NewBinding(key.WithKeys(slices.Concat([]string{"down"}, keys.Down.Keys())...))
+	km.HalfPageUp = key.NewBinding(key.WithKeys(slices.Concat(keys.HalfPageUp.Keys(), keys.UpPage.Keys())...))
+	km.HalfPageDown = key.NewBinding(key.WithKeys(slices.Concat(keys.HalfPageDown.Keys(), keys.DownPage.Keys())...))
+	return km
+}
+
+// keyConflictStatus warns about keys bound to clashing actions; only the
+// first of them ever runs.
+func keyConflictStatus(keys state.KeyMap) string {
+	conflicts := keys.Conflicts()
+	if len(conflicts) == 0 {
+		return ""
+	}
+	parts := make([]string, len(conflicts))
+	for i, c := range conflicts {
+		parts[i] = c.String()
+	}
+	return "Key conflicts: " + strings.Join(parts, "; ")
+}
+
 func loadHistory(readingSvc *usecase.ReadingService) *reading.History {
 	hist, _ := readingSvc.LoadHistoryMetadata()
 	if hist == nil {
@@ -3,9 +3,9 @@ package tui
 import (
 	"errors"
 	"os/exec"
+	"slices"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -162,6 +162,80 @@ func TestHandleFeedViewKeys_AddDelete(t *testing.T) {
 	}
 }
 
+func TestUpdateAddingFeedView_DuplicateFeed(t *testing.T) {
+	cfg := settings.Settings{
+		FeedGroups: []subscription.FeedGroup{
+			{Name: "Tech", Feeds: []string{"http://example.com/1"}},
+		},
+		KeyMap: settings.KeyMapConfig{AddFeed: "a"},
+	}
+	repo := &stubSubscriptionRepo{groups: cfg.FeedGroups}
+	m := newTestModel(cfg, repo, &stubHistoryRepo{}, &stubFeedFetcher{})
+	m.state.Session = state.AddingFeedView
+	m.state.TextInput.SetValue("http://EXAMPLE.com/1")
+
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+	if m.state.Session != state.FeedView {
+		t.Fatalf("session = %v, want FeedView", m.state.Session)
+	}
+	if m.state.Err != nil {
+		t.Fatalf("duplicate feed should not set error, got %v", m.state.Err)
+	}
+	if !strings.Contains(m.state.StatusMessage, "Already subscribed") {
+		t.Fatalf("status message = %q, want already subscribed notice", m.state.StatusMessage)
+	}
+	if len(repo.feeds) != 0 {
+		t.Fatalf("duplicate feed should not be stored, got %#v", repo.feeds)
+	}
+}
+
+func TestUpdateAddingFeedView_ValidatesFeed(t *testing.T) {
+	cfg := settings.Settings{ValidateNewFeeds: true}
+	repo := &stubSubscriptionRepo{}
+	fetcher := &stubFeedFetcher{err: errors.New("not an RSS or Atom document")}
+	m := newTestModel(cfg, repo, &stubHistoryRepo{}, fetcher)
+	m.state.Session = state.AddingFeedView
+	m.state.TextInput.SetValue("https://example.com/page")
+
+	tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+	if cmd == nil || m.state.AddFeedStatus != state.AddFeedChecking {
+		t.Fatalf("status = %v, want checking with a validation command", m.state.AddFeedStatus)
+	}
+	if !strings.Contains(m.buildModalProps().Body, "Checking feed") {
+		t.Fatalf("modal should show the checking hint:\n%s", m.buildModalProps().Body)
+	}
+
+	tm, _ = m.Update(cmd())
+	m = tm.(*Model)
+	if m.state.Session != state.AddingFeedView || m.state.AddFeedStatus != state.AddFeedInvalid {
+		t.Fatalf("invalid feed should keep the dialog open, session = %v status = %v", m.state.Session, m.state.AddFeedStatus)
+	}
+	if body := m.buildModalProps().Body; !strings.Contains(body, "Not a valid feed") {
+		t.Fatalf("modal should explain the failure:\n%s", body)
+	}
+	if len(repo.feeds) != 0 {
+		t.Fatalf("invalid feed should not be stored, got %#v", repo.feeds)
+	}
+
+	fetcher.err = nil
+	fetcher.feed = &reading.Feed{Title: "Example", Items: []reading.Item{{GUID: "1", Title: "Hello"}}}
+	tm, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+	tm, _ = m.Update(cmd())
+	m = tm.(*Model)
+	if m.state.Session != state.FeedView || len(repo.feeds) != 1 {
+		t.Fatalf("valid feed should be subscribed, session = %v feeds = %#v", m.state.Session, repo.feeds)
+	}
+	if m.state.StatusMessage != "Subscribed to Example" {
+		t.Fatalf("status message = %q", m.state.StatusMessage)
+	}
+	if _, ok := m.state.History.Item("1"); !ok {
+		t.Fatal("validated feed items should be merged into history")
+	}
+}
+
 func TestHandleFeedViewKeys_GroupFeeds(t *testing.T) {
 	cfg := settings.Settings{
 		Feeds:  []string{"https://news.ycombinator.com/rss", "https://github.com/golang/go/releases.atom", "https://planetpython.org/rss20.xml"},
@@ -438,6 +512,30 @@ func TestHandleArticleViewKeys_SectionJumpByJK(t *testing.T) {
 	}
 }
 
+func TestHandleFeedViewKeys_GroupFeedsBelowMinFeeds(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:    []string{"https://a.example.com/rss", "https://b.example.com/rss", "https://c.example.com/rss"},
+		KeyMap:   settings.KeyMapConfig{GroupFeeds: "z", Summarize: "s"},
+		Grouping: settings.GroupingConfig{MinFeeds: 5},
+	}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	m.state.Session = state.FeedView
+
+	for _, r := range []rune{'z', 's'} {
+		tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = tm.(*Model)
+		if m.state.Loading {
+			t.Fatalf("key %q should not start feed grouping below min_feeds", r)
+		}
+		if cmd != nil {
+			t.Fatalf("key %q should not return a grouping command", r)
+		}
+		if m.state.StatusMessage != "Feed grouping needs at least 5 feeds (3 subscribed)" {
+			t.Fatalf("unexpected status message: %q", m.state.StatusMessage)
+		}
+	}
+}
+
 func TestHandleFeedViewKeys_GroupFeedsError(t *testing.T) {
 	cfg := settings.Settings{
 		Feeds:  []string{"https://a.example.com/rss", "https://b.example.com/rss"},
@@ -509,9 +607,165 @@ func TestHandleArticleViewKeys_MarkRead(t *testing.T) {
 		t.Error("History item should be marked read")
 	}
 
-	// Give background save goroutine time to release file handle
-	// to prevent cleanup errors on Windows/Mac
-	time.Sleep(100 * time.Millisecond)
+	// Release the database file before the temp dir is removed.
+	if err := hm.Close(); err != nil {
+		t.Fatalf("close history: %v", err)
+	}
+}
+
+func TestHandleArticleViewKeys_MarkReadViews(t *testing.T) {
+	tests := []struct {
+		name     string
+		views    []string
+		feedURL  string
+		wantRead bool
+	}{
+		{name: "bookmarks not marked by default", feedURL: reading.BookmarksURL, wantRead: false},
+		{name: "feeds marked by default", feedURL: "http://example.com", wantRead: true},
+		{name: "all feeds marked by default", feedURL: reading.AllFeedsURL, wantRead: true},
+		{name: "bookmarks opted in", views: []string{"bookmarks"}, feedURL: reading.BookmarksURL, wantRead: true},
+		{name: "feeds opted out", views: []string{"all"}, feedURL: "http://example.com", wantRead: false},
+		{name: "dismissed marked by default", feedURL: reading.DismissedURL, wantRead: true},
+		{name: "dismissed opted out separately from feeds", views: []string{"feeds"}, feedURL: reading.DismissedURL, wantRead: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := settings.Settings{
+				Feeds:         []string{"http://example.com"},
+				KeyMap:        settings.KeyMapConfig{Right: "l"},
+				MarkReadViews: tt.views,
+			}
+			m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+
+			guid := "test-guid"
+			m.state.History.Items()[guid] = &reading.HistoryItem{GUID: guid}
+			m.state.Session = state.ArticleView
+			m.state.CurrentFeed = &reading.Feed{URL: tt.feedURL}
+			it := mockFeedItem("Unread", "http://example.com/unread")
+			it.GUID = guid
+			m.state.ArticleList.SetItems([]list.Item{it})
+			m.state.ArticleList.Select(0)
+
+			tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+			m = tm.(*Model)
+
+			if got := m.state.History.Items()[guid].IsRead; got != tt.wantRead {
+				t.Fatalf("IsRead = %v, want %v", got, tt.wantRead)
+			}
+			if m.state.LastOpenedGUID != guid {
+				t.Fatalf("LastOpenedGUID = %q, want the opened article", m.state.LastOpenedGUID)
+			}
+		})
+	}
+}
+
+func TestHandleArticleViewKeys_MarksLastOpened(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com"},
+		KeyMap: settings.KeyMapConfig{Right: "l", Left: "h"},
+	}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+
+	first := mockFeedItem("First", "http://example.com/1")
+	first.GUID = "g1"
+	first.BodyHydrated = true
+	second := mockFeedItem("Second", "http://example.com/2")
+	second.GUID = "g2"
+	second.BodyHydrated = true
+	m.state.Session = state.ArticleView
+	m.state.ArticleList.SetItems([]list.Item{first, second})
+
+	open := func(index int) {
+		m.state.ArticleList.Select(index)
+		tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+		m = tm.(*Model)
+		tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+		m = tm.(*Model)
+	}
+
+	open(0)
+	if m.state.LastOpenedGUID != "g1" || !first.LastOpened || second.LastOpened {
+		t.Fatalf("first article should be marked last opened: guid=%q first=%v second=%v", m.state.LastOpenedGUID, first.LastOpened, second.LastOpened)
+	}
+
+	open(1)
+	if m.state.LastOpenedGUID != "g2" || first.LastOpened || !second.LastOpened {
+		t.Fatalf("marker should move to the newly opened article: guid=%q first=%v second=%v", m.state.LastOpenedGUID, first.LastOpened, second.LastOpened)
+	}
+}
+
+func TestHandleArticleViewKeys_DefaultOpenActionBrowser(t *testing.T) {
+	oldOpen := OSOpenCmd
+	defer func() { OSOpenCmd = oldOpen }()
+
+	var openedURL string
+	OSOpenCmd = func(url string) *exec.Cmd {
+		openedURL = url
+		return exec.Command("echo", "mock")
+	}
+
+	cfg := settings.Settings{
+		Feeds:             []string{"http://example.com"},
+		KeyMap:            settings.KeyMapConfig{Right: "l"},
+		DefaultOpenAction: settings.OpenActionBrowser,
+	}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+
+	article := mockFeedItem("Article", "http://example.com/1")
+	article.GUID = "g1"
+	m.state.Session = state.ArticleView
+	m.state.ArticleList.SetItems([]list.Item{article})
+
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = tm.(*Model)
+	if m.state.Session != state.ArticleView {
+		t.Fatalf("session = %v, want article view to stay open", m.state.Session)
+	}
+	if openedURL != "http://example.com/1" {
+		t.Fatalf("opened URL = %q, want article link", openedURL)
+	}
+	if !article.Read || m.state.LastOpenedGUID != "g1" {
+		t.Fatalf("article should be marked read and last opened: read=%v last=%q", article.Read, m.state.LastOpenedGUID)
+	}
+}
+
+func TestArticleList_WrapNavigation(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:              []string{"http://example.com"},
+		KeyMap:             settings.KeyMapConfig{Up: "k", Down: "j"},
+		WrapListNavigation: true,
+	}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+
+	m.state.Session = state.ArticleView
+	m.state.ArticleList.SetSize(80, 20)
+	m.state.ArticleList.SetItems([]list.Item{
+		&presenter.Item{TitleText: "== Today ==", SectionHeader: true},
+		&presenter.Item{TitleText: "First", GUID: "g1"},
+		&presenter.Item{TitleText: "== Yesterday ==", SectionHeader: true},
+		&presenter.Item{TitleText: "Last", GUID: "g2"},
+	})
+	m.state.ArticleList.Select(3)
+
+	press := func(r rune) {
+		tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = tm.(*Model)
+	}
+
+	press('j')
+	if got := m.state.ArticleList.Index(); got != 1 {
+		t.Fatalf("down at the last item should wrap to the first article, got index %d", got)
+	}
+	press('k')
+	if got := m.state.ArticleList.Index(); got != 3 {
+		t.Fatalf("up at the first article should wrap to the last item, got index %d", got)
+	}
+
+	m.state.WrapListNavigation = false
+	press('j')
+	if got := m.state.ArticleList.Index(); got != 3 {
+		t.Fatalf("down at the last item should stay put without wrapping, got index %d", got)
+	}
 }
 
 func TestHandleArticleViewKeys_OpenSectionHeaderDoesNothing(t *testing.T) {
@@ -648,10 +902,12 @@ func TestHandleArticleViewKeys_Summarize(t *testing.T) {
 
 func TestHandleDetailViewKeys_Summarize(t *testing.T) {
 	cfg := settings.Settings{
-		Feeds:  []string{"http://example.com"},
-		KeyMap: settings.KeyMapConfig{Summarize: "s"},
+		Feeds:                []string{"http://example.com"},
+		KeyMap:               settings.KeyMapConfig{Summarize: "s"},
+		ShowAISummaryDefault: true,
 	}
-	m := newTestModelWithInsightGenerator(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{}, &stubInsightGenerator{
+	subs := &stubSubscriptionRepo{feeds: cfg.Feeds}
+	m := newTestModelWithInsightGenerator(cfg, subs, &stubHistoryRepo{}, &stubFeedFetcher{}, &stubInsightGenerator{
 		insight: usecase.Insight{
 			Summary: "Detail AI summary",
 			Tags:    []string{"tag1", "tag2"},
@@ -705,4 +961,107 @@ func TestHandleDetailViewKeys_Summarize(t *testing.T) {
 	if !strings.Contains(m.state.Viewport.View(), "(hidden; press Shift+S to toggle)") {
 		t.Fatalf("Viewport should indicate hidden summary, got: %s", m.state.Viewport.View())
 	}
+	if len(subs.savedShowAISummary) != 1 || subs.savedShowAISummary[0] {
+		t.Fatalf("toggle should persist hidden summary, saved %v", subs.savedShowAISummary)
+	}
+}
+
+func TestInsightGeneratedMsg_FallbackToFeedDescription(t *testing.T) {
+	m := newTestModel(settings.Settings{AI: settings.AIConfig{FallbackWhenUnavailable: true}}, &stubSubscriptionRepo{}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	m.state.ArticleList.SetItems([]list.Item{
+		&presenter.Item{RawTitle: "Article", GUID: "guid-1", Desc: "The feed's own description"},
+	})
+
+	tm, _ := m.Update(update.InsightGeneratedMsg{GUID: "guid-1", Err: errors.New("codex unreachable")})
+	m = tm.(*Model)
+
+	item := m.state.ArticleList.Items()[0].(*presenter.Item)
+	if item.AISummary != "The feed's own description" {
+		t.Fatalf("AISummary = %q, want feed description", item.AISummary)
+	}
+	if !strings.Contains(m.state.AIStatus, "showing feed description") {
+		t.Fatalf("AIStatus = %q, want fallback notice", m.state.AIStatus)
+	}
+	if got, _ := m.state.History.Item("guid-1"); got != nil && got.AISummary != "" {
+		t.Fatal("fallback summary should not be persisted")
+	}
+}
+
+func TestHandleArticleViewKeys_AutoSummarizeOnOpen(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com"},
+		KeyMap: settings.KeyMapConfig{Open: "enter"},
+		AI:     settings.AIConfig{AutoSummarizeOnOpen: true},
+	}
+	m := newTestModelWithInsightGenerator(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{}, &stubInsightGenerator{
+		insight: usecase.Insight{Summary: "Auto summary"},
+	})
+
+	m.state.Session = state.ArticleView
+	m.state.ArticleList.SetItems([]list.Item{
+		&presenter.Item{RawTitle: "Hydrated", TitleText: "1. Hydrated", GUID: "guid-hydrated", Content: "Body", BodyHydrated: true},
+		&presenter.Item{RawTitle: "Pending", TitleText: "2. Pending", GUID: "guid-pending"},
+		&presenter.Item{RawTitle: "Cached", TitleText: "3. Cached", GUID: "guid-cached", AISummary: "Existing", BodyHydrated: true},
+	})
+
+	m.state.ArticleList.Select(0)
+	tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+	if m.state.Session != state.DetailView || cmd == nil {
+		t.Fatalf("session = %v, cmd = %v; want detail view with a command", m.state.Session, cmd)
+	}
+	if !strings.HasPrefix(m.state.AIStatus, "AI: generating summary and tags (~") {
+		t.Fatalf("AIStatus = %q, want generation to start", m.state.AIStatus)
+	}
+
+	m.state.Session = state.ArticleView
+	m.state.AIStatus = ""
+	m.state.ArticleList.Select(1)
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+	if m.state.PendingInsightGUID != "guid-pending" {
+		t.Fatalf("PendingInsightGUID = %q, want summary queued until hydration", m.state.PendingInsightGUID)
+	}
+
+	m.state.Session = state.ArticleView
+	m.state.PendingInsightGUID = ""
+	m.state.AIStatus = ""
+	m.state.ArticleList.Select(2)
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+	if m.state.AIStatus != "" || m.state.PendingInsightGUID != "" {
+		t.Fatalf("articles with a summary should not be summarized again, AIStatus=%q", m.state.AIStatus)
+	}
+}
+
+func TestUndoFeedGroupingKeepsFeedsAddedSince(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"https://a.example.com/rss", "https://b.example.com/rss"},
+		KeyMap: settings.KeyMapConfig{Undo: "u"},
+	}
+	repo := &stubSubscriptionRepo{feeds: cfg.Feeds}
+	m := newTestModel(cfg, repo, &stubHistoryRepo{}, &stubFeedFetcher{})
+	m.state.Session = state.FeedView
+	tm, _ := m.Update(update.FeedGroupingCompletedMsg{
+		Feeds:  cfg.Feeds,
+		Groups: []subscription.FeedGroup{{Name: "Example", Feeds: cfg.Feeds}},
+	})
+	m = tm.(*Model)
+	repo.groups = []subscription.FeedGroup{{Name: "Example", Feeds: cfg.Feeds}}
+	repo.feeds = nil
+
+	m.state.Session = state.AddingFeedView
+	m.state.TextInput.SetValue("https://c.example.com/rss")
+	m = pressKey(m, tea.KeyEnter)
+	if !slices.Contains(m.state.Feeds, "https://c.example.com/rss") {
+		t.Fatalf("feed should be added, feeds = %v", m.state.Feeds)
+	}
+
+	m = typeText(m, "u")
+	if !slices.Contains(m.state.Feeds, "https://c.example.com/rss") || !slices.Contains(repo.feeds, "https://c.example.com/rss") {
+		t.Fatalf("undo should keep the feed added after grouping: state=%v repo=%v", m.state.Feeds, repo.feeds)
+	}
+	if m.state.FeedGroupingUndo != nil || !strings.Contains(m.state.StatusMessage, "nothing to undo") {
+		t.Fatalf("undo should be dropped once feeds changed, status = %q", m.state.StatusMessage)
+	}
 }
@@ -1,9 +1,11 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -12,8 +14,12 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/application/usecase"
 	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/domain/subscription"
 	"github.com/tesso57/reazy/internal/infrastructure/history"
+	"github.com/tesso57/reazy/internal/presentation/tui/components/header"
+	"github.com/tesso57/reazy/internal/presentation/tui/metrics"
 	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
 	"github.com/tesso57/reazy/internal/presentation/tui/state"
 	"github.com/tesso57/reazy/internal/presentation/tui/update"
@@ -39,6 +45,19 @@ func TestNewModel(t *testing.T) {
 	}
 }
 
+func TestNewModel_WarnsAboutKeyConflicts(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/rss"},
+		KeyMap: settings.KeyMapConfig{Bookmark: "s"},
+	}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+
+	want := "Key conflicts: s (bookmark, summarize)"
+	if m.state.StatusMessage != want {
+		t.Fatalf("StatusMessage = %q, want %q", m.state.StatusMessage, want)
+	}
+}
+
 func TestItemMethods(t *testing.T) {
 	i := presenter.Item{
 		TitleText:     "Title",
@@ -88,7 +107,8 @@ func TestKeyMap(t *testing.T) {
 
 func TestUpdate(t *testing.T) {
 	cfg := settings.Settings{
-		Feeds: []string{"http://example.com"},
+		Feeds:                []string{"http://example.com"},
+		ShowAISummaryDefault: true,
 		KeyMap: settings.KeyMapConfig{
 			Up: "k", Down: "j", Left: "h", Right: "l",
 			AddFeed: "a", Quit: "q",
@@ -638,12 +658,341 @@ func TestArticleViewHeaderHiddenForNewsDateSectionHeader(t *testing.T) {
 	}
 }
 
+func TestFeedViewHeaderShowsFeedFreshness(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds: []string{"http://example.com/feed"},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a": {GUID: "a", Title: "A", FeedURL: "http://example.com/feed", Date: time.Now().Add(-3*time.Hour - time.Minute)},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = tm.(*Model)
+	m.state.FeedList.Select(presenter.BuiltinTabCount(m.state.BuiltinTabs))
+
+	props := m.buildHeaderProps()
+	if props.Updated != "3h ago" {
+		t.Fatalf("header updated = %q, want %q", props.Updated, "3h ago")
+	}
+
+	m.state.FeedList.Select(presenter.BuiltinTabIndex(m.state.BuiltinTabs, reading.BookmarksURL))
+	if props := m.buildHeaderProps(); props.Updated != "" {
+		t.Fatalf("bookmarks header updated = %q, want empty", props.Updated)
+	}
+}
+
+func TestBookmarksIgnoreReadDim(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:     []string{"http://example.com/feed"},
+		Bookmarks: settings.BookmarksConfig{IgnoreReadDim: true},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"kept": {GUID: "kept", Title: "Kept", FeedURL: "http://example.com/feed", Date: time.Now(), IsRead: true, IsBookmarked: true},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+
+	dims := func(feedURL string) bool {
+		update.ApplyArticleList(m.state, feedURL)
+		for _, listItem := range m.state.ArticleList.Items() {
+			if item, ok := listItem.(*presenter.Item); ok && item.GUID == "kept" {
+				return item.DimsWhenRead()
+			}
+		}
+		t.Fatalf("%s should list the bookmark", feedURL)
+		return false
+	}
+	if dims(reading.BookmarksURL) {
+		t.Fatal("a read bookmark should stay bright in the Bookmarks view")
+	}
+	if !dims(reading.AllFeedsURL) {
+		t.Fatal("a read bookmark should still be dimmed outside the Bookmarks view")
+	}
+	m.state.BookmarksIgnoreReadDim = false
+	if !dims(reading.BookmarksURL) {
+		t.Fatal("read bookmarks should be dimmed unless ignore_read_dim is set")
+	}
+}
+
+func TestFeedViewHeaderPreviewsNewestUnreadTitle(t *testing.T) {
+	now := time.Now()
+	cfg := settings.Settings{
+		Feeds:       []string{"http://example.com/feed"},
+		FeedPreview: true,
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"read":  {GUID: "read", Title: "Read already", FeedURL: "http://example.com/feed", Date: now, IsRead: true},
+		"new":   {GUID: "new", Title: "Newest\nunread", FeedURL: "http://example.com/feed", Date: now.Add(-time.Hour)},
+		"older": {GUID: "older", Title: "Older unread", FeedURL: "http://example.com/feed", Date: now.Add(-2 * time.Hour)},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = tm.(*Model)
+	m.state.FeedList.Select(presenter.BuiltinTabCount(m.state.BuiltinTabs))
+
+	if props := m.buildHeaderProps(); props.Preview != "Newest unread" {
+		t.Fatalf("header preview = %q, want %q", props.Preview, "Newest unread")
+	}
+
+	// Opening the article marks it read, so the preview moves on.
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: "http://example.com/feed"}
+	update.ApplyArticleList(m.state, "http://example.com/feed")
+	m.state.ArticleList.Select(2) // "new", below the read article
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(*Model)
+	m.state.Session = state.FeedView
+	if props := m.buildHeaderProps(); props.Preview != "Older unread" {
+		t.Fatalf("header preview after reading = %q, want %q", props.Preview, "Older unread")
+	}
+
+	m.state.FeedList.Select(presenter.BuiltinTabIndex(m.state.BuiltinTabs, reading.NewsURL))
+	if props := m.buildHeaderProps(); props.Preview != "" {
+		t.Fatalf("news header preview = %q, want empty", props.Preview)
+	}
+
+	m.state.FeedPreview = false
+	m.state.FeedList.Select(presenter.BuiltinTabCount(m.state.BuiltinTabs))
+	if props := m.buildHeaderProps(); props.Preview != "" {
+		t.Fatalf("disabled preview = %q, want empty", props.Preview)
+	}
+}
+
+func TestDetailViewHeaderShowsArticleLength(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/feed"}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = tm.(*Model)
+
+	m.state.Session = state.DetailView
+	m.state.ArticleList.SetItems([]list.Item{
+		&presenter.Item{
+			TitleText:     "1. Long read",
+			FeedTitleText: "Example",
+			Link:          "http://example.com/a",
+			Content:       strings.Repeat("word ", 1240),
+			BodyHydrated:  true,
+		},
+	})
+	m.state.ArticleList.Select(0)
+
+	if props := m.buildHeaderProps(); props.Length != "1,240 words · ~7 min" {
+		t.Fatalf("header length = %q, want %q", props.Length, "1,240 words · ~7 min")
+	}
+
+	m.state.Session = state.ArticleView
+	if props := m.buildHeaderProps(); props.Length != "" {
+		t.Fatalf("article list header length = %q, want empty", props.Length)
+	}
+}
+
+func TestDetailViewHeaderStacksMetadataWhenNarrow(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/feed"}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	m.state.Session = state.DetailView
+	m.state.ArticleList.SetItems([]list.Item{
+		&presenter.Item{TitleText: "1. Story", FeedTitleText: "Example", Link: "http://example.com/a", AISummary: "Short"},
+	})
+	m.state.ArticleList.Select(0)
+
+	for _, tt := range []struct {
+		width, lines int
+	}{
+		{width: 160, lines: metrics.HeaderLines},
+		{width: 90, lines: metrics.StackedDetailHeaderLines},
+	} {
+		update.HandleWindowSize(m.state, tea.WindowSizeMsg{Width: tt.width, Height: 40})
+		props := m.buildHeaderProps()
+		if !props.Detail || props.AI != "AI: summarized" {
+			t.Fatalf("width %d: props = %+v, want detail metadata", tt.width, props)
+		}
+		if got := lipgloss.Height(header.Render(props)); got != tt.lines {
+			t.Fatalf("width %d: header height = %d, want %d", tt.width, got, tt.lines)
+		}
+		if got := lipgloss.Height(m.View()); got > 40 {
+			t.Fatalf("width %d: view height = %d, want it to fit 40 rows", tt.width, got)
+		}
+	}
+}
+
+func TestDetailViewHidesSidebarBelowMinReadingWidth(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/feed"}, MinReadingWidth: 70}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	m.state.Session = state.DetailView
+	m.state.ArticleList.SetItems([]list.Item{
+		&presenter.Item{TitleText: "1. Story", FeedTitleText: "Example", Link: "http://example.com/a", Content: "Body", BodyHydrated: true},
+	})
+	m.state.ArticleList.Select(0)
+
+	update.HandleWindowSize(m.state, tea.WindowSizeMsg{Width: 90, Height: 40})
+	if !m.buildSidebarProps().Hidden || m.state.Viewport.Width != 89 {
+		t.Fatalf("width 90: viewport width = %d, want the detail view across the window", m.state.Viewport.Width)
+	}
+	if strings.Contains(m.View(), "Reazy Feeds") {
+		t.Fatal("width 90: the sidebar should not be rendered")
+	}
+
+	update.HandleWindowSize(m.state, tea.WindowSizeMsg{Width: 120, Height: 40})
+	if m.buildSidebarProps().Hidden || m.state.Viewport.Width != metrics.MainWidth(120)-1 {
+		t.Fatalf("width 120: viewport width = %d, want the split restored", m.state.Viewport.Width)
+	}
+
+	update.HandleWindowSize(m.state, tea.WindowSizeMsg{Width: 90, Height: 40})
+	m.state.Session = state.ArticleView
+	update.UpdateListSizes(m.state)
+	if m.buildSidebarProps().Hidden {
+		t.Fatal("the article list should keep the sidebar")
+	}
+}
+
+func TestDetailViewFocusModeHidesChrome(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/feed"}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	m.state.Session = state.DetailView
+	m.state.ArticleList.SetItems([]list.Item{
+		&presenter.Item{TitleText: "1. Story", FeedTitleText: "Example", Link: "http://example.com/a", Content: "Body", BodyHydrated: true},
+	})
+	m.state.ArticleList.Select(0)
+	update.HandleWindowSize(m.state, tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	m, _ = typeKeys(m, "f")
+	view := m.View()
+	if !m.state.FocusMode || strings.Contains(view, "Reazy Feeds") || strings.Contains(view, "http://example.com/a") {
+		t.Fatalf("focus mode should hide the sidebar and header:\n%s", view)
+	}
+	if m.state.Viewport.Width != 119 || m.state.Viewport.Height != 40 {
+		t.Fatalf("viewport = %dx%d, want the whole 120x40 window", m.state.Viewport.Width, m.state.Viewport.Height)
+	}
+	if got := lipgloss.Height(view); got != 40 {
+		t.Fatalf("view height = %d, want 40 without a footer", got)
+	}
+
+	m, _ = typeKeys(m, "f")
+	if m.state.FocusMode || !strings.Contains(m.View(), "Reazy Feeds") {
+		t.Fatal("toggling again should restore the sidebar")
+	}
+	if m.state.Viewport.Width != metrics.MainWidth(120)-1 {
+		t.Fatalf("viewport width = %d, want the split restored", m.state.Viewport.Width)
+	}
+}
+
+type stubArticleExtractor struct {
+	text string
+	err  error
+}
+
+func (s stubArticleExtractor) ExtractArticle(context.Context, string) (string, error) {
+	return s.text, s.err
+}
+
+func TestLoadArticleDetailCmd_FullText(t *testing.T) {
+	feedURL := "http://example.com/feed"
+	cfg := settings.Settings{
+		Feeds:       []string{feedURL},
+		FeedOptions: map[string]settings.FeedOptions{feedURL: {FullText: true}},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a": {GUID: "a", Title: "A", Link: "http://example.com/a", FeedURL: feedURL, Description: "Teaser"},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	m.reading.Extractor = stubArticleExtractor{text: "The whole article"}
+	if !m.state.FullTextFeeds[feedURL] {
+		t.Fatalf("FullTextFeeds = %#v, want %s", m.state.FullTextFeeds, feedURL)
+	}
+
+	msg := update.LoadArticleDetailCmd(context.Background(), m.reading, "a", true, false)().(update.ArticleDetailLoadedMsg)
+	if msg.Item.Content != "" {
+		t.Fatalf("excerpt feeds should keep the feed content, got %q", msg.Item.Content)
+	}
+
+	msg = update.LoadArticleDetailCmd(context.Background(), m.reading, "a", true, true)().(update.ArticleDetailLoadedMsg)
+	if msg.FullTextErr != nil || msg.Item.Content != "The whole article" {
+		t.Fatalf("full-text load = %q, %v; want extracted text", msg.Item.Content, msg.FullTextErr)
+	}
+	if historyRepo.items["a"].Content != "The whole article" {
+		t.Fatal("extracted text should be persisted")
+	}
+
+	historyRepo.items["b"] = &reading.HistoryItem{GUID: "b", Link: "http://example.com/b", FeedURL: feedURL}
+	m.reading.Extractor = stubArticleExtractor{err: fmt.Errorf("blocked")}
+	msg = update.LoadArticleDetailCmd(context.Background(), m.reading, "b", true, true)().(update.ArticleDetailLoadedMsg)
+	tm, _ := m.Update(msg)
+	m = tm.(*Model)
+	if !strings.Contains(m.state.StatusMessage, "Full text unavailable") {
+		t.Fatalf("StatusMessage = %q, want full-text failure notice", m.state.StatusMessage)
+	}
+	if m.state.Err != nil {
+		t.Fatalf("extraction failure should not be fatal, got %v", m.state.Err)
+	}
+}
+
+func TestNewModelWithoutBuiltinTabsSelectsFirstFeed(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:       []string{"http://example.com/feed"},
+		FeedGroups:  []subscription.FeedGroup{{Name: "Tech", Feeds: []string{"http://example.com/feed"}}},
+		BuiltinTabs: []string{},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a": {GUID: "a", Title: "A", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+
+	selected, ok := m.state.FeedList.SelectedItem().(*presenter.Item)
+	if !ok || selected.Link != "http://example.com/feed" {
+		t.Fatalf("selected = %#v, want the first feed", selected)
+	}
+	if len(m.state.ArticleList.Items()) == 0 {
+		t.Fatal("article list should show the selected feed")
+	}
+}
+
+func TestDetailViewFetchFullText(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/feed"},
+		KeyMap: settings.KeyMapConfig{FetchFullText: "F"},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a": {GUID: "a", Title: "A", Link: "http://example.com/a", FeedURL: "http://example.com/feed", Content: "Teaser"},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	m.reading.Extractor = stubArticleExtractor{text: "The whole article"}
+	m.state.Session = state.DetailView
+	m.state.Viewport.Width = 80
+	m.state.Viewport.Height = 20
+	m.state.ArticleList.SetItems([]list.Item{
+		&presenter.Item{RawTitle: "A", TitleText: "1. A", GUID: "a", Link: "http://example.com/a", Content: "Teaser"},
+	})
+	m.state.ArticleList.Select(0)
+
+	tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	m = tm.(*Model)
+	if cmd == nil || !m.state.Loading {
+		t.Fatal("reader mode should start a full-text fetch with a spinner")
+	}
+
+	msg := update.FetchFullTextCmd(context.Background(), m.reading, "a")().(update.ArticleDetailLoadedMsg)
+	tm, _ = m.Update(msg)
+	m = tm.(*Model)
+	if m.state.Loading {
+		t.Fatal("loading should stop once the full text arrives")
+	}
+	if !strings.Contains(m.state.Viewport.View(), "The whole article") {
+		t.Fatalf("viewport should show the full text:\n%s", m.state.Viewport.View())
+	}
+	if historyRepo.items["a"].Content != "The whole article" {
+		t.Fatal("fetched text should be cached in history")
+	}
+	if m.state.StatusMessage != "Showing full article text" {
+		t.Fatalf("StatusMessage = %q", m.state.StatusMessage)
+	}
+}
+
 func TestFetchFeedCmd(t *testing.T) {
 	cfg := settings.Settings{Feeds: []string{"http://example.com"}}
 	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
 
 	// Invoke cmd
-	cmd := update.FetchFeedCmd(m.reading, "http://example.com", m.state.Feeds)
+	cmd := update.FetchFeedCmd(context.Background(), m.reading, "http://example.com", m.state.Feeds)
 	if cmd == nil {
 		t.Error("fetchFeedCmd nil")
 	}
@@ -654,7 +1003,7 @@ func TestFetchFeedCmd(t *testing.T) {
 	}
 
 	// Test All Feeds Cmd
-	cmd = update.FetchFeedCmd(m.reading, reading.AllFeedsURL, m.state.Feeds)
+	cmd = update.FetchFeedCmd(context.Background(), m.reading, reading.AllFeedsURL, m.state.Feeds)
 	if cmd == nil {
 		t.Error("fetchFeedCmd (All) nil")
 	}
@@ -844,6 +1193,151 @@ func TestHistoryIntegration(t *testing.T) {
 		t.Error("Should enter detail view")
 	}
 
-	// Wait for async history saves.
-	time.Sleep(100 * time.Millisecond)
+	// Release the database file before the temp dir is removed.
+	if err := hm.Close(); err != nil {
+		t.Fatalf("close history: %v", err)
+	}
+}
+
+func TestNewsTabListsTodayArticlesWhenAIIsDisabled(t *testing.T) {
+	year, month, day := time.Now().Date()
+	now := time.Date(year, month, day, 12, 0, 0, 0, time.Local)
+	cfg := settings.Settings{
+		Feeds:      []string{"http://example.com/a", "http://example.com/b"},
+		NewsDigest: settings.NewsDigestConfig{FallbackToToday: true},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a-today":     {GUID: "a-today", Title: "A today", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now},
+		"b-today":     {GUID: "b-today", Title: "B today", FeedURL: "http://example.com/b", Kind: reading.ArticleKind, Date: now.Add(-time.Minute)},
+		"a-yesterday": {GUID: "a-yesterday", Title: "A yesterday", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now.AddDate(0, 0, -1)},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	if !m.state.NewsShowsToday {
+		t.Fatal("News should fall back to today's articles without an AI generator")
+	}
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: reading.NewsURL}
+	m.state.FeedList.Select(presenter.BuiltinTabIndex(m.state.BuiltinTabs, reading.NewsURL))
+
+	tm, cmd := m.Update(update.FeedFetchedMsg{URL: reading.NewsURL, Feed: &reading.Feed{URL: reading.NewsURL}})
+	m = tm.(*Model)
+	if cmd != nil {
+		t.Fatal("no daily news generation should start")
+	}
+	if guids := articleListGUIDs(m); !slices.Equal(guids, []string{"a-today", "b-today"}) {
+		t.Fatalf("news list = %v, want today's articles newest first", guids)
+	}
+	if m.state.AIStatus != "AI: disabled, showing today's articles" || m.state.Err != nil {
+		t.Fatalf("AIStatus = %q, err = %v", m.state.AIStatus, m.state.Err)
+	}
+
+	cfg.NewsDigest.FallbackToToday = false
+	m = newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	if m.state.NewsShowsToday {
+		t.Fatal("News should not fall back when fallback_to_today is off")
+	}
+}
+
+func TestFooterShowsSessionTokenEstimate(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/a"}}
+	m := newTestModelWithInsightGenerator(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{}, &stubInsightGenerator{
+		insight: usecase.Insight{Summary: "Generated"},
+	})
+	m.state.AIStatus = "AI: updated"
+	if footer := m.buildFooterProps(); strings.Contains(footer, "tokens this session") {
+		t.Fatalf("footer = %q, want no estimate before any request", footer)
+	}
+
+	if _, err := m.insights.Generate(context.Background(), usecase.InsightRequest{Title: "Title", Content: "Body"}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	m.state.Session = state.ArticleView
+	if footer := m.buildFooterProps(); !strings.Contains(footer, "AI: updated · ~") || !strings.Contains(footer, "tokens this session") {
+		t.Fatalf("footer = %q, want the session token estimate", footer)
+	}
+}
+
+func TestLoadingBodyWrapsToNarrowWidthAfterResize(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://example.com"}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 30})
+	m = tm.(*Model)
+
+	m.state.Session = state.ArticleView
+	m.state.Loading = true
+	m.state.AIStatus = "AI: generating daily news from every subscribed feed, this can take a while..."
+
+	tm, _ = m.Update(tea.WindowSizeMsg{Width: 50, Height: 30})
+	m = tm.(*Model)
+	props := m.buildMainProps()
+	if props.Width <= 0 {
+		t.Fatalf("main width = %d, want a positive width", props.Width)
+	}
+	for _, line := range strings.Split(props.Body, "\n") {
+		if got := lipgloss.Width(line); got > props.Width {
+			t.Fatalf("loading line %q is %d wide, want at most %d", line, got, props.Width)
+		}
+	}
+	if !strings.Contains(props.Body, "this can take") {
+		t.Fatalf("loading body = %q, want the whole message kept", props.Body)
+	}
+}
+
+func TestExpandRowPeeksDescription(t *testing.T) {
+	now := time.Now()
+	cfg := settings.Settings{
+		Feeds:          []string{"http://example.com/feed"},
+		ExpandRowLines: 2,
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a": {GUID: "a", Title: "Ambiguous", Description: "<p>Alpha body</p>", FeedURL: "http://example.com/feed", Date: now},
+		"b": {GUID: "b", Title: "Bare", FeedURL: "http://example.com/feed", Date: now.Add(-time.Hour)},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = tm.(*Model)
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: "http://example.com/feed"}
+	update.ApplyArticleList(m.state, "http://example.com/feed")
+	update.UpdateListSizes(m.state)
+	selectArticleByGUID(t, m, "a")
+	height := m.state.ArticleList.Height()
+
+	m = typeText(m, "e")
+	if m.state.ExpandedGUID != "a" || m.state.Session != state.ArticleView {
+		t.Fatalf("expand row should peek in place, expanded = %q session = %v", m.state.ExpandedGUID, m.state.Session)
+	}
+	if !strings.Contains(m.View(), "Alpha body") {
+		t.Fatal("the expanded row should show its description")
+	}
+	if got := m.state.ArticleList.Height(); got != height-2 {
+		t.Fatalf("list height = %d, want %d to make room for the description", got, height-2)
+	}
+
+	m = typeText(m, "e")
+	if m.state.ExpandedGUID != "" || m.state.ArticleList.Height() != height {
+		t.Fatal("expanding the row again should collapse it")
+	}
+
+	m = typeText(m, "e")
+	m = pressKey(m, tea.KeyDown)
+	if m.state.ExpandedGUID != "" || strings.Contains(m.View(), "Alpha body") {
+		t.Fatal("moving the cursor should collapse the row")
+	}
+
+	m = typeText(m, "e")
+	if m.state.ExpandedGUID != "" || m.state.StatusMessage != "No description to show" {
+		t.Fatalf("rows without a description should not expand, status = %q", m.state.StatusMessage)
+	}
+}
+
+func selectArticleByGUID(t *testing.T, m *Model, guid string) {
+	t.Helper()
+	for idx, listItem := range m.state.ArticleList.Items() {
+		if item, ok := listItem.(*presenter.Item); ok && item.GUID == guid {
+			m.state.ArticleList.Select(idx)
+			return
+		}
+	}
+	t.Fatalf("article %s is not listed", guid)
 }
@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/components/modal"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+func movedFeedMsg(from, to string) update.FeedFetchedMsg {
+	return update.FeedFetchedMsg{
+		URL:    from,
+		Feed:   &reading.Feed{URL: from, MovedTo: to},
+		Report: usecase.FeedFetchReport{Requested: 1, Succeeded: 1, Moved: map[string]string{from: to}},
+	}
+}
+
+func TestMovedFeedAsksBeforeUpdating(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://old.example.com/rss", "http://example.com/2"}}
+	repo := &stubSubscriptionRepo{feeds: append([]string(nil), cfg.Feeds...)}
+	m := newTestModel(cfg, repo, &stubHistoryRepo{}, &stubFeedFetcher{})
+
+	tm, _ := m.Update(movedFeedMsg("http://old.example.com/rss", "https://new.example.com/rss"))
+	m = tm.(*Model)
+	if m.state.Session != state.MoveFeedView {
+		t.Fatalf("session = %v, want MoveFeedView", m.state.Session)
+	}
+	props := m.buildModalProps()
+	if props.Kind != modal.MoveFeed || !strings.Contains(props.Body, "https://new.example.com/rss") {
+		t.Fatalf("modal = %#v", props)
+	}
+
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = tm.(*Model)
+	if m.state.Session != state.FeedView {
+		t.Fatalf("session = %v, want FeedView", m.state.Session)
+	}
+	if m.state.Feeds[0] != "https://new.example.com/rss" || repo.feeds[0] != "https://new.example.com/rss" {
+		t.Fatalf("feed was not updated: state=%#v repo=%#v", m.state.Feeds, repo.feeds)
+	}
+}
+
+func TestMovedFeedKeepsItsArticles(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://old.example.com/rss"}, FollowPermanentRedirects: true}
+	repo := &stubSubscriptionRepo{feeds: append([]string(nil), cfg.Feeds...)}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a": {GUID: "a", Kind: reading.ArticleKind, FeedURL: "http://old.example.com/rss"},
+	}}
+	m := newTestModel(cfg, repo, historyRepo, &stubFeedFetcher{})
+
+	tm, _ := m.Update(movedFeedMsg("http://old.example.com/rss", "https://new.example.com/rss"))
+	m = tm.(*Model)
+	if item, ok := m.state.History.Item("a"); !ok || item.FeedURL != "https://new.example.com/rss" {
+		t.Fatalf("history item = %+v, want it under the new feed URL", item)
+	}
+	if want := [][2]string{{"http://old.example.com/rss", "https://new.example.com/rss"}}; !slices.Equal(historyRepo.movedFeeds, want) {
+		t.Fatalf("persisted moves = %v, want %v", historyRepo.movedFeeds, want)
+	}
+	if got := m.state.History.UnreadCountByFeed()["https://new.example.com/rss"]; got != 1 {
+		t.Fatalf("unread under the new URL = %d, want 1", got)
+	}
+}
+
+func TestMovedFeedCanBeDeclined(t *testing.T) {
+	cfg := settings.Settings{Feeds: []string{"http://old.example.com/rss"}}
+	repo := &stubSubscriptionRepo{feeds: append([]string(nil), cfg.Feeds...)}
+	m := newTestModel(cfg, repo, &stubHistoryRepo{}, &stubFeedFetcher{})
+
+	tm, _ := m.Update(movedFeedMsg("http://old.example.com/rss", "https://new.example.com/rss"))
+	m = tm.(*Model)
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = tm.(*Model)
+	if m.state.Session != state.FeedView {
+		t.Fatalf("session = %v, want FeedView", m.state.Session)
+	}
+	if m.state.Feeds[0] != "http://old.example.com/rss" {
+		t.Fatalf("feed should be kept, got %#v", m.state.Feeds)
+	}
+	if len(m.state.PendingFeedMoves) != 0 {
+		t.Fatalf("pending moves = %#v, want none", m.state.PendingFeedMoves)
+	}
+}
+
+func TestMovedFeedUpdatesAutomaticallyWhenConfigured(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:                    []string{"http://old.example.com/rss"},
+		FollowPermanentRedirects: true,
+	}
+	repo := &stubSubscriptionRepo{feeds: append([]string(nil), cfg.Feeds...)}
+	m := newTestModel(cfg, repo, &stubHistoryRepo{}, &stubFeedFetcher{})
+
+	tm, _ := m.Update(movedFeedMsg("http://old.example.com/rss", "https://new.example.com/rss"))
+	m = tm.(*Model)
+	if m.state.Session != state.FeedView {
+		t.Fatalf("session = %v, want FeedView", m.state.Session)
+	}
+	if repo.feeds[0] != "https://new.example.com/rss" {
+		t.Fatalf("repo feeds = %#v", repo.feeds)
+	}
+	if !strings.Contains(m.state.StatusMessage, "Feed moved") {
+		t.Fatalf("status = %q", m.state.StatusMessage)
+	}
+}
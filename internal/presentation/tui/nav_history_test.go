@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+func TestNavBackAndForwardMoveBetweenOpenedArticles(t *testing.T) {
+	year, month, day := time.Now().Date()
+	now := time.Date(year, month, day, 12, 0, 0, 0, time.Local)
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/a", "http://example.com/b"},
+		KeyMap: settings.KeyMapConfig{Open: "enter", Back: "esc", NavBack: "[", NavForward: "]"},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", Title: "A one", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now},
+		"a2": {GUID: "a2", Title: "A two", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now.Add(-time.Hour)},
+		"b1": {GUID: "b1", Title: "B one", FeedURL: "http://example.com/b", Kind: reading.ArticleKind, Date: now},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	open := func(feedURL, guid string) {
+		t.Helper()
+		m.state.Session = state.ArticleView
+		m.state.CurrentFeed = &reading.Feed{URL: feedURL}
+		presenter.ApplyArticleList(&m.state.ArticleList, m.state.History, feedURL, presenter.ArticleListOptions{})
+		for idx, guidAt := range articleListGUIDs(m) {
+			if guidAt == guid {
+				m.state.ArticleList.Select(idx + 1) // skip the date section header
+			}
+		}
+		tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = tm.(*Model)
+		if selected := selectedGUID(m); m.state.Session != state.DetailView || selected != guid {
+			t.Fatalf("opened %q in session %v, want %s in the detail view", selected, m.state.Session, guid)
+		}
+	}
+
+	open("http://example.com/a", "a1")
+	open("http://example.com/a", "a2")
+	open("http://example.com/b", "b1")
+
+	m, _ = typeKeys(m, "[")
+	if got := selectedGUID(m); got != "a2" || m.state.Session != state.DetailView {
+		t.Fatalf("after back: %q in session %v, want a2 in the detail view", got, m.state.Session)
+	}
+	if m.state.CurrentFeed == nil || m.state.CurrentFeed.URL != "http://example.com/a" {
+		t.Fatalf("current feed = %+v, want the list switched to a2's feed", m.state.CurrentFeed)
+	}
+	m, _ = typeKeys(m, "[")
+	m, _ = typeKeys(m, "[")
+	if got := selectedGUID(m); got != "a1" || m.state.StatusMessage != "No earlier opened article" {
+		t.Fatalf("at the start: %q, status %q", got, m.state.StatusMessage)
+	}
+
+	m, _ = typeKeys(m, "]")
+	if got := selectedGUID(m); got != "a2" {
+		t.Fatalf("after forward: %q, want a2", got)
+	}
+
+	// Opening another article drops the forward entries.
+	open("http://example.com/a", "a1")
+	m, _ = typeKeys(m, "]")
+	if got := selectedGUID(m); got != "a1" || m.state.StatusMessage != "No later opened article" {
+		t.Fatalf("forward after a new open: %q, status %q", got, m.state.StatusMessage)
+	}
+	if len(m.state.NavHistory) != 3 {
+		t.Fatalf("nav history = %v, want a1 a2 a1", m.state.NavHistory)
+	}
+}
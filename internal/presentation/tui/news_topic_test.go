@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+func TestBuildNewsTopicBody_ShowsCoverage(t *testing.T) {
+	st := &state.ModelState{
+		NewsTopicDigestGUID: "digest",
+		NewsTopicTitle:      "Go release",
+		History: reading.NewHistory(map[string]*reading.HistoryItem{
+			"digest": {GUID: "digest", Kind: reading.NewsDigestKind, RelatedGUIDs: []string{"a", "b", "c", "missing"}},
+			"a":      {GUID: "a", FeedURL: "http://example.com/1"},
+			"b":      {GUID: "b", FeedURL: "http://example.com/1"},
+			"c":      {GUID: "c", FeedURL: "http://example.com/2"},
+		}),
+	}
+
+	body := buildNewsTopicBody(st)
+	if !strings.Contains(body, "Related Articles (3 articles from 2 feeds)") {
+		t.Fatalf("coverage line missing:\n%s", body)
+	}
+
+	st.NewsTopicDigestGUID = "unknown"
+	body = buildNewsTopicBody(st)
+	if strings.Contains(body, "articles from") {
+		t.Fatalf("coverage should be omitted without a digest:\n%s", body)
+	}
+}
+
+func TestNewsTopicView_ToggleTranslatedTitles(t *testing.T) {
+	m := newTestModel(settings.Settings{}, &stubSubscriptionRepo{}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	m.state.History.UpsertItem(&reading.HistoryItem{GUID: "a", Kind: reading.ArticleKind, Title: "Go 1.26 released", FeedTitle: "Go Blog"})
+	m.state.History.UpsertItem(&reading.HistoryItem{GUID: "b", Kind: reading.ArticleKind, Title: "Rust 2026", FeedTitle: "Rust Blog"})
+	m.state.Session = state.NewsTopicView
+	m.state.NewsTopicRelatedGUIDs = []string{"a", "b"}
+	m.state.NewsTopicRelatedTitles = map[string]string{"a": "Go 1.26 リリース"}
+	presenter.ApplyRelatedArticleList(&m.state.ArticleList, m.state.History, m.state.NewsTopicRelatedGUIDs, nil, 0)
+
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	m = tm.(*Model)
+	first := m.state.ArticleList.Items()[0].(*presenter.Item)
+	if !m.state.ShowTranslatedTitles || !strings.Contains(first.TitleText, "Go 1.26 リリース") {
+		t.Fatalf("expected translated title, got %q", first.TitleText)
+	}
+	if !strings.Contains(buildNewsTopicBody(m.state), "[translated]") {
+		t.Fatal("header should mark translated titles")
+	}
+
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	m = tm.(*Model)
+	first = m.state.ArticleList.Items()[0].(*presenter.Item)
+	if m.state.ShowTranslatedTitles || !strings.Contains(first.TitleText, "Go 1.26 released") {
+		t.Fatalf("expected original title, got %q", first.TitleText)
+	}
+}
+
+func newNewsSelectionModel(subs *stubSubscriptionRepo, selection *settings.NewsSelection) *Model {
+	today := time.Now().Format("2006-01-02")
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	cfg := settings.Settings{
+		NewsDigest:    settings.NewsDigestConfig{RememberSelection: true},
+		NewsSelection: selection,
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"d1":  {GUID: "d1", Title: "First", Kind: reading.NewsDigestKind, DigestDate: today},
+		"d2":  {GUID: "d2", Title: "Second", Kind: reading.NewsDigestKind, DigestDate: today},
+		"old": {GUID: "old", Title: "Old", Kind: reading.NewsDigestKind, DigestDate: yesterday},
+	}}
+	m := newTestModel(cfg, subs, historyRepo, &stubFeedFetcher{})
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = tm.(*Model)
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: reading.NewsURL}
+	m.state.FeedList.Select(presenter.BuiltinTabIndex(m.state.BuiltinTabs, reading.NewsURL))
+	tm, _ = m.Update(update.FeedFetchedMsg{URL: reading.NewsURL, Feed: &reading.Feed{URL: reading.NewsURL}})
+	return tm.(*Model)
+}
+
+func TestNewsSelection_RestoresTopicOfLatestDigest(t *testing.T) {
+	today := time.Now().Format("2006-01-02")
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	tests := []struct {
+		name      string
+		selection *settings.NewsSelection
+		want      string
+	}{
+		{name: "same digest", selection: &settings.NewsSelection{Date: today, GUID: "d2"}, want: "d2"},
+		{name: "regenerated digest", selection: &settings.NewsSelection{Date: today, GUID: "gone"}, want: "d1"},
+		{name: "earlier day", selection: &settings.NewsSelection{Date: yesterday, GUID: "old"}, want: "d1"},
+		{name: "nothing saved", want: "d1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newNewsSelectionModel(&stubSubscriptionRepo{}, tt.selection)
+			if got := selectedGUID(m); got != tt.want {
+				t.Fatalf("selected = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewsSelection_SavesOpenedTopic(t *testing.T) {
+	subs := &stubSubscriptionRepo{}
+	m := newNewsSelectionModel(subs, nil)
+	m.state.ArticleList.CursorDown()
+	if got := selectedGUID(m); got != "d2" {
+		t.Fatalf("selected = %q, want d2", got)
+	}
+
+	m = pressKey(m, tea.KeyEnter)
+	if m.state.Session != state.NewsTopicView {
+		t.Fatalf("session = %v, want the topic view", m.state.Session)
+	}
+	want := []usecase.NewsSelection{{DateKey: time.Now().Format("2006-01-02"), GUID: "d2"}}
+	if !slices.Equal(subs.savedNewsSelection, want) {
+		t.Fatalf("saved = %+v, want %+v", subs.savedNewsSelection, want)
+	}
+
+	m = pressKey(m, tea.KeyEsc)
+	m.state.RememberNewsSelection = false
+	m.state.ArticleList.CursorUp()
+	_ = pressKey(m, tea.KeyEnter)
+	if len(subs.savedNewsSelection) != 1 {
+		t.Fatalf("saved = %+v, want nothing saved once disabled", subs.savedNewsSelection)
+	}
+}
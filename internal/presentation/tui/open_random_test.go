@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"math/rand/v2"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+func newRandomPickModel(items map[string]*reading.HistoryItem, seed uint64) *Model {
+	cfg := settings.Settings{Feeds: []string{"http://example.com/feed"}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{items: items}, &stubFeedFetcher{})
+	m.random = rand.New(rand.NewPCG(seed, seed))
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: "http://example.com/feed"}
+	presenter.ApplyArticleList(&m.state.ArticleList, m.state.History, "http://example.com/feed", presenter.ArticleListOptions{})
+	return m
+}
+
+func TestOpenRandomOpensAnUnreadArticle(t *testing.T) {
+	now := time.Now()
+	items := func() map[string]*reading.HistoryItem {
+		return map[string]*reading.HistoryItem{
+			"read": {GUID: "read", Title: "Read", FeedTitle: "Example", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: now, IsRead: true},
+			"u1":   {GUID: "u1", Title: "Unread 1", FeedTitle: "Example", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: now.Add(-time.Second)},
+			"u2":   {GUID: "u2", Title: "Unread 2", FeedTitle: "Example", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: now.Add(-2 * time.Second)},
+			"u3":   {GUID: "u3", Title: "Unread 3", FeedTitle: "Example", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: now.Add(-3 * time.Second)},
+		}
+	}
+
+	m, _ := typeKeys(newRandomPickModel(items(), 7), "o")
+	if m.state.Session != state.DetailView {
+		t.Fatalf("Session = %v, want the detail view", m.state.Session)
+	}
+	picked, ok := m.state.ArticleList.SelectedItem().(*presenter.Item)
+	if !ok || picked.GUID == "read" {
+		t.Fatalf("picked %+v, want an unread article", picked)
+	}
+	if m.state.StatusMessage != "Random pick from Example" {
+		t.Fatalf("StatusMessage = %q, want the source feed", m.state.StatusMessage)
+	}
+
+	again, _ := typeKeys(newRandomPickModel(items(), 7), "o")
+	if got := again.state.ArticleList.SelectedItem().(*presenter.Item).GUID; got != picked.GUID {
+		t.Fatalf("same seed picked %q, then %q", picked.GUID, got)
+	}
+}
+
+func TestOpenRandomReportsWhenEverythingIsRead(t *testing.T) {
+	m := newRandomPickModel(map[string]*reading.HistoryItem{
+		"read": {GUID: "read", Title: "Read", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: time.Now(), IsRead: true},
+	}, 1)
+
+	m, cmd := typeKeys(m, "o")
+	if cmd != nil || m.state.Session != state.ArticleView {
+		t.Fatalf("Session = %v, nothing should open", m.state.Session)
+	}
+	if m.state.StatusMessage != "No unread articles to pick from" {
+		t.Fatalf("StatusMessage = %q", m.state.StatusMessage)
+	}
+}
+
+func TestOpenRandomKeepsFilterAndFollowsOpenAction(t *testing.T) {
+	oldOpen := OSOpenCmd
+	defer func() { OSOpenCmd = oldOpen }()
+	var opened []string
+	OSOpenCmd = func(url string) *exec.Cmd {
+		opened = append(opened, url)
+		return exec.Command("true")
+	}
+
+	now := time.Now()
+	m := newRandomPickModel(map[string]*reading.HistoryItem{
+		"u1": {GUID: "u1", Title: "Go release", Link: "http://example.com/u1", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: now},
+		"u2": {GUID: "u2", Title: "Rust release", Link: "http://example.com/u2", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: now.Add(-time.Second)},
+	}, 3)
+	m.state.OpenInBrowser = true
+	m.state.ArticleList.SetFilterText("rust")
+
+	m, _ = typeKeys(m, "o")
+	if m.state.Session != state.ArticleView {
+		t.Fatalf("Session = %v, want the list kept while the browser opens", m.state.Session)
+	}
+	if len(opened) != 1 || opened[0] != "http://example.com/u2" {
+		t.Fatalf("opened %v, want the only article the filter shows", opened)
+	}
+	if m.state.ArticleList.FilterState() != list.FilterApplied || m.state.ArticleList.FilterValue() != "rust" {
+		t.Fatalf("filter = %v %q, want it kept", m.state.ArticleList.FilterState(), m.state.ArticleList.FilterValue())
+	}
+	if picked := m.state.ArticleList.SelectedItem().(*presenter.Item); picked.GUID != "u2" || !picked.Read {
+		t.Fatalf("selected %+v, want u2 marked read", picked)
+	}
+}
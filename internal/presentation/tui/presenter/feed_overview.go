@@ -0,0 +1,49 @@
+package presenter
+
+import (
+	"strings"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/domain/subscription"
+)
+
+// FeedOverviewRow is a view model for one subscription in the Manage Feeds screen.
+type FeedOverviewRow struct {
+	URL               string
+	Name              string
+	Group             string
+	Total             int
+	Unread            int
+	SubscriptionIndex int
+}
+
+// BuildFeedOverviewRows builds one row per subscribed feed in sidebar order,
+// counting the articles stored in history for each feed.
+func BuildFeedOverviewRows(history *reading.History, feeds []string, groups []subscription.FeedGroup) []FeedOverviewRow {
+	rows := make([]FeedOverviewRow, 0, len(feeds))
+	for _, listItem := range BuildFeedListItems(feeds, groups, nil) {
+		item, ok := listItem.(*Item)
+		if !ok || item == nil || item.IsSectionHeader() || reading.IsVirtualFeedURL(item.Link) {
+			continue
+		}
+		row := FeedOverviewRow{
+			URL:               item.Link,
+			Name:              item.Link,
+			Group:             item.GroupName,
+			SubscriptionIndex: item.SubscriptionIndex,
+		}
+		if history != nil {
+			for _, it := range history.ItemsByFeed(item.Link) {
+				row.Total++
+				if !it.IsRead {
+					row.Unread++
+				}
+				if title := strings.TrimSpace(it.FeedTitle); title != "" && row.Name == item.Link {
+					row.Name = title
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
@@ -0,0 +1,29 @@
+package presenter
+
+import (
+	"testing"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/domain/subscription"
+)
+
+func TestBuildFeedOverviewRows(t *testing.T) {
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", FeedURL: "https://a.example.com/rss", FeedTitle: "Feed A", IsRead: true},
+		"a2": {GUID: "a2", FeedURL: "https://a.example.com/rss", FeedTitle: "Feed A"},
+		"d1": {GUID: "d1", Kind: reading.NewsDigestKind, FeedURL: "https://a.example.com/rss"},
+	})
+	feeds := []string{"https://a.example.com/rss", "https://b.example.com/rss"}
+	groups := []subscription.FeedGroup{{Name: "Tech", Feeds: []string{"https://a.example.com/rss"}}}
+
+	rows := BuildFeedOverviewRows(history, feeds, groups)
+	if len(rows) != 2 {
+		t.Fatalf("len(rows) = %d, want 2", len(rows))
+	}
+	if got := rows[0]; got.Name != "Feed A" || got.Group != "Tech" || got.Total != 2 || got.Unread != 1 || got.SubscriptionIndex != 0 {
+		t.Fatalf("unexpected first row: %#v", got)
+	}
+	if got := rows[1]; got.Name != "https://b.example.com/rss" || got.Group != "" || got.Total != 0 || got.SubscriptionIndex != 1 {
+		t.Fatalf("unexpected second row: %#v", got)
+	}
+}
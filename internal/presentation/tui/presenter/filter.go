@@ -0,0 +1,117 @@
+package presenter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// filterFieldSeparator separates searchable fields inside an article filter value.
+const filterFieldSeparator = "\x1f"
+
+const (
+	filterTitleField = iota
+	filterDescField
+	filterFeedField
+	filterTagsField
+	filterDateField
+	filterFieldCount
+)
+
+// filterValue returns the searchable text for an item.
+// Articles encode title, description, feed, tags and date so ArticleFilter can
+// match on content and qualifiers; other rows only expose their title.
+func (i *Item) filterValue() string {
+	if i.GUID == "" || i.SectionHeader {
+		return i.TitleText
+	}
+	fields := make([]string, filterFieldCount)
+	fields[filterTitleField] = i.TitleText
+	fields[filterDescField] = i.Desc
+	fields[filterFeedField] = i.FeedTitleText
+	fields[filterTagsField] = strings.Join(append(append([]string(nil), i.AITags...), i.FeedCategories...), ",")
+	fields[filterDateField] = i.DateKey
+	for idx, field := range fields {
+		fields[idx] = strings.ReplaceAll(field, filterFieldSeparator, " ")
+	}
+	return strings.Join(fields, filterFieldSeparator)
+}
+
+// ArticleFilter is a list.FilterFunc for article lists.
+// Every whitespace-separated term must match. Plain terms match title,
+// description, feed title or AI tags. Qualifiers narrow the match:
+//   - tag:<name>   AI tag prefix
+//   - feed:<name>  feed title substring
+//   - date:today, date:yesterday or date:<YYYY-MM-DD prefix>, matched
+//     against the publish date (the last fetch date when there is none)
+func ArticleFilter(term string, targets []string) []list.Rank {
+	return filterArticles(term, targets, time.Now())
+}
+
+func filterArticles(term string, targets []string, now time.Time) []list.Rank {
+	terms := strings.Fields(strings.ToLower(term))
+	ranks := make([]list.Rank, 0, len(targets))
+	if len(terms) == 0 {
+		for idx := range targets {
+			ranks = append(ranks, list.Rank{Index: idx})
+		}
+		return ranks
+	}
+
+	for idx, target := range targets {
+		fields := strings.Split(strings.ToLower(target), filterFieldSeparator)
+		matched := true
+		for _, t := range terms {
+			if !matchFilterTerm(fields, t, now) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			ranks = append(ranks, list.Rank{Index: idx})
+		}
+	}
+	return ranks
+}
+
+func matchFilterTerm(fields []string, term string, now time.Time) bool {
+	field := func(index int) string {
+		if index < len(fields) {
+			return fields[index]
+		}
+		return ""
+	}
+
+	qualifier, value, ok := strings.Cut(term, ":")
+	if ok && value != "" {
+		switch qualifier {
+		case "tag":
+			for tag := range strings.SplitSeq(field(filterTagsField), ",") {
+				if strings.HasPrefix(strings.TrimSpace(tag), value) {
+					return true
+				}
+			}
+			return false
+		case "feed":
+			return strings.Contains(field(filterFeedField), value)
+		case "date":
+			dateKey := field(filterDateField)
+			switch value {
+			case "today":
+				return dateKey == now.In(time.Local).Format("2006-01-02")
+			case "yesterday":
+				return dateKey == now.In(time.Local).AddDate(0, 0, -1).Format("2006-01-02")
+			default:
+				return dateKey != "" && strings.HasPrefix(dateKey, value)
+			}
+		}
+	}
+
+	for _, index := range []int{filterTitleField, filterDescField, filterFeedField, filterTagsField} {
+		if strings.Contains(field(index), term) {
+			return true
+		}
+	}
+	return false
+}
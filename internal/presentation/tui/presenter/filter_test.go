@@ -0,0 +1,93 @@
+package presenter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+)
+
+func TestFilterArticles(t *testing.T) {
+	now := time.Date(2026, 2, 14, 12, 0, 0, 0, time.Local)
+	items := []*Item{
+		{
+			GUID:          "a",
+			TitleText:     "1. Go 1.26 released",
+			Desc:          "Release notes for the toolchain",
+			FeedTitleText: "Go Blog",
+			AITags:        []string{"golang", "release"},
+			DateKey:       "2026-02-14",
+		},
+		{
+			GUID:           "b",
+			TitleText:      "2. Rust news",
+			Desc:           "Weekly summary",
+			FeedTitleText:  "This Week in Rust",
+			AITags:         []string{"rust"},
+			FeedCategories: []string{"Newsletter"},
+			DateKey:        "2026-02-13",
+		},
+		{
+			TitleText:     "== 2026-02-14 (Sat) (1) ==",
+			SectionHeader: true,
+		},
+	}
+	targets := make([]string, 0, len(items))
+	for _, item := range items {
+		targets = append(targets, item.FilterValue())
+	}
+
+	tests := []struct {
+		name string
+		term string
+		want []int
+	}{
+		{name: "empty term keeps all", term: "", want: []int{0, 1, 2}},
+		{name: "matches description", term: "toolchain", want: []int{0}},
+		{name: "matches tag text", term: "golang", want: []int{0}},
+		{name: "tag qualifier", term: "tag:rus", want: []int{1}},
+		{name: "tag qualifier matches feed category", term: "tag:newsletter", want: []int{1}},
+		{name: "feed qualifier", term: "feed:go blog", want: []int{0}},
+		{name: "feed qualifier single word", term: "feed:week", want: []int{1}},
+		{name: "date today", term: "date:today", want: []int{0}},
+		{name: "date yesterday", term: "date:yesterday", want: []int{1}},
+		{name: "date prefix", term: "date:2026-02", want: []int{0, 1}},
+		{name: "all terms must match", term: "release date:yesterday", want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranks := filterArticles(tt.term, targets, now)
+			got := make([]int, 0, len(ranks))
+			for _, rank := range ranks {
+				got = append(got, rank.Index)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("filterArticles(%q) = %v, want %v", tt.term, got, tt.want)
+			}
+			for idx := range got {
+				if got[idx] != tt.want[idx] {
+					t.Fatalf("filterArticles(%q) = %v, want %v", tt.term, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildArticleListItems_SetsDateKey(t *testing.T) {
+	date := time.Date(2026, 2, 14, 9, 0, 0, 0, time.Local)
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"a": {GUID: "a", Title: "A", FeedURL: "feed", Date: date, IsBookmarked: true},
+	})
+
+	items := BuildArticleListItems(history, reading.BookmarksURL, ArticleListOptions{})
+	if len(items) != 2 {
+		t.Fatalf("len(items) = %d, want 2", len(items))
+	}
+	article, ok := items[1].(*Item)
+	if !ok {
+		t.Fatalf("items[1] should be *Item")
+	}
+	if article.DateKey != "2026-02-14" {
+		t.Fatalf("DateKey = %q, want 2026-02-14", article.DateKey)
+	}
+}
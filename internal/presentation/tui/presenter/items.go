@@ -3,11 +3,16 @@ package presenter
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/tesso57/reazy/internal/application/settings"
 	"github.com/tesso57/reazy/internal/domain/reading"
 	"github.com/tesso57/reazy/internal/domain/subscription"
 	"github.com/tesso57/reazy/internal/presentation/tui/textutil"
@@ -21,35 +26,82 @@ type Item struct {
 	Content           string
 	Link              string
 	Published         string
+	DateKey           string
 	GUID              string
 	Read              bool
 	Bookmarked        bool
+	LastOpened        bool
+	Opens             int
 	AISummary         string
 	AITags            []string
+	FeedCategories    []string
 	AIUpdatedAt       time.Time
 	FeedTitleText     string
 	FeedURL           string
 	Kind              string
 	RelatedGUIDs      []string
+	RelatedTitles     map[string]string
 	SectionHeader     bool
 	BodyHydrated      bool
 	GroupName         string
 	SubscriptionIndex int
+	// Sources counts the same-title articles a collapsed All Feeds row
+	// stands for; TitleKey is their normalized title. Both are zero on
+	// ordinary rows.
+	Sources  int
+	TitleKey string
+	// BookmarkBright keeps the row undimmed while it is bookmarked, even
+	// when read (see KeepBookmarksBright).
+	BookmarkBright bool
+	// Expanded shows the description under the row (see ExpandRow).
+	Expanded bool
+	// Selected marks the row as picked for export (see MarkSelected).
+	Selected bool
 }
 
-const (
-	// BuiltinAllFeedsListIndex is the sidebar index of the built-in "All Feeds" tab.
-	BuiltinAllFeedsListIndex = iota
-	// BuiltinNewsListIndex is the sidebar index of the built-in "News" tab.
-	BuiltinNewsListIndex
-	// BuiltinBookmarksListIndex is the sidebar index of the built-in "Bookmarks" tab.
-	BuiltinBookmarksListIndex
-	// BuiltinFeedItemCount is the number of non-removable built-in feed tabs.
-	BuiltinFeedItemCount
-)
+// builtinTabs maps settings.BuiltinTab* names to the sidebar tab they show.
+var builtinTabs = map[string]struct{ title, url string }{
+	settings.BuiltinTabAll:       {title: "All Feeds", url: reading.AllFeedsURL},
+	settings.BuiltinTabNews:      {title: "News", url: reading.NewsURL},
+	settings.BuiltinTabBookmarks: {title: "Bookmarks", url: reading.BookmarksURL},
+	settings.BuiltinTabDismissed: {title: "Dismissed", url: reading.DismissedURL},
+}
+
+// BuiltinTabCount returns how many built-in tabs precede the subscriptions
+// in the sidebar. Unknown tab names are ignored.
+func BuiltinTabCount(tabs []string) int {
+	return len(builtinTabItems(tabs))
+}
+
+// BuiltinTabIndex returns the sidebar index of the built-in tab showing url,
+// or -1 when tabs hides it.
+func BuiltinTabIndex(tabs []string, url string) int {
+	for index, listItem := range builtinTabItems(tabs) {
+		if listItem.(*Item).Link == url {
+			return index
+		}
+	}
+	return -1
+}
+
+func builtinTabItems(tabs []string) []list.Item {
+	items := make([]list.Item, 0, len(tabs))
+	for _, name := range tabs {
+		tab, ok := builtinTabs[name]
+		if !ok {
+			continue
+		}
+		items = append(items, &Item{
+			TitleText: fmt.Sprintf("%d. * %s", len(items), tab.title),
+			RawTitle:  tab.title,
+			Link:      tab.url,
+		})
+	}
+	return items
+}
 
 // FilterValue implements list.Item.
-func (i *Item) FilterValue() string { return i.TitleText }
+func (i *Item) FilterValue() string { return i.filterValue() }
 
 // Title returns the item title.
 func (i *Item) Title() string { return i.TitleText }
@@ -63,9 +115,33 @@ func (i *Item) IsRead() bool { return i.Read }
 // IsBookmarked returns the bookmarked state.
 func (i *Item) IsBookmarked() bool { return i.Bookmarked }
 
+// IsLastOpened reports whether the item is the most recently opened article.
+func (i *Item) IsLastOpened() bool { return i.LastOpened }
+
+// IsSelected reports whether the article is picked for export.
+func (i *Item) IsSelected() bool { return i.Selected }
+
+// DimsWhenRead reports whether the row is dimmed once read.
+func (i *Item) DimsWhenRead() bool { return !i.BookmarkBright || !i.Bookmarked }
+
+// PeekText returns the description shown under an expanded row, empty while
+// the row is collapsed.
+func (i *Item) PeekText() string {
+	if !i.Expanded {
+		return ""
+	}
+	return textutil.PlainText(i.Desc)
+}
+
+// OpenCount returns how many times the article has been opened.
+func (i *Item) OpenCount() int { return i.Opens }
+
 // HasAISummary returns true when AI summary is available.
 func (i *Item) HasAISummary() bool { return strings.TrimSpace(i.AISummary) != "" }
 
+// SourceCount returns how many same-title articles the row stands for.
+func (i *Item) SourceCount() int { return i.Sources }
+
 // FeedTitle returns the feed title for the item.
 func (i *Item) FeedTitle() string { return i.FeedTitleText }
 
@@ -83,109 +159,286 @@ func (i *Item) Description() string {
 	return i.Desc
 }
 
-// BuildFeedListItems builds list items for the feed list.
-func BuildFeedListItems(feeds []string, groups []subscription.FeedGroup) []list.Item {
-	items := make([]list.Item, 0, len(feeds)+BuiltinFeedItemCount+len(groups)+1)
-	items = append(items, &Item{
-		TitleText: "0. * All Feeds",
-		RawTitle:  "All Feeds",
-		Link:      reading.AllFeedsURL,
-	})
-	items = append(items, &Item{
-		TitleText: "1. * News",
-		RawTitle:  "News",
-		Link:      reading.NewsURL,
-	})
-	items = append(items, &Item{
-		TitleText: "2. * Bookmarks",
-		RawTitle:  "Bookmarks",
-		Link:      reading.BookmarksURL,
-	})
+// BuildFeedListItems builds list items for the feed list. tabs lists the
+// built-in tabs (settings.BuiltinTab* names) shown first, in order.
+func BuildFeedListItems(feeds []string, groups []subscription.FeedGroup, tabs []string) []list.Item {
+	return BuildFilteredFeedListItems(feeds, groups, tabs, nil, settings.GroupSortManual, nil, nil)
+}
 
-	displayIndex := BuiltinFeedItemCount
-	subscriptionIndex := 0
-	renderedGroup := false
-	groupDisplayIndex := 1
+// BuildFilteredFeedListItems builds the feed list showing only feeds for
+// which keep returns true; a nil keep shows every feed. Built-in tabs are
+// always shown, groups left empty are omitted, and group and display numbers
+// follow the visible feeds. Subscription indexes still refer to feeds.
+// Groups are ordered by groupSort (see settings.GroupSort), using unread
+// counts per feed URL for settings.GroupSortUnreadDesc; the Ungrouped
+// section always comes last. Feeds with an entry in titles (see
+// settings.FeedOptions.Title) show it in place of their URL.
+func BuildFilteredFeedListItems(
+	feeds []string,
+	groups []subscription.FeedGroup,
+	tabs []string,
+	keep func(feedURL string) bool,
+	groupSort string,
+	unread map[string]int,
+	titles map[string]string,
+) []list.Item {
+	type feedBlock struct {
+		name    string
+		indexes []int
+	}
+	visible := func(index int) bool {
+		return keep == nil || keep(feeds[index])
+	}
 
+	subscriptionIndex := 0
+	var blocks []feedBlock
 	for _, group := range groups {
 		if strings.TrimSpace(group.Name) == "" || len(group.Feeds) == 0 {
 			continue
 		}
-		renderedGroup = true
-		items = append(items, &Item{
-			TitleText:     fmt.Sprintf("== [%d] %s ==", groupDisplayIndex, textutil.SingleLine(group.Name)),
-			RawTitle:      group.Name,
-			FeedTitleText: group.Name,
-			SectionHeader: true,
-		})
-		groupDisplayIndex++
-
+		block := feedBlock{name: group.Name}
 		for range group.Feeds {
 			if subscriptionIndex >= len(feeds) {
 				break
 			}
-			feedURL := feeds[subscriptionIndex]
+			if visible(subscriptionIndex) {
+				block.indexes = append(block.indexes, subscriptionIndex)
+			}
+			subscriptionIndex++
+		}
+		if len(block.indexes) > 0 {
+			blocks = append(blocks, block)
+		}
+	}
+	var ungrouped []int
+	for ; subscriptionIndex < len(feeds); subscriptionIndex++ {
+		if visible(subscriptionIndex) {
+			ungrouped = append(ungrouped, subscriptionIndex)
+		}
+	}
+
+	switch groupSort {
+	case settings.GroupSortAlpha:
+		slices.SortStableFunc(blocks, func(a, b feedBlock) int {
+			return strings.Compare(strings.ToLower(a.name), strings.ToLower(b.name))
+		})
+	case settings.GroupSortUnreadDesc:
+		unreadTotal := func(block feedBlock) int {
+			total := 0
+			for _, index := range block.indexes {
+				total += unread[feeds[index]]
+			}
+			return total
+		}
+		slices.SortStableFunc(blocks, func(a, b feedBlock) int {
+			return unreadTotal(b) - unreadTotal(a)
+		})
+	}
+
+	items := builtinTabItems(tabs)
+	items = slices.Grow(items, len(feeds)+len(blocks)+1)
+	displayIndex := len(items)
+	appendFeeds := func(groupName string, indexes []int) {
+		for _, index := range indexes {
+			feedURL := feeds[index]
+			label := feedURL
+			if title := titles[feedURL]; title != "" {
+				label = title
+			}
 			items = append(items, &Item{
-				TitleText:         fmt.Sprintf("%d. %s", displayIndex, textutil.SingleLine(feedURL)),
+				TitleText:         fmt.Sprintf("%d. %s", displayIndex, textutil.SingleLine(label)),
 				RawTitle:          feedURL,
 				Link:              feedURL,
-				GroupName:         group.Name,
-				SubscriptionIndex: subscriptionIndex,
+				GroupName:         groupName,
+				SubscriptionIndex: index,
 			})
 			displayIndex++
-			subscriptionIndex++
 		}
 	}
 
-	if renderedGroup && subscriptionIndex < len(feeds) {
+	for groupIndex, block := range blocks {
 		items = append(items, &Item{
-			TitleText:     fmt.Sprintf("== [%d] Ungrouped ==", groupDisplayIndex),
-			RawTitle:      "Ungrouped",
-			FeedTitleText: "Ungrouped",
+			TitleText:     fmt.Sprintf("== [%d] %s ==", groupIndex+1, textutil.SingleLine(block.name)),
+			RawTitle:      block.name,
+			FeedTitleText: block.name,
 			SectionHeader: true,
 		})
+		appendFeeds(block.name, block.indexes)
 	}
-	for subscriptionIndex < len(feeds) {
-		feedURL := feeds[subscriptionIndex]
+	if len(blocks) > 0 && len(ungrouped) > 0 {
 		items = append(items, &Item{
-			TitleText:         fmt.Sprintf("%d. %s", displayIndex, textutil.SingleLine(feedURL)),
-			RawTitle:          feedURL,
-			Link:              feedURL,
-			SubscriptionIndex: subscriptionIndex,
+			TitleText:     fmt.Sprintf("== [%d] Ungrouped ==", len(blocks)+1),
+			RawTitle:      "Ungrouped",
+			FeedTitleText: "Ungrouped",
+			SectionHeader: true,
 		})
-		displayIndex++
-		subscriptionIndex++
 	}
+	appendFeeds("", ungrouped)
 
 	return items
 }
 
 // ApplyFeedList updates the list model with feed items.
-func ApplyFeedList(model *list.Model, feeds []string, groups []subscription.FeedGroup) {
-	model.SetItems(BuildFeedListItems(feeds, groups))
+func ApplyFeedList(model *list.Model, feeds []string, groups []subscription.FeedGroup, tabs []string) {
+	model.SetItems(BuildFeedListItems(feeds, groups, tabs))
+	if item, ok := model.SelectedItem().(*Item); ok && item.IsSectionHeader() {
+		selectFirstSelectableItem(model)
+	}
+}
+
+// ApplyFilteredFeedList updates the list model with the feeds keep accepts and
+// groups ordered by groupSort (see BuildFilteredFeedListItems), keeping the
+// selection on the same item when it is still listed.
+func ApplyFilteredFeedList(
+	model *list.Model,
+	feeds []string,
+	groups []subscription.FeedGroup,
+	tabs []string,
+	keep func(feedURL string) bool,
+	groupSort string,
+	unread map[string]int,
+	titles map[string]string,
+) {
+	selected := ""
+	if item, ok := model.SelectedItem().(*Item); ok && item != nil && !item.IsSectionHeader() {
+		selected = item.Link
+	}
+	model.SetItems(BuildFilteredFeedListItems(feeds, groups, tabs, keep, groupSort, unread, titles))
+	for index, listItem := range model.Items() {
+		if item, ok := listItem.(*Item); ok && selected != "" && item.Link == selected {
+			model.Select(index)
+			return
+		}
+	}
+	if model.Index() >= len(model.Items()) {
+		model.Select(0)
+	}
+	if item, ok := model.SelectedItem().(*Item); ok && item.IsSectionHeader() {
+		selectFirstSelectableItem(model)
+	}
+}
+
+// TitleCollapse controls folding same-title articles into one All Feeds row
+// (see settings.CollapseDuplicateTitles).
+type TitleCollapse struct {
+	Enabled bool
+	// Expanded holds the Item.TitleKey of rows whose articles are listed
+	// one by one again.
+	Expanded map[string]bool
 }
 
-// BuildArticleListItems builds list items for articles.
-func BuildArticleListItems(history *reading.History, feedURL string) []list.Item {
+// ArticleListOptions holds the settings that shape article list rows.
+type ArticleListOptions struct {
+	// HeaderFormat formats date section headers (see
+	// settings.SectionHeaderFormat); empty uses the default.
+	HeaderFormat string
+	// FeedTagMaxChars cuts feed tags in All Feeds and Bookmarks rows to that
+	// many columns (see settings.FeedTagMaxChars); zero or less leaves them
+	// whole.
+	FeedTagMaxChars int
+	// MaxAge leaves older articles out of feed views, but never out of
+	// Bookmarks or Dismissed; zero or less shows every article.
+	MaxAge time.Duration
+	// Collapse, when enabled, keeps only the newest of All Feeds articles
+	// sharing a title.
+	Collapse TitleCollapse
+}
+
+// BuildArticleListItems builds list items for the articles of feedURL,
+// shaped by opts.
+func BuildArticleListItems(history *reading.History, feedURL string, opts ArticleListOptions) []list.Item {
 	if history == nil {
 		return nil
 	}
 
 	if feedURL == reading.NewsURL {
-		return buildNewsDigestListItems(history.DigestItems())
+		return buildNewsDigestListItems(history.DigestItems(), opts.HeaderFormat)
 	}
 
 	items := history.ItemsByFeed(feedURL)
+	if opts.MaxAge > 0 && feedURL != reading.BookmarksURL && feedURL != reading.DismissedURL {
+		items = withinMaxAge(items, opts.MaxAge, time.Now())
+	}
 	sort.Slice(items, func(i, j int) bool {
 		return articleSortDate(items[i]).After(articleSortDate(items[j]))
 	})
 
-	return buildDateSectionedArticleListItems(items, feedURL == reading.AllFeedsURL || feedURL == reading.BookmarksURL)
+	showFeedTitle := feedURL == reading.AllFeedsURL || feedURL == reading.BookmarksURL || feedURL == reading.DismissedURL
+	if feedURL != reading.AllFeedsURL || !opts.Collapse.Enabled {
+		return buildDateSectionedArticleListItems(items, showFeedTitle, opts.FeedTagMaxChars, opts.HeaderFormat)
+	}
+
+	items, sources := collapseDuplicateTitles(items, opts.Collapse.Expanded)
+	if len(items) == 0 {
+		return nil
+	}
+	groups := groupItemsByDate(items, articleDateKeyAndLabel)
+	return buildSectionedListItems(groups, len(items), opts.HeaderFormat, func(index int, it *reading.HistoryItem) *Item {
+		item := buildArticleItem(index, it, showFeedTitle, opts.FeedTagMaxChars)
+		if count := sources[it.GUID]; count > 1 {
+			item.Sources = count
+			item.TitleKey = normalizeTitle(it.Title)
+		}
+		return item
+	})
 }
 
-// ApplyArticleList updates the article list and title based on feed URL.
-func ApplyArticleList(model *list.Model, history *reading.History, feedURL string) {
-	model.SetItems(BuildArticleListItems(history, feedURL))
+// collapseDuplicateTitles folds articles sharing a normalized title into
+// the first of them, unless that title is expanded, and counts how many each
+// kept article stands for by GUID. Only copies of the same story fold: an
+// article from another feed, or with the same link. Recurring titles within
+// one feed, such as "Weekly digest", stay separate rows. items must be
+// sorted newest first.
+func collapseDuplicateTitles(items []*reading.HistoryItem, expanded map[string]bool) ([]*reading.HistoryItem, map[string]int) {
+	kept := make([]*reading.HistoryItem, 0, len(items))
+	byTitle := make(map[string][]*reading.HistoryItem)
+	sources := make(map[string]int)
+	for _, it := range items {
+		key := normalizeTitle(it.Title)
+		if key == "" || expanded[key] {
+			kept = append(kept, it)
+			continue
+		}
+		if same := sameStory(byTitle[key], it); same != nil {
+			sources[same.GUID]++
+			continue
+		}
+		byTitle[key] = append(byTitle[key], it)
+		sources[it.GUID] = 1
+		kept = append(kept, it)
+	}
+	return kept, sources
+}
+
+// sameStory returns the first of candidates that it is a copy of: one from
+// another feed or with the same link.
+func sameStory(candidates []*reading.HistoryItem, it *reading.HistoryItem) *reading.HistoryItem {
+	for _, c := range candidates {
+		if c.FeedURL != it.FeedURL || c.Link == it.Link {
+			return c
+		}
+	}
+	return nil
+}
+
+// normalizeTitle folds case, punctuation and spacing so cross-posts of a
+// story with small title differences compare equal.
+func normalizeTitle(title string) string {
+	title = textutil.StripBidiControls(title)
+	return strings.Join(strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}), " ")
+}
+
+// ApplyArticleList updates the article list and title based on feed URL,
+// keeping an active filter applied. Items always come from history, never from a fetch payload, so a refresh
+// that returns fewer items cannot empty the list.
+func ApplyArticleList(model *list.Model, history *reading.History, feedURL string, opts ArticleListOptions) {
+	if cmd := model.SetItems(BuildArticleListItems(history, feedURL, opts)); cmd != nil {
+		// Re-run an active filter now, as list.SetFilterText does, so the
+		// visible rows come from the new items.
+		*model, _ = model.Update(cmd())
+	}
 	if feedURL == reading.AllFeedsURL {
 		model.Title = "All Feeds"
 	} else if feedURL == reading.NewsURL {
@@ -193,13 +446,87 @@ func ApplyArticleList(model *list.Model, history *reading.History, feedURL strin
 		selectFirstSelectableItem(model)
 	} else if feedURL == reading.BookmarksURL {
 		model.Title = "Bookmarks"
+	} else if feedURL == reading.DismissedURL {
+		model.Title = "Dismissed"
 	} else {
 		model.Title = "Articles"
 	}
 }
 
+// ApplyTodayArticleList fills the News tab with today's articles across feeds,
+// newest first. It stands in for the AI digest when none can be generated.
+func ApplyTodayArticleList(model *list.Model, history *reading.History, feeds []string, now time.Time, headerFormat string, feedTagMaxChars int) {
+	var items []list.Item
+	if history != nil {
+		today := history.TodayArticleItems(now.Format("2006-01-02"), feeds, now.Location())
+		items = buildDateSectionedArticleListItems(today, true, feedTagMaxChars, headerFormat)
+	}
+	model.SetItems(items)
+	model.Title = "News (today's articles)"
+}
+
+// MarkLastOpened flags the article with guid as the most recently opened one
+// and clears the flag on every other item.
+func MarkLastOpened(model *list.Model, guid string) {
+	if model == nil {
+		return
+	}
+	for _, listItem := range model.Items() {
+		if item, ok := listItem.(*Item); ok && item != nil {
+			item.LastOpened = guid != "" && item.GUID == guid
+		}
+	}
+}
+
+// MarkSelected flags the articles whose GUIDs are in guids as picked for
+// export and clears the flag on every other item.
+func MarkSelected(model *list.Model, guids []string) {
+	if model == nil {
+		return
+	}
+	selected := make(map[string]bool, len(guids))
+	for _, guid := range guids {
+		selected[guid] = true
+	}
+	for _, listItem := range model.Items() {
+		if item, ok := listItem.(*Item); ok && item != nil {
+			item.Selected = !item.SectionHeader && selected[item.GUID]
+		}
+	}
+}
+
+// ExpandRow flags the article with guid to show its description under the
+// row and collapses every other item. It reports whether guid is listed.
+func ExpandRow(model *list.Model, guid string) bool {
+	if model == nil {
+		return false
+	}
+	found := false
+	for _, listItem := range model.Items() {
+		if item, ok := listItem.(*Item); ok && item != nil {
+			item.Expanded = guid != "" && item.GUID == guid
+			found = found || item.Expanded
+		}
+	}
+	return found
+}
+
+// KeepBookmarksBright stops read dimming of the bookmarked items in the list.
+func KeepBookmarksBright(model *list.Model) {
+	if model == nil {
+		return
+	}
+	for _, listItem := range model.Items() {
+		if item, ok := listItem.(*Item); ok && item != nil {
+			item.BookmarkBright = true
+		}
+	}
+}
+
 // ApplyRelatedArticleList updates the list with related article items.
-func ApplyRelatedArticleList(model *list.Model, history *reading.History, relatedGUIDs []string) {
+// Non-nil titles replace the displayed title of matching GUIDs, e.g. with
+// translations from the digest. Feed tags are cut as in BuildArticleListItems.
+func ApplyRelatedArticleList(model *list.Model, history *reading.History, relatedGUIDs []string, titles map[string]string, feedTagMaxChars int) {
 	if model == nil || history == nil {
 		return
 	}
@@ -209,44 +536,61 @@ func ApplyRelatedArticleList(model *list.Model, history *reading.History, relate
 	})
 	result := make([]list.Item, 0, len(related))
 	for index, it := range related {
-		result = append(result, buildArticleItem(index+1, it, true))
+		title := strings.TrimSpace(titles[it.GUID])
+		if title == "" {
+			result = append(result, buildArticleItem(index+1, it, true, feedTagMaxChars))
+			continue
+		}
+		translated := *it
+		translated.Title = title
+		item := buildArticleItem(index+1, &translated, true, feedTagMaxChars)
+		item.RawTitle = it.Title
+		result = append(result, item)
 	}
 	model.SetItems(result)
 	model.Title = "Related Articles"
 	selectFirstSelectableItem(model)
 }
 
-func buildArticleItem(index int, it *reading.HistoryItem, showFeedTitle bool) *Item {
-	title := textutil.SingleLine(it.Title)
-	feedTitle := textutil.SingleLine(it.FeedTitle)
+func buildArticleItem(index int, it *reading.HistoryItem, showFeedTitle bool, feedTagMaxChars int) *Item {
+	title := textutil.SingleLine(textutil.StripBidiControls(it.Title))
+	feedTitle := feedTag(it.FeedTitle, feedTagMaxChars)
 	if showFeedTitle && feedTitle != "" {
 		title = fmt.Sprintf("%d. [%s] %s", index, feedTitle, title)
 	} else {
 		title = fmt.Sprintf("%d. %s", index, title)
 	}
 
+	dateKey, _ := articleDateKeyAndLabel(it)
+	if dateKey == unknownDateKey {
+		dateKey = ""
+	}
+
 	return &Item{
-		TitleText:     title,
-		RawTitle:      it.Title,
-		Desc:          it.Description,
-		Content:       it.Content,
-		Link:          it.Link,
-		Published:     it.Published,
-		GUID:          it.GUID,
-		Read:          it.IsRead,
-		Bookmarked:    it.IsBookmarked,
-		AISummary:     it.AISummary,
-		AITags:        append([]string(nil), it.AITags...),
-		AIUpdatedAt:   it.AIUpdatedAt,
-		FeedTitleText: it.FeedTitle,
-		FeedURL:       it.FeedURL,
-		Kind:          kindOrDefault(it.Kind),
-		RelatedGUIDs:  append([]string(nil), it.RelatedGUIDs...),
-		BodyHydrated:  it.BodyHydrated,
+		TitleText:      title,
+		RawTitle:       it.Title,
+		Desc:           it.Description,
+		Content:        it.Content,
+		Link:           it.Link,
+		Published:      it.Published,
+		DateKey:        dateKey,
+		GUID:           it.GUID,
+		Read:           it.IsRead,
+		Bookmarked:     it.IsBookmarked,
+		Opens:          it.OpenCount,
+		AISummary:      it.AISummary,
+		AITags:         append([]string(nil), it.AITags...),
+		FeedCategories: append([]string(nil), it.FeedCategories...),
+		AIUpdatedAt:    it.AIUpdatedAt,
+		FeedTitleText:  it.FeedTitle,
+		FeedURL:        it.FeedURL,
+		Kind:           kindOrDefault(it.Kind),
+		RelatedGUIDs:   append([]string(nil), it.RelatedGUIDs...),
+		BodyHydrated:   it.BodyHydrated,
 	}
 }
 
-func buildNewsDigestListItems(items []*reading.HistoryItem) []list.Item {
+func buildNewsDigestListItems(items []*reading.HistoryItem, headerFormat string) []list.Item {
 	if len(items) == 0 {
 		return nil
 	}
@@ -279,7 +623,7 @@ func buildNewsDigestListItems(items []*reading.HistoryItem) []list.Item {
 	})
 
 	groups := groupItemsByDate(sorted, newsDigestDateKeyAndLabel)
-	return buildSectionedListItems(groups, len(sorted), buildNewsDigestItem)
+	return buildSectionedListItems(groups, len(sorted), headerFormat, buildNewsDigestItem)
 }
 
 type articleGroup struct {
@@ -292,13 +636,35 @@ const (
 	unknownDateLabel = "Unknown Date"
 )
 
-func buildDateSectionedArticleListItems(items []*reading.HistoryItem, showFeedTitle bool) []list.Item {
+func buildDateSectionedArticleListItems(items []*reading.HistoryItem, showFeedTitle bool, feedTagMaxChars int, headerFormat string) []list.Item {
 	if len(items) == 0 {
 		return nil
 	}
 	groups := groupItemsByDate(items, articleDateKeyAndLabel)
-	return buildSectionedListItems(groups, len(items), func(index int, it *reading.HistoryItem) *Item {
-		return buildArticleItem(index, it, showFeedTitle)
+	return buildSectionedListItems(groups, len(items), headerFormat, func(index int, it *reading.HistoryItem) *Item {
+		return buildArticleItem(index, it, showFeedTitle, feedTagMaxChars)
+	})
+}
+
+// feedTag prepares a feed title for the bracketed tag in article rows. Bidi
+// control characters are dropped so right-to-left titles cannot reorder the
+// rest of the row, and the result is cut on grapheme boundaries to at most
+// maxWidth columns, keeping combining marks with their base character.
+func feedTag(feedTitle string, maxWidth int) string {
+	tag := textutil.SingleLine(textutil.StripBidiControls(feedTitle))
+	if maxWidth <= 0 {
+		return tag
+	}
+	return textutil.Truncate(tag, maxWidth)
+}
+
+// withinMaxAge drops articles dated before now minus maxAge. Articles without
+// any date are kept.
+func withinMaxAge(items []*reading.HistoryItem, maxAge time.Duration, now time.Time) []*reading.HistoryItem {
+	cutoff := now.Add(-maxAge)
+	return slices.DeleteFunc(items, func(item *reading.HistoryItem) bool {
+		date := articleSortDate(item)
+		return !date.IsZero() && date.Before(cutoff)
 	})
 }
 
@@ -350,12 +716,17 @@ func buildNewsDigestItem(index int, it *reading.HistoryItem) *Item {
 	if title == "" {
 		title = "Untitled Topic"
 	}
+	dateKey, _ := newsDigestDateKeyAndLabel(it)
+	if dateKey == unknownDateKey {
+		dateKey = ""
+	}
 	return &Item{
 		TitleText:     fmt.Sprintf("%d. %s", index, title),
 		RawTitle:      it.Title,
 		Desc:          strings.TrimSpace(it.Description),
 		Content:       strings.TrimSpace(it.Content),
 		Published:     it.Published,
+		DateKey:       dateKey,
 		GUID:          it.GUID,
 		AITags:        append([]string(nil), it.AITags...),
 		AIUpdatedAt:   it.AIUpdatedAt,
@@ -363,13 +734,21 @@ func buildNewsDigestItem(index int, it *reading.HistoryItem) *Item {
 		FeedURL:       reading.NewsURL,
 		Kind:          reading.NewsDigestKind,
 		RelatedGUIDs:  append([]string(nil), it.RelatedGUIDs...),
+		RelatedTitles: maps.Clone(it.RelatedTitles),
 		BodyHydrated:  true,
 	}
 }
 
-func buildSectionHeaderItem(label string, count int) *Item {
+func buildSectionHeaderItem(label string, count int, format string) *Item {
+	if format == "" {
+		format = settings.DefaultSectionHeaderFormat
+	}
+	title := strings.NewReplacer(
+		settings.SectionHeaderLabel, label,
+		settings.SectionHeaderCount, strconv.Itoa(count),
+	).Replace(format)
 	return &Item{
-		TitleText:     fmt.Sprintf("== %s (%d) ==", label, count),
+		TitleText:     title,
 		RawTitle:      label,
 		FeedTitleText: label,
 		SectionHeader: true,
@@ -379,12 +758,13 @@ func buildSectionHeaderItem(label string, count int) *Item {
 func buildSectionedListItems(
 	groups []articleGroup,
 	itemCount int,
+	headerFormat string,
 	rowBuilder func(index int, it *reading.HistoryItem) *Item,
 ) []list.Item {
 	result := make([]list.Item, 0, itemCount+len(groups))
 	index := 1
 	for _, group := range groups {
-		result = append(result, buildSectionHeaderItem(group.label, len(group.items)))
+		result = append(result, buildSectionHeaderItem(group.label, len(group.items), headerFormat))
 		for _, it := range group.items {
 			result = append(result, rowBuilder(index, it))
 			index++
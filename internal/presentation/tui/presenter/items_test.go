@@ -1,11 +1,16 @@
 package presenter
 
 import (
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/tesso57/reazy/internal/application/settings"
 	"github.com/tesso57/reazy/internal/domain/reading"
 	"github.com/tesso57/reazy/internal/domain/subscription"
 )
@@ -14,7 +19,7 @@ func TestBuildFeedListItems(t *testing.T) {
 	items := BuildFeedListItems([]string{
 		"https://example.com/feed1.xml",
 		"https://example.com/feed2.xml",
-	}, nil)
+	}, nil, settings.DefaultBuiltinTabs())
 
 	if len(items) != 5 {
 		t.Fatalf("len(items) = %d, want 5", len(items))
@@ -51,6 +56,7 @@ func TestBuildFeedListItems_WithGroups(t *testing.T) {
 		[]subscription.FeedGroup{
 			{Name: "Tech", Feeds: []string{"https://example.com/tech.xml", "https://example.com/golang.xml"}},
 		},
+		settings.DefaultBuiltinTabs(),
 	)
 
 	if len(items) != 8 {
@@ -127,7 +133,7 @@ func TestBuildArticleListItems_AddsDateSections(t *testing.T) {
 		},
 	})
 
-	items := BuildArticleListItems(history, "http://example.com/feed")
+	items := BuildArticleListItems(history, "http://example.com/feed", ArticleListOptions{})
 	if len(items) != 5 {
 		t.Fatalf("len(items) = %d, want 5", len(items))
 	}
@@ -146,6 +152,25 @@ func TestBuildArticleListItems_AddsDateSections(t *testing.T) {
 	}
 }
 
+func TestBuildArticleListItems_SectionHeaderFormat(t *testing.T) {
+	date := time.Date(2026, 2, 14, 10, 0, 0, 0, time.Local)
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"a": {GUID: "a", Kind: reading.ArticleKind, Title: "A", Date: date, FeedURL: "http://example.com/feed"},
+		"b": {GUID: "b", Kind: reading.ArticleKind, Title: "B", Date: date, FeedURL: "http://example.com/feed"},
+	})
+
+	items := BuildArticleListItems(history, "http://example.com/feed", ArticleListOptions{HeaderFormat: "── {label} · {count} ──"})
+	header := items[0].(*Item)
+	if want := "── 2026-02-14 (Sat) · 2 ──"; header.TitleText != want {
+		t.Fatalf("header = %q, want %q", header.TitleText, want)
+	}
+
+	items = BuildArticleListItems(history, "http://example.com/feed", ArticleListOptions{})
+	if want := "== 2026-02-14 (Sat) (2) =="; items[0].(*Item).TitleText != want {
+		t.Fatalf("default header = %q, want %q", items[0].(*Item).TitleText, want)
+	}
+}
+
 func TestBuildArticleListItems_AllFeedsIncludesFeedName(t *testing.T) {
 	now := time.Now()
 	history := reading.NewHistory(map[string]*reading.HistoryItem{
@@ -159,7 +184,7 @@ func TestBuildArticleListItems_AllFeedsIncludesFeedName(t *testing.T) {
 		},
 	})
 
-	items := BuildArticleListItems(history, reading.AllFeedsURL)
+	items := BuildArticleListItems(history, reading.AllFeedsURL, ArticleListOptions{})
 	if len(items) != 2 {
 		t.Fatalf("len(items) = %d, want 2", len(items))
 	}
@@ -169,6 +194,117 @@ func TestBuildArticleListItems_AllFeedsIncludesFeedName(t *testing.T) {
 	}
 }
 
+func TestBuildArticleListItems_CollapsesDuplicateTitles(t *testing.T) {
+	now := time.Now()
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"a": {GUID: "a", Title: "Big News!", FeedURL: "http://a.example/feed", Link: "http://a.example/1", Date: now},
+		"b": {GUID: "b", Title: "big  news", FeedURL: "http://b.example/feed", Link: "http://b.example/1", Date: now.Add(-time.Minute)},
+		"c": {GUID: "c", Title: "Other", FeedURL: "http://b.example/feed", Link: "http://b.example/2", Date: now.Add(-2 * time.Minute)},
+	})
+
+	guids := func(items []list.Item) []string {
+		var out []string
+		for _, listItem := range items {
+			if item := listItem.(*Item); !item.IsSectionHeader() {
+				out = append(out, item.GUID)
+			}
+		}
+		return out
+	}
+
+	collapse := TitleCollapse{Enabled: true}
+	items := BuildArticleListItems(history, reading.AllFeedsURL, ArticleListOptions{Collapse: collapse})
+	if got := guids(items); !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Fatalf("collapsed guids = %v, want [a c]", got)
+	}
+	if row := items[1].(*Item); row.Sources != 2 || row.TitleKey != "big news" {
+		t.Fatalf("collapsed row = %d sources, key %q; want 2, \"big news\"", row.Sources, row.TitleKey)
+	}
+	if row := items[2].(*Item); row.Sources != 0 || row.TitleKey != "" {
+		t.Fatalf("unique row should not be marked collapsed: %+v", row)
+	}
+
+	collapse.Expanded = map[string]bool{"big news": true}
+	if got := guids(BuildArticleListItems(history, reading.AllFeedsURL, ArticleListOptions{Collapse: collapse})); len(got) != 3 {
+		t.Fatalf("expanded guids = %v, want every article", got)
+	}
+	if got := guids(BuildArticleListItems(history, "http://b.example/feed", ArticleListOptions{Collapse: TitleCollapse{Enabled: true}})); len(got) != 2 {
+		t.Fatalf("feed view should never collapse, got %v", got)
+	}
+}
+
+func TestBuildArticleListItems_KeepsRecurringTitlesOfOneFeed(t *testing.T) {
+	now := time.Now()
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"w2":  {GUID: "w2", Title: "Weekly digest", FeedURL: "http://a.example/feed", Link: "http://a.example/week-2", Date: now},
+		"w1":  {GUID: "w1", Title: "Weekly digest", FeedURL: "http://a.example/feed", Link: "http://a.example/week-1", Date: now.Add(-time.Minute)},
+		"x1":  {GUID: "x1", Title: "Weekly Digest", FeedURL: "http://b.example/feed", Link: "http://b.example/1", Date: now.Add(-2 * time.Minute)},
+		"w1b": {GUID: "w1b", Title: "Weekly digest", FeedURL: "http://a.example/feed", Link: "http://a.example/week-1", Date: now.Add(-3 * time.Minute)},
+	})
+
+	var rows []*Item
+	for _, listItem := range BuildArticleListItems(history, reading.AllFeedsURL, ArticleListOptions{Collapse: TitleCollapse{Enabled: true}}) {
+		if item := listItem.(*Item); !item.IsSectionHeader() {
+			rows = append(rows, item)
+		}
+	}
+	if len(rows) != 2 || rows[0].GUID != "w2" || rows[1].GUID != "w1" {
+		t.Fatalf("rows = %+v, want each weekly issue of the feed on its own row", rows)
+	}
+	// The other feed's post folds into the newest issue, and the repeated
+	// link into the issue it repeats.
+	if rows[0].Sources != 2 || rows[1].Sources != 2 {
+		t.Fatalf("sources = %d, %d; want 2, 2", rows[0].Sources, rows[1].Sources)
+	}
+}
+
+func TestBuildArticleListItems_TruncatesLongFeedTag(t *testing.T) {
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"guid1": {
+			GUID:      "guid1",
+			Kind:      reading.ArticleKind,
+			Title:     "記事のタイトル",
+			FeedURL:   "http://example.com/cjk",
+			FeedTitle: "とても長い日本語のフィード名がここに続いていきます",
+			Date:      time.Now(),
+		},
+		"guid2": {
+			GUID:      "guid2",
+			Kind:      reading.ArticleKind,
+			Title:     "Headline",
+			FeedURL:   "http://example.com/rtl",
+			FeedTitle: "\u202eأخبار\u202c",
+			Date:      time.Now().Add(-time.Minute),
+		},
+	})
+
+	items := BuildArticleListItems(history, reading.AllFeedsURL, ArticleListOptions{FeedTagMaxChars: 10})
+	if len(items) != 3 {
+		t.Fatalf("len(items) = %d, want 3", len(items))
+	}
+	cjk := items[1].(*Item).TitleText
+	tag := cjk[strings.Index(cjk, "[")+1 : strings.Index(cjk, "]")]
+	if width := ansi.StringWidth(tag); width > 10 {
+		t.Fatalf("feed tag %q is %d columns wide, want at most 10", tag, width)
+	}
+	if !utf8.ValidString(tag) || !strings.HasPrefix(tag, "とても") || !strings.HasSuffix(tag, "...") {
+		t.Fatalf("feed tag should be cut on a character boundary: %q", tag)
+	}
+	if !strings.HasSuffix(cjk, "] 記事のタイトル") {
+		t.Fatalf("article title should follow the tag intact: %q", cjk)
+	}
+
+	rtl := items[2].(*Item).TitleText
+	if rtl != "2. [أخبار] Headline" {
+		t.Fatalf("bidi controls should be stripped from the feed tag: %q", rtl)
+	}
+
+	items = BuildArticleListItems(history, reading.AllFeedsURL, ArticleListOptions{})
+	if got := items[1].(*Item).TitleText; !strings.Contains(got, "[とても長い日本語のフィード名がここに続いていきます]") {
+		t.Fatalf("feed tag should be whole without a limit: %q", got)
+	}
+}
+
 func TestBuildArticleListItems_NewsShowsDigestHistoryByDate(t *testing.T) {
 	today := time.Now().In(time.Local).Format("2006-01-02")
 	yesterday := time.Now().In(time.Local).Add(-24 * time.Hour).Format("2006-01-02")
@@ -197,7 +333,7 @@ func TestBuildArticleListItems_NewsShowsDigestHistoryByDate(t *testing.T) {
 		},
 	})
 
-	items := BuildArticleListItems(history, reading.NewsURL)
+	items := BuildArticleListItems(history, reading.NewsURL, ArticleListOptions{})
 	if len(items) != 4 {
 		t.Fatalf("len(items) = %d, want 4", len(items))
 	}
@@ -244,7 +380,7 @@ func TestBuildArticleListItems_NewsSameDateShowsLatestFirst(t *testing.T) {
 		},
 	})
 
-	items := BuildArticleListItems(history, reading.NewsURL)
+	items := BuildArticleListItems(history, reading.NewsURL, ArticleListOptions{})
 	if len(items) != 3 {
 		t.Fatalf("len(items) = %d, want 3", len(items))
 	}
@@ -275,7 +411,7 @@ func TestApplyArticleList_SelectsFirstNewsDigest(t *testing.T) {
 	})
 
 	model := list.New([]list.Item{}, list.NewDefaultDelegate(), 80, 20)
-	ApplyArticleList(&model, history, reading.NewsURL)
+	ApplyArticleList(&model, history, reading.NewsURL, ArticleListOptions{})
 
 	if model.Title != "News" {
 		t.Fatalf("model.Title = %q, want News", model.Title)
@@ -285,6 +421,62 @@ func TestApplyArticleList_SelectsFirstNewsDigest(t *testing.T) {
 	}
 }
 
+func TestMarkLastOpened(t *testing.T) {
+	first := &Item{GUID: "a", TitleText: "A"}
+	second := &Item{GUID: "b", TitleText: "B", LastOpened: true}
+	model := list.New([]list.Item{first, second}, list.NewDefaultDelegate(), 80, 20)
+
+	MarkLastOpened(&model, "a")
+	if !first.IsLastOpened() || second.IsLastOpened() {
+		t.Fatalf("MarkLastOpened(a): first=%v second=%v", first.LastOpened, second.LastOpened)
+	}
+
+	MarkLastOpened(&model, "")
+	if first.IsLastOpened() || second.IsLastOpened() {
+		t.Fatal("MarkLastOpened with empty guid should clear every marker")
+	}
+}
+
+func TestKeepBookmarksBright(t *testing.T) {
+	bookmarked := &Item{GUID: "a", Read: true, Bookmarked: true}
+	plain := &Item{GUID: "b", Read: true}
+	model := list.New([]list.Item{bookmarked, plain}, list.NewDefaultDelegate(), 80, 20)
+	if !bookmarked.DimsWhenRead() {
+		t.Fatal("read bookmarks should be dimmed by default")
+	}
+
+	KeepBookmarksBright(&model)
+	if bookmarked.DimsWhenRead() {
+		t.Fatal("KeepBookmarksBright should stop dimming a read bookmark")
+	}
+	if !plain.DimsWhenRead() {
+		t.Fatal("items that are not bookmarked should still be dimmed")
+	}
+	bookmarked.Bookmarked = false
+	if !bookmarked.DimsWhenRead() {
+		t.Fatal("removing the bookmark should dim the item again")
+	}
+}
+
+func TestExpandRow(t *testing.T) {
+	first := &Item{GUID: "a", Desc: "<p>First &amp; best</p>"}
+	second := &Item{GUID: "b", Desc: "Second"}
+	model := list.New([]list.Item{first, second}, list.NewDefaultDelegate(), 80, 20)
+	if first.PeekText() != "" {
+		t.Fatal("collapsed rows should not show their description")
+	}
+
+	if !ExpandRow(&model, "a") || first.PeekText() != "First & best" {
+		t.Fatalf("expanded row should show its plain description, got %q", first.PeekText())
+	}
+	if !ExpandRow(&model, "b") || first.Expanded || !second.Expanded {
+		t.Fatal("expanding a row should collapse the others")
+	}
+	if ExpandRow(&model, "missing") || second.Expanded {
+		t.Fatal("an unlisted guid should collapse every row and report false")
+	}
+}
+
 func TestApplyRelatedArticleList(t *testing.T) {
 	history := reading.NewHistory(map[string]*reading.HistoryItem{
 		"a1": {
@@ -304,7 +496,7 @@ func TestApplyRelatedArticleList(t *testing.T) {
 	})
 
 	model := list.New([]list.Item{}, list.NewDefaultDelegate(), 80, 20)
-	ApplyRelatedArticleList(&model, history, []string{"a2", "missing", "a1"})
+	ApplyRelatedArticleList(&model, history, []string{"a2", "missing", "a1"}, nil, 0)
 
 	if model.Title != "Related Articles" {
 		t.Fatalf("model.Title = %q, want Related Articles", model.Title)
@@ -316,4 +508,192 @@ func TestApplyRelatedArticleList(t *testing.T) {
 	if !strings.Contains(first.TitleText, "[Feed 2]") {
 		t.Fatalf("first related item should keep guid order with feed name: %q", first.TitleText)
 	}
+
+	ApplyRelatedArticleList(&model, history, []string{"a2", "a1"}, map[string]string{"a2": "記事 2"}, 0)
+	first = model.Items()[0].(*Item)
+	if !strings.Contains(first.TitleText, "記事 2") || first.RawTitle != "Article 2" {
+		t.Fatalf("translated title should be displayed while keeping the original: %q / %q", first.TitleText, first.RawTitle)
+	}
+}
+
+func TestBuildFilteredFeedListItems_SkipsFeedsAndEmptyGroups(t *testing.T) {
+	keep := map[string]bool{
+		"https://example.com/golang.xml": true,
+		"https://example.com/misc.xml":   true,
+	}
+	items := BuildFilteredFeedListItems(
+		[]string{
+			"https://example.com/tech.xml",
+			"https://example.com/golang.xml",
+			"https://example.com/news.xml",
+			"https://example.com/misc.xml",
+		},
+		[]subscription.FeedGroup{
+			{Name: "World", Feeds: []string{"https://example.com/news.xml"}},
+			{Name: "Tech", Feeds: []string{"https://example.com/tech.xml", "https://example.com/golang.xml"}},
+		},
+		settings.DefaultBuiltinTabs(),
+		func(feedURL string) bool { return keep[feedURL] },
+		settings.GroupSortManual,
+		nil,
+		nil,
+	)
+
+	if len(items) != 7 {
+		t.Fatalf("len(items) = %d, want 7", len(items))
+	}
+	header := items[3].(*Item)
+	if !header.IsSectionHeader() || header.TitleText != "== [1] Tech ==" {
+		t.Fatalf("items[3] should be the Tech header: %#v", header)
+	}
+	golang := items[4].(*Item)
+	if golang.Link != "https://example.com/golang.xml" || golang.SubscriptionIndex != 1 {
+		t.Fatalf("items[4] = %#v, want golang feed with subscription index 1", golang)
+	}
+	if golang.TitleText != "3. https://example.com/golang.xml" {
+		t.Fatalf("items[4].TitleText = %q", golang.TitleText)
+	}
+	ungrouped := items[5].(*Item)
+	if !ungrouped.IsSectionHeader() || ungrouped.TitleText != "== [2] Ungrouped ==" {
+		t.Fatalf("items[5] should be the Ungrouped header: %#v", ungrouped)
+	}
+	misc := items[6].(*Item)
+	if misc.SubscriptionIndex != 3 {
+		t.Fatalf("items[6].SubscriptionIndex = %d, want 3", misc.SubscriptionIndex)
+	}
+}
+
+func TestBuildFilteredFeedListItems_GroupSort(t *testing.T) {
+	feeds := []string{
+		"https://example.com/zeta.xml",
+		"https://example.com/alpha.xml",
+		"https://example.com/beta1.xml",
+		"https://example.com/beta2.xml",
+		"https://example.com/loose.xml",
+	}
+	groups := []subscription.FeedGroup{
+		{Name: "zeta", Feeds: []string{feeds[0]}},
+		{Name: "Alpha", Feeds: []string{feeds[1]}},
+		{Name: "Beta", Feeds: []string{feeds[2], feeds[3]}},
+	}
+	unread := map[string]int{feeds[1]: 1, feeds[2]: 2, feeds[3]: 3}
+
+	titles := func(items []list.Item) []string {
+		var out []string
+		for _, listItem := range items[3:] {
+			out = append(out, listItem.(*Item).TitleText)
+		}
+		return out
+	}
+	tests := []struct {
+		name      string
+		groupSort string
+		want      []string
+	}{
+		{
+			name:      "manual",
+			groupSort: settings.GroupSortManual,
+			want: []string{
+				"== [1] zeta ==", "3. https://example.com/zeta.xml",
+				"== [2] Alpha ==", "4. https://example.com/alpha.xml",
+				"== [3] Beta ==", "5. https://example.com/beta1.xml", "6. https://example.com/beta2.xml",
+				"== [4] Ungrouped ==", "7. https://example.com/loose.xml",
+			},
+		},
+		{
+			name:      "alpha",
+			groupSort: settings.GroupSortAlpha,
+			want: []string{
+				"== [1] Alpha ==", "3. https://example.com/alpha.xml",
+				"== [2] Beta ==", "4. https://example.com/beta1.xml", "5. https://example.com/beta2.xml",
+				"== [3] zeta ==", "6. https://example.com/zeta.xml",
+				"== [4] Ungrouped ==", "7. https://example.com/loose.xml",
+			},
+		},
+		{
+			name:      "unread desc",
+			groupSort: settings.GroupSortUnreadDesc,
+			want: []string{
+				"== [1] Beta ==", "3. https://example.com/beta1.xml", "4. https://example.com/beta2.xml",
+				"== [2] Alpha ==", "5. https://example.com/alpha.xml",
+				"== [3] zeta ==", "6. https://example.com/zeta.xml",
+				"== [4] Ungrouped ==", "7. https://example.com/loose.xml",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := BuildFilteredFeedListItems(feeds, groups, settings.DefaultBuiltinTabs(), nil, tt.groupSort, unread, nil)
+			if got := titles(items); strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Fatalf("titles =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+			for _, listItem := range items[3:] {
+				item := listItem.(*Item)
+				if !item.IsSectionHeader() && feeds[item.SubscriptionIndex] != item.Link {
+					t.Fatalf("%s has subscription index %d", item.Link, item.SubscriptionIndex)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildFeedListItems_CustomBuiltinTabs(t *testing.T) {
+	tabs := []string{settings.BuiltinTabBookmarks, settings.BuiltinTabAll}
+	items := BuildFeedListItems([]string{"https://example.com/feed1.xml"}, nil, tabs)
+
+	if len(items) != 3 {
+		t.Fatalf("len(items) = %d, want 3", len(items))
+	}
+	want := []string{"0. * Bookmarks", "1. * All Feeds", "2. https://example.com/feed1.xml"}
+	for index, title := range want {
+		if got := items[index].(*Item).TitleText; got != title {
+			t.Fatalf("items[%d].TitleText = %q, want %q", index, got, title)
+		}
+	}
+	if got := BuiltinTabCount(tabs); got != 2 {
+		t.Fatalf("BuiltinTabCount() = %d, want 2", got)
+	}
+	if got := BuiltinTabIndex(tabs, reading.AllFeedsURL); got != 1 {
+		t.Fatalf("BuiltinTabIndex(all) = %d, want 1", got)
+	}
+	if got := BuiltinTabIndex(tabs, reading.NewsURL); got != -1 {
+		t.Fatalf("BuiltinTabIndex(news) = %d, want -1 for a hidden tab", got)
+	}
+
+	noTabs := BuildFeedListItems([]string{"https://example.com/feed1.xml"}, nil, nil)
+	if len(noTabs) != 1 || noTabs[0].(*Item).TitleText != "0. https://example.com/feed1.xml" {
+		t.Fatalf("feed list without built-in tabs = %#v", noTabs)
+	}
+}
+
+func TestBuildArticleListItems_MaxArticleAge(t *testing.T) {
+	now := time.Now()
+	history := reading.NewHistory(map[string]*reading.HistoryItem{
+		"fresh":   {GUID: "fresh", FeedURL: "http://example.com/feed", Title: "Fresh", Date: now.Add(-time.Hour)},
+		"stale":   {GUID: "stale", FeedURL: "http://example.com/feed", Title: "Stale", Date: now.Add(-40 * 24 * time.Hour), IsBookmarked: true},
+		"undated": {GUID: "undated", FeedURL: "http://example.com/feed", Title: "Undated"},
+	})
+	guids := func(items []list.Item) []string {
+		var out []string
+		for _, listItem := range items {
+			if item := listItem.(*Item); !item.IsSectionHeader() {
+				out = append(out, item.GUID)
+			}
+		}
+		sort.Strings(out)
+		return out
+	}
+	maxAge := 30 * 24 * time.Hour
+
+	for _, feedURL := range []string{"http://example.com/feed", reading.AllFeedsURL} {
+		if got := guids(BuildArticleListItems(history, feedURL, ArticleListOptions{MaxAge: maxAge})); !reflect.DeepEqual(got, []string{"fresh", "undated"}) {
+			t.Fatalf("%s with max age = %v, want fresh and undated", feedURL, got)
+		}
+	}
+	if got := guids(BuildArticleListItems(history, reading.BookmarksURL, ArticleListOptions{MaxAge: maxAge})); !reflect.DeepEqual(got, []string{"stale"}) {
+		t.Fatalf("bookmarks with max age = %v, want old bookmark kept", got)
+	}
+	if got := guids(BuildArticleListItems(history, "http://example.com/feed", ArticleListOptions{})); len(got) != 3 {
+		t.Fatalf("max age 0 = %v, want every article", got)
+	}
 }
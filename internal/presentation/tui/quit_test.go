@@ -1,10 +1,13 @@
 package tui
 
 import (
+	"sync/atomic"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/application/usecase"
 	"github.com/tesso57/reazy/internal/presentation/tui/state"
 )
 
@@ -81,7 +84,87 @@ func TestQuitDialog(t *testing.T) {
 	if cmd == nil {
 		t.Error("Should return command on 'y'")
 	}
+	if m.ctx.Err() == nil {
+		t.Error("Quitting should cancel in-flight fetches")
+	}
 	// Note: We can't easily verify it is exactly tea.Quit without deep inspection or comparing func pointers which is hard.
 	// But standard pattern is returning tea.Quit which is a tea.Cmd.
 	// Checking if it's not nil is a good enough proxy for now given the implementation returns tea.Quit.
 }
+
+type closingHistoryRepo struct {
+	stubHistoryRepo
+	closed bool
+}
+
+func (r *closingHistoryRepo) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestQuitWaitsForBackgroundSavesAndClosesHistory(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:                  []string{"http://example.com"},
+		KeyMap:                 settings.KeyMapConfig{Quit: "q"},
+		ShutdownTimeoutSeconds: 5,
+	}
+	historyRepo := &closingHistoryRepo{}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+
+	var saved atomic.Bool
+	// Capture the wait group: m is reassigned below while the save runs.
+	saves := &m.saves
+	saves.Add(1)
+	go func() {
+		defer saves.Done()
+		time.Sleep(20 * time.Millisecond)
+		saved.Store(true)
+	}()
+
+	m, _ = typeKeys(m, "q")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("confirming quit should return a command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Fatal("the shutdown command should end with quitting")
+	}
+	if !saved.Load() {
+		t.Fatal("quitting should wait for the pending save")
+	}
+	if !historyRepo.closed {
+		t.Fatal("quitting should close the history store")
+	}
+}
+
+type waitingNewItemHook struct {
+	done atomic.Bool
+}
+
+func (h *waitingNewItemHook) NotifyNewItems([]usecase.NewItemEvent) {}
+
+func (h *waitingNewItemHook) Wait() {
+	time.Sleep(20 * time.Millisecond)
+	h.done.Store(true)
+}
+
+func TestQuitWaitsForNewItemHook(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:                  []string{"http://example.com"},
+		KeyMap:                 settings.KeyMapConfig{Quit: "q"},
+		ShutdownTimeoutSeconds: 5,
+	}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	hook := &waitingNewItemHook{}
+	m.reading.NewItemHook = hook
+
+	m, _ = typeKeys(m, "q")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("confirming quit should return a command")
+	}
+	cmd()
+	if !hook.done.Load() {
+		t.Fatal("quitting should wait for running new article commands")
+	}
+}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+func articleListGUIDs(m *Model) []string {
+	var guids []string
+	for _, item := range m.state.ArticleList.Items() {
+		if it, ok := item.(*presenter.Item); ok && !it.IsSectionHeader() {
+			guids = append(guids, it.GUID)
+		}
+	}
+	return guids
+}
+
+func TestSnoozeHidesArticleUntilItWakes(t *testing.T) {
+	now := time.Now()
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/a"},
+		KeyMap: settings.KeyMapConfig{Snooze: "Z"},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", Title: "Newer", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now, IsRead: true},
+		"a2": {GUID: "a2", Title: "Older", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now.Add(-time.Hour)},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: reading.AllFeedsURL}
+	presenter.ApplyArticleList(&m.state.ArticleList, m.state.History, reading.AllFeedsURL, presenter.ArticleListOptions{})
+	m.state.ArticleList.Select(1)
+
+	m, _ = typeKeys(m, "Z")
+	if m.state.Session != state.SnoozeView || m.state.SnoozeGUID != "a1" {
+		t.Fatalf("session = %v, snooze guid = %q", m.state.Session, m.state.SnoozeGUID)
+	}
+	if body := m.buildModalProps().Body; !strings.Contains(body, "tomorrow morning") {
+		t.Fatalf("snooze dialog body = %q", body)
+	}
+
+	m, _ = typeKeys(m, "2")
+	if m.state.Session != state.ArticleView {
+		t.Fatalf("session = %v, want ArticleView after snoozing", m.state.Session)
+	}
+	if guids := articleListGUIDs(m); len(guids) != 1 || guids[0] != "a2" {
+		t.Fatalf("article list = %v, want only a2", guids)
+	}
+	if !strings.HasPrefix(m.state.StatusMessage, "Snoozed until ") {
+		t.Fatalf("status = %q", m.state.StatusMessage)
+	}
+
+	historyRepo.items["a1"].SnoozedUntil = now.Add(-time.Minute)
+	tm, cmd := m.Update(update.SnoozeTickMsg{})
+	m = tm.(*Model)
+	if cmd == nil {
+		t.Fatal("snooze check should schedule the next check")
+	}
+	if guids := articleListGUIDs(m); len(guids) != 2 {
+		t.Fatalf("article list = %v, want the woken article back", guids)
+	}
+	if historyRepo.items["a1"].IsRead {
+		t.Fatal("woken article should be unread")
+	}
+	if m.state.StatusMessage != "Snoozed articles are back (1)" {
+		t.Fatalf("status = %q", m.state.StatusMessage)
+	}
+}
+
+func TestSnoozeDialogCancel(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/a"},
+		KeyMap: settings.KeyMapConfig{Snooze: "Z"},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", Title: "Only", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: time.Now()},
+	}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{})
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: "http://example.com/a"}
+	presenter.ApplyArticleList(&m.state.ArticleList, m.state.History, "http://example.com/a", presenter.ArticleListOptions{})
+	m.state.ArticleList.Select(1)
+
+	m, _ = typeKeys(m, "Z")
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = tm.(*Model)
+	if m.state.Session != state.ArticleView || len(articleListGUIDs(m)) != 1 {
+		t.Fatalf("cancel should keep the article: session = %v, list = %v", m.state.Session, articleListGUIDs(m))
+	}
+	if historyRepo.items["a1"].IsSnoozed() {
+		t.Fatal("canceled snooze should not hide the article")
+	}
+}
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+// startupDigestMsg runs the commands Init batches and returns the digest
+// result among them, if any.
+func startupDigestMsg(t *testing.T, m *Model) (update.NewsDigestGeneratedMsg, bool) {
+	t.Helper()
+	batch, ok := m.Init()().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Init should batch its commands")
+	}
+	for _, cmd := range batch {
+		if cmd == nil {
+			continue
+		}
+		if msg, ok := cmd().(update.NewsDigestGeneratedMsg); ok {
+			return msg, true
+		}
+	}
+	return update.NewsDigestGeneratedMsg{}, false
+}
+
+func newStartupDigestModel(generateOnStartup bool, generator *stubNewsDigestGenerator) *Model {
+	cfg := settings.Settings{
+		Feeds:      []string{"http://example.com/feed"},
+		NewsDigest: settings.NewsDigestConfig{GenerateOnStartup: generateOnStartup},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", Title: "Today", FeedURL: "http://example.com/feed", Kind: reading.ArticleKind, Date: time.Now(), BodyHydrated: true},
+	}}
+	return newTestModelWithInsightAndNewsDigestGenerator(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{}, nil, generator)
+}
+
+func TestInitGeneratesNewsDigestOnStartup(t *testing.T) {
+	generator := &stubNewsDigestGenerator{topics: []usecase.NewsDigestTopic{
+		{Title: "Topic", Summary: "Summary", ArticleGUIDs: []string{"a1"}},
+	}}
+	m := newStartupDigestModel(true, generator)
+
+	msg, ok := startupDigestMsg(t, m)
+	if !ok || !msg.Startup {
+		t.Fatalf("Init should start the digest in the background, got %+v", msg)
+	}
+	if !m.state.StartupDigestPending || m.state.Loading {
+		t.Fatalf("pending = %v, loading = %v; want a pending digest without blocking the UI", m.state.StartupDigestPending, m.state.Loading)
+	}
+
+	tm, _ := m.Update(msg)
+	m = tm.(*Model)
+	if m.state.StartupDigestPending {
+		t.Fatal("the digest result should clear the pending flag")
+	}
+	if got := len(m.state.History.DigestItems()); got != 1 {
+		t.Fatalf("digest items = %d, want the startup topic stored", got)
+	}
+}
+
+func TestInitSkipsNewsDigestUnlessEnabled(t *testing.T) {
+	if _, ok := startupDigestMsg(t, newStartupDigestModel(false, &stubNewsDigestGenerator{})); ok {
+		t.Fatal("Init should not build the digest when generate_on_startup is off")
+	}
+
+	cfg := settings.Settings{NewsDigest: settings.NewsDigestConfig{GenerateOnStartup: true}}
+	m := newTestModel(cfg, &stubSubscriptionRepo{}, &stubHistoryRepo{}, &stubFeedFetcher{})
+	if _, ok := startupDigestMsg(t, m); ok {
+		t.Fatal("Init should not build the digest without AI")
+	}
+}
+
+func TestStartupNewsDigestFailureStaysInBackground(t *testing.T) {
+	m := newStartupDigestModel(true, &stubNewsDigestGenerator{err: errors.New("quota")})
+	msg, ok := startupDigestMsg(t, m)
+	if !ok || msg.Err == nil {
+		t.Fatalf("expected a failed startup digest, got %+v", msg)
+	}
+
+	tm, _ := m.Update(msg)
+	m = tm.(*Model)
+	if m.state.Err != nil {
+		t.Fatalf("Err = %v, a background failure should only show in the AI status", m.state.Err)
+	}
+	if m.state.AIStatus == "" {
+		t.Fatal("AI status should report the failure")
+	}
+}
+
+func TestOpeningNewsWaitsForStartupDigest(t *testing.T) {
+	m := newStartupDigestModel(true, &stubNewsDigestGenerator{topics: []usecase.NewsDigestTopic{
+		{Title: "Topic", Summary: "Summary", ArticleGUIDs: []string{"a1"}},
+	}})
+	msg, _ := startupDigestMsg(t, m)
+	m.state.FeedList.Select(presenter.BuiltinTabIndex(m.state.BuiltinTabs, reading.NewsURL))
+	m.state.CurrentFeed = &reading.Feed{URL: reading.NewsURL}
+
+	tm, cmd := m.Update(update.FeedFetchedMsg{URL: reading.NewsURL, Feed: &reading.Feed{URL: reading.NewsURL}})
+	m = tm.(*Model)
+	if !m.state.Loading {
+		t.Fatal("News should show it is loading while the startup digest runs")
+	}
+	if _, ok := cmd().(update.NewsDigestGeneratedMsg); ok {
+		t.Fatal("News should wait for the startup digest instead of generating again")
+	}
+
+	tm, _ = m.Update(msg)
+	m = tm.(*Model)
+	if m.state.Loading || len(m.state.ArticleList.Items()) == 0 {
+		t.Fatalf("loading = %v, items = %d; want the startup digest listed", m.state.Loading, len(m.state.ArticleList.Items()))
+	}
+}
@@ -0,0 +1,146 @@
+package state
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// keyScope is the set of views a binding acts in.
+type keyScope uint8
+
+const (
+	feedScope keyScope = 1 << iota
+	articleScope
+	newsTopicScope
+	detailScope
+	manageScope
+
+	sectionScopes = feedScope | articleScope | newsTopicScope
+	allScopes     = sectionScopes | detailScope | manageScope
+)
+
+type scopedBinding struct {
+	action  string
+	binding key.Binding
+	scope   keyScope
+	// intent names the action intent.FromKeyMsg parses the binding into,
+	// empty when the binding is read elsewhere. FromKeyMsg returns the first
+	// match whatever the view, so two intents sharing a key clash even when
+	// they act in different views.
+	intent string
+}
+
+// scopedBindings lists every binding with the config name users change to
+// fix a conflict and the views the action is handled in.
+func (k KeyMap) scopedBindings() []scopedBinding {
+	return []scopedBinding{
+		{"up", k.Up, allScopes, ""},
+		{"down", k.Down, allScopes, ""},
+		{"left", k.Left, allScopes, "back"},
+		{"right", k.Right, allScopes, "open"},
+		{"up_page", k.UpPage, allScopes, ""},
+		{"down_page", k.DownPage, allScopes, ""},
+		{"half_page_up", k.HalfPageUp, detailScope, ""},
+		{"half_page_down", k.HalfPageDown, detailScope, ""},
+		{"top", k.Top, allScopes, ""},
+		{"bottom", k.Bottom, allScopes, ""},
+		{"open", k.Open, allScopes, "open"},
+		{"back", k.Back, allScopes, "back"},
+		{"quit", k.Quit, allScopes, "quit"},
+		{"help", k.Help, allScopes, "help"},
+		{"add_feed", k.AddFeed, feedScope, "add_feed"},
+		{"import_feeds", k.ImportFeeds, feedScope, "import_feeds"},
+		{"delete_feed", k.DeleteFeed, feedScope | manageScope, "delete_feed"},
+		{"mark_feed_read", k.MarkFeedRead, feedScope, "mark_feed_read"},
+		{"open_random", k.OpenRandom, feedScope | articleScope | detailScope, "open_random"},
+		{"group_feeds", k.GroupFeeds, feedScope, "group_feeds"},
+		{"undo", k.Undo, feedScope, "undo"},
+		{"clear_history", k.ClearHistory, feedScope, "clear_history"},
+		{"tag_stats", k.TagStats, feedScope, "tag_stats"},
+		{"manage_feeds", k.ManageFeeds, feedScope | manageScope, "manage_feeds"},
+		{"move_to_group", k.MoveToGroup, manageScope, "move_to_group"},
+		{"rename_group", k.RenameGroup, manageScope, "rename_group"},
+		{"section jump", k.GroupJump, sectionScopes, ""},
+		{"next section", k.GroupNext, sectionScopes, ""},
+		{"prev section", k.GroupPrev, sectionScopes, ""},
+		{"list filter", list.DefaultKeyMap().Filter, feedScope | articleScope, ""},
+		{"refresh", k.Refresh, articleScope | newsTopicScope, "refresh"},
+		{"refresh_all", k.RefreshAll, feedScope | articleScope, "refresh_all"},
+		{"refresh_group", k.RefreshGroup, feedScope | articleScope, "refresh_group"},
+		{"bookmark", k.Bookmark, articleScope, "bookmark"},
+		{"summarize", k.Summarize, feedScope | articleScope | detailScope, "summarize"},
+		{"summarize_missing", k.SummarizeMissing, articleScope, "summarize_missing"},
+		{"ask_ai", k.AskAI, detailScope, "ask_ai"},
+		{"nav_back", k.NavBack, detailScope, "nav_back"},
+		{"nav_forward", k.NavForward, detailScope, "nav_forward"},
+		{"toggle_summary", k.ToggleSummary, articleScope | detailScope, "toggle_summary"},
+		{"expand_row", k.ExpandRow, articleScope | newsTopicScope, "expand_row"},
+		{"toggle_titles", k.ToggleTitles, newsTopicScope, "toggle_titles"},
+		{"unread_feeds", k.UnreadFeeds, feedScope, "unread_feeds"},
+		{"fetch_full_text", k.FetchFullText, detailScope, "fetch_full_text"},
+		{"toggle_focus", k.ToggleFocus, detailScope, "toggle_focus"},
+		{"detail_search", k.DetailSearch, detailScope, ""},
+		{"detail_next", k.DetailNext, detailScope, ""},
+		{"detail_prev", k.DetailPrev, detailScope, ""},
+		{"goto_feed", k.GotoFeed, feedScope, "goto_feed"},
+		{"snooze", k.Snooze, articleScope, "snooze"},
+		{"dismiss", k.Dismiss, articleScope, "dismiss"},
+		{"toggle_select", k.ToggleSelect, articleScope, "toggle_select"},
+		{"export_selected", k.ExportSelected, articleScope, "export_selected"},
+	}
+}
+
+// KeyConflict is a key bound to actions that hide one another: only one of
+// them runs when the key is pressed.
+type KeyConflict struct {
+	Key     string
+	Actions []string
+}
+
+func (c KeyConflict) String() string {
+	return fmt.Sprintf("%s (%s)", c.Key, strings.Join(c.Actions, ", "))
+}
+
+// Conflicts reports keys shared by actions that clash, sorted by key.
+// Sharing a key is fine for actions that never act in the same view, like
+// undo in the feed list and half_page_up in the reading pane.
+func (k KeyMap) Conflicts() []KeyConflict {
+	byKey := make(map[string][]scopedBinding)
+	for _, b := range k.scopedBindings() {
+		for _, name := range b.binding.Keys() {
+			bound := byKey[name]
+			if !slices.ContainsFunc(bound, func(o scopedBinding) bool { return o.action == b.action }) {
+				byKey[name] = append(bound, b)
+			}
+		}
+	}
+
+	var conflicts []KeyConflict
+	for name, bound := range byKey {
+		var actions []string
+		for i, a := range bound {
+			for j, b := range bound {
+				if i != j && clash(a, b) {
+					actions = append(actions, a.action)
+					break
+				}
+			}
+		}
+		if len(actions) > 0 {
+			conflicts = append(conflicts, KeyConflict{Key: name, Actions: actions})
+		}
+	}
+	slices.SortFunc(conflicts, func(a, b KeyConflict) int { return strings.Compare(a.Key, b.Key) })
+	return conflicts
+}
+
+func clash(a, b scopedBinding) bool {
+	if a.intent != "" && a.intent == b.intent {
+		return false
+	}
+	return (a.intent != "" && b.intent != "") || a.scope&b.scope != 0
+}
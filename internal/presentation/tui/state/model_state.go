@@ -2,6 +2,10 @@
 package state
 
 import (
+	"context"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -9,36 +13,306 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/tesso57/reazy/internal/domain/reading"
 	"github.com/tesso57/reazy/internal/domain/subscription"
+	"github.com/tesso57/reazy/internal/presentation/tui/glyph"
+)
+
+// FeedGroupingSnapshot records the sidebar grouping before an AI regroup.
+type FeedGroupingSnapshot struct {
+	Groups    []subscription.FeedGroup
+	Ungrouped []string
+}
+
+// FeedFetchStatus records the outcome of the latest fetch of a single feed.
+type FeedFetchStatus struct {
+	FetchedAt time.Time
+	Err       string
+}
+
+// FeedProgressState is the fetch state of one feed in a bulk refresh.
+type FeedProgressState int
+
+const (
+	// FeedProgressPending means the feed has not reported back yet.
+	FeedProgressPending FeedProgressState = iota
+	// FeedProgressSucceeded means the feed was fetched.
+	FeedProgressSucceeded
+	// FeedProgressFailed means the feed failed or timed out.
+	FeedProgressFailed
+)
+
+// FetchProgress tracks per-feed completion of an in-flight bulk refresh.
+type FetchProgress struct {
+	Feeds  []string
+	States map[string]FeedProgressState
+	Errors map[string]string
+	// FollowUp names the step that runs after the fetch, if any.
+	FollowUp string
+	// Group names the feed group being refreshed; empty for a refresh of
+	// every feed.
+	Group string
+}
+
+// NewFetchProgress returns progress with every feed pending.
+func NewFetchProgress(feeds []string) *FetchProgress {
+	p := &FetchProgress{
+		Feeds:  make([]string, 0, len(feeds)),
+		States: make(map[string]FeedProgressState, len(feeds)),
+		Errors: make(map[string]string),
+	}
+	for _, feed := range feeds {
+		feed = strings.TrimSpace(feed)
+		if feed == "" {
+			continue
+		}
+		if _, ok := p.States[feed]; ok {
+			continue
+		}
+		p.Feeds = append(p.Feeds, feed)
+		p.States[feed] = FeedProgressPending
+	}
+	return p
+}
+
+// Done returns how many feeds have reported back.
+func (p *FetchProgress) Done() int {
+	done := 0
+	for _, st := range p.States {
+		if st != FeedProgressPending {
+			done++
+		}
+	}
+	return done
+}
+
+// SummaryBatch tracks a run that summarizes every listed article still
+// missing an AI summary, one article at a time.
+type SummaryBatch struct {
+	Queue  []string
+	Total  int
+	Done   int
+	Failed int
+	// Ctx scopes the batch's AI calls; Cancel stops the one in flight.
+	Ctx    context.Context
+	Cancel context.CancelFunc
+}
+
+// ClearPreview is what a confirmed history clear would delete.
+type ClearPreview struct {
+	Count    int
+	Examples []string
+}
+
+// TagCount is how many articles carry one AI tag.
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// DetailSearchMatch locates one search hit in the detail view content as a
+// line number and byte range within that line.
+type DetailSearchMatch struct {
+	Line  int
+	Start int
+	End   int
+}
+
+// FeedMove is a subscription whose feed permanently redirected elsewhere.
+type FeedMove struct {
+	From string
+	To   string
+}
+
+// GroupEditKind says what the group edit dialog changes.
+type GroupEditKind int
+
+const (
+	// MoveFeedToGroup moves one feed into the named group.
+	MoveFeedToGroup GroupEditKind = iota
+	// RenameFeedGroup renames the group of a feed.
+	RenameFeedGroup
+)
+
+// GroupEdit is a Manage Feeds action waiting for a group name.
+type GroupEdit struct {
+	Kind    GroupEditKind
+	FeedURL string
+	// Group is the current group of FeedURL, empty when it is ungrouped.
+	Group string
+}
+
+// FeedImportSummary records the outcome of the latest feed import so it can
+// be reviewed and undone.
+type FeedImportSummary struct {
+	Added      []string
+	Duplicates []string
+	Invalid    []string
+}
+
+// AddFeedStatus is the validation state of the URL in the add feed dialog.
+type AddFeedStatus int
+
+const (
+	// AddFeedIdle means the URL has not been submitted yet.
+	AddFeedIdle AddFeedStatus = iota
+	// AddFeedChecking means the URL is being fetched to check it is a feed.
+	AddFeedChecking
+	// AddFeedInvalid means the URL could not be read as a feed.
+	AddFeedInvalid
 )
 
 // ModelState holds the presentation state for the TUI.
 type ModelState struct {
-	Session                Session
-	FeedList               list.Model
-	ArticleList            list.Model
-	TextInput              textinput.Model
-	Viewport               viewport.Model
-	Help                   help.Model
-	Spinner                spinner.Model
-	Loading                bool
-	Keys                   KeyMap
-	Width                  int
-	Height                 int
-	CurrentFeed            *reading.Feed
-	Err                    error
-	AIStatus               string
-	StatusMessage          string
-	ShowAISummary          bool
-	Previous               Session
-	DetailParentSession    Session
-	History                *reading.History
-	Feeds                  []string
-	FeedGroups             []subscription.FeedGroup
-	PendingInsightGUID     string
-	PendingJJExit          bool
+	Session         Session
+	FeedList        list.Model
+	ArticleList     list.Model
+	TextInput       textinput.Model
+	AskInput        textinput.Model
+	Viewport        viewport.Model
+	Help            help.Model
+	Spinner         spinner.Model
+	Loading         bool
+	Keys            KeyMap
+	Width           int
+	Height          int
+	CurrentFeed     *reading.Feed
+	Err             error
+	AIStatus        string
+	StatusMessage   string
+	ShowAISummary   bool
+	ReadingWidth    int
+	MinReadingWidth int
+	// FocusMode shows the article alone in the detail view; it lasts until
+	// toggled off or the app quits.
+	FocusMode               bool
+	PageSize                int
+	WrapListNavigation      bool
+	FilterExitEsc           bool
+	MarkReadViews           map[string]bool
+	FullTextFeeds           map[string]bool
+	FeedTitles              map[string]string
+	SectionHeaderFormat     string
+	FeedTagMaxChars         int
+	MaxArticleAge           time.Duration
+	CollapseDuplicateTitles bool
+	// BookmarksIgnoreReadDim keeps read articles undimmed in the Bookmarks
+	// view.
+	BookmarksIgnoreReadDim bool
+	// ExpandedGUID is the article row showing its description below the
+	// title, in at most ExpandRowLines lines; empty when none is expanded.
+	ExpandedGUID   string
+	ExpandRowLines int
+	// Selected holds the GUIDs of articles picked for export, in the order
+	// they were picked. The selection survives switching feeds.
+	Selected []string
+	// ExpandedTitles holds the normalized titles of collapsed All Feeds rows
+	// opened to list each source.
+	ExpandedTitles           map[string]bool
+	FeedPreview              bool
+	Glyphs                   glyph.Set
+	UnreadFeedsOnly          bool
+	GroupSort                string
+	OpenInBrowser            bool
+	ContentSanitizer         *reading.ContentSanitizer
+	ResolveRelativeLinks     bool
+	AIFallback               bool
+	AutoSummarizeOnOpen      bool
+	PreserveManualGroups     bool
+	MinGroupingFeeds         int
+	HeuristicGrouping        bool
+	FollowPermanentRedirects bool
+	ValidateNewFeeds         bool
+	ShutdownTimeout          time.Duration
+	LoadingTimeout           time.Duration
+	LoadingSince             time.Time
+	LoadingTask              string
+	LoadingGeneration        int
+	LoadingTimedGeneration   int
+	AddFeedStatus            AddFeedStatus
+	AddFeedURL               string
+	AddFeedError             string
+	Previous                 Session
+	DetailParentSession      Session
+	History                  *reading.History
+	// FeedLatestDates holds the newest article date per feed, refreshed
+	// whenever History changes so rendering doesn't scan it.
+	FeedLatestDates map[string]time.Time
+	// FeedPreviews holds the newest unread title per feed when FeedPreview
+	// is on, refreshed along with FeedLatestDates and after marking read.
+	FeedPreviews     map[string]string
+	Feeds            []string
+	FeedGroups       []subscription.FeedGroup
+	BuiltinTabs      []string
+	FeedGroupingUndo *FeedGroupingSnapshot
+	FeedFetchStatus  map[string]FeedFetchStatus
+	PendingFeedMoves []FeedMove
+	GroupEdit        *GroupEdit
+	FeedImport       *FeedImportSummary
+	// OPMLDownloadURL is the OPML list being downloaded for import; only
+	// the latest download is imported.
+	OPMLDownloadURL    string
+	FetchProgress      *FetchProgress
+	PendingInsightGUID string
+	SummaryBatch       *SummaryBatch
+	LastOpenedGUID     string
+	// NavHistory lists articles opened this session, oldest first, and
+	// NavIndex points at the one shown, for back/forward navigation.
+	NavHistory                []string
+	NavIndex                  int
+	PendingJJExit             bool
+	ClearHistoryConfirmed     bool
+	ClearHistoryKeepBookmarks bool
+	// ClearHistoryPreview is shown on the final confirmation; nil when the
+	// history store cannot count what would go.
+	ClearHistoryPreview *ClearPreview
+	// TagStats lists the most common AI tags while the tag stats view is
+	// open, most common first.
+	TagStats               []TagCount
 	ForceNewsDigestRefresh bool
+	// ForceFeedRegroup makes the next feed grouping skip the cache; it is
+	// set when the last grouping reused the cached result.
+	ForceFeedRegroup bool
+	// RefreshAllPending is set while a refresh-all waits for its feeds
+	// before building the News digest.
+	RefreshAllPending bool
+	// RefreshGroupPending names the feed group whose refresh is running.
+	RefreshGroupPending string
+	// StartupDigestPending is set while the digest started at launch runs;
+	// opening News waits for it instead of generating again.
+	StartupDigestPending bool
+	// NewsShowsToday makes the News tab list today's articles because no AI
+	// digest can be generated.
+	NewsShowsToday bool
+	// RememberNewsSelection reselects NewsSelectionGUID when News lists the
+	// digest of NewsSelectionDate as its latest day; the pair is saved to the
+	// config each time a topic is opened.
+	RememberNewsSelection  bool
+	NewsSelectionDate      string
+	NewsSelectionGUID      string
 	NewsTopicDigestGUID    string
 	NewsTopicTitle         string
 	NewsTopicSummary       string
 	NewsTopicTags          []string
+	NewsTopicRelatedGUIDs  []string
+	NewsTopicRelatedTitles map[string]string
+	ShowTranslatedTitles   bool
+	DetailContent          string
+	// DetailGUID is the article whose body the reading pane shows; its scroll
+	// position is saved when the pane moves on.
+	DetailGUID          string
+	DetailSearching     bool
+	DetailSearchQuery   string
+	DetailSearchMatches []DetailSearchMatch
+	DetailSearchIndex   int
+	GotoFeeding         bool
+	GotoFeedInput       string
+	SnoozeGUID          string
+	// MarkReadFeedURL and MarkReadFeedCount describe the feed waiting for
+	// confirmation before all its articles are marked read.
+	MarkReadFeedURL   string
+	MarkReadFeedCount int
+	// AskGUID is the article a question is being asked about; AskQuestion
+	// and AskAnswer hold the answer shown in place of the article body.
+	AskGUID     string
+	AskQuestion string
+	AskAnswer   string
 }
@@ -19,32 +19,72 @@ const (
 	AddingFeedView
 	DeleteFeedView
 	QuitView
+	ClearHistoryView
+	ManageFeedsView
+	GroupEditView
+	MoveFeedView
+	ImportFeedsView
+	ImportSummaryView
+	SnoozeView
+	AskAIView
+	MarkFeedReadView
+	TagStatsView
 )
 
 // KeyMap defines the keybindings for the application.
 type KeyMap struct {
-	Up            key.Binding
-	Down          key.Binding
-	Left          key.Binding
-	Right         key.Binding
-	UpPage        key.Binding
-	DownPage      key.Binding
-	Top           key.Binding
-	Bottom        key.Binding
-	Open          key.Binding
-	Back          key.Binding
-	Quit          key.Binding
-	AddFeed       key.Binding
-	DeleteFeed    key.Binding
-	GroupFeeds    key.Binding
-	GroupJump     key.Binding
-	GroupNext     key.Binding
-	GroupPrev     key.Binding
-	Refresh       key.Binding
-	Bookmark      key.Binding
-	Summarize     key.Binding
-	ToggleSummary key.Binding
-	Help          key.Binding
+	Up               key.Binding
+	Down             key.Binding
+	Left             key.Binding
+	Right            key.Binding
+	UpPage           key.Binding
+	DownPage         key.Binding
+	HalfPageUp       key.Binding
+	HalfPageDown     key.Binding
+	Top              key.Binding
+	Bottom           key.Binding
+	Open             key.Binding
+	Back             key.Binding
+	Quit             key.Binding
+	AddFeed          key.Binding
+	ImportFeeds      key.Binding
+	DeleteFeed       key.Binding
+	MarkFeedRead     key.Binding
+	OpenRandom       key.Binding
+	GroupFeeds       key.Binding
+	Undo             key.Binding
+	ClearHistory     key.Binding
+	ManageFeeds      key.Binding
+	MoveToGroup      key.Binding
+	RenameGroup      key.Binding
+	GroupJump        key.Binding
+	GroupNext        key.Binding
+	GroupPrev        key.Binding
+	Refresh          key.Binding
+	RefreshAll       key.Binding
+	RefreshGroup     key.Binding
+	Bookmark         key.Binding
+	Summarize        key.Binding
+	SummarizeMissing key.Binding
+	AskAI            key.Binding
+	NavBack          key.Binding
+	NavForward       key.Binding
+	ToggleSummary    key.Binding
+	ExpandRow        key.Binding
+	ToggleTitles     key.Binding
+	UnreadFeeds      key.Binding
+	FetchFullText    key.Binding
+	ToggleFocus      key.Binding
+	DetailSearch     key.Binding
+	DetailNext       key.Binding
+	DetailPrev       key.Binding
+	GotoFeed         key.Binding
+	Snooze           key.Binding
+	Dismiss          key.Binding
+	ToggleSelect     key.Binding
+	ExportSelected   key.Binding
+	TagStats         key.Binding
+	Help             key.Binding
 }
 
 // ShortHelp returns a subset of keybindings for the help view.
@@ -56,72 +96,107 @@ func (k *KeyMap) ShortHelp() []key.Binding {
 func (k *KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
-		{k.Top, k.Bottom, k.UpPage, k.DownPage},
-		{k.Open, k.Back, k.Quit},
-		{k.AddFeed, k.DeleteFeed, k.GroupFeeds, k.Refresh},
-		{k.GroupJump, k.GroupNext, k.GroupPrev},
-		{k.Bookmark, k.Summarize, k.ToggleSummary, k.Help},
+		{k.Top, k.Bottom, k.UpPage, k.DownPage, k.HalfPageUp, k.HalfPageDown},
+		{k.Open, k.Back, k.Quit, k.ClearHistory},
+		{k.AddFeed, k.ImportFeeds, k.DeleteFeed, k.MarkFeedRead, k.ManageFeeds, k.MoveToGroup, k.RenameGroup, k.GroupFeeds, k.Undo, k.Refresh, k.RefreshAll, k.RefreshGroup, k.UnreadFeeds, k.TagStats},
+		{k.GroupJump, k.GroupNext, k.GroupPrev, k.GotoFeed, k.NavBack, k.NavForward, k.OpenRandom},
+		{k.Bookmark, k.Snooze, k.Dismiss, k.ToggleSelect, k.ExportSelected, k.Summarize, k.SummarizeMissing, k.AskAI, k.ToggleSummary, k.ExpandRow, k.ToggleTitles, k.FetchFullText, k.ToggleFocus, k.DetailSearch, k.DetailNext, k.DetailPrev, k.Help},
 	}
 }
 
 // NewKeyMap creates a new KeyMap from the configuration.
+// Keys left empty in the configuration fall back to their documented defaults
+// so a minimal config.yaml still produces a fully navigable UI.
 func NewKeyMap(cfg settings.KeyMapConfig) KeyMap {
+	defaults := settings.DefaultKeyMapConfig()
 	return KeyMap{
 		Up: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.Up)...),
-			key.WithHelp(cfg.Up, "up"),
+			key.WithKeys(splitKeys(defaultKey(cfg.Up, defaults.Up))...),
+			key.WithHelp(defaultKey(cfg.Up, defaults.Up), "up"),
 		),
 		Down: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.Down)...),
-			key.WithHelp(cfg.Down, "down"),
+			key.WithKeys(splitKeys(defaultKey(cfg.Down, defaults.Down))...),
+			key.WithHelp(defaultKey(cfg.Down, defaults.Down), "down"),
 		),
 		Left: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.Left)...),
-			key.WithHelp(cfg.Left, "back/feeds"),
+			key.WithKeys(splitKeys(defaultKey(cfg.Left, defaults.Left))...),
+			key.WithHelp(defaultKey(cfg.Left, defaults.Left), "back/feeds"),
 		),
 		Right: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.Right)...),
-			key.WithHelp(cfg.Right, "details"),
+			key.WithKeys(splitKeys(defaultKey(cfg.Right, defaults.Right))...),
+			key.WithHelp(defaultKey(cfg.Right, defaults.Right), "details"),
 		),
 		UpPage: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.UpPage)...),
-			key.WithHelp(cfg.UpPage, "pgup"),
+			key.WithKeys(splitKeys(defaultKey(cfg.UpPage, defaults.UpPage))...),
+			key.WithHelp(defaultKey(cfg.UpPage, defaults.UpPage), "pgup"),
 		),
 		DownPage: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.DownPage)...),
-			key.WithHelp(cfg.DownPage, "pgdn"),
+			key.WithKeys(splitKeys(defaultKey(cfg.DownPage, defaults.DownPage))...),
+			key.WithHelp(defaultKey(cfg.DownPage, defaults.DownPage), "pgdn"),
+		),
+		HalfPageUp: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.HalfPageUp, defaults.HalfPageUp))...),
+			key.WithHelp(defaultKey(cfg.HalfPageUp, defaults.HalfPageUp), "half pgup"),
+		),
+		HalfPageDown: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.HalfPageDown, defaults.HalfPageDown))...),
+			key.WithHelp(defaultKey(cfg.HalfPageDown, defaults.HalfPageDown), "half pgdn"),
 		),
 		Top: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.Top)...),
-			key.WithHelp(cfg.Top, "top"),
+			key.WithKeys(splitKeys(defaultKey(cfg.Top, defaults.Top))...),
+			key.WithHelp(defaultKey(cfg.Top, defaults.Top), "top"),
 		),
 		Bottom: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.Bottom)...),
-			key.WithHelp(cfg.Bottom, "bottom"),
+			key.WithKeys(splitKeys(defaultKey(cfg.Bottom, defaults.Bottom))...),
+			key.WithHelp(defaultKey(cfg.Bottom, defaults.Bottom), "bottom"),
 		),
 		Open: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.Open)...),
-			key.WithHelp(cfg.Open, "open"),
+			key.WithKeys(splitKeys(defaultKey(cfg.Open, defaults.Open))...),
+			key.WithHelp(defaultKey(cfg.Open, defaults.Open), "open"),
 		),
 		Back: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.Back)...),
-			key.WithHelp(cfg.Back, "back"),
+			key.WithKeys(splitKeys(defaultKey(cfg.Back, defaults.Back))...),
+			key.WithHelp(defaultKey(cfg.Back, defaults.Back), "back"),
 		),
 		Quit: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.Quit)...),
-			key.WithHelp(cfg.Quit, "quit"),
+			key.WithKeys(splitKeys(defaultKey(cfg.Quit, defaults.Quit))...),
+			key.WithHelp(defaultKey(cfg.Quit, defaults.Quit), "quit"),
 		),
 		AddFeed: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.AddFeed)...),
-			key.WithHelp(cfg.AddFeed, "add"),
+			key.WithKeys(splitKeys(defaultKey(cfg.AddFeed, defaults.AddFeed))...),
+			key.WithHelp(defaultKey(cfg.AddFeed, defaults.AddFeed), "add"),
+		),
+		ImportFeeds: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.ImportFeeds, defaults.ImportFeeds))...),
+			key.WithHelp(defaultKey(cfg.ImportFeeds, defaults.ImportFeeds), "import opml"),
 		),
 		DeleteFeed: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.DeleteFeed)...),
-			key.WithHelp(cfg.DeleteFeed, "delete"),
+			key.WithKeys(splitKeys(defaultKey(cfg.DeleteFeed, defaults.DeleteFeed))...),
+			key.WithHelp(defaultKey(cfg.DeleteFeed, defaults.DeleteFeed), "delete"),
 		),
 		GroupFeeds: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.GroupFeeds)...),
-			key.WithHelp(cfg.GroupFeeds, "ai group feeds"),
+			key.WithKeys(splitKeys(defaultKey(cfg.GroupFeeds, defaults.GroupFeeds))...),
+			key.WithHelp(defaultKey(cfg.GroupFeeds, defaults.GroupFeeds), "ai group feeds"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.Undo, defaults.Undo))...),
+			key.WithHelp(defaultKey(cfg.Undo, defaults.Undo), "undo grouping"),
+		),
+		ClearHistory: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.ClearHistory, defaults.ClearHistory))...),
+			key.WithHelp(defaultKey(cfg.ClearHistory, defaults.ClearHistory), "clear history"),
+		),
+		ManageFeeds: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.ManageFeeds, defaults.ManageFeeds))...),
+			key.WithHelp(defaultKey(cfg.ManageFeeds, defaults.ManageFeeds), "manage feeds"),
+		),
+		MoveToGroup: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.MoveToGroup, defaults.MoveToGroup))...),
+			key.WithHelp(defaultKey(cfg.MoveToGroup, defaults.MoveToGroup), "move to group"),
+		),
+		RenameGroup: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.RenameGroup, defaults.RenameGroup))...),
+			key.WithHelp(defaultKey(cfg.RenameGroup, defaults.RenameGroup), "rename group"),
 		),
 		GroupJump: key.NewBinding(
 			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9", "0"),
@@ -136,20 +211,108 @@ func NewKeyMap(cfg settings.KeyMapConfig) KeyMap {
 			key.WithHelp("K", "prev section"),
 		),
 		Refresh: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.Refresh)...),
-			key.WithHelp(cfg.Refresh, "refresh"),
+			key.WithKeys(splitKeys(defaultKey(cfg.Refresh, defaults.Refresh))...),
+			key.WithHelp(defaultKey(cfg.Refresh, defaults.Refresh), "refresh"),
+		),
+		RefreshAll: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.RefreshAll, defaults.RefreshAll))...),
+			key.WithHelp(defaultKey(cfg.RefreshAll, defaults.RefreshAll), "refresh all + news"),
+		),
+		RefreshGroup: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.RefreshGroup, defaults.RefreshGroup))...),
+			key.WithHelp(defaultKey(cfg.RefreshGroup, defaults.RefreshGroup), "refresh group"),
 		),
 		Bookmark: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.Bookmark)...),
-			key.WithHelp(cfg.Bookmark, "bookmark"),
+			key.WithKeys(splitKeys(defaultKey(cfg.Bookmark, defaults.Bookmark))...),
+			key.WithHelp(defaultKey(cfg.Bookmark, defaults.Bookmark), "bookmark"),
 		),
 		Summarize: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.Summarize)...),
-			key.WithHelp(cfg.Summarize, "ai summary"),
+			key.WithKeys(splitKeys(defaultKey(cfg.Summarize, defaults.Summarize))...),
+			key.WithHelp(defaultKey(cfg.Summarize, defaults.Summarize), "ai summary"),
 		),
 		ToggleSummary: key.NewBinding(
-			key.WithKeys(splitKeys(cfg.ToggleSummary)...),
-			key.WithHelp(cfg.ToggleSummary, "toggle summary"),
+			key.WithKeys(splitKeys(defaultKey(cfg.ToggleSummary, defaults.ToggleSummary))...),
+			key.WithHelp(defaultKey(cfg.ToggleSummary, defaults.ToggleSummary), "toggle summary"),
+		),
+		ExpandRow: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.ExpandRow, defaults.ExpandRow))...),
+			key.WithHelp(defaultKey(cfg.ExpandRow, defaults.ExpandRow), "expand row"),
+		),
+		ToggleTitles: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.ToggleTitles, defaults.ToggleTitles))...),
+			key.WithHelp(defaultKey(cfg.ToggleTitles, defaults.ToggleTitles), "toggle titles"),
+		),
+		UnreadFeeds: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.UnreadFeeds, defaults.UnreadFeeds))...),
+			key.WithHelp(defaultKey(cfg.UnreadFeeds, defaults.UnreadFeeds), "unread feeds only"),
+		),
+		FetchFullText: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.FetchFullText, defaults.FetchFullText))...),
+			key.WithHelp(defaultKey(cfg.FetchFullText, defaults.FetchFullText), "reader mode"),
+		),
+		ToggleFocus: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.ToggleFocus, defaults.ToggleFocus))...),
+			key.WithHelp(defaultKey(cfg.ToggleFocus, defaults.ToggleFocus), "focus mode"),
+		),
+		DetailSearch: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.DetailSearch, defaults.DetailSearch))...),
+			key.WithHelp(defaultKey(cfg.DetailSearch, defaults.DetailSearch), "search article"),
+		),
+		DetailNext: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.DetailNext, defaults.DetailNext))...),
+			key.WithHelp(defaultKey(cfg.DetailNext, defaults.DetailNext), "next match"),
+		),
+		DetailPrev: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.DetailPrev, defaults.DetailPrev))...),
+			key.WithHelp(defaultKey(cfg.DetailPrev, defaults.DetailPrev), "prev match"),
+		),
+		GotoFeed: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.GotoFeed, defaults.GotoFeed))...),
+			key.WithHelp(defaultKey(cfg.GotoFeed, defaults.GotoFeed), "go to feed #"),
+		),
+		MarkFeedRead: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.MarkFeedRead, defaults.MarkFeedRead))...),
+			key.WithHelp(defaultKey(cfg.MarkFeedRead, defaults.MarkFeedRead), "mark feed read"),
+		),
+		OpenRandom: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.OpenRandom, defaults.OpenRandom))...),
+			key.WithHelp(defaultKey(cfg.OpenRandom, defaults.OpenRandom), "random unread"),
+		),
+		Snooze: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.Snooze, defaults.Snooze))...),
+			key.WithHelp(defaultKey(cfg.Snooze, defaults.Snooze), "snooze"),
+		),
+		Dismiss: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.Dismiss, defaults.Dismiss))...),
+			key.WithHelp(defaultKey(cfg.Dismiss, defaults.Dismiss), "dismiss/restore"),
+		),
+		ToggleSelect: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.ToggleSelect, defaults.ToggleSelect))...),
+			key.WithHelp(defaultKey(cfg.ToggleSelect, defaults.ToggleSelect), "select"),
+		),
+		ExportSelected: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.ExportSelected, defaults.ExportSelected))...),
+			key.WithHelp(defaultKey(cfg.ExportSelected, defaults.ExportSelected), "export markdown"),
+		),
+		TagStats: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.TagStats, defaults.TagStats))...),
+			key.WithHelp(defaultKey(cfg.TagStats, defaults.TagStats), "tag stats"),
+		),
+		SummarizeMissing: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.SummarizeMissing, defaults.SummarizeMissing))...),
+			key.WithHelp(defaultKey(cfg.SummarizeMissing, defaults.SummarizeMissing), "summarize missing"),
+		),
+		AskAI: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.AskAI, defaults.AskAI))...),
+			key.WithHelp(defaultKey(cfg.AskAI, defaults.AskAI), "ask AI"),
+		),
+		NavBack: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.NavBack, defaults.NavBack))...),
+			key.WithHelp(defaultKey(cfg.NavBack, defaults.NavBack), "prev opened"),
+		),
+		NavForward: key.NewBinding(
+			key.WithKeys(splitKeys(defaultKey(cfg.NavForward, defaults.NavForward))...),
+			key.WithHelp(defaultKey(cfg.NavForward, defaults.NavForward), "next opened"),
 		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
@@ -158,6 +321,14 @@ func NewKeyMap(cfg settings.KeyMapConfig) KeyMap {
 	}
 }
 
+// defaultKey returns the configured key list, or fallback when it is blank.
+func defaultKey(configured, fallback string) string {
+	if strings.TrimSpace(configured) == "" {
+		return fallback
+	}
+	return configured
+}
+
 func splitKeys(keys string) []string {
 	parts := strings.Split(keys, ",")
 	out := make([]string, 0, len(parts))
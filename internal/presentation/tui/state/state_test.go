@@ -0,0 +1,168 @@
+package state
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+)
+
+func TestNewKeyMap_ActionDefaults(t *testing.T) {
+	keys := NewKeyMap(settings.KeyMapConfig{Up: "k", Down: "j", Bookmark: "B"})
+
+	press := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+	tests := []struct {
+		name    string
+		binding key.Binding
+		msg     tea.KeyMsg
+	}{
+		{name: "configured bookmark", binding: keys.Bookmark, msg: press('B')},
+		{name: "default summarize", binding: keys.Summarize, msg: press('s')},
+		{name: "default toggle summary", binding: keys.ToggleSummary, msg: press('S')},
+		{name: "default group feeds", binding: keys.GroupFeeds, msg: press('z')},
+		{name: "default refresh", binding: keys.Refresh, msg: press('r')},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !key.Matches(tt.msg, tt.binding) {
+				t.Fatalf("%s should match %q (keys %v)", tt.name, tt.msg.String(), tt.binding.Keys())
+			}
+			if tt.binding.Help().Key == "" {
+				t.Fatalf("%s should have help text", tt.name)
+			}
+		})
+	}
+	if key.Matches(press('b'), keys.Bookmark) {
+		t.Fatal("configured bookmark key should replace the default")
+	}
+}
+
+func TestNewKeyMap_EmptyConfigUsesDefaults(t *testing.T) {
+	keys := NewKeyMap(settings.KeyMapConfig{})
+	defaults := settings.DefaultKeyMapConfig()
+
+	tests := []struct {
+		name    string
+		binding key.Binding
+		want    string
+	}{
+		{name: "up", binding: keys.Up, want: defaults.Up},
+		{name: "down", binding: keys.Down, want: defaults.Down},
+		{name: "left", binding: keys.Left, want: defaults.Left},
+		{name: "right", binding: keys.Right, want: defaults.Right},
+		{name: "up page", binding: keys.UpPage, want: defaults.UpPage},
+		{name: "down page", binding: keys.DownPage, want: defaults.DownPage},
+		{name: "top", binding: keys.Top, want: defaults.Top},
+		{name: "bottom", binding: keys.Bottom, want: defaults.Bottom},
+		{name: "open", binding: keys.Open, want: defaults.Open},
+		{name: "back", binding: keys.Back, want: defaults.Back},
+		{name: "quit", binding: keys.Quit, want: defaults.Quit},
+		{name: "add feed", binding: keys.AddFeed, want: defaults.AddFeed},
+		{name: "delete feed", binding: keys.DeleteFeed, want: defaults.DeleteFeed},
+		{name: "group feeds", binding: keys.GroupFeeds, want: defaults.GroupFeeds},
+		{name: "undo", binding: keys.Undo, want: defaults.Undo},
+		{name: "clear history", binding: keys.ClearHistory, want: defaults.ClearHistory},
+		{name: "manage feeds", binding: keys.ManageFeeds, want: defaults.ManageFeeds},
+		{name: "move to group", binding: keys.MoveToGroup, want: defaults.MoveToGroup},
+		{name: "rename group", binding: keys.RenameGroup, want: defaults.RenameGroup},
+		{name: "refresh", binding: keys.Refresh, want: defaults.Refresh},
+		{name: "bookmark", binding: keys.Bookmark, want: defaults.Bookmark},
+		{name: "summarize", binding: keys.Summarize, want: defaults.Summarize},
+		{name: "toggle summary", binding: keys.ToggleSummary, want: defaults.ToggleSummary},
+		{name: "expand row", binding: keys.ExpandRow, want: defaults.ExpandRow},
+		{name: "unread feeds", binding: keys.UnreadFeeds, want: defaults.UnreadFeeds},
+		{name: "import feeds", binding: keys.ImportFeeds, want: defaults.ImportFeeds},
+		{name: "fetch full text", binding: keys.FetchFullText, want: defaults.FetchFullText},
+		{name: "toggle focus", binding: keys.ToggleFocus, want: defaults.ToggleFocus},
+		{name: "goto feed", binding: keys.GotoFeed, want: defaults.GotoFeed},
+		{name: "snooze", binding: keys.Snooze, want: defaults.Snooze},
+		{name: "dismiss", binding: keys.Dismiss, want: defaults.Dismiss},
+		{name: "toggle select", binding: keys.ToggleSelect, want: defaults.ToggleSelect},
+		{name: "export selected", binding: keys.ExportSelected, want: defaults.ExportSelected},
+		{name: "tag stats", binding: keys.TagStats, want: defaults.TagStats},
+		{name: "refresh all", binding: keys.RefreshAll, want: defaults.RefreshAll},
+		{name: "refresh group", binding: keys.RefreshGroup, want: defaults.RefreshGroup},
+		{name: "summarize missing", binding: keys.SummarizeMissing, want: defaults.SummarizeMissing},
+		{name: "ask ai", binding: keys.AskAI, want: defaults.AskAI},
+		{name: "nav back", binding: keys.NavBack, want: defaults.NavBack},
+		{name: "nav forward", binding: keys.NavForward, want: defaults.NavForward},
+		{name: "mark feed read", binding: keys.MarkFeedRead, want: defaults.MarkFeedRead},
+		{name: "open random", binding: keys.OpenRandom, want: defaults.OpenRandom},
+		{name: "half page up", binding: keys.HalfPageUp, want: defaults.HalfPageUp},
+		{name: "half page down", binding: keys.HalfPageDown, want: defaults.HalfPageDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.binding.Keys()
+			if len(got) == 0 || got[0] != tt.want {
+				t.Fatalf("keys = %v, want %q first", got, tt.want)
+			}
+			for _, k := range got {
+				if k == "" {
+					t.Fatalf("binding contains an empty key: %v", got)
+				}
+			}
+			if tt.binding.Help().Key != tt.want {
+				t.Fatalf("help key = %q, want %q", tt.binding.Help().Key, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyMap_Conflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  settings.KeyMapConfig
+		want []KeyConflict
+	}{
+		{name: "defaults", cfg: settings.DefaultKeyMapConfig()},
+		{
+			name: "intents sharing a key",
+			cfg:  settings.KeyMapConfig{Bookmark: "s"},
+			want: []KeyConflict{{Key: "s", Actions: []string{"bookmark", "summarize"}}},
+		},
+		{
+			name: "intents in different views",
+			cfg:  settings.KeyMapConfig{AskAI: "a"},
+			want: []KeyConflict{{Key: "a", Actions: []string{"add_feed", "ask_ai"}}},
+		},
+		{
+			name: "navigation shadowing an intent",
+			cfg:  settings.KeyMapConfig{Top: "b"},
+			want: []KeyConflict{{Key: "b", Actions: []string{"top", "bookmark"}}},
+		},
+		{
+			name: "navigation in a different view",
+			cfg:  settings.KeyMapConfig{HalfPageDown: "x"},
+		},
+		{
+			name: "detail action on the search next key",
+			cfg:  settings.KeyMapConfig{ToggleFocus: "n"},
+			want: []KeyConflict{{Key: "n", Actions: []string{"rename_group", "toggle_focus", "detail_next"}}},
+		},
+		{
+			name: "detail action on the search key",
+			cfg:  settings.KeyMapConfig{AskAI: "/"},
+			want: []KeyConflict{{Key: "/", Actions: []string{"ask_ai", "detail_search"}}},
+		},
+		{
+			name: "list filter shadowing an intent",
+			cfg:  settings.KeyMapConfig{Bookmark: "/"},
+			want: []KeyConflict{{Key: "/", Actions: []string{"list filter", "bookmark"}}},
+		},
+		{
+			name: "back bound to left",
+			cfg:  settings.KeyMapConfig{Back: "h"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewKeyMap(tt.cfg).Conflicts()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Conflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
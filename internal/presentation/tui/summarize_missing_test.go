@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/update"
+)
+
+// nextInsightMsg runs cmd, descending into batches, and returns the insight
+// result it produces.
+func nextInsightMsg(t *testing.T, cmd tea.Cmd) update.InsightGeneratedMsg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	switch msg := cmd().(type) {
+	case update.InsightGeneratedMsg:
+		return msg
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if c == nil {
+				continue
+			}
+			if inner, ok := c().(update.InsightGeneratedMsg); ok {
+				return inner
+			}
+		}
+	}
+	t.Fatal("command did not generate an insight")
+	return update.InsightGeneratedMsg{}
+}
+
+func TestSummarizeMissingSummarizesListedArticlesWithoutSummary(t *testing.T) {
+	now := time.Now()
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/a"},
+		KeyMap: settings.KeyMapConfig{SummarizeMissing: "A"},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", Title: "Summarized", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now, AISummary: "Existing"},
+		"a2": {GUID: "a2", Title: "Second", Content: "Body two", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now.Add(-time.Hour)},
+		"a3": {GUID: "a3", Title: "Third", Content: "Body three", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now.Add(-2 * time.Hour)},
+	}}
+	m := newTestModelWithInsightGenerator(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{}, &stubInsightGenerator{
+		insight: usecase.Insight{Summary: "Generated", Tags: []string{"go"}},
+	})
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: "http://example.com/a"}
+	presenter.ApplyArticleList(&m.state.ArticleList, m.state.History, "http://example.com/a", presenter.ArticleListOptions{})
+
+	m, cmd := typeKeys(m, "A")
+	if m.state.AIStatus != "AI: summarizing 1/2..." {
+		t.Fatalf("AIStatus = %q, want progress for the first article", m.state.AIStatus)
+	}
+	msg := nextInsightMsg(t, cmd)
+	if msg.GUID != "a2" || msg.Hydrated == nil {
+		t.Fatalf("first result = %+v, want hydrated a2", msg)
+	}
+
+	tm, cmd := m.Update(msg)
+	m = tm.(*Model)
+	if m.state.AIStatus != "AI: summarizing 2/2..." {
+		t.Fatalf("AIStatus = %q, want progress for the second article", m.state.AIStatus)
+	}
+	tm, _ = m.Update(nextInsightMsg(t, cmd))
+	m = tm.(*Model)
+
+	if m.state.AIStatus != "AI: summarized 2/2 articles" || m.state.SummaryBatch != nil || m.state.Loading {
+		t.Fatalf("AIStatus = %q, batch = %+v, loading = %v; want a finished batch", m.state.AIStatus, m.state.SummaryBatch, m.state.Loading)
+	}
+	for _, guid := range []string{"a2", "a3"} {
+		if item, _ := m.state.History.Item(guid); item.AISummary != "Generated" {
+			t.Fatalf("%s summary = %q, want generated summary", guid, item.AISummary)
+		}
+	}
+	if item, _ := m.state.History.Item("a1"); item.AISummary != "Existing" {
+		t.Fatalf("a1 summary = %q, existing summary should be kept", item.AISummary)
+	}
+}
+
+func TestSummarizeMissingCancelsWithBack(t *testing.T) {
+	now := time.Now()
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/a"},
+		KeyMap: settings.KeyMapConfig{SummarizeMissing: "A", Back: "esc"},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", Title: "First", Content: "Body one", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now},
+		"a2": {GUID: "a2", Title: "Second", Content: "Body two", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: now.Add(-time.Hour)},
+	}}
+	m := newTestModelWithInsightGenerator(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{}, &stubInsightGenerator{
+		insight: usecase.Insight{Summary: "Generated"},
+	})
+	m.state.Session = state.ArticleView
+	m.state.CurrentFeed = &reading.Feed{URL: "http://example.com/a"}
+	presenter.ApplyArticleList(&m.state.ArticleList, m.state.History, "http://example.com/a", presenter.ArticleListOptions{})
+
+	m, cmd := typeKeys(m, "A")
+	if m.state.SummaryBatch == nil {
+		t.Fatal("expected a running batch")
+	}
+	ctx := m.state.SummaryBatch.Ctx
+
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = tm.(*Model)
+	if ctx.Err() == nil {
+		t.Fatal("esc should cancel the batch context")
+	}
+	if m.state.SummaryBatch != nil || m.state.Loading || m.state.Session != state.ArticleView {
+		t.Fatalf("batch = %+v, loading = %v, session = %v; want a stopped batch in the article list", m.state.SummaryBatch, m.state.Loading, m.state.Session)
+	}
+	if m.state.AIStatus != "AI: summary batch cancelled after 0/2 articles" {
+		t.Fatalf("AIStatus = %q", m.state.AIStatus)
+	}
+
+	// The article in flight finishing late must not restart the queue.
+	tm, cmd = m.Update(nextInsightMsg(t, cmd))
+	m = tm.(*Model)
+	if cmd != nil || m.state.SummaryBatch != nil {
+		t.Fatal("a cancelled batch should not summarize further articles")
+	}
+	if item, _ := m.state.History.Item("a2"); item.AISummary != "" {
+		t.Fatalf("a2 summary = %q, want it left unsummarized", item.AISummary)
+	}
+}
+
+func TestSummarizeMissingReportsWhenNothingIsMissing(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/a"},
+		KeyMap: settings.KeyMapConfig{SummarizeMissing: "A"},
+	}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", Title: "Summarized", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: time.Now(), AISummary: "Existing"},
+	}}
+	m := newTestModelWithInsightGenerator(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, historyRepo, &stubFeedFetcher{}, &stubInsightGenerator{})
+	m.state.Session = state.ArticleView
+	presenter.ApplyArticleList(&m.state.ArticleList, m.state.History, "http://example.com/a", presenter.ArticleListOptions{})
+
+	m, cmd := typeKeys(m, "A")
+	if cmd != nil || m.state.SummaryBatch != nil {
+		t.Fatal("no batch should start when every article has a summary")
+	}
+	if m.state.AIStatus != "AI: every listed article already has a summary" {
+		t.Fatalf("AIStatus = %q", m.state.AIStatus)
+	}
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+func TestTagStatsListsTopTagsAndBrowsesOne(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/a"},
+		KeyMap: settings.KeyMapConfig{TagStats: "i"},
+	}
+	items := map[string]*reading.HistoryItem{
+		"a1": {GUID: "a1", Title: "One", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: time.Now(), AITags: []string{"Go", "AI"}},
+		"a2": {GUID: "a2", Title: "Two", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: time.Now(), AITags: []string{"go"}},
+		"a3": {GUID: "a3", Title: "Three", FeedURL: "http://example.com/a", Kind: reading.ArticleKind, Date: time.Now(), AITags: []string{"rust"}},
+	}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{items: items}, &stubFeedFetcher{})
+
+	m, _ = typeKeys(m, "i")
+	if m.state.Session != state.TagStatsView {
+		t.Fatalf("Session = %v, want TagStatsView", m.state.Session)
+	}
+	body := m.buildModalProps().Body
+	if !strings.Contains(body, " 1  go (2)") || !strings.Contains(body, " 2  ai (1)") || !strings.Contains(body, " 3  rust (1)") {
+		t.Fatalf("modal body = %q, want tags by count then name", body)
+	}
+
+	m, _ = typeKeys(m, "1")
+	if m.state.Session != state.ArticleView || m.state.CurrentFeed == nil || m.state.CurrentFeed.URL != reading.AllFeedsURL {
+		t.Fatalf("Session = %v, CurrentFeed = %#v, want All Feeds articles", m.state.Session, m.state.CurrentFeed)
+	}
+	var titles []string
+	for _, listItem := range m.state.ArticleList.VisibleItems() {
+		if item, ok := listItem.(*presenter.Item); ok && !item.IsSectionHeader() {
+			titles = append(titles, item.TitleText)
+		}
+	}
+	if len(titles) != 2 || strings.Contains(strings.Join(titles, ","), "Three") {
+		t.Fatalf("visible articles = %v, want the two tagged go", titles)
+	}
+}
+
+func TestTagStatsClosesWithoutTags(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/a"},
+		KeyMap: settings.KeyMapConfig{TagStats: "i"},
+	}
+	m := newTestModel(cfg, &stubSubscriptionRepo{feeds: cfg.Feeds}, &stubHistoryRepo{items: map[string]*reading.HistoryItem{}}, &stubFeedFetcher{})
+
+	m, _ = typeKeys(m, "i")
+	if body := m.buildModalProps().Body; !strings.HasPrefix(body, "No AI tags yet.") {
+		t.Fatalf("modal body = %q, want the empty state", body)
+	}
+	m, _ = typeKeys(m, "1q")
+	if m.state.Session != state.FeedView {
+		t.Fatalf("Session = %v, want FeedView after closing", m.state.Session)
+	}
+}
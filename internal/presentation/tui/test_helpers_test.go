@@ -3,6 +3,7 @@ package tui
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/stretchr/testify/mock"
@@ -10,12 +11,17 @@ import (
 	"github.com/tesso57/reazy/internal/application/usecase"
 	"github.com/tesso57/reazy/internal/domain/reading"
 	"github.com/tesso57/reazy/internal/domain/subscription"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
 )
 
 type stubSubscriptionRepo struct {
 	mock.Mock
 	feeds  []string
 	groups []subscription.FeedGroup
+
+	savedShowAISummary []bool
+	savedNewsSelection []usecase.NewsSelection
+	metadata           map[string]usecase.FeedMetadata
 }
 
 func (s *stubSubscriptionRepo) List() ([]string, error) {
@@ -95,9 +101,67 @@ func (s *stubSubscriptionRepo) ReplaceFeedGroups(groups []subscription.FeedGroup
 	return nil
 }
 
+func (s *stubSubscriptionRepo) UpdateFeedURL(oldURL, newURL string) error {
+	for groupIndex := range s.groups {
+		for i, feed := range s.groups[groupIndex].Feeds {
+			if feed == oldURL {
+				s.groups[groupIndex].Feeds[i] = newURL
+				return nil
+			}
+		}
+	}
+	for i, feed := range s.feeds {
+		if feed == oldURL {
+			s.feeds[i] = newURL
+			return nil
+		}
+	}
+	return fmt.Errorf("feed is not subscribed: %s", oldURL)
+}
+
+func (s *stubSubscriptionRepo) LoadFeedMetadata() (map[string]usecase.FeedMetadata, error) {
+	return s.metadata, nil
+}
+
+func (s *stubSubscriptionRepo) SaveFeedMetadata(metadata map[string]usecase.FeedMetadata) error {
+	if s.metadata == nil {
+		s.metadata = make(map[string]usecase.FeedMetadata)
+	}
+	for url, meta := range metadata {
+		if meta.Title == "" && len(meta.Tags) == 0 {
+			delete(s.metadata, url)
+			continue
+		}
+		s.metadata[url] = meta
+	}
+	return nil
+}
+
+func (s *stubSubscriptionRepo) SaveShowAISummary(show bool) error {
+	s.savedShowAISummary = append(s.savedShowAISummary, show)
+	return nil
+}
+
+func (s *stubSubscriptionRepo) SaveNewsSelection(selection usecase.NewsSelection) error {
+	s.savedNewsSelection = append(s.savedNewsSelection, selection)
+	return nil
+}
+
 type stubHistoryRepo struct {
 	mock.Mock
-	items map[string]*reading.HistoryItem
+	items      map[string]*reading.HistoryItem
+	movedFeeds [][2]string
+	optimized  int
+}
+
+func (s *stubHistoryRepo) Optimize() (before, after int64, err error) {
+	s.optimized++
+	return 0, 0, nil
+}
+
+func (s *stubHistoryRepo) MoveFeedURL(from, to string) error {
+	s.movedFeeds = append(s.movedFeeds, [2]string{from, to})
+	return nil
 }
 
 func (s *stubHistoryRepo) LoadMetadata() (map[string]*reading.HistoryItem, error) {
@@ -158,6 +222,13 @@ func (s *stubHistoryRepo) SetRead(guid string, isRead bool) error {
 	return nil
 }
 
+func (s *stubHistoryRepo) SetScrollOffset(guid string, offset int) error {
+	if item, ok := s.items[guid]; ok && item != nil {
+		item.ScrollOffset = offset
+	}
+	return nil
+}
+
 func (s *stubHistoryRepo) SetBookmark(guid string, isBookmarked bool) error {
 	if len(s.ExpectedCalls) > 0 {
 		args := s.Called(guid, isBookmarked)
@@ -182,6 +253,44 @@ func (s *stubHistoryRepo) SetInsight(guid, summary string, tags []string, update
 	return nil
 }
 
+func (s *stubHistoryRepo) ClearAll() error {
+	s.items = make(map[string]*reading.HistoryItem)
+	return nil
+}
+
+func (s *stubHistoryRepo) CountClearAll(limit int) (int, []string, error) {
+	return s.countClear(false, limit)
+}
+
+func (s *stubHistoryRepo) CountClearUnbookmarked(limit int) (int, []string, error) {
+	return s.countClear(true, limit)
+}
+
+func (s *stubHistoryRepo) countClear(keepBookmarks bool, limit int) (int, []string, error) {
+	var titles []string
+	for _, item := range s.items {
+		if item != nil && keepBookmarks && (item.IsBookmarked || item.IsDismissed) {
+			continue
+		}
+		title := ""
+		if item != nil {
+			title = item.Title
+		}
+		titles = append(titles, title)
+	}
+	slices.Sort(titles)
+	return len(titles), titles[:min(limit, len(titles))], nil
+}
+
+func (s *stubHistoryRepo) ClearUnbookmarked() error {
+	for guid, item := range s.items {
+		if item == nil || !item.IsBookmarked {
+			delete(s.items, guid)
+		}
+	}
+	return nil
+}
+
 func (s *stubHistoryRepo) ReplaceDigestItemsByDate(dateKey string, items []*reading.HistoryItem) error {
 	if len(s.ExpectedCalls) > 0 {
 		args := s.Called(dateKey, items)
@@ -233,7 +342,7 @@ type stubFeedFetcher struct {
 	err  error
 }
 
-func (s *stubFeedFetcher) Fetch(_ string) (*reading.Feed, error) {
+func (s *stubFeedFetcher) Fetch(_ context.Context, _ string) (*reading.Feed, error) {
 	if len(s.ExpectedCalls) > 0 {
 		args := s.Called()
 		feed, _ := args.Get(0).(*reading.Feed)
@@ -242,7 +351,7 @@ func (s *stubFeedFetcher) Fetch(_ string) (*reading.Feed, error) {
 	return s.feed, s.err
 }
 
-func (s *stubFeedFetcher) FetchAll(_ []string, _ usecase.FeedFetchOptions) (*reading.Feed, usecase.FeedFetchReport, error) {
+func (s *stubFeedFetcher) FetchAll(_ context.Context, _ []string, _ usecase.FeedFetchOptions) (*reading.Feed, usecase.FeedFetchReport, error) {
 	if len(s.ExpectedCalls) > 0 {
 		args := s.Called()
 		feed, _ := args.Get(0).(*reading.Feed)
@@ -255,9 +364,14 @@ func (s *stubFeedFetcher) FetchAll(_ []string, _ usecase.FeedFetchOptions) (*rea
 type stubInsightGenerator struct {
 	mock.Mock
 	insight usecase.Insight
+	answer  string
 	err     error
 }
 
+func (s *stubInsightGenerator) Answer(_ context.Context, _ usecase.ArticleQuestion) (string, error) {
+	return s.answer, s.err
+}
+
 func (s *stubInsightGenerator) Generate(_ context.Context, _ usecase.InsightRequest) (usecase.Insight, error) {
 	if len(s.ExpectedCalls) > 0 {
 		args := s.Called()
@@ -343,8 +457,21 @@ func newTestModelWithInsightAndNewsDigestAndGroupingGenerator(
 ) *Model {
 	subs := usecase.NewSubscriptionService(subsRepo)
 	readingSvc := usecase.NewReadingService(fetcher, historyRepo, nil)
+	tokens := &usecase.TokenMeter{}
 	insightSvc := usecase.NewInsightService(insightGen, nil)
+	insightSvc.Tokens = tokens
 	newsSvc := usecase.NewNewsDigestService(newsDigestGen, nil, nil)
+	newsSvc.Tokens = tokens
 	groupSvc := usecase.NewFeedGroupingService(groupingGen)
-	return NewModelWithServices(cfg, subs, readingSvc, insightSvc, newsSvc, groupSvc)
+	groupSvc.Tokens = tokens
+	return NewModelWithServices(cfg, subs, readingSvc, insightSvc, newsSvc, groupSvc, tokens)
+}
+
+// selectedGUID returns the GUID of the selected article list row, or ""
+// when no article is selected.
+func selectedGUID(m *Model) string {
+	if item, ok := m.state.ArticleList.SelectedItem().(*presenter.Item); ok {
+		return item.GUID
+	}
+	return ""
 }
@@ -2,7 +2,12 @@
 package textutil
 
 import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/x/ansi"
 )
@@ -15,6 +20,58 @@ func SingleLine(text string) string {
 	return strings.Join(strings.Fields(text), " ")
 }
 
+// tagPattern matches an HTML tag.
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// PlainText drops the HTML tags of a feed description, decodes its entities
+// and collapses whitespace.
+func PlainText(text string) string {
+	return SingleLine(html.UnescapeString(tagPattern.ReplaceAllString(text, " ")))
+}
+
+// StripBidiControls removes Unicode bidirectional formatting characters
+// (embeddings, overrides, isolates and directional marks) so that text from
+// feeds cannot change the display order of surrounding text.
+func StripBidiControls(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\u061c', r == '\u200e', r == '\u200f':
+			return -1
+		case r >= '\u202a' && r <= '\u202e':
+			return -1
+		case r >= '\u2066' && r <= '\u2069':
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// linkAttrPattern matches href and src attributes with double-quoted,
+// single-quoted or unquoted values.
+var linkAttrPattern = regexp.MustCompile(`(?i)(\b(?:href|src)\s*=\s*)("[^"]*"|'[^']*'|[^\s"'>]+)`)
+
+// ResolveLinks rewrites relative href and src values in html against base,
+// the article's own URL, so links copied from the text still work. Absolute
+// links are kept, and html is returned unchanged when base isn't absolute.
+func ResolveLinks(html, base string) string {
+	baseURL, err := url.Parse(strings.TrimSpace(base))
+	if err != nil || !baseURL.IsAbs() || !strings.Contains(html, "=") {
+		return html
+	}
+	return linkAttrPattern.ReplaceAllStringFunc(html, func(attr string) string {
+		parts := linkAttrPattern.FindStringSubmatch(attr)
+		value, quote := parts[2], ""
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+			quote, value = value[:1], value[1:len(value)-1]
+		}
+		ref, err := url.Parse(strings.TrimSpace(value))
+		if err != nil || ref.IsAbs() || strings.TrimSpace(value) == "" {
+			return attr
+		}
+		return parts[1] + quote + baseURL.ResolveReference(ref).String() + quote
+	})
+}
+
 // Truncate trims a string to the given width with an ellipsis.
 func Truncate(text string, width int) string {
 	if width <= 0 {
@@ -22,3 +79,21 @@ func Truncate(text string, width int) string {
 	}
 	return ansi.Truncate(text, width, "...")
 }
+
+// RelativeTime formats t relative to now (e.g. "just now", "5m ago", "3h ago", "2d ago").
+func RelativeTime(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	elapsed := now.Sub(t)
+	switch {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%dm ago", int(elapsed/time.Minute))
+	case elapsed < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(elapsed/time.Hour))
+	default:
+		return fmt.Sprintf("%dd ago", int(elapsed/(24*time.Hour)))
+	}
+}
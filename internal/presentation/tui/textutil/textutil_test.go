@@ -0,0 +1,66 @@
+package textutil
+
+import "testing"
+
+func TestResolveLinks(t *testing.T) {
+	const base = "https://example.com/blog/post.html"
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "root relative",
+			html: `<a href="/foo">foo</a>`,
+			want: `<a href="https://example.com/foo">foo</a>`,
+		},
+		{
+			name: "path relative and single quoted",
+			html: `<img src='img/a.png'>`,
+			want: `<img src='https://example.com/blog/img/a.png'>`,
+		},
+		{
+			name: "unquoted and upper case",
+			html: `<A HREF=../about>about</A>`,
+			want: `<A HREF=https://example.com/about>about</A>`,
+		},
+		{
+			name: "protocol relative",
+			html: `<a href="//cdn.example.net/x">x</a>`,
+			want: `<a href="https://cdn.example.net/x">x</a>`,
+		},
+		{
+			name: "absolute and other schemes kept",
+			html: `<a href="https://other.example/a">a</a> <a href="mailto:me@example.com">m</a>`,
+			want: `<a href="https://other.example/a">a</a> <a href="mailto:me@example.com">m</a>`,
+		},
+		{
+			name: "plain text untouched",
+			html: "No links here.",
+			want: "No links here.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveLinks(tt.html, base); got != tt.want {
+				t.Fatalf("ResolveLinks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveLinksWithoutAbsoluteBase(t *testing.T) {
+	html := `<a href="/foo">foo</a>`
+	for _, base := range []string{"", "/relative/base", "::bad"} {
+		if got := ResolveLinks(html, base); got != html {
+			t.Fatalf("ResolveLinks(%q) = %q, want unchanged", base, got)
+		}
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	got := PlainText("<p>Go 1.26 &amp; more</p>\n<ul><li>faster   builds</li></ul>")
+	if want := "Go 1.26 & more faster builds"; got != want {
+		t.Fatalf("PlainText() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+)
+
+func feedListLinks(m *Model) []string {
+	var links []string
+	for _, item := range m.state.FeedList.Items() {
+		if it, ok := item.(*presenter.Item); ok && !it.IsSectionHeader() {
+			links = append(links, it.Link)
+		}
+	}
+	return links
+}
+
+func TestUnreadFeedsToggleFiltersSidebar(t *testing.T) {
+	cfg := settings.Settings{
+		Feeds:  []string{"http://example.com/read", "http://example.com/unread"},
+		KeyMap: settings.KeyMapConfig{UnreadFeeds: "U"},
+	}
+	repo := &stubSubscriptionRepo{feeds: append([]string(nil), cfg.Feeds...)}
+	historyRepo := &stubHistoryRepo{items: map[string]*reading.HistoryItem{
+		"r": {GUID: "r", FeedURL: "http://example.com/read", Kind: reading.ArticleKind, IsRead: true},
+		"u": {GUID: "u", FeedURL: "http://example.com/unread", Kind: reading.ArticleKind},
+	}}
+	m := newTestModel(cfg, repo, historyRepo, &stubFeedFetcher{})
+	if got := len(feedListLinks(m)); got != 5 {
+		t.Fatalf("feed list has %d entries, want 5", got)
+	}
+
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	m = tm.(*Model)
+	links := feedListLinks(m)
+	if len(links) != 4 || links[3] != "http://example.com/unread" {
+		t.Fatalf("filtered feed list = %#v", links)
+	}
+	if m.state.StatusMessage != "Showing feeds with unread articles" {
+		t.Fatalf("status = %q", m.state.StatusMessage)
+	}
+
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	m = tm.(*Model)
+	if got := len(feedListLinks(m)); got != 5 {
+		t.Fatalf("feed list has %d entries after toggling back, want 5", got)
+	}
+	if m.state.StatusMessage != "Showing all feeds" {
+		t.Fatalf("status = %q", m.state.StatusMessage)
+	}
+}
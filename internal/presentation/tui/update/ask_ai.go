@@ -0,0 +1,121 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// ArticleAnswerMsg is emitted after the AI answers a question about an article.
+type ArticleAnswerMsg struct {
+	GUID     string
+	Question string
+	Answer   string
+	Err      error
+}
+
+// AskArticleCmd asks the AI a free-form question about one article.
+func AskArticleCmd(ctx context.Context, insightSvc *usecase.InsightService, guid, question string, article usecase.InsightRequest) tea.Cmd {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return func() tea.Msg {
+		answer, err := insightSvc.Ask(ctx, article, question)
+		return ArticleAnswerMsg{GUID: guid, Question: question, Answer: answer, Err: err}
+	}
+}
+
+// openAskAIDialog asks for a question about the article open in the detail view.
+func openAskAIDialog(s *state.ModelState, deps Deps) tea.Cmd {
+	item, ok := selectedActionableArticleItem(s)
+	if !ok || item.IsNewsDigest() {
+		return nil
+	}
+	if !deps.Insights.Enabled() {
+		s.AIStatus = "AI: codex integration is disabled"
+		return nil
+	}
+	s.AskGUID = item.GUID
+	s.AskInput.Reset()
+	s.Session = state.AskAIView
+	return textinput.Blink
+}
+
+func handleAskAIView(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "enter":
+		question := strings.TrimSpace(s.AskInput.Value())
+		s.AskInput.Reset()
+		s.Session = state.DetailView
+		item, ok := selectedActionableArticleItem(s)
+		if question == "" || !ok || item.GUID != s.AskGUID {
+			return nil, true
+		}
+		BeginLoading(s, loadingAnswer)
+		s.Err = nil
+		article := buildInsightRequest(item, s.ContentSanitizer)
+		estimate := usecase.ArticleQuestion{Article: article, Question: question}.EstimatedTokens()
+		s.AIStatus = fmt.Sprintf("AI: answering your question (~%d tokens)...", estimate)
+		return tea.Batch(
+			s.Spinner.Tick,
+			AskArticleCmd(deps.Context, deps.Insights, item.GUID, question, article),
+		), true
+	case "esc":
+		s.AskInput.Reset()
+		s.AskGUID = ""
+		s.Session = state.DetailView
+		return nil, true
+	}
+
+	var cmd tea.Cmd
+	s.AskInput, cmd = s.AskInput.Update(msg)
+	return cmd, true
+}
+
+// HandleArticleAnswerMsg shows the answer in place of the article body while
+// that article is still open.
+func HandleArticleAnswerMsg(s *state.ModelState, msg ArticleAnswerMsg) {
+	s.Loading = false
+	if msg.Err != nil {
+		s.AIStatus = fmt.Sprintf("AI: question failed (%s)", strings.TrimSpace(msg.Err.Error()))
+		return
+	}
+	s.AIStatus = "AI: answered"
+	item, ok := selectedActionableArticleItem(s)
+	if s.Session != state.DetailView || !ok || item.GUID != msg.GUID {
+		return
+	}
+	s.AskQuestion = msg.Question
+	s.AskAnswer = msg.Answer
+	clearDetailSearch(s)
+	wrapWidth := detailWrapWidth(s)
+	content := strings.Join([]string{
+		wrapDetailText("Q: "+msg.Question, wrapWidth),
+		detailSectionDivider,
+		wrapDetailText(msg.Answer, wrapWidth),
+		"",
+		"(esc to return to the article)",
+	}, "\n")
+	s.DetailContent = centerDetailColumn(content, wrapWidth, detailContentWidth(s))
+	s.Viewport.SetContent(s.DetailContent)
+	s.Viewport.GotoTop()
+}
+
+// handleAnswerPanelKey returns from a shown answer to the article on Back.
+func handleAnswerPanelKey(s *state.ModelState, msg tea.KeyMsg) bool {
+	if s.AskAnswer == "" || !key.Matches(msg, s.Keys.Back) {
+		return false
+	}
+	s.AskQuestion = ""
+	s.AskAnswer = ""
+	if item, ok := selectedActionableArticleItem(s); ok {
+		refreshDetailViewport(s, item)
+	}
+	return true
+}
@@ -8,7 +8,10 @@ import (
 	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
 )
 
-const detailSectionDivider = "----------------------------------------"
+const (
+	detailSectionDivider = "----------------------------------------"
+	detailLoadingLine    = "(Loading full article...)"
+)
 
 func buildDetailContent(i *presenter.Item, showAISummary bool) string {
 	return buildDetailContentForWidth(i, showAISummary, 0)
@@ -19,7 +22,7 @@ func buildDetailContentForWidth(i *presenter.Item, showAISummary bool, width int
 		return ""
 	}
 
-	title := strings.TrimSpace(i.TitleText)
+	title := wrapDetailText(strings.TrimSpace(i.TitleText), width)
 	summaryHeader := "AI Summary"
 	if !i.AIUpdatedAt.IsZero() {
 		summaryHeader = fmt.Sprintf("AI Summary (%s)", i.AIUpdatedAt.Format("2006-01-02 15:04"))
@@ -41,15 +44,17 @@ func buildDetailContentForWidth(i *presenter.Item, showAISummary bool, width int
 	if summary == "" {
 		summary = "(No AI summary available.)"
 	}
-	if body == "" {
-		if !i.BodyHydrated {
-			body = "(Loading article body...)"
-		} else {
-			body = "(No article body available. Open it in the browser.)"
-		}
-	}
 	summary = wrapDetailText(summary, width)
 	body = wrapDetailText(body, width)
+	switch {
+	case body == "" && !i.BodyHydrated:
+		body = "(Loading article body...)"
+	case body == "":
+		body = "(No article body available. Open it in the browser.)"
+	case !i.BodyHydrated:
+		// Keep the feed's preview readable while the full article loads.
+		body = detailLoadingLine + "\n\n" + body
+	}
 
 	if title == "" {
 		return fmt.Sprintf(
@@ -74,3 +79,19 @@ func wrapDetailText(text string, width int) string {
 	// Preserve all text by hard-wrapping long lines (including CJK/no-space text).
 	return ansi.Hardwrap(text, width, true)
 }
+
+// centerDetailColumn indents content so a column narrower than the viewport is centered.
+func centerDetailColumn(content string, columnWidth, totalWidth int) string {
+	if columnWidth <= 0 || totalWidth <= columnWidth {
+		return content
+	}
+	margin := strings.Repeat(" ", (totalWidth-columnWidth)/2)
+	lines := strings.Split(content, "\n")
+	for idx, line := range lines {
+		if line == "" {
+			continue
+		}
+		lines[idx] = margin + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -5,7 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/tesso57/reazy/internal/domain/reading"
 	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
 )
 
 func TestBuildDetailContent(t *testing.T) {
@@ -54,8 +57,9 @@ func TestBuildDetailContent(t *testing.T) {
 
 	t.Run("rss description is used as article body fallback", func(t *testing.T) {
 		got := buildDetailContent(&presenter.Item{
-			TitleText: "1. Example",
-			Desc:      "RSS body text",
+			TitleText:    "1. Example",
+			Desc:         "RSS body text",
+			BodyHydrated: true,
 		}, true)
 
 		if !strings.Contains(got, "(No AI summary available.)") {
@@ -80,6 +84,25 @@ func TestBuildDetailContent(t *testing.T) {
 		}
 	})
 
+	t.Run("unhydrated body keeps the preview while loading", func(t *testing.T) {
+		got := buildDetailContent(&presenter.Item{
+			TitleText: "1. Example",
+			Desc:      "RSS preview",
+		}, true)
+		if !strings.Contains(got, "Article Body\n(Loading full article...)\n\nRSS preview") {
+			t.Errorf("expected loading line above the preview, got %q", got)
+		}
+
+		hydrated := buildDetailContent(&presenter.Item{
+			TitleText:    "1. Example",
+			Content:      "Full body",
+			BodyHydrated: true,
+		}, true)
+		if strings.Contains(hydrated, "(Loading full article...)") {
+			t.Error("did not expect loading line once the body is hydrated")
+		}
+	})
+
 	t.Run("hydrated empty body uses browser fallback", func(t *testing.T) {
 		got := buildDetailContent(&presenter.Item{
 			TitleText:    "Only Title",
@@ -145,3 +168,78 @@ func TestBuildDetailContent(t *testing.T) {
 		}
 	})
 }
+
+func TestDetailWrapWidth_ReadingWidth(t *testing.T) {
+	s := newLayoutTestState()
+	s.Viewport = viewport.New(100, 10)
+
+	if got := detailWrapWidth(s); got != 100 {
+		t.Fatalf("detailWrapWidth() without reading width = %d, want 100", got)
+	}
+
+	s.ReadingWidth = 60
+	if got := detailWrapWidth(s); got != 60 {
+		t.Fatalf("detailWrapWidth() with reading width = %d, want 60", got)
+	}
+
+	s.ReadingWidth = 200
+	if got := detailWrapWidth(s); got != 100 {
+		t.Fatalf("detailWrapWidth() with wide reading width = %d, want 100", got)
+	}
+}
+
+func TestRefreshDetailViewport_StripsBoilerplate(t *testing.T) {
+	s := newLayoutTestState()
+	s.Viewport = viewport.New(100, 20)
+	sanitizer, err := reading.NewContentSanitizer([]string{`(?s)Subscribe now.*$`})
+	if err != nil {
+		t.Fatalf("NewContentSanitizer() error = %v", err)
+	}
+	s.ContentSanitizer = sanitizer
+
+	item := &presenter.Item{TitleText: "Title", Content: "Article body.\nSubscribe now for more", BodyHydrated: true}
+	refreshDetailViewport(s, item)
+
+	if view := s.Viewport.View(); strings.Contains(view, "Subscribe now") || !strings.Contains(view, "Article body.") {
+		t.Fatalf("boilerplate should be stripped from the detail view:\n%s", view)
+	}
+	if !strings.Contains(item.Content, "Subscribe now") {
+		t.Fatal("stripping should not modify the underlying item")
+	}
+	if req := buildInsightRequest(item, sanitizer); req.Content != "Article body." {
+		t.Fatalf("insight request content = %q, want stripped body", req.Content)
+	}
+}
+
+func TestRefreshDetailViewportResolvesRelativeLinks(t *testing.T) {
+	s := &state.ModelState{Viewport: viewport.New(200, 20), ResolveRelativeLinks: true}
+	item := &presenter.Item{
+		TitleText:    "Title",
+		Link:         "https://example.com/posts/1",
+		Content:      `See <a href="/about">about</a>.`,
+		BodyHydrated: true,
+	}
+	refreshDetailViewport(s, item)
+	if !strings.Contains(s.DetailContent, `href="https://example.com/about"`) {
+		t.Fatalf("relative link should be resolved against the article URL:\n%s", s.DetailContent)
+	}
+	if item.Content != `See <a href="/about">about</a>.` {
+		t.Fatal("resolving should not modify the underlying item")
+	}
+
+	s.ResolveRelativeLinks = false
+	refreshDetailViewport(s, item)
+	if !strings.Contains(s.DetailContent, `href="/about"`) {
+		t.Fatalf("links should be left alone when disabled:\n%s", s.DetailContent)
+	}
+}
+
+func TestCenterDetailColumn(t *testing.T) {
+	got := centerDetailColumn("abc\n\ndef", 4, 10)
+	if got != "   abc\n\n   def" {
+		t.Fatalf("centerDetailColumn() = %q", got)
+	}
+	if got := centerDetailColumn("abc", 10, 10); got != "abc" {
+		t.Fatalf("centerDetailColumn() full width = %q, want unchanged", got)
+	}
+}
@@ -0,0 +1,150 @@
+package update
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+var (
+	detailMatchStyle        = lipgloss.NewStyle().Reverse(true)
+	detailCurrentMatchStyle = lipgloss.NewStyle().Background(lipgloss.Color("205")).Foreground(lipgloss.Color("0"))
+)
+
+// handleDetailSearchKey handles in-article search in the detail view:
+// DetailSearch starts typing a query, enter keeps it, and DetailNext and
+// DetailPrev jump between matches.
+// Back clears an active search before leaving the view.
+func handleDetailSearchKey(s *state.ModelState, msg tea.KeyMsg) bool {
+	if s.DetailSearching {
+		switch msg.Type {
+		case tea.KeyEnter:
+			s.DetailSearching = false
+			if s.DetailSearchQuery == "" {
+				clearDetailSearch(s)
+			}
+		case tea.KeyEsc:
+			clearDetailSearch(s)
+		case tea.KeyBackspace:
+			if query := []rune(s.DetailSearchQuery); len(query) > 0 {
+				s.DetailSearchQuery = string(query[:len(query)-1])
+				applyDetailSearch(s, true)
+			}
+		case tea.KeySpace:
+			s.DetailSearchQuery += " "
+			applyDetailSearch(s, true)
+		case tea.KeyRunes:
+			s.DetailSearchQuery += string(msg.Runes)
+			applyDetailSearch(s, true)
+		}
+		return true
+	}
+
+	switch {
+	case key.Matches(msg, s.Keys.DetailSearch):
+		clearDetailSearch(s)
+		s.DetailSearching = true
+		return true
+	case key.Matches(msg, s.Keys.DetailNext):
+		return jumpDetailMatch(s, 1)
+	case key.Matches(msg, s.Keys.DetailPrev):
+		return jumpDetailMatch(s, -1)
+	}
+	if s.DetailSearchQuery != "" && key.Matches(msg, s.Keys.Back) {
+		clearDetailSearch(s)
+		return true
+	}
+	return false
+}
+
+// applyDetailSearch recomputes matches for the current query and highlights
+// them, optionally scrolling to the first one.
+func applyDetailSearch(s *state.ModelState, scroll bool) {
+	s.DetailSearchMatches = findDetailMatches(s.DetailContent, s.DetailSearchQuery)
+	s.DetailSearchIndex = 0
+	renderDetailSearch(s)
+	if scroll {
+		scrollToDetailMatch(s)
+	}
+}
+
+func clearDetailSearch(s *state.ModelState) {
+	hadQuery := s.DetailSearchQuery != ""
+	s.DetailSearching = false
+	s.DetailSearchQuery = ""
+	s.DetailSearchMatches = nil
+	s.DetailSearchIndex = 0
+	if hadQuery {
+		s.Viewport.SetContent(s.DetailContent)
+	}
+}
+
+func jumpDetailMatch(s *state.ModelState, direction int) bool {
+	total := len(s.DetailSearchMatches)
+	if total == 0 {
+		return false
+	}
+	s.DetailSearchIndex = (s.DetailSearchIndex + direction + total) % total
+	renderDetailSearch(s)
+	scrollToDetailMatch(s)
+	return true
+}
+
+// findDetailMatches returns case-insensitive matches of query, line by line.
+func findDetailMatches(content, query string) []state.DetailSearchMatch {
+	if query == "" || content == "" {
+		return nil
+	}
+	pattern := regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+	var matches []state.DetailSearchMatch
+	for lineIndex, line := range strings.Split(content, "\n") {
+		for _, loc := range pattern.FindAllStringIndex(line, -1) {
+			matches = append(matches, state.DetailSearchMatch{Line: lineIndex, Start: loc[0], End: loc[1]})
+		}
+	}
+	return matches
+}
+
+func renderDetailSearch(s *state.ModelState) {
+	if len(s.DetailSearchMatches) == 0 {
+		s.Viewport.SetContent(s.DetailContent)
+		return
+	}
+	lines := strings.Split(s.DetailContent, "\n")
+	byLine := make(map[int][]int)
+	for idx, match := range s.DetailSearchMatches {
+		byLine[match.Line] = append(byLine[match.Line], idx)
+	}
+	for lineIndex, matchIndexes := range byLine {
+		line := lines[lineIndex]
+		var b strings.Builder
+		prev := 0
+		for _, idx := range matchIndexes {
+			match := s.DetailSearchMatches[idx]
+			style := detailMatchStyle
+			if idx == s.DetailSearchIndex {
+				style = detailCurrentMatchStyle
+			}
+			b.WriteString(line[prev:match.Start])
+			b.WriteString(style.Render(line[match.Start:match.End]))
+			prev = match.End
+		}
+		b.WriteString(line[prev:])
+		lines[lineIndex] = b.String()
+	}
+	s.Viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+// scrollToDetailMatch scrolls the viewport so the current match sits near
+// the top third of the view.
+func scrollToDetailMatch(s *state.ModelState) {
+	if s.DetailSearchIndex >= len(s.DetailSearchMatches) {
+		return
+	}
+	line := s.DetailSearchMatches[s.DetailSearchIndex].Line
+	s.Viewport.SetYOffset(max(line-s.Viewport.Height/3, 0))
+}
@@ -0,0 +1,114 @@
+package update
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+func typeDetailSearch(s *state.ModelState, text string) {
+	for _, r := range text {
+		handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestFindDetailMatches(t *testing.T) {
+	got := findDetailMatches("Go is fun\nno match\ngo GO", "go")
+	want := []state.DetailSearchMatch{
+		{Line: 0, Start: 0, End: 2},
+		{Line: 2, Start: 0, End: 2},
+		{Line: 2, Start: 3, End: 5},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("findDetailMatches() = %#v, want %#v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("match[%d] = %#v, want %#v", i, got[i], want[i])
+		}
+	}
+	if got := findDetailMatches("a+b", "+"); len(got) != 1 {
+		t.Fatalf("query should be matched literally, got %#v", got)
+	}
+}
+
+func TestDetailSearch_JumpsBetweenMatches(t *testing.T) {
+	s := newLayoutTestState()
+	s.Session = state.DetailView
+	s.Viewport = viewport.New(80, 3)
+	lines := make([]string, 30)
+	for i := range lines {
+		lines[i] = "filler"
+	}
+	lines[10] = "first needle"
+	lines[25] = "second NEEDLE"
+	refreshDetailViewport(s, &presenter.Item{TitleText: "Title", Content: strings.Join(lines, "\n"), BodyHydrated: true})
+
+	if !handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}}) || !s.DetailSearching {
+		t.Fatal("'/' should start searching")
+	}
+	typeDetailSearch(s, "needle")
+	handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if s.DetailSearching || len(s.DetailSearchMatches) != 2 {
+		t.Fatalf("searching=%v matches=%#v", s.DetailSearching, s.DetailSearchMatches)
+	}
+	first := s.Viewport.YOffset
+	if !strings.Contains(s.Viewport.View(), "first needle") {
+		t.Fatalf("viewport should show the first match:\n%s", s.Viewport.View())
+	}
+
+	handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if s.DetailSearchIndex != 1 || s.Viewport.YOffset <= first {
+		t.Fatalf("n should move to the next match, index=%d offset=%d", s.DetailSearchIndex, s.Viewport.YOffset)
+	}
+	handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if s.DetailSearchIndex != 0 {
+		t.Fatalf("n should wrap to the first match, index=%d", s.DetailSearchIndex)
+	}
+	handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	if s.DetailSearchIndex != 1 {
+		t.Fatalf("N should wrap to the last match, index=%d", s.DetailSearchIndex)
+	}
+
+	if !handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyEsc}) || s.DetailSearchQuery != "" {
+		t.Fatal("esc should clear the active search")
+	}
+	if handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyEsc}) {
+		t.Fatal("esc without a search should fall through to Back")
+	}
+}
+
+func TestDetailSearch_UsesConfiguredKeys(t *testing.T) {
+	s := newLayoutTestState()
+	s.Keys = state.NewKeyMap(settings.KeyMapConfig{DetailSearch: "?", DetailNext: "ctrl+n", DetailPrev: "ctrl+p"})
+	s.Session = state.DetailView
+	s.Viewport = viewport.New(80, 3)
+	refreshDetailViewport(s, &presenter.Item{TitleText: "Title", Content: "needle\nneedle", BodyHydrated: true})
+
+	if handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}}) {
+		t.Fatal("'/' should fall through once detail_search is rebound")
+	}
+	if !handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}}) || !s.DetailSearching {
+		t.Fatal("the detail_search key should start searching")
+	}
+	typeDetailSearch(s, "needle")
+	handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}}) {
+		t.Fatal("'n' should fall through once detail_next is rebound")
+	}
+	handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyCtrlN})
+	if s.DetailSearchIndex != 1 {
+		t.Fatalf("detail_next should move to the next match, index=%d", s.DetailSearchIndex)
+	}
+	handleDetailSearchKey(s, tea.KeyMsg{Type: tea.KeyCtrlP})
+	if s.DetailSearchIndex != 0 {
+		t.Fatalf("detail_prev should move to the previous match, index=%d", s.DetailSearchIndex)
+	}
+}
@@ -0,0 +1,35 @@
+package update
+
+import (
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// toggleDismissSelection dismisses the selected article so it disappears from
+// every list, or restores it when it is already dismissed (as in the
+// Dismissed view).
+func toggleDismissSelection(s *state.ModelState, deps Deps) {
+	selected, ok := selectedActionableArticleItem(s)
+	if !ok || selected.IsNewsDigest() {
+		return
+	}
+	item, ok := s.History.Item(selected.GUID)
+	if !ok || item == nil {
+		return
+	}
+	dismiss := !item.IsDismissed
+	if err := deps.Reading.SetDismissed(s.History, selected.GUID, dismiss); err != nil {
+		s.Err = err
+		return
+	}
+	if dismiss {
+		s.StatusMessage = "Dismissed"
+	} else {
+		s.StatusMessage = "Restored"
+	}
+	refreshArticleListKeepingSelection(s)
+	if feedListUsesUnreadCounts(s) {
+		applyFeedList(s)
+	} else {
+		RefreshFeedHeaders(s)
+	}
+}
@@ -0,0 +1,21 @@
+package update
+
+import (
+	"fmt"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// expandDuplicateTitles lists each article folded into a collapsed All Feeds
+// row, keeping the selection on the article the row showed.
+func expandDuplicateTitles(s *state.ModelState, item *presenter.Item) {
+	if s.ExpandedTitles == nil {
+		s.ExpandedTitles = make(map[string]bool)
+	}
+	s.ExpandedTitles[item.TitleKey] = true
+	ApplyArticleList(s, reading.AllFeedsURL)
+	selectArticleItemByGUID(&s.ArticleList, item.GUID)
+	s.StatusMessage = fmt.Sprintf("Showing %d sources of this story", item.Sources)
+}
@@ -0,0 +1,46 @@
+package update
+
+import (
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/textutil"
+)
+
+// toggleExpandedRow shows the description of the selected article under its
+// row without opening it, or hides it again. The list gives up the lines the
+// description takes, so the page keeps its height.
+func toggleExpandedRow(s *state.ModelState) {
+	item, ok := selectedActionableArticleItem(s)
+	if !ok {
+		return
+	}
+	if s.ExpandedGUID == item.GUID {
+		CollapseExpandedRow(s)
+		return
+	}
+	if textutil.PlainText(item.Desc) == "" {
+		s.StatusMessage = "No description to show"
+		return
+	}
+	s.ExpandedGUID = item.GUID
+	presenter.ExpandRow(&s.ArticleList, item.GUID)
+	UpdateListSizes(s)
+}
+
+// CollapseExpandedRow hides the description shown under an expanded row.
+func CollapseExpandedRow(s *state.ModelState) {
+	if s.ExpandedGUID == "" {
+		return
+	}
+	s.ExpandedGUID = ""
+	presenter.ExpandRow(&s.ArticleList, "")
+	UpdateListSizes(s)
+}
+
+// restoreExpandedRow expands the row again after the article list is
+// rebuilt, and forgets it once the article is no longer listed.
+func restoreExpandedRow(s *state.ModelState) {
+	if s.ExpandedGUID != "" && !presenter.ExpandRow(&s.ArticleList, s.ExpandedGUID) {
+		s.ExpandedGUID = ""
+	}
+}
@@ -0,0 +1,83 @@
+package update
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// ArticlesExportedMsg is emitted once articles were written out as Markdown.
+type ArticlesExportedMsg struct {
+	GUIDs  []string
+	Report usecase.ExportReport
+	Err    error
+}
+
+// ExportArticlesCmd writes the articles with guids to one Markdown export.
+func ExportArticlesCmd(readingSvc *usecase.ReadingService, guids []string) tea.Cmd {
+	guids = slices.Clone(guids)
+	return func() tea.Msg {
+		report, err := readingSvc.ExportArticles(guids)
+		return ArticlesExportedMsg{GUIDs: guids, Report: report, Err: err}
+	}
+}
+
+// toggleArticleSelection picks the highlighted article for export, or drops
+// it from the selection when it is already picked.
+func toggleArticleSelection(s *state.ModelState) {
+	item, ok := selectedActionableArticleItem(s)
+	if !ok {
+		return
+	}
+	if i := slices.Index(s.Selected, item.GUID); i >= 0 {
+		s.Selected = slices.Delete(s.Selected, i, i+1)
+	} else {
+		s.Selected = append(s.Selected, item.GUID)
+	}
+	presenter.MarkSelected(&s.ArticleList, s.Selected)
+	s.StatusMessage = fmt.Sprintf("Selected %d articles for export", len(s.Selected))
+}
+
+// startExportSelected exports the selected articles in the order they were
+// picked, or the highlighted article when nothing is selected.
+func startExportSelected(s *state.ModelState, deps Deps) tea.Cmd {
+	guids := s.Selected
+	if len(guids) == 0 {
+		item, ok := selectedActionableArticleItem(s)
+		if !ok {
+			s.StatusMessage = "No article to export"
+			return nil
+		}
+		guids = []string{item.GUID}
+	}
+	s.Err = nil
+	s.StatusMessage = "Exporting..."
+	return ExportArticlesCmd(deps.Reading, guids)
+}
+
+// HandleArticlesExportedMsg reports where the export went and drops the
+// exported articles from the selection.
+func HandleArticlesExportedMsg(s *state.ModelState, msg ArticlesExportedMsg) {
+	if msg.Err != nil {
+		s.StatusMessage = ""
+		s.Err = msg.Err
+		return
+	}
+	s.Selected = slices.DeleteFunc(s.Selected, func(guid string) bool {
+		return slices.Contains(msg.GUIDs, guid)
+	})
+	presenter.MarkSelected(&s.ArticleList, s.Selected)
+	s.StatusMessage = fmt.Sprintf("Exported %d articles to %s", msg.Report.Articles, msg.Report.Location)
+}
+
+// restoreSelection marks the selected articles again after the article list
+// is rebuilt.
+func restoreSelection(s *state.ModelState) {
+	if len(s.Selected) > 0 {
+		presenter.MarkSelected(&s.ArticleList, s.Selected)
+	}
+}
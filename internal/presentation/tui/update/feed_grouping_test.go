@@ -6,8 +6,10 @@ import (
 	"testing"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/tesso57/reazy/internal/application/settings"
 	"github.com/tesso57/reazy/internal/application/usecase"
 	"github.com/tesso57/reazy/internal/domain/subscription"
+	"github.com/tesso57/reazy/internal/presentation/tui/intent"
 	"github.com/tesso57/reazy/internal/presentation/tui/state"
 )
 
@@ -23,6 +25,7 @@ func (g updateFeedGroupingGenerator) Generate(_ context.Context, _ usecase.FeedG
 type updateSubscriptionRepo struct {
 	feeds  []string
 	groups []subscription.FeedGroup
+	cache  usecase.FeedGroupingCache
 }
 
 func (r *updateSubscriptionRepo) List() ([]string, error) {
@@ -84,6 +87,15 @@ func (r *updateSubscriptionRepo) ReplaceFeedGroups(groups []subscription.FeedGro
 	return nil
 }
 
+func (r *updateSubscriptionRepo) LoadFeedGroupingCache() (usecase.FeedGroupingCache, error) {
+	return r.cache, nil
+}
+
+func (r *updateSubscriptionRepo) SaveFeedGroupingCache(cache usecase.FeedGroupingCache) error {
+	r.cache = cache
+	return nil
+}
+
 func TestGenerateFeedGroupingCmd(t *testing.T) {
 	repo := &updateSubscriptionRepo{
 		feeds: []string{"https://planetpython.org/rss20.xml"},
@@ -101,7 +113,7 @@ func TestGenerateFeedGroupingCmd(t *testing.T) {
 	cmd := GenerateFeedGroupingCmd(grouping, subscriptions, []string{
 		"https://news.ycombinator.com/rss",
 		"https://planetpython.org/rss20.xml",
-	})
+	}, nil, false)
 	raw := cmd()
 	msg, ok := raw.(FeedGroupingCompletedMsg)
 	if !ok {
@@ -118,8 +130,112 @@ func TestGenerateFeedGroupingCmd(t *testing.T) {
 	}
 }
 
+func TestGenerateFeedGroupingCmd_UsesCacheForUnchangedFeeds(t *testing.T) {
+	feeds := []string{"https://news.ycombinator.com/rss", "https://planetpython.org/rss20.xml"}
+	repo := &updateSubscriptionRepo{feeds: append([]string(nil), feeds...)}
+	subscriptions := usecase.NewSubscriptionService(repo)
+	grouping := usecase.NewFeedGroupingService(updateFeedGroupingGenerator{
+		groups: []subscription.FeedGroup{
+			{Name: "Tech", Feeds: []string{"https://news.ycombinator.com/rss"}},
+		},
+	})
+
+	first, ok := GenerateFeedGroupingCmd(grouping, subscriptions, feeds, nil, false)().(FeedGroupingCompletedMsg)
+	if !ok || first.Err != nil || first.UsedCache {
+		t.Fatalf("unexpected first message: %#v", first)
+	}
+	if repo.cache.InputHash == "" {
+		t.Fatal("expected grouping cache to be saved")
+	}
+
+	failing := usecase.NewFeedGroupingService(updateFeedGroupingGenerator{err: errors.New("should not be called")})
+	reversed := []string{feeds[1], feeds[0]}
+	second, ok := GenerateFeedGroupingCmd(failing, subscriptions, reversed, nil, false)().(FeedGroupingCompletedMsg)
+	if !ok {
+		t.Fatal("unexpected cmd message type")
+	}
+	if second.Err != nil {
+		t.Fatalf("FeedGroupingCompletedMsg.Err = %v", second.Err)
+	}
+	if !second.UsedCache {
+		t.Fatal("expected cached grouping to be used")
+	}
+	if len(second.Groups) != 1 || second.Groups[0].Name != "Tech" {
+		t.Fatalf("unexpected groups: %#v", second.Groups)
+	}
+}
+
+func TestGenerateFeedGroupingCmd_ForceSkipsCache(t *testing.T) {
+	feeds := []string{"https://news.ycombinator.com/rss", "https://planetpython.org/rss20.xml"}
+	repo := &updateSubscriptionRepo{
+		feeds: append([]string(nil), feeds...),
+		cache: usecase.FeedGroupingCache{
+			InputHash: usecase.FeedGroupingInputHash(feeds),
+			Groups:    []subscription.FeedGroup{{Name: "Cached", Feeds: feeds}},
+		},
+	}
+	grouping := usecase.NewFeedGroupingService(updateFeedGroupingGenerator{
+		groups: []subscription.FeedGroup{{Name: "Fresh", Feeds: feeds}},
+	})
+
+	msg, ok := GenerateFeedGroupingCmd(grouping, usecase.NewSubscriptionService(repo), feeds, nil, true)().(FeedGroupingCompletedMsg)
+	if !ok || msg.Err != nil || msg.UsedCache {
+		t.Fatalf("unexpected message: %#v", msg)
+	}
+	if len(msg.Groups) != 1 || msg.Groups[0].Name != "Fresh" {
+		t.Fatalf("groups = %#v, want a fresh grouping", msg.Groups)
+	}
+	if len(repo.cache.Groups) != 1 || repo.cache.Groups[0].Name != "Fresh" {
+		t.Fatalf("cache = %#v, want it replaced by the fresh grouping", repo.cache)
+	}
+}
+
+func TestHandleFeedGroupingCompletedMsg_CacheHitArmsForcedRegroup(t *testing.T) {
+	s := &state.ModelState{
+		FeedList: list.New(nil, list.NewDefaultDelegate(), 80, 20),
+		Keys:     state.NewKeyMap(settings.DefaultKeyMapConfig()),
+	}
+	feeds := []string{"https://a.example.com/rss", "https://b.example.com/rss"}
+
+	HandleFeedGroupingCompletedMsg(s, FeedGroupingCompletedMsg{
+		Feeds:     feeds,
+		Groups:    []subscription.FeedGroup{{Name: "Cached", Feeds: feeds}},
+		UsedCache: true,
+	})
+	if !s.ForceFeedRegroup || s.StatusMessage != "AI: using cached grouping (z again to regroup)" {
+		t.Fatalf("ForceFeedRegroup = %v, StatusMessage = %q", s.ForceFeedRegroup, s.StatusMessage)
+	}
+}
+
+func TestGenerateFeedGroupingCmd_PreservesKeepGroups(t *testing.T) {
+	manual := []subscription.FeedGroup{
+		{Name: "Manual", Feeds: []string{"https://a.example.com/rss"}},
+	}
+	repo := &updateSubscriptionRepo{
+		feeds:  []string{"https://b.example.com/rss", "https://c.example.com/rss"},
+		groups: cloneFeedGroups(manual),
+	}
+	grouping := usecase.NewFeedGroupingService(updateFeedGroupingGenerator{
+		groups: []subscription.FeedGroup{
+			{Name: "New", Feeds: []string{"https://b.example.com/rss", "https://c.example.com/rss"}},
+		},
+	})
+	feeds, _ := repo.List()
+
+	msg, ok := GenerateFeedGroupingCmd(grouping, usecase.NewSubscriptionService(repo), feeds, manual, false)().(FeedGroupingCompletedMsg)
+	if !ok || msg.Err != nil {
+		t.Fatalf("unexpected message: %#v", msg)
+	}
+	if len(repo.groups) != 2 || repo.groups[0].Name != "Manual" || repo.groups[1].Name != "New" {
+		t.Fatalf("manual group should be kept, got %#v", repo.groups)
+	}
+	if len(repo.groups[0].Feeds) != 1 || repo.groups[0].Feeds[0] != "https://a.example.com/rss" {
+		t.Fatalf("manual group feeds changed: %#v", repo.groups[0].Feeds)
+	}
+}
+
 func TestGenerateFeedGroupingCmd_Disabled(t *testing.T) {
-	cmd := GenerateFeedGroupingCmd(nil, nil, []string{"https://a.example.com/rss", "https://b.example.com/rss"})
+	cmd := GenerateFeedGroupingCmd(nil, nil, []string{"https://a.example.com/rss", "https://b.example.com/rss"}, nil, false)
 	raw := cmd()
 	msg, ok := raw.(FeedGroupingCompletedMsg)
 	if !ok {
@@ -154,6 +270,26 @@ func TestHandleFeedGroupingCompletedMsg(t *testing.T) {
 	}
 }
 
+func TestHandleFeedGroupingCompletedMsg_UnknownFeeds(t *testing.T) {
+	s := &state.ModelState{
+		FeedList: list.New(nil, list.NewDefaultDelegate(), 80, 20),
+	}
+
+	HandleFeedGroupingCompletedMsg(s, FeedGroupingCompletedMsg{
+		Feeds: []string{"https://news.ycombinator.com/rss", "https://planetpython.org/rss20.xml"},
+		Groups: []subscription.FeedGroup{
+			{Name: "Tech", Feeds: []string{"https://news.ycombinator.com/rss"}},
+		},
+		Ungrouped:    []string{"https://planetpython.org/rss20.xml"},
+		UnknownFeeds: 3,
+	})
+
+	want := "AI grouped 1 feeds into 1 groups (AI suggested 3 unknown feeds, ignored)"
+	if s.StatusMessage != want {
+		t.Fatalf("status = %q, want %q", s.StatusMessage, want)
+	}
+}
+
 func TestHandleFeedGroupingCompletedMsg_Error(t *testing.T) {
 	s := &state.ModelState{
 		FeedList: list.New(nil, list.NewDefaultDelegate(), 80, 20),
@@ -170,3 +306,55 @@ func TestHandleFeedGroupingCompletedMsg_Error(t *testing.T) {
 		t.Fatal("expected failure status message")
 	}
 }
+
+func TestUndoFeedGrouping(t *testing.T) {
+	manual := []subscription.FeedGroup{
+		{Name: "Manual", Feeds: []string{"https://news.ycombinator.com/rss"}},
+	}
+	repo := &updateSubscriptionRepo{
+		feeds:  []string{"https://planetpython.org/rss20.xml"},
+		groups: cloneFeedGroups(manual),
+	}
+	deps := Deps{Subscriptions: usecase.NewSubscriptionService(repo)}
+	s := &state.ModelState{
+		FeedList:   list.New(nil, list.NewDefaultDelegate(), 80, 20),
+		Feeds:      []string{"https://news.ycombinator.com/rss", "https://planetpython.org/rss20.xml"},
+		FeedGroups: cloneFeedGroups(manual),
+	}
+
+	aiGroups := []subscription.FeedGroup{
+		{Name: "AI", Feeds: []string{"https://news.ycombinator.com/rss", "https://planetpython.org/rss20.xml"}},
+	}
+	_ = repo.ReplaceFeedGroups(aiGroups, nil)
+	HandleFeedGroupingCompletedMsg(s, FeedGroupingCompletedMsg{
+		Feeds:  []string{"https://news.ycombinator.com/rss", "https://planetpython.org/rss20.xml"},
+		Groups: aiGroups,
+	})
+	if s.FeedGroupingUndo == nil {
+		t.Fatal("expected grouping snapshot to be recorded")
+	}
+
+	if _, handled := handleFeedViewIntent(s, intent.Intent{Type: intent.Undo}, deps); !handled {
+		t.Fatal("undo intent should be handled")
+	}
+	if s.StatusMessage != "grouping undone" {
+		t.Fatalf("status = %q, want grouping undone", s.StatusMessage)
+	}
+	if s.FeedGroupingUndo != nil {
+		t.Fatal("snapshot should be cleared after undo")
+	}
+	if len(s.FeedGroups) != 1 || s.FeedGroups[0].Name != "Manual" {
+		t.Fatalf("unexpected feed groups after undo: %#v", s.FeedGroups)
+	}
+	if len(repo.groups) != 1 || repo.groups[0].Name != "Manual" {
+		t.Fatalf("undo should re-persist groups, got %#v", repo.groups)
+	}
+	if len(repo.feeds) != 1 || repo.feeds[0] != "https://planetpython.org/rss20.xml" {
+		t.Fatalf("undo should re-persist ungrouped feeds, got %#v", repo.feeds)
+	}
+
+	handleFeedViewIntent(s, intent.Intent{Type: intent.Undo}, deps)
+	if s.StatusMessage != "Nothing to undo" {
+		t.Fatalf("status = %q, want Nothing to undo", s.StatusMessage)
+	}
+}
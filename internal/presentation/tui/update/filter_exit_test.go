@@ -0,0 +1,50 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+func newFilteringTestState(escExit bool) *state.ModelState {
+	s := newLayoutTestState()
+	s.Session = state.ArticleView
+	s.FilterExitEsc = escExit
+	s.ArticleList.SetItems([]list.Item{&presenter.Item{TitleText: "one"}})
+	s.ArticleList.SetFilterText("on")
+	s.ArticleList.SetFilterState(list.Filtering)
+	return s
+}
+
+func TestHandleFilterExit_JJ(t *testing.T) {
+	s := newFilteringTestState(false)
+	jKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}
+
+	if handleFilterExit(s, tea.KeyMsg{Type: tea.KeyEsc}) {
+		t.Fatal("esc should not exit filtering in jj mode")
+	}
+	if handleFilterExit(s, jKey) {
+		t.Fatal("first j should be typed into the filter")
+	}
+	if !handleFilterExit(s, jKey) || s.ArticleList.FilterState() != list.Unfiltered {
+		t.Fatalf("jj should clear the filter, state=%v", s.ArticleList.FilterState())
+	}
+}
+
+func TestHandleFilterExit_Esc(t *testing.T) {
+	s := newFilteringTestState(true)
+	jKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}
+
+	if handleFilterExit(s, jKey) || handleFilterExit(s, jKey) {
+		t.Fatal("jj should not exit filtering in esc mode")
+	}
+	if !handleFilterExit(s, tea.KeyMsg{Type: tea.KeyEsc}) || s.ArticleList.FilterState() != list.Unfiltered {
+		t.Fatalf("esc should clear the filter, state=%v", s.ArticleList.FilterState())
+	}
+	if s.Session != state.ArticleView {
+		t.Fatalf("esc should stay in the article view, got %v", s.Session)
+	}
+}
@@ -0,0 +1,91 @@
+package update
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/presentation/tui/intent"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// maxGotoFeedDigits bounds the typed feed number.
+const maxGotoFeedDigits = 4
+
+// startGotoFeed begins typing a feed number in the feed view.
+func startGotoFeed(s *state.ModelState) {
+	s.GotoFeeding = true
+	s.GotoFeedInput = ""
+	s.StatusMessage = "Go to feed: "
+}
+
+// handleGotoFeedKey handles typing a feed number after the goto-feed key:
+// digits select the sidebar entry with that "N." number as they are typed,
+// enter opens it, and back cancels. Any other key ends the mode and is
+// handled as usual. Number keys outside this mode keep jumping to sections.
+func handleGotoFeedKey(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.Cmd, bool) {
+	if !s.GotoFeeding {
+		return nil, false
+	}
+	switch msg.Type {
+	case tea.KeyEnter:
+		input := s.GotoFeedInput
+		stopGotoFeed(s)
+		if input == "" {
+			return nil, true
+		}
+		number, _ := strconv.Atoi(input)
+		if !selectFeedNumber(&s.FeedList, number) {
+			s.StatusMessage = fmt.Sprintf("No feed %d", number)
+			return nil, true
+		}
+		return handleFeedViewIntent(s, intent.Intent{Type: intent.Open}, deps)
+	case tea.KeyEsc:
+		stopGotoFeed(s)
+		return nil, true
+	case tea.KeyBackspace:
+		if input := s.GotoFeedInput; input != "" {
+			s.GotoFeedInput = input[:len(input)-1]
+		}
+		s.StatusMessage = "Go to feed: " + s.GotoFeedInput
+		return nil, true
+	case tea.KeyRunes:
+		if len(msg.Runes) == 1 && msg.Runes[0] >= '0' && msg.Runes[0] <= '9' {
+			if len(s.GotoFeedInput) < maxGotoFeedDigits {
+				s.GotoFeedInput += string(msg.Runes)
+			}
+			number, _ := strconv.Atoi(s.GotoFeedInput)
+			selectFeedNumber(&s.FeedList, number)
+			s.StatusMessage = "Go to feed: " + s.GotoFeedInput
+			return nil, true
+		}
+	}
+	stopGotoFeed(s)
+	return nil, false
+}
+
+func stopGotoFeed(s *state.ModelState) {
+	s.GotoFeeding = false
+	s.GotoFeedInput = ""
+	s.StatusMessage = ""
+}
+
+// selectFeedNumber selects the sidebar entry displayed as "number.": built-in
+// tabs and feeds are numbered in list order from zero, skipping group headers.
+func selectFeedNumber(model *list.Model, number int) bool {
+	current := 0
+	for index, listItem := range model.Items() {
+		item, ok := listItem.(*presenter.Item)
+		if !ok || item.IsSectionHeader() {
+			continue
+		}
+		if current == number {
+			model.Select(index)
+			return true
+		}
+		current++
+	}
+	return false
+}
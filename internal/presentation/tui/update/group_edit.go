@@ -0,0 +1,162 @@
+package update
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/domain/subscription"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// openGroupEdit asks for a group name for the feed selected on the Manage
+// Feeds screen, prefilled with its current group.
+func openGroupEdit(s *state.ModelState, kind state.GroupEditKind) tea.Cmd {
+	item, ok := selectedFeedItem(s)
+	if !ok || reading.IsVirtualFeedURL(item.Link) {
+		return nil
+	}
+	group := feedGroupName(s.FeedGroups, item.Link)
+	if kind == state.RenameFeedGroup && group == "" {
+		s.StatusMessage = fmt.Sprintf("%s is not in a group", feedLabel(item.Link))
+		return nil
+	}
+	s.GroupEdit = &state.GroupEdit{Kind: kind, FeedURL: item.Link, Group: group}
+	s.TextInput.Reset()
+	s.TextInput.SetValue(group)
+	s.TextInput.CursorEnd()
+	s.Session = state.GroupEditView
+	return textinput.Blink
+}
+
+// handleGroupEditView reads the group name and applies the move or rename,
+// returning to the Manage Feeds screen either way.
+func handleGroupEditView(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "enter":
+		edit := s.GroupEdit
+		name := strings.TrimSpace(s.TextInput.Value())
+		closeGroupEdit(s)
+		if edit != nil {
+			applyGroupEdit(s, deps, *edit, name)
+		}
+		return nil, true
+	case "esc":
+		closeGroupEdit(s)
+		return nil, true
+	}
+
+	var cmd tea.Cmd
+	s.TextInput, cmd = s.TextInput.Update(msg)
+	return cmd, true
+}
+
+func closeGroupEdit(s *state.ModelState) {
+	s.TextInput.Reset()
+	s.GroupEdit = nil
+	s.Session = state.ManageFeedsView
+}
+
+func applyGroupEdit(s *state.ModelState, deps Deps, edit state.GroupEdit, name string) {
+	var groups []subscription.FeedGroup
+	var status string
+	switch edit.Kind {
+	case state.MoveFeedToGroup:
+		if name == edit.Group {
+			return
+		}
+		groups = moveFeedToGroup(s.FeedGroups, edit.FeedURL, name)
+		status = fmt.Sprintf("Moved %s to %s", feedLabel(edit.FeedURL), name)
+		if name == "" {
+			status = fmt.Sprintf("Moved %s out of its group", feedLabel(edit.FeedURL))
+		}
+	case state.RenameFeedGroup:
+		if name == "" {
+			s.StatusMessage = "Group name can't be empty"
+			return
+		}
+		if name == edit.Group {
+			return
+		}
+		groups = renameFeedGroup(s.FeedGroups, edit.Group, name)
+		status = fmt.Sprintf("Renamed group %s to %s", edit.Group, name)
+	}
+	if deps.Subscriptions == nil {
+		s.Err = fmt.Errorf("subscription service is not configured")
+		return
+	}
+	feeds, supported, err := deps.Subscriptions.ReplaceFeedGroups(groups, ungroupedFeeds(s.Feeds, groups))
+	if err != nil {
+		s.Err = err
+		return
+	}
+	if !supported {
+		s.Err = fmt.Errorf("feed grouping persistence is not supported")
+		return
+	}
+	s.Feeds = feeds
+	if !syncFeedGroupsFromRepository(s, deps) {
+		s.FeedGroups = groups
+	}
+	// Undoing an earlier AI grouping would silently revert this edit.
+	s.FeedGroupingUndo = nil
+	applyFeedList(s)
+	UpdateListSizes(s)
+	s.StatusMessage = status
+}
+
+// moveFeedToGroup returns groups with feedURL taken out of its group and
+// added to the group named target, which is created when missing. An empty
+// target leaves the feed ungrouped. Groups left empty are dropped.
+func moveFeedToGroup(groups []subscription.FeedGroup, feedURL, target string) []subscription.FeedGroup {
+	moved := make([]subscription.FeedGroup, 0, len(groups)+1)
+	added := target == ""
+	for _, group := range groups {
+		feeds := slices.DeleteFunc(slices.Clone(group.Feeds), func(feed string) bool { return feed == feedURL })
+		if group.Name == target {
+			feeds = append(feeds, feedURL)
+			added = true
+		}
+		if len(feeds) > 0 {
+			moved = append(moved, subscription.FeedGroup{Name: group.Name, Feeds: feeds})
+		}
+	}
+	if !added {
+		moved = append(moved, subscription.FeedGroup{Name: target, Feeds: []string{feedURL}})
+	}
+	return moved
+}
+
+// renameFeedGroup returns groups with the group named from renamed to to.
+// Renaming onto an existing group merges the two.
+func renameFeedGroup(groups []subscription.FeedGroup, from, to string) []subscription.FeedGroup {
+	renamed := make([]subscription.FeedGroup, 0, len(groups))
+	var moving []string
+	for _, group := range groups {
+		if group.Name == from {
+			moving = slices.Clone(group.Feeds)
+			continue
+		}
+		renamed = append(renamed, subscription.FeedGroup{Name: group.Name, Feeds: slices.Clone(group.Feeds)})
+	}
+	if idx := slices.IndexFunc(renamed, func(group subscription.FeedGroup) bool { return group.Name == to }); idx >= 0 {
+		renamed[idx].Feeds = append(renamed[idx].Feeds, moving...)
+		return renamed
+	}
+	// Keep the renamed group where it was.
+	at := slices.IndexFunc(groups, func(group subscription.FeedGroup) bool { return group.Name == from })
+	at = min(max(at, 0), len(renamed))
+	return slices.Insert(renamed, at, subscription.FeedGroup{Name: to, Feeds: moving})
+}
+
+func feedGroupName(groups []subscription.FeedGroup, feedURL string) string {
+	for _, group := range groups {
+		if slices.Contains(group.Feeds, feedURL) {
+			return group.Name
+		}
+	}
+	return ""
+}
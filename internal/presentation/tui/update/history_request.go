@@ -0,0 +1,35 @@
+package update
+
+import (
+	"errors"
+
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// HistoryRequestMsg asks the update loop to run Apply on the TUI's history
+// for code outside it, such as the control API. Update tells whether Apply
+// may change history; the result goes to Reply, which must be buffered.
+type HistoryRequestMsg struct {
+	Apply  func(*reading.History) error
+	Update bool
+	Reply  chan<- error
+}
+
+// HandleHistoryRequestMsg runs a history request and, when it may have
+// changed history, rebuilds the lists so the change shows up.
+func HandleHistoryRequestMsg(s *state.ModelState, msg HistoryRequestMsg) {
+	if s.History == nil {
+		msg.Reply <- errors.New("history is not loaded")
+		return
+	}
+	err := msg.Apply(s.History)
+	msg.Reply <- err
+	if !msg.Update {
+		return
+	}
+	if s.Session == state.FeedView || s.Session == state.ArticleView {
+		refreshArticleListKeepingSelection(s)
+	}
+	applyFeedList(s)
+}
@@ -0,0 +1,91 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/domain/subscription"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// OPMLDownloadedMsg is emitted after downloading an OPML list entered in the
+// import dialog.
+type OPMLDownloadedMsg struct {
+	URL     string
+	Entries []subscription.OPMLFeed
+	Err     error
+}
+
+// DownloadOPMLCmd fetches the OPML list at url. Subscribing happens when
+// the result arrives, so the config is only written from the update loop.
+func DownloadOPMLCmd(ctx context.Context, subscriptions *usecase.SubscriptionService, url string) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := subscriptions.DownloadOPML(ctx, url)
+		return OPMLDownloadedMsg{URL: url, Entries: entries, Err: err}
+	}
+}
+
+// HandleOPMLDownloadedMsg imports the downloaded feeds and opens the import
+// summary, unless another dialog has been opened meanwhile.
+func HandleOPMLDownloadedMsg(s *state.ModelState, msg OPMLDownloadedMsg, deps Deps) {
+	if s.OPMLDownloadURL != msg.URL {
+		return
+	}
+	s.OPMLDownloadURL = ""
+	s.StatusMessage = ""
+	if msg.Err != nil {
+		s.Err = msg.Err
+		return
+	}
+	feeds, result, err := deps.Subscriptions.ImportOPMLFeeds(msg.Entries)
+	if !showFeedImport(s, deps, feeds, result, err) {
+		return
+	}
+	if s.Session != state.FeedView && s.Session != state.ArticleView {
+		s.FeedImport = nil
+		s.StatusMessage = fmt.Sprintf("Imported %d feeds from OPML", len(result.Added))
+		return
+	}
+	s.Session = state.ImportSummaryView
+}
+
+// startOPMLImport imports the OPML file at source, or starts downloading it
+// when source is a URL.
+func startOPMLImport(s *state.ModelState, deps Deps, source string) tea.Cmd {
+	if usecase.IsOPMLURL(source) {
+		url := strings.TrimSpace(source)
+		s.OPMLDownloadURL = url
+		s.StatusMessage = "Downloading OPML..."
+		return DownloadOPMLCmd(deps.fetchContext(), deps.Subscriptions, url)
+	}
+	feeds, result, err := deps.Subscriptions.ImportOPML(source)
+	if showFeedImport(s, deps, feeds, result, err) {
+		s.Session = state.ImportSummaryView
+	}
+	return nil
+}
+
+// showFeedImport applies a finished import to the sidebar and records its
+// summary, reporting false when the import failed.
+func showFeedImport(s *state.ModelState, deps Deps, feeds []string, result usecase.FeedImportResult, err error) bool {
+	if err != nil {
+		s.Err = err
+		return false
+	}
+	if len(result.Added) > 0 {
+		s.Feeds = feeds
+		syncFeedGroupsFromRepository(s, deps)
+		syncFeedTitlesFromRepository(s, deps)
+		applyFeedList(s)
+		UpdateListSizes(s)
+	}
+	s.FeedImport = &state.FeedImportSummary{
+		Added:      result.Added,
+		Duplicates: result.Duplicates,
+		Invalid:    result.Invalid,
+	}
+	return true
+}
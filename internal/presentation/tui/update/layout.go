@@ -1,7 +1,9 @@
 package update
 
 import (
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/tesso57/reazy/internal/presentation/tui/metrics"
 	"github.com/tesso57/reazy/internal/presentation/tui/state"
@@ -22,22 +24,63 @@ func UpdateListSizes(s *state.ModelState) {
 	layout := buildLayoutMetrics(s)
 	s.FeedList.SetSize(layout.sidebarWidth, layout.sidebarListHeight)
 	s.ArticleList.SetSize(layout.mainWidth, layout.mainListHeight)
+	previousWidth := s.Viewport.Width
 	s.Viewport.Width = clampMin(layout.mainWidth-1, 1) // main view has left padding of 1
 	s.Viewport.Height = layout.mainListHeight
+	// Rewrap the article when the reading pane changes width, e.g. when the
+	// sidebar is hidden or shown again.
+	if s.Session == state.DetailView && s.Viewport.Width != previousWidth && s.AskAnswer == "" {
+		if item, ok := selectedActionableArticleItem(s); ok {
+			refreshDetailViewport(s, item)
+		}
+	}
+}
+
+// DetailFullScreen reports whether the detail view currently hides the
+// sidebar, either in focus mode or to keep the reading pane at least
+// MinReadingWidth wide.
+func DetailFullScreen(s *state.ModelState) bool {
+	return s.Session == state.DetailView && (s.FocusMode || metrics.DetailFullScreen(s.Width, s.MinReadingWidth))
+}
+
+// FocusLayout reports whether the detail view shows the article alone,
+// without sidebar, header or footer.
+func FocusLayout(s *state.ModelState) bool {
+	return s.FocusMode && s.Session == state.DetailView
+}
+
+// FooterHidden reports whether focus mode leaves out the footer. It comes
+// back while an in-article search is typed or shown, so the prompt and its
+// matches stay visible.
+func FooterHidden(s *state.ModelState) bool {
+	return FocusLayout(s) && !s.DetailSearching && s.DetailSearchQuery == ""
 }
 
 func buildLayoutMetrics(s *state.ModelState) layoutMetrics {
 	footerHeight := footerHeight(s)
 	availableHeight := clampMin(s.Height-footerHeight, 1)
 
-	mainListHeight := clampMin(availableHeight-metrics.HeaderLines, 1)
+	sidebarWidth := metrics.SidebarWidth(s.Width)
+	mainWidth := clampMin(metrics.MainWidth(s.Width), 1)
+	if DetailFullScreen(s) {
+		mainWidth = s.Width
+	}
+
+	headerLines := metrics.HeaderLines
+	switch {
+	case FocusLayout(s):
+		headerLines = 0
+	case s.Session == state.DetailView:
+		headerLines = metrics.DetailHeaderLines(mainWidth - metrics.HeaderWidthPadding)
+	}
+	mainListHeight := clampMin(availableHeight-headerLines, 1)
 	sidebarListHeight := clampMin(availableHeight-metrics.SidebarTitleLines, 1)
 	if s.Session == state.NewsTopicView {
 		mainListHeight = clampMin(mainListHeight-metrics.NewsTopicSummaryLines, 1)
 	}
-
-	sidebarWidth := s.Width / 3
-	mainWidth := clampMin(s.Width-sidebarWidth-metrics.SidebarRightBorderWidth, 1)
+	if s.ExpandedGUID != "" && (s.Session == state.ArticleView || s.Session == state.NewsTopicView) {
+		mainListHeight = clampMin(mainListHeight-metrics.ExpandedRowLines(s.ExpandRowLines), 1)
+	}
 
 	sidebarListHeight = reservePaginationSpace(s.FeedList, sidebarListHeight)
 	mainListHeight = reservePaginationSpace(s.ArticleList, mainListHeight)
@@ -51,6 +94,9 @@ func buildLayoutMetrics(s *state.ModelState) layoutMetrics {
 }
 
 func footerHeight(s *state.ModelState) int {
+	if FooterHidden(s) {
+		return 0
+	}
 	s.Help.Width = s.Width
 	helpText := state.FooterHelpText(s.Help, s.Keys)
 	return lipgloss.Height(state.FooterText(s.Session, s.Loading, s.AIStatus, s.StatusMessage, helpText))
@@ -80,6 +126,45 @@ func reservePaginationSpace(m list.Model, height int) int {
 	return height
 }
 
+// handlePageSizeJump moves the article cursor by exactly PageSize items on
+// the page keys, so page boundaries no longer depend on terminal height.
+// The list scrolls within a page that is taller than the screen.
+func handlePageSizeJump(s *state.ModelState, msg tea.KeyMsg) bool {
+	if s.PageSize <= 0 || (s.Session != state.ArticleView && s.Session != state.NewsTopicView) {
+		return false
+	}
+	if s.ArticleList.FilterState() == list.Filtering {
+		return false
+	}
+	var direction int
+	switch {
+	case key.Matches(msg, s.Keys.DownPage):
+		direction = 1
+	case key.Matches(msg, s.Keys.UpPage):
+		direction = -1
+	default:
+		return false
+	}
+	count := len(s.ArticleList.VisibleItems())
+	if count == 0 {
+		return true
+	}
+	s.ArticleList.Select(pageSizeTarget(s.ArticleList.Index(), count, s.PageSize, direction))
+	return true
+}
+
+// pageSizeTarget returns the first index of the adjacent page of pageSize
+// items, clamped to the list. Paging up from inside a page returns to its
+// start first.
+func pageSizeTarget(index, count, pageSize, direction int) int {
+	start := index / pageSize * pageSize
+	target := start + direction*pageSize
+	if direction < 0 && index > start {
+		target = start
+	}
+	return min(max(target, 0), count-1)
+}
+
 func clampMin(value, min int) int {
 	if value < min {
 		return min
@@ -5,8 +5,10 @@ import (
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/tesso57/reazy/internal/application/settings"
 	"github.com/tesso57/reazy/internal/presentation/tui/metrics"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
 	"github.com/tesso57/reazy/internal/presentation/tui/state"
 )
 
@@ -42,6 +44,70 @@ func TestBuildLayoutMetrics_MainWidthSubtractsSidebarBorder(t *testing.T) {
 	}
 }
 
+func TestUpdateListSizes_PageSizeKeepsHeight(t *testing.T) {
+	items := make([]list.Item, 0, 50)
+	for range 50 {
+		items = append(items, &presenter.Item{TitleText: "item"})
+	}
+
+	s := newLayoutTestState()
+	s.ArticleList.SetItems(items)
+	UpdateListSizes(s)
+	height := s.ArticleList.Height()
+
+	s.PageSize = 5
+	UpdateListSizes(s)
+	if s.ArticleList.Height() != height {
+		t.Fatalf("list height = %d, want %d (page size must not shrink the list)", s.ArticleList.Height(), height)
+	}
+}
+
+func TestHandlePageSizeJump(t *testing.T) {
+	items := make([]list.Item, 0, 12)
+	for range 12 {
+		items = append(items, &presenter.Item{TitleText: "item"})
+	}
+
+	s := newLayoutTestState()
+	s.Session = state.ArticleView
+	s.ArticleList.SetItems(items)
+	UpdateListSizes(s)
+	pgDown := tea.KeyMsg{Type: tea.KeyPgDown}
+	pgUp := tea.KeyMsg{Type: tea.KeyPgUp}
+
+	if handlePageSizeJump(s, pgDown) {
+		t.Fatal("page keys should fall through to the list without page_size")
+	}
+
+	s.PageSize = 5
+	steps := []struct {
+		msg  tea.KeyMsg
+		want int
+	}{
+		{pgDown, 5},
+		{pgDown, 10},
+		{pgDown, 11},
+		{pgUp, 10},
+		{pgUp, 5},
+		{pgUp, 0},
+		{pgUp, 0},
+	}
+	for i, step := range steps {
+		if !handlePageSizeJump(s, step.msg) {
+			t.Fatalf("step %d: page key not handled", i)
+		}
+		if got := s.ArticleList.Index(); got != step.want {
+			t.Fatalf("step %d: index = %d, want %d", i, got, step.want)
+		}
+	}
+
+	s.ArticleList.Select(7)
+	handlePageSizeJump(s, pgUp)
+	if got := s.ArticleList.Index(); got != 5 {
+		t.Fatalf("page up from inside a page: index = %d, want 5", got)
+	}
+}
+
 func newLayoutTestState() *state.ModelState {
 	keys := state.NewKeyMap(settings.KeyMapConfig{
 		Up: "k", Down: "j", Left: "h", Right: "l",
@@ -0,0 +1,90 @@
+package update
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// loadingCheckInterval is how often the loading watchdog looks at Loading.
+const loadingCheckInterval = time.Second
+
+// Loading tasks name the operation behind the spinner in the watchdog's
+// timeout message.
+const (
+	LoadingFeeds     = "feed fetch"
+	loadingGroup     = "group refresh"
+	loadingDigest    = "daily news"
+	loadingSummary   = "AI summary"
+	loadingSummaries = "AI summaries"
+	loadingGrouping  = "AI grouping"
+	loadingAnswer    = "AI answer"
+	loadingArticle   = "article load"
+	loadingFullText  = "full-text fetch"
+)
+
+// LoadingTickMsg asks the loading watchdog to check how long the spinner has
+// been running.
+type LoadingTickMsg struct {
+	At time.Time
+}
+
+// LoadingTickCmd schedules the next loading check.
+func LoadingTickCmd() tea.Cmd {
+	return tea.Tick(loadingCheckInterval, func(at time.Time) tea.Msg { return LoadingTickMsg{At: at} })
+}
+
+// BeginLoading starts the spinner for task. Each call starts a new loading
+// generation, so the watchdog times it afresh even if an earlier task never
+// cleared Loading.
+func BeginLoading(s *state.ModelState, task string) {
+	s.Loading = true
+	s.LoadingTask = task
+	s.LoadingGeneration++
+}
+
+// HandleLoadingTickMsg stops the spinner once one loading generation has
+// lasted LoadingTimeout, so a lost message or hung command can't leave the
+// UI loading forever, and schedules the next check. A generation is timed
+// from the first check that sees it.
+func HandleLoadingTickMsg(s *state.ModelState, msg LoadingTickMsg) tea.Cmd {
+	switch {
+	case !s.Loading || s.LoadingTimeout <= 0:
+		s.LoadingSince = time.Time{}
+	case s.LoadingSince.IsZero() || s.LoadingTimedGeneration != s.LoadingGeneration:
+		s.LoadingSince = msg.At
+		s.LoadingTimedGeneration = s.LoadingGeneration
+	case msg.At.Sub(s.LoadingSince) >= s.LoadingTimeout:
+		timeOutLoading(s)
+	}
+	return LoadingTickCmd()
+}
+
+// timeOutLoading clears every loading state and reports which operation
+// timed out, with a retry hint when a key in the current view repeats it.
+// A result that still arrives later is applied as usual.
+func timeOutLoading(s *state.ModelState) {
+	task := s.LoadingTask
+	if task == "" {
+		task = "loading"
+	}
+	s.Err = fmt.Errorf("%s timed out", task)
+	switch {
+	case task == LoadingFeeds && s.Session == state.ArticleView:
+		s.Err = fmt.Errorf("%s timed out; press %s to retry", task, s.Keys.Refresh.Help().Key)
+	case task == loadingGroup:
+		s.Err = fmt.Errorf("%s timed out; press %s to retry", task, s.Keys.RefreshGroup.Help().Key)
+	}
+	s.Loading = false
+	s.LoadingSince = time.Time{}
+	s.FetchProgress = nil
+	s.RefreshAllPending = false
+	s.RefreshGroupPending = ""
+	if s.SummaryBatch != nil {
+		s.SummaryBatch.Cancel()
+		s.SummaryBatch = nil
+	}
+	s.PendingInsightGUID = ""
+}
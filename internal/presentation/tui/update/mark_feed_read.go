@@ -0,0 +1,71 @@
+package update
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// markFeedReadConfirmThreshold is the unread count from which marking a feed
+// read asks for confirmation first.
+const markFeedReadConfirmThreshold = 20
+
+// startMarkFeedRead marks every article of the feed selected in the sidebar
+// read, asking first when it has many unread articles.
+func startMarkFeedRead(s *state.ModelState, deps Deps) {
+	item, ok := selectedFeedItem(s)
+	if !ok || reading.IsVirtualFeedURL(item.Link) {
+		s.StatusMessage = "Select a feed to mark it read"
+		return
+	}
+	unread := s.History.UnreadCountByFeed()[item.Link]
+	if unread == 0 {
+		s.StatusMessage = fmt.Sprintf("%s: no unread articles", feedLabel(item.Link))
+		return
+	}
+	if unread < markFeedReadConfirmThreshold {
+		markFeedRead(s, deps, item.Link)
+		return
+	}
+	s.MarkReadFeedURL = item.Link
+	s.MarkReadFeedCount = unread
+	s.Previous = s.Session
+	s.Session = state.MarkFeedReadView
+}
+
+func handleMarkFeedReadView(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "y", "Y":
+		markFeedRead(s, deps, s.MarkReadFeedURL)
+	case "n", "N", "esc", "q", "Q":
+	default:
+		return nil, true
+	}
+	s.MarkReadFeedURL = ""
+	s.MarkReadFeedCount = 0
+	s.Session = s.Previous
+	return nil, true
+}
+
+func markFeedRead(s *state.ModelState, deps Deps, feedURL string) {
+	marked, err := deps.Reading.MarkFeedRead(s.History, feedURL)
+	if err != nil {
+		s.Err = err
+	}
+	if marked == 0 {
+		return
+	}
+	if marked == 1 {
+		s.StatusMessage = fmt.Sprintf("%s: marked 1 article read", feedLabel(feedURL))
+	} else {
+		s.StatusMessage = fmt.Sprintf("%s: marked %d articles read", feedLabel(feedURL), marked)
+	}
+	if feedListUsesUnreadCounts(s) {
+		applyFeedList(s)
+	} else {
+		RefreshFeedHeaders(s)
+	}
+	refreshArticleListKeepingSelection(s)
+}
@@ -0,0 +1,84 @@
+package update
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// navHistoryLimit caps how many opened articles back/forward remembers.
+const navHistoryLimit = 100
+
+// pushNavHistory records guid as the article now shown. Like a browser, it
+// drops the entries ahead of the current one.
+func pushNavHistory(s *state.ModelState, guid string) {
+	keep := 0
+	if len(s.NavHistory) > 0 {
+		if s.NavHistory[s.NavIndex] == guid {
+			return
+		}
+		keep = s.NavIndex + 1
+	}
+	s.NavHistory = append(s.NavHistory[:keep], guid)
+	if overflow := len(s.NavHistory) - navHistoryLimit; overflow > 0 {
+		s.NavHistory = slices.Delete(s.NavHistory, 0, overflow)
+	}
+	s.NavIndex = len(s.NavHistory) - 1
+}
+
+// navigateOpenedArticles shows the article opened before (step -1) or after
+// (step 1) the current one. Articles that can no longer be listed are
+// forgotten and skipped.
+func navigateOpenedArticles(s *state.ModelState, deps Deps, step int) tea.Cmd {
+	target := s.NavIndex + step
+	for target >= 0 && target < len(s.NavHistory) {
+		if cmd, ok := showNavArticle(s, deps, s.NavHistory[target]); ok {
+			s.NavIndex = target
+			return cmd
+		}
+		s.NavHistory = slices.Delete(s.NavHistory, target, target+1)
+		if target < s.NavIndex {
+			s.NavIndex--
+		}
+		if step < 0 {
+			target--
+		}
+	}
+	if step < 0 {
+		s.StatusMessage = "No earlier opened article"
+	} else {
+		s.StatusMessage = "No later opened article"
+	}
+	return nil
+}
+
+// showNavArticle opens guid in the detail view, switching the article list
+// to its feed when the current list doesn't have it.
+func showNavArticle(s *state.ModelState, deps Deps, guid string) (tea.Cmd, bool) {
+	parent := s.DetailParentSession
+	s.ArticleList.ResetFilter()
+	if _, ok := listedArticle(s, guid); !ok {
+		item, ok := s.History.Item(guid)
+		if !ok || item == nil || item.IsHidden() || item.FeedURL == "" {
+			return nil, false
+		}
+		s.CurrentFeed = &reading.Feed{URL: item.FeedURL, Title: item.FeedTitle}
+		ApplyArticleList(s, item.FeedURL)
+		if _, ok := listedArticle(s, guid); !ok {
+			return nil, false
+		}
+		parent = state.ArticleView
+	}
+	selectArticleItemByGUID(&s.ArticleList, guid)
+	selected, ok := selectedActionableArticleItem(s)
+	if !ok {
+		return nil, false
+	}
+	clearDetailSearch(s)
+	s.LastOpenedGUID = guid
+	presenter.MarkLastOpened(&s.ArticleList, guid)
+	return openArticleDetail(s, selected, deps, parent), true
+}
@@ -0,0 +1,71 @@
+package update
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/tesso57/reazy/internal/application/usecase"
+)
+
+// newItemsStatusFeeds caps how many feeds the new items status names.
+const newItemsStatusFeeds = 3
+
+// newItemsStatusMessage lists the feeds a refresh added articles to, busiest
+// first, e.g. "example.com: 4 new, blog.example.jp: 1 new".
+func newItemsStatusMessage(result usecase.MergeResult) string {
+	type feedCount struct {
+		label string
+		count int
+	}
+	var counts []feedCount
+	for feedURL, n := range result.NewByFeed() {
+		counts = append(counts, feedCount{label: feedLabel(feedURL), count: n})
+	}
+	if len(counts) == 0 {
+		return ""
+	}
+	slices.SortFunc(counts, func(a, b feedCount) int {
+		if c := cmp.Compare(b.count, a.count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.label, b.label)
+	})
+
+	parts := make([]string, 0, newItemsStatusFeeds+1)
+	for i, fc := range counts {
+		if i == newItemsStatusFeeds {
+			rest := len(counts) - i
+			if rest == 1 {
+				parts = append(parts, "+1 more feed")
+			} else {
+				parts = append(parts, fmt.Sprintf("+%d more feeds", rest))
+			}
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s: %d new", fc.label, fc.count))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// feedLabel shortens a feed URL to its host for status messages.
+func feedLabel(feedURL string) string {
+	parsed, err := url.Parse(feedURL)
+	if err != nil || parsed.Hostname() == "" {
+		return feedURL
+	}
+	return strings.TrimPrefix(parsed.Hostname(), "www.")
+}
+
+// JoinStatus combines the non-empty status messages into one line.
+func JoinStatus(messages ...string) string {
+	parts := make([]string, 0, len(messages))
+	for _, m := range messages {
+		if m != "" {
+			parts = append(parts, m)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,56 @@
+package update
+
+import (
+	"fmt"
+
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// restoreNewsSelection reselects the remembered News topic after the News
+// list is rebuilt. It only applies while the latest listed digest is the one
+// the topic came from: a digest of a new day, or one regenerated with new
+// topics, keeps the first topic selected.
+func restoreNewsSelection(s *state.ModelState, feedURL string) {
+	if s == nil || !s.RememberNewsSelection || feedURL != reading.NewsURL || s.NewsShowsToday {
+		return
+	}
+	if s.NewsSelectionGUID == "" || latestNewsDigestDate(s) != s.NewsSelectionDate {
+		return
+	}
+	selectArticleItemByGUID(&s.ArticleList, s.NewsSelectionGUID)
+}
+
+// latestNewsDigestDate returns the digest day of the first News topic listed.
+func latestNewsDigestDate(s *state.ModelState) string {
+	for _, listItem := range s.ArticleList.Items() {
+		item, ok := listItem.(*presenter.Item)
+		if !ok || item == nil || item.IsSectionHeader() {
+			continue
+		}
+		return item.DateKey
+	}
+	return ""
+}
+
+// rememberNewsSelection records the opened News topic and saves it so the
+// next launch can reselect it.
+func rememberNewsSelection(s *state.ModelState, item *presenter.Item, deps Deps) {
+	if !s.RememberNewsSelection || item == nil {
+		return
+	}
+	if s.NewsSelectionDate == item.DateKey && s.NewsSelectionGUID == item.GUID {
+		return
+	}
+	s.NewsSelectionDate = item.DateKey
+	s.NewsSelectionGUID = item.GUID
+	if deps.Subscriptions == nil {
+		return
+	}
+	selection := usecase.NewsSelection{DateKey: item.DateKey, GUID: item.GUID}
+	if _, err := deps.Subscriptions.SaveNewsSelection(selection); err != nil {
+		s.StatusMessage = fmt.Sprintf("Failed to save News selection: %v", err)
+	}
+}
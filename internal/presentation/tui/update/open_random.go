@@ -0,0 +1,71 @@
+package update
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// openRandomUnread opens a randomly picked unread article from the article
+// list: the feed selected in the sidebar, or the feed being read, limited to
+// the articles an active filter shows. The pick opens the way Enter does.
+func openRandomUnread(s *state.ModelState, deps Deps) tea.Cmd {
+	parent := state.ArticleView
+	switch s.Session {
+	case state.FeedView:
+		item, ok := s.FeedList.SelectedItem().(*presenter.Item)
+		if !ok || item.IsSectionHeader() {
+			return nil
+		}
+		if s.CurrentFeed == nil || s.CurrentFeed.URL != item.Link {
+			// A filter typed for another feed's articles does not carry over,
+			// as when opening the feed with Enter.
+			s.ArticleList.ResetFilter()
+			s.CurrentFeed = &reading.Feed{URL: item.Link, Title: item.TitleText}
+			ApplyArticleList(s, item.Link)
+		}
+	case state.DetailView:
+		parent = s.DetailParentSession
+	}
+
+	var unread []int
+	visible := s.ArticleList.VisibleItems()
+	for index, listItem := range visible {
+		item, ok := listItem.(*presenter.Item)
+		if ok && !item.IsSectionHeader() && !item.IsNewsDigest() && !item.Read {
+			unread = append(unread, index)
+		}
+	}
+	if len(unread) == 0 {
+		s.StatusMessage = "No unread articles to pick from"
+		return nil
+	}
+	intN := rand.IntN
+	if deps.Rand != nil {
+		intN = deps.Rand.IntN
+	}
+	index := unread[intN(len(unread))]
+	picked := visible[index].(*presenter.Item)
+
+	s.ArticleList.Select(index)
+	clearDetailSearch(s)
+	markArticleOpened(s, picked, deps)
+	s.StatusMessage = fmt.Sprintf("Random pick from %s", randomPickSource(picked))
+	if s.OpenInBrowser {
+		_ = deps.OpenBrowser(picked.Link)
+		return nil
+	}
+	return openArticleDetail(s, picked, deps, parent)
+}
+
+// randomPickSource names the feed a random pick came from.
+func randomPickSource(item *presenter.Item) string {
+	if item.FeedTitleText != "" {
+		return item.FeedTitleText
+	}
+	return feedLabel(item.FeedURL)
+}
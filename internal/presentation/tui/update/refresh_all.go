@@ -0,0 +1,45 @@
+package update
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// startRefreshAll refetches every subscription with the bulk refresh overlay
+// and, once the articles are merged, builds today's News digest from them.
+// The digest step is skipped when AI is not available.
+func startRefreshAll(s *state.ModelState, deps Deps) tea.Cmd {
+	if len(s.Feeds) == 0 {
+		s.StatusMessage = "No feeds to refresh"
+		return nil
+	}
+	BeginLoading(s, LoadingFeeds)
+	s.RefreshAllPending = deps.NewsDigests.Enabled()
+	cmd := startBulkRefresh(s, deps)
+	if s.RefreshAllPending {
+		s.FetchProgress.FollowUp = "then daily news"
+	}
+	return cmd
+}
+
+// continueRefreshAll starts the digest step of a refresh-all after the feeds
+// came back. A fetch that failed outright ends the workflow.
+func continueRefreshAll(s *state.ModelState, msg FeedFetchedMsg, deps Deps) tea.Cmd {
+	s.RefreshAllPending = false
+	if msg.Feed == nil && msg.Err != nil {
+		return nil
+	}
+	BeginLoading(s, loadingDigest)
+	s.AIStatus = "AI: generating daily news..."
+	return tea.Batch(
+		s.Spinner.Tick,
+		trackSave(deps, GenerateDailyNewsDigestCmd(deps.NewsDigests, deps.Reading, s.History, s.Feeds, false)),
+	)
+}
+
+// isRefreshAllResult reports whether msg completes the feed step of a
+// refresh-all.
+func isRefreshAllResult(s *state.ModelState, msg FeedFetchedMsg) bool {
+	return s.RefreshAllPending && msg.URL == reading.AllFeedsURL
+}
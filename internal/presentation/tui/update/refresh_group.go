@@ -0,0 +1,72 @@
+package update
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// startRefreshGroup refetches every feed in the group of the feed selected
+// in the sidebar with the bulk refresh overlay, so the group stays fresh
+// without fetching every subscription.
+func startRefreshGroup(s *state.ModelState, deps Deps) tea.Cmd {
+	item, ok := selectedFeedItem(s)
+	if !ok || reading.IsVirtualFeedURL(item.Link) {
+		s.StatusMessage = "Select a feed to refresh its group"
+		return nil
+	}
+	feeds := groupFeeds(s, item.GroupName)
+	if len(feeds) == 0 {
+		s.StatusMessage = fmt.Sprintf("%s is not in a group", feedLabel(item.Link))
+		return nil
+	}
+	BeginLoading(s, loadingGroup)
+	s.RefreshGroupPending = item.GroupName
+	cmd := startBulkFetch(s, deps, feeds)
+	s.FetchProgress.Group = item.GroupName
+	return cmd
+}
+
+// finishRefreshGroup ends a group refresh and relists the selected feed so
+// its new articles show up.
+func finishRefreshGroup(s *state.ModelState, msg FeedFetchedMsg) {
+	group := s.RefreshGroupPending
+	s.RefreshGroupPending = ""
+	s.Loading = false
+	if msg.Feed == nil && msg.Err != nil {
+		s.Err = msg.Err
+		return
+	}
+	item, ok := selectedFeedItem(s)
+	if !ok || !slices.Contains(groupFeeds(s, group), item.Link) {
+		return
+	}
+	selected := ""
+	if i, ok := selectedActionableArticleItem(s); ok {
+		selected = i.GUID
+	}
+	ApplyArticleList(s, item.Link)
+	selectArticleItemByGUID(&s.ArticleList, selected)
+	UpdateListSizes(s)
+}
+
+// isRefreshGroupResult reports whether msg completes a group refresh.
+func isRefreshGroupResult(s *state.ModelState, msg FeedFetchedMsg) bool {
+	return s.RefreshGroupPending != "" && msg.URL == reading.AllFeedsURL && !s.RefreshAllPending
+}
+
+// groupFeeds returns the feeds of the named group.
+func groupFeeds(s *state.ModelState, name string) []string {
+	if name == "" {
+		return nil
+	}
+	for _, group := range s.FeedGroups {
+		if group.Name == name {
+			return group.Feeds
+		}
+	}
+	return nil
+}
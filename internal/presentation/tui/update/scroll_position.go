@@ -0,0 +1,47 @@
+package update
+
+import (
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// saveDetailScroll remembers how far the article in the reading pane was
+// scrolled, so reopening it later continues from there.
+func saveDetailScroll(s *state.ModelState, deps Deps) {
+	guid := s.DetailGUID
+	s.DetailGUID = ""
+	if guid == "" || s.AskAnswer != "" || deps.Reading == nil {
+		return
+	}
+	if err := deps.Reading.SaveScrollOffset(s.History, guid, s.Viewport.YOffset); err != nil {
+		s.Err = err
+	}
+}
+
+// restoreDetailScroll positions freshly rendered detail content. Re-rendering
+// the same article keeps the current offset; another article starts where it
+// was last left, or at the top. The saved offset is only applied once the
+// full body is loaded, since the excerpt shown meanwhile is shorter.
+func restoreDetailScroll(s *state.ModelState, item *presenter.Item) {
+	if item == nil {
+		s.DetailGUID = ""
+		s.Viewport.GotoTop()
+		return
+	}
+	if item.GUID == s.DetailGUID {
+		s.Viewport.SetYOffset(s.Viewport.YOffset)
+		return
+	}
+	s.Viewport.GotoTop()
+	if !item.BodyHydrated {
+		s.DetailGUID = ""
+		return
+	}
+	s.DetailGUID = item.GUID
+	if s.History == nil {
+		return
+	}
+	if saved, ok := s.History.Item(item.GUID); ok && saved != nil {
+		s.Viewport.SetYOffset(saved.ScrollOffset)
+	}
+}
@@ -0,0 +1,46 @@
+package update
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// trackSave registers cmd as a background history write that quitting waits
+// for.
+func trackSave(deps Deps, cmd tea.Cmd) tea.Cmd {
+	if deps.Saves == nil || cmd == nil {
+		return cmd
+	}
+	deps.Saves.Add(1)
+	return func() tea.Msg {
+		defer deps.Saves.Done()
+		return cmd()
+	}
+}
+
+// shutdownCmd waits up to timeout for tracked background saves and the new
+// article commands they started, closes the history store and quits. Work
+// still running after the timeout is dropped.
+func shutdownCmd(deps Deps, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		if deps.Saves != nil && timeout > 0 {
+			done := make(chan struct{})
+			go func() {
+				deps.Saves.Wait()
+				if deps.Reading != nil {
+					deps.Reading.WaitNewItemHook()
+				}
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(timeout):
+			}
+		}
+		if deps.Reading != nil {
+			_ = deps.Reading.Close()
+		}
+		return tea.Quit()
+	}
+}
@@ -0,0 +1,105 @@
+package update
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// snoozeCheckInterval is how often snoozed articles are checked for waking.
+const snoozeCheckInterval = time.Minute
+
+// SnoozeTickMsg asks to bring back articles whose snooze has ended.
+type SnoozeTickMsg struct{}
+
+// SnoozeTickCmd schedules the next snooze check.
+func SnoozeTickCmd() tea.Cmd {
+	return tea.Tick(snoozeCheckInterval, func(time.Time) tea.Msg { return SnoozeTickMsg{} })
+}
+
+// HandleSnoozeTickMsg brings back articles whose snooze has ended, refreshes
+// the lists showing them, and schedules the next check.
+func HandleSnoozeTickMsg(s *state.ModelState, deps Deps) tea.Cmd {
+	woken, err := deps.Reading.WakeSnoozed(s.History)
+	if err != nil {
+		s.Err = err
+	}
+	if woken > 0 {
+		refreshArticleListKeepingSelection(s)
+		if feedListUsesUnreadCounts(s) {
+			applyFeedList(s)
+		} else {
+			RefreshFeedHeaders(s)
+		}
+		s.StatusMessage = fmt.Sprintf("Snoozed articles are back (%d)", woken)
+	}
+	return SnoozeTickCmd()
+}
+
+// openSnoozeDialog asks how long to snooze the selected article.
+func openSnoozeDialog(s *state.ModelState) {
+	item, ok := selectedActionableArticleItem(s)
+	if !ok || item.IsNewsDigest() {
+		return
+	}
+	s.SnoozeGUID = item.GUID
+	s.Previous = s.Session
+	s.Session = state.SnoozeView
+}
+
+func handleSnoozeView(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.Cmd, bool) {
+	var preset usecase.SnoozePreset
+	switch msg.String() {
+	case "1":
+		preset = usecase.SnoozeLaterToday
+	case "2":
+		preset = usecase.SnoozeTomorrow
+	case "3":
+		preset = usecase.SnoozeNextWeek
+	case "esc", "n", "N", "q", "Q":
+		s.SnoozeGUID = ""
+		s.Session = s.Previous
+		return nil, true
+	default:
+		return nil, true
+	}
+
+	until, err := deps.Reading.SnoozeFor(s.History, s.SnoozeGUID, preset)
+	if err != nil {
+		s.Err = err
+	} else {
+		s.StatusMessage = "Snoozed until " + until.Format("Mon 15:04")
+	}
+	s.SnoozeGUID = ""
+	s.Session = s.Previous
+	refreshArticleListKeepingSelection(s)
+	RefreshFeedHeaders(s)
+	return nil, true
+}
+
+// refreshArticleListKeepingSelection rebuilds the open article list after
+// articles were hidden or shown, keeping the cursor near where it was.
+func refreshArticleListKeepingSelection(s *state.ModelState) {
+	if s.CurrentFeed == nil || s.Session == state.NewsTopicView {
+		return
+	}
+	index := s.ArticleList.Index()
+	ApplyArticleList(s, s.CurrentFeed.URL)
+	items := s.ArticleList.Items()
+	first, last := selectableBounds(items)
+	if first < 0 {
+		return
+	}
+	index = min(max(index, first), last)
+	for index < last {
+		if item, ok := items[index].(*presenter.Item); !ok || !item.IsSectionHeader() {
+			break
+		}
+		index++
+	}
+	s.ArticleList.Select(index)
+}
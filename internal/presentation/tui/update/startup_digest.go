@@ -0,0 +1,23 @@
+package update
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// StartupNewsDigestCmd builds today's digest in the background so the News
+// tab is ready when opened. A digest already made today is reused. It
+// returns nil when AI is disabled.
+func StartupNewsDigestCmd(s *state.ModelState, deps Deps) tea.Cmd {
+	if !deps.NewsDigests.Enabled() || deps.Reading == nil {
+		return nil
+	}
+	s.StartupDigestPending = true
+	s.AIStatus = "AI: generating daily news..."
+	generate := GenerateDailyNewsDigestCmd(deps.NewsDigests, deps.Reading, s.History, s.Feeds, false)
+	return trackSave(deps, func() tea.Msg {
+		msg := generate().(NewsDigestGeneratedMsg)
+		msg.Startup = true
+		return msg
+	})
+}
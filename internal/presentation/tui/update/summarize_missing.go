@@ -0,0 +1,150 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/usecase"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// SummarizeArticleCmd generates the insight for one article of a summary
+// batch, loading its body first when the list only holds metadata.
+func SummarizeArticleCmd(ctx context.Context, insightSvc *usecase.InsightService, readingSvc *usecase.ReadingService, item presenter.Item, sanitizer *reading.ContentSanitizer, fullText bool) tea.Cmd {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return func() tea.Msg {
+		msg := InsightGeneratedMsg{GUID: item.GUID, Batch: true}
+		if !item.BodyHydrated {
+			loaded, err := readingSvc.LoadHistoryItem(item.GUID)
+			if err != nil {
+				msg.Err = err
+				return msg
+			}
+			if fullText {
+				// A failed extraction still leaves the feed's excerpt to summarize.
+				_, _ = readingSvc.HydrateFullText(ctx, loaded)
+			}
+			item.Desc = loaded.Description
+			item.Content = loaded.Content
+			msg.Hydrated = loaded
+		}
+		msg.Insight, msg.Err = insightSvc.Generate(ctx, buildInsightRequest(&item, sanitizer))
+		return msg
+	}
+}
+
+// startSummarizeMissing queues every listed article without an AI summary and
+// summarizes them one after another, reporting progress in the AI status.
+func startSummarizeMissing(s *state.ModelState, deps Deps) tea.Cmd {
+	if s.SummaryBatch != nil {
+		return nil
+	}
+	if !deps.Insights.Enabled() {
+		s.AIStatus = "AI: codex integration is disabled"
+		return nil
+	}
+	var queue []string
+	for _, listItem := range s.ArticleList.VisibleItems() {
+		item, ok := listItem.(*presenter.Item)
+		if !ok || item.IsSectionHeader() || item.IsNewsDigest() {
+			continue
+		}
+		if strings.TrimSpace(item.AISummary) != "" {
+			continue
+		}
+		queue = append(queue, item.GUID)
+	}
+	if len(queue) == 0 {
+		s.AIStatus = "AI: every listed article already has a summary"
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(deps.fetchContext())
+	s.SummaryBatch = &state.SummaryBatch{Queue: queue, Total: len(queue), Ctx: ctx, Cancel: cancel}
+	s.Loading = true
+	s.Err = nil
+	return tea.Batch(s.Spinner.Tick, nextSummaryInBatch(s, deps))
+}
+
+// nextSummaryInBatch starts the next queued article, skipping ones that left
+// the list or gained a summary meanwhile, and finishes the batch when the
+// queue is empty.
+func nextSummaryInBatch(s *state.ModelState, deps Deps) tea.Cmd {
+	batch := s.SummaryBatch
+	for len(batch.Queue) > 0 {
+		guid := batch.Queue[0]
+		batch.Queue = batch.Queue[1:]
+		item, ok := listedArticle(s, guid)
+		if !ok || strings.TrimSpace(item.AISummary) != "" {
+			batch.Done++
+			continue
+		}
+		// Each article is timed on its own so a long batch is not cut short.
+		BeginLoading(s, loadingSummaries)
+		s.AIStatus = fmt.Sprintf("AI: summarizing %d/%d...", batch.Done+1, batch.Total)
+		return trackSave(deps, SummarizeArticleCmd(batch.Ctx, deps.Insights, deps.Reading, *item, s.ContentSanitizer, s.FullTextFeeds[item.FeedURL]))
+	}
+
+	batch.Cancel()
+	s.SummaryBatch = nil
+	s.Loading = false
+	s.AIStatus = fmt.Sprintf("AI: summarized %d/%d articles", batch.Total-batch.Failed, batch.Total)
+	if batch.Failed > 0 {
+		s.AIStatus += fmt.Sprintf(" (%d failed)", batch.Failed)
+	}
+	return nil
+}
+
+// cancelSummaryBatch stops a running batch with the Back key: the article in
+// flight is abandoned and the rest of the queue is dropped. Summaries saved
+// so far are kept.
+func cancelSummaryBatch(s *state.ModelState) {
+	batch := s.SummaryBatch
+	batch.Cancel()
+	s.SummaryBatch = nil
+	s.Loading = false
+	s.LoadingSince = time.Time{}
+	s.AIStatus = fmt.Sprintf("AI: summary batch cancelled after %d/%d articles", batch.Done, batch.Total)
+}
+
+// handleSummaryBatchResult records one batch result and moves on to the next
+// queued article.
+func handleSummaryBatchResult(s *state.ModelState, msg InsightGeneratedMsg, deps Deps) tea.Cmd {
+	defer UpdateListSizes(s)
+	if msg.Hydrated != nil {
+		msg.Hydrated.BodyHydrated = true
+		s.History.UpsertItem(msg.Hydrated)
+		applyHydratedItemToList(&s.ArticleList, msg.Hydrated)
+	}
+	failed := msg.Err != nil
+	if !failed {
+		_, err := applyInsight(s, msg.GUID, msg.Insight, deps)
+		failed = err != nil
+	}
+	if s.SummaryBatch == nil {
+		// The batch was cancelled while this article was in flight.
+		return nil
+	}
+	s.SummaryBatch.Done++
+	if failed {
+		s.SummaryBatch.Failed++
+	}
+	return nextSummaryInBatch(s, deps)
+}
+
+func listedArticle(s *state.ModelState, guid string) (*presenter.Item, bool) {
+	for _, listItem := range s.ArticleList.Items() {
+		item, ok := listItem.(*presenter.Item)
+		if ok && item.GUID == guid && !item.IsSectionHeader() {
+			return item, true
+		}
+	}
+	return nil, false
+}
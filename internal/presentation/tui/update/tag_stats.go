@@ -0,0 +1,73 @@
+package update
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/domain/reading"
+	"github.com/tesso57/reazy/internal/presentation/tui/state"
+)
+
+// tagStatsLimit is how many of the most common AI tags the stats view lists.
+const tagStatsLimit = 10
+
+// openTagStats shows the most common AI tags across the reading history.
+func openTagStats(s *state.ModelState) {
+	s.TagStats = topTags(s.History.TagCounts(), tagStatsLimit)
+	s.Previous = s.Session
+	s.Session = state.TagStatsView
+}
+
+// topTags orders tags by article count, then name, keeping the first limit.
+func topTags(counts map[string]int, limit int) []state.TagCount {
+	tags := make([]state.TagCount, 0, len(counts))
+	for tag, count := range counts {
+		tags = append(tags, state.TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+	if len(tags) > limit {
+		tags = tags[:limit]
+	}
+	return tags
+}
+
+func handleTagStatsView(s *state.ModelState, msg tea.KeyMsg, _ Deps) (tea.Cmd, bool) {
+	keyText := msg.String()
+	switch {
+	case len(keyText) == 1 && keyText[0] >= '0' && keyText[0] <= '9':
+		index := int(keyText[0]-'0') - 1
+		if keyText == "0" {
+			index = 9
+		}
+		if index >= len(s.TagStats) {
+			return nil, true
+		}
+		browseTag(s, s.TagStats[index].Tag)
+		return nil, true
+	case keyText == "esc" || keyText == "q" || keyText == "Q" || keyText == "n" || keyText == "N":
+		s.TagStats = nil
+		s.Session = s.Previous
+		return nil, true
+	}
+	return nil, true
+}
+
+// browseTag opens All Feeds filtered to the articles carrying tag.
+func browseTag(s *state.ModelState, tag string) {
+	s.TagStats = nil
+	s.CurrentFeed = &reading.Feed{URL: reading.AllFeedsURL, Title: "All Feeds"}
+	s.Session = state.ArticleView
+	ApplyArticleList(s, reading.AllFeedsURL)
+	UpdateListSizes(s)
+	// Filter terms split on spaces and tag: matches a prefix, so the first
+	// word of a multi-word tag is enough.
+	s.ArticleList.SetFilterText("tag:" + strings.Fields(tag)[0])
+	s.StatusMessage = fmt.Sprintf("Articles tagged %s", tag)
+}
@@ -3,19 +3,27 @@ package update
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tesso57/reazy/internal/application/settings"
 	"github.com/tesso57/reazy/internal/application/usecase"
 	"github.com/tesso57/reazy/internal/domain/reading"
 	"github.com/tesso57/reazy/internal/domain/subscription"
 	"github.com/tesso57/reazy/internal/presentation/tui/intent"
 	"github.com/tesso57/reazy/internal/presentation/tui/presenter"
 	"github.com/tesso57/reazy/internal/presentation/tui/state"
+	"github.com/tesso57/reazy/internal/presentation/tui/textutil"
 )
 
 // Deps groups external dependencies for updates.
@@ -26,6 +34,14 @@ type Deps struct {
 	NewsDigests   *usecase.NewsDigestService
 	FeedGrouping  *usecase.FeedGroupingService
 	OpenBrowser   func(string) error
+	// Context scopes in-flight fetches; CancelFetches cancels it on quit.
+	Context       context.Context
+	CancelFetches context.CancelFunc
+	// Saves tracks background commands that write history, so quitting can
+	// wait for them.
+	Saves *sync.WaitGroup
+	// Rand picks random articles; nil uses the global source. Tests seed it.
+	Rand *rand.Rand
 }
 
 // FeedFetchedMsg is emitted after fetching feeds.
@@ -36,11 +52,23 @@ type FeedFetchedMsg struct {
 	URL    string
 }
 
+// FeedValidatedMsg is emitted after fetching a URL entered in the add feed
+// dialog to check that it is a feed.
+type FeedValidatedMsg struct {
+	URL  string
+	Feed *reading.Feed
+	Err  error
+}
+
 // InsightGeneratedMsg is emitted after generating AI insight for an article.
 type InsightGeneratedMsg struct {
 	GUID    string
 	Insight usecase.Insight
 	Err     error
+	// Batch marks results of a summarize-missing run; see SummarizeArticleCmd.
+	Batch bool
+	// Hydrated is the article body loaded to build the request, if any.
+	Hydrated *reading.HistoryItem
 }
 
 // ArticleDetailLoadedMsg is emitted after loading one hydrated history item.
@@ -49,6 +77,11 @@ type ArticleDetailLoadedMsg struct {
 	Item   *reading.HistoryItem
 	Err    error
 	Silent bool
+	// FullTextErr reports a failed full-text extraction; Item still holds
+	// the feed's excerpt.
+	FullTextErr error
+	// FullTextFetched is set when Item's content was just extracted on demand.
+	FullTextFetched bool
 }
 
 // NewsDigestGeneratedMsg is emitted after generating daily news digest topics.
@@ -56,28 +89,88 @@ type NewsDigestGeneratedMsg struct {
 	DateKey   string
 	Items     []*reading.HistoryItem
 	UsedCache bool
+	Note      string
 	Force     bool
-	Err       error
+	// Startup marks the background digest started at launch.
+	Startup bool
+	Err     error
 }
 
 // FeedGroupingCompletedMsg is emitted after AI feed grouping is applied.
 type FeedGroupingCompletedMsg struct {
-	Feeds     []string
-	Groups    []subscription.FeedGroup
-	Ungrouped []string
-	Err       error
+	Feeds        []string
+	Groups       []subscription.FeedGroup
+	Ungrouped    []string
+	UsedCache    bool
+	UsedFallback bool
+	Heuristic    bool
+	UnknownFeeds int
+	Err          error
+}
+
+// FetchProgressMsg is emitted each time one feed of a bulk refresh completes.
+type FetchProgressMsg struct {
+	Progress usecase.FeedFetchProgress
+	Updates  <-chan usecase.FeedFetchProgress
+}
+
+func (d Deps) fetchContext() context.Context {
+	if d.Context == nil {
+		return context.Background()
+	}
+	return d.Context
 }
 
 // FetchFeedCmd creates a command to fetch feeds using the reading service.
-func FetchFeedCmd(readingSvc *usecase.ReadingService, url string, feeds []string) tea.Cmd {
+func FetchFeedCmd(ctx context.Context, readingSvc *usecase.ReadingService, url string, feeds []string) tea.Cmd {
+	allFeeds := append([]string(nil), feeds...)
+	trimmed := strings.TrimSpace(url)
+	return func() tea.Msg {
+		f, report, err := readingSvc.FetchFeed(ctx, trimmed, allFeeds)
+		return FeedFetchedMsg{Feed: f, Report: report, Err: err, URL: trimmed}
+	}
+}
+
+// ValidateFeedCmd fetches url once to check that it serves a feed before
+// it is subscribed.
+func ValidateFeedCmd(ctx context.Context, readingSvc *usecase.ReadingService, url string) tea.Cmd {
+	return func() tea.Msg {
+		f, _, err := readingSvc.FetchFeed(ctx, url, nil)
+		if err == nil && f == nil {
+			err = errors.New("no feed found")
+		}
+		return FeedValidatedMsg{URL: url, Feed: f, Err: err}
+	}
+}
+
+// FetchFeedWithProgressCmd fetches like FetchFeedCmd while streaming per-feed
+// completion into updates, which is closed once the fetch finishes.
+func FetchFeedWithProgressCmd(ctx context.Context, readingSvc *usecase.ReadingService, url string, feeds []string, updates chan<- usecase.FeedFetchProgress) tea.Cmd {
 	allFeeds := append([]string(nil), feeds...)
 	trimmed := strings.TrimSpace(url)
 	return func() tea.Msg {
-		f, report, err := readingSvc.FetchFeed(trimmed, allFeeds)
+		defer close(updates)
+		f, report, err := readingSvc.FetchFeedWithProgress(ctx, trimmed, allFeeds, func(progress usecase.FeedFetchProgress) {
+			select {
+			case updates <- progress:
+			default:
+			}
+		})
 		return FeedFetchedMsg{Feed: f, Report: report, Err: err, URL: trimmed}
 	}
 }
 
+// WaitForFetchProgressCmd waits for the next per-feed completion event.
+func WaitForFetchProgressCmd(updates <-chan usecase.FeedFetchProgress) tea.Cmd {
+	return func() tea.Msg {
+		progress, ok := <-updates
+		if !ok {
+			return nil
+		}
+		return FetchProgressMsg{Progress: progress, Updates: updates}
+	}
+}
+
 // GenerateInsightCmd creates a command to generate AI summary/tags for one article.
 func GenerateInsightCmd(insightSvc *usecase.InsightService, guid string, req usecase.InsightRequest) tea.Cmd {
 	return func() tea.Msg {
@@ -92,7 +185,7 @@ func GenerateInsightCmd(insightSvc *usecase.InsightService, guid string, req use
 
 // GenerateDailyNewsDigestCmd creates a command to build today's digest topics.
 func GenerateDailyNewsDigestCmd(newsSvc *usecase.NewsDigestService, readingSvc *usecase.ReadingService, history *reading.History, feeds []string, force bool) tea.Cmd {
-	historySnapshot := cloneHistoryForDigest(history)
+	historySnapshot := history.Clone()
 	feedSnapshot := append([]string(nil), feeds...)
 	return func() tea.Msg {
 		if newsSvc == nil {
@@ -124,6 +217,7 @@ func GenerateDailyNewsDigestCmd(newsSvc *usecase.NewsDigestService, readingSvc *
 			DateKey:   digest.DateKey,
 			Items:     digest.Items,
 			UsedCache: digest.UsedCache,
+			Note:      digest.Note,
 			Force:     force,
 			Err:       err,
 		}
@@ -131,8 +225,11 @@ func GenerateDailyNewsDigestCmd(newsSvc *usecase.NewsDigestService, readingSvc *
 }
 
 // GenerateFeedGroupingCmd creates a command to group feeds by AI and persist config.
-func GenerateFeedGroupingCmd(groupingSvc *usecase.FeedGroupingService, subscriptions *usecase.SubscriptionService, feeds []string) tea.Cmd {
+// Non-empty keepGroups are preserved and only the remaining feeds are grouped.
+// force ignores the cached grouping and asks the generator again.
+func GenerateFeedGroupingCmd(groupingSvc *usecase.FeedGroupingService, subscriptions *usecase.SubscriptionService, feeds []string, keepGroups []subscription.FeedGroup, force bool) tea.Cmd {
 	feedSnapshot := append([]string(nil), feeds...)
+	keepSnapshot := cloneFeedGroups(keepGroups)
 	return func() tea.Msg {
 		if groupingSvc == nil {
 			return FeedGroupingCompletedMsg{Err: fmt.Errorf("codex integration is disabled")}
@@ -141,12 +238,19 @@ func GenerateFeedGroupingCmd(groupingSvc *usecase.FeedGroupingService, subscript
 			return FeedGroupingCompletedMsg{Err: fmt.Errorf("subscription service is not configured")}
 		}
 
-		result, err := groupingSvc.Group(context.Background(), feedSnapshot)
+		var cache usecase.FeedGroupingCache
+		if !force {
+			var err error
+			if cache, _, err = subscriptions.LoadFeedGroupingCache(); err != nil {
+				return FeedGroupingCompletedMsg{Err: err}
+			}
+		}
+		result, err := groupingSvc.GroupWithCache(context.Background(), feedSnapshot, keepSnapshot, cache)
 		if err != nil {
 			return FeedGroupingCompletedMsg{Err: err}
 		}
 
-		updatedFeeds, supported, err := subscriptions.ReplaceFeedGroups(result.Groups, result.Ungrouped)
+		updatedFeeds, supported, err := subscriptions.ApplyFeedGrouping(result)
 		if err != nil {
 			return FeedGroupingCompletedMsg{Err: err}
 		}
@@ -162,42 +266,55 @@ func GenerateFeedGroupingCmd(groupingSvc *usecase.FeedGroupingService, subscript
 		}
 
 		return FeedGroupingCompletedMsg{
-			Feeds:     updatedFeeds,
-			Groups:    persistedGroups,
-			Ungrouped: result.Ungrouped,
+			Feeds:        updatedFeeds,
+			Groups:       persistedGroups,
+			Ungrouped:    result.Ungrouped,
+			UsedCache:    result.UsedCache,
+			UsedFallback: result.UsedFallback,
+			Heuristic:    result.Heuristic,
+			UnknownFeeds: result.UnknownFeeds,
 		}
 	}
 }
 
-// LoadArticleDetailCmd loads one article body from persistence.
-func LoadArticleDetailCmd(readingSvc *usecase.ReadingService, guid string, silent bool) tea.Cmd {
+// LoadArticleDetailCmd loads one article body from persistence. With
+// fullText, excerpt-only articles are replaced by their extracted page text.
+func LoadArticleDetailCmd(ctx context.Context, readingSvc *usecase.ReadingService, guid string, silent, fullText bool) tea.Cmd {
 	guid = strings.TrimSpace(guid)
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	return func() tea.Msg {
 		item, err := readingSvc.LoadHistoryItem(guid)
-		return ArticleDetailLoadedMsg{
+		msg := ArticleDetailLoadedMsg{
 			GUID:   guid,
 			Item:   item,
 			Err:    err,
 			Silent: silent,
 		}
+		if err == nil && fullText {
+			_, msg.FullTextErr = readingSvc.HydrateFullText(ctx, item)
+		}
+		return msg
 	}
 }
 
-func cloneHistoryForDigest(history *reading.History) *reading.History {
-	if history == nil {
-		return reading.NewHistory(nil)
+// FetchFullTextCmd extracts one article's page text on demand (reader mode),
+// regardless of the feed's full-text preference.
+func FetchFullTextCmd(ctx context.Context, readingSvc *usecase.ReadingService, guid string) tea.Cmd {
+	guid = strings.TrimSpace(guid)
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	cloned := make(map[string]*reading.HistoryItem, len(history.Items()))
-	for guid, item := range history.Items() {
-		if item == nil {
-			continue
+	return func() tea.Msg {
+		item, err := readingSvc.LoadHistoryItem(guid)
+		msg := ArticleDetailLoadedMsg{GUID: guid, Item: item, Err: err}
+		if err == nil {
+			msg.FullTextErr = readingSvc.FetchFullText(ctx, item)
+			msg.FullTextFetched = msg.FullTextErr == nil
 		}
-		copyItem := *item
-		copyItem.AITags = append([]string(nil), item.AITags...)
-		copyItem.RelatedGUIDs = append([]string(nil), item.RelatedGUIDs...)
-		cloned[guid] = &copyItem
+		return msg
 	}
-	return reading.NewHistory(cloned)
 }
 
 // HandleKeyMsg processes key input based on the current session.
@@ -206,20 +323,81 @@ func HandleKeyMsg(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.Cmd, bool
 		return handleAddingFeedView(s, msg, deps)
 	}
 	if s.Session == state.QuitView {
-		return handleQuitView(s, msg)
+		return handleQuitView(s, msg, deps)
 	}
 	if s.Session == state.DeleteFeedView {
 		return handleDeleteFeedView(s, msg, deps)
 	}
-	if handleFilterExitWithJJ(s, msg) {
+	if s.Session == state.ClearHistoryView {
+		return handleClearHistoryView(s, msg, deps)
+	}
+	if s.Session == state.MoveFeedView {
+		return handleMoveFeedView(s, msg, deps)
+	}
+	if s.Session == state.GroupEditView {
+		return handleGroupEditView(s, msg, deps)
+	}
+	if s.Session == state.ImportFeedsView {
+		return handleImportFeedsView(s, msg, deps)
+	}
+	if s.Session == state.ImportSummaryView {
+		return handleImportSummaryView(s, msg, deps)
+	}
+	if s.Session == state.SnoozeView {
+		return handleSnoozeView(s, msg, deps)
+	}
+	if s.Session == state.AskAIView {
+		return handleAskAIView(s, msg, deps)
+	}
+	if s.Session == state.MarkFeedReadView {
+		return handleMarkFeedReadView(s, msg, deps)
+	}
+	if s.Session == state.TagStatsView {
+		return handleTagStatsView(s, msg, deps)
+	}
+	if s.SummaryBatch != nil && key.Matches(msg, s.Keys.Back) {
+		cancelSummaryBatch(s)
+		return nil, true
+	}
+	if s.FetchProgress != nil && key.Matches(msg, s.Keys.Back) {
+		// Hide the refresh overlay; the fetch keeps running in the background.
+		s.FetchProgress = nil
+		return nil, true
+	}
+	if s.Session == state.DetailView && handleDetailSearchKey(s, msg) {
+		return nil, true
+	}
+	if s.Session == state.DetailView && handleAnswerPanelKey(s, msg) {
+		return nil, true
+	}
+	if s.Session == state.DetailView && handleDetailJumpKey(s, msg) {
+		return nil, true
+	}
+	if s.Session == state.FeedView {
+		if cmd, handled := handleGotoFeedKey(s, msg, deps); handled {
+			return cmd, true
+		}
+	}
+	if handleFilterExit(s, msg) {
 		return nil, true
 	}
 	if handleSectionJump(s, msg) {
 		return nil, true
 	}
+	if handlePageSizeJump(s, msg) {
+		return nil, true
+	}
 
 	parsed := intent.FromKeyMsg(msg, s.Keys)
+	if filteringActiveList(s) && (isTypedText(msg) || (parsed.Type != intent.Open && parsed.Type != intent.Back)) {
+		// Keys typed into a list filter are search text; only confirming the
+		// selection or going back reaches the view's actions.
+		return nil, false
+	}
 	if parsed.Type == intent.Quit {
+		if s.Session == state.DetailView {
+			saveDetailScroll(s, deps)
+		}
 		s.Previous = s.Session
 		s.Session = state.QuitView
 		return nil, true
@@ -234,17 +412,28 @@ func HandleKeyMsg(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.Cmd, bool
 		return handleNewsTopicViewIntent(s, parsed, deps)
 	case state.DetailView:
 		return handleDetailViewIntent(s, parsed, deps)
+	case state.ManageFeedsView:
+		return handleManageFeedsViewIntent(s, parsed)
 	default:
 		return nil, false
 	}
 }
 
-func handleFilterExitWithJJ(s *state.ModelState, msg tea.KeyMsg) bool {
+// handleFilterExit clears an active list filter with the configured exit:
+// the Back key, or "jj" typed into the filter.
+func handleFilterExit(s *state.ModelState, msg tea.KeyMsg) bool {
 	activeList, ok := activeListForFiltering(s)
 	if !ok || activeList.FilterState() != list.Filtering {
 		s.PendingJJExit = false
 		return false
 	}
+	if s.FilterExitEsc {
+		if !key.Matches(msg, s.Keys.Back) {
+			return false
+		}
+		activeList.ResetFilter()
+		return true
+	}
 	if msg.String() != "j" {
 		s.PendingJJExit = false
 		return false
@@ -258,6 +447,18 @@ func handleFilterExitWithJJ(s *state.ModelState, msg tea.KeyMsg) bool {
 	return false
 }
 
+// filteringActiveList reports whether a filter is being typed into the list
+// of the current view.
+func filteringActiveList(s *state.ModelState) bool {
+	activeList, ok := activeListForFiltering(s)
+	return ok && activeList.FilterState() == list.Filtering
+}
+
+// isTypedText reports whether msg enters text, as opposed to a control key.
+func isTypedText(msg tea.KeyMsg) bool {
+	return msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace
+}
+
 func activeListForFiltering(s *state.ModelState) (*list.Model, bool) {
 	switch s.Session {
 	case state.FeedView:
@@ -392,6 +593,51 @@ func selectFirstItemInSection(model *list.Model, headerIndex int) {
 	model.Select(targetIndex)
 }
 
+// WrapListNavigation moves the selection to the opposite end of the list when
+// the cursor keys are pressed at a boundary and wrap-around is enabled.
+// It reports whether the key was consumed.
+func WrapListNavigation(s *state.ModelState, model *list.Model, msg tea.Msg) bool {
+	if s == nil || model == nil || !s.WrapListNavigation || model.FilterState() == list.Filtering {
+		return false
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false
+	}
+
+	items := model.VisibleItems()
+	first, last := selectableBounds(items)
+	if first < 0 {
+		return false
+	}
+
+	index := model.Index()
+	switch {
+	case key.Matches(keyMsg, model.KeyMap.CursorDown) && index >= last:
+		model.Select(first)
+		return true
+	case key.Matches(keyMsg, model.KeyMap.CursorUp) && index <= first:
+		model.Select(last)
+		return true
+	default:
+		return false
+	}
+}
+
+func selectableBounds(items []list.Item) (int, int) {
+	first, last := -1, -1
+	for index, item := range items {
+		if feedItem, ok := item.(*presenter.Item); ok && feedItem != nil && feedItem.IsSectionHeader() {
+			continue
+		}
+		if first < 0 {
+			first = index
+		}
+		last = index
+	}
+	return first, last
+}
+
 // HandleWindowSize updates layout sizing based on terminal size.
 func HandleWindowSize(s *state.ModelState, msg tea.WindowSizeMsg) {
 	s.Width = msg.Width
@@ -400,15 +646,42 @@ func HandleWindowSize(s *state.ModelState, msg tea.WindowSizeMsg) {
 	UpdateListSizes(s)
 }
 
-// HandleFeedFetchedMsg merges history and updates lists if applicable.
+// HandleFeedFetchedMsg merges history and updates lists if applicable. When
+// the fetch is the first step of a refresh-all, the News digest follows.
 func HandleFeedFetchedMsg(s *state.ModelState, msg FeedFetchedMsg, deps Deps) tea.Cmd {
+	cmd := applyFetchedFeed(s, msg, deps)
+	if isRefreshAllResult(s, msg) {
+		return tea.Batch(cmd, continueRefreshAll(s, msg, deps))
+	}
+	if isRefreshGroupResult(s, msg) {
+		finishRefreshGroup(s, msg)
+	}
+	return cmd
+}
+
+func applyFetchedFeed(s *state.ModelState, msg FeedFetchedMsg, deps Deps) tea.Cmd {
+	recordFeedFetchStatus(s, msg, time.Now())
+	if msg.URL == reading.AllFeedsURL {
+		s.FetchProgress = nil
+	}
 	if msg.Err == nil {
 		s.Loading = false
-		if err := deps.Reading.MergeHistory(s.History, msg.Feed); err != nil {
+		merged, err := deps.Reading.MergeHistory(s.History, msg.Feed)
+		if err != nil {
 			s.Err = err
 		}
-		s.StatusMessage = feedFetchStatusMessage(msg.Report)
+		s.StatusMessage = JoinStatus(feedFetchStatusMessage(msg.Report), newItemsStatusMessage(merged))
+		// A fetch never changes read state, so only added articles move the
+		// unread counts the sidebar is filtered or sorted by.
+		if len(merged.Added) > 0 {
+			if feedListUsesUnreadCounts(s) {
+				applyFeedList(s)
+			} else {
+				RefreshFeedHeaders(s)
+			}
+		}
 	}
+	handleFeedMoves(s, msg.Report.Moved, deps)
 
 	currentURL := ""
 	if i, ok := s.FeedList.SelectedItem().(*presenter.Item); ok {
@@ -422,34 +695,230 @@ func HandleFeedFetchedMsg(s *state.ModelState, msg FeedFetchedMsg, deps Deps) te
 			s.Session = state.FeedView
 			return nil
 		}
-		s.CurrentFeed = msg.Feed
-		presenter.ApplyArticleList(&s.ArticleList, s.History, msg.URL)
+		if msg.Feed != nil {
+			s.CurrentFeed = msg.Feed
+		}
+		ApplyArticleList(s, msg.URL)
 		UpdateListSizes(s)
+		if msg.URL == reading.NewsURL && s.NewsShowsToday {
+			s.ForceNewsDigestRefresh = false
+			s.AIStatus = "AI: disabled, showing today's articles"
+			return nil
+		}
 		if msg.URL == reading.NewsURL {
 			force := s.ForceNewsDigestRefresh
 			s.ForceNewsDigestRefresh = false
-			s.Loading = true
+			BeginLoading(s, loadingDigest)
 			s.Err = nil
 			s.AIStatus = "AI: generating daily news..."
+			if s.StartupDigestPending && !force {
+				return s.Spinner.Tick
+			}
 			return tea.Batch(
 				s.Spinner.Tick,
-				GenerateDailyNewsDigestCmd(deps.NewsDigests, deps.Reading, s.History, s.Feeds, force),
+				trackSave(deps, GenerateDailyNewsDigestCmd(deps.NewsDigests, deps.Reading, s.History, s.Feeds, force)),
 			)
 		}
 	}
 	return nil
 }
 
+// handleFeedMoves follows permanent redirects reported by a fetch. Moves are
+// applied right away when configured; otherwise they are queued and the user
+// is asked to confirm each one.
+func handleFeedMoves(s *state.ModelState, moved map[string]string, deps Deps) {
+	for _, from := range slices.Sorted(maps.Keys(moved)) {
+		move := state.FeedMove{From: from, To: moved[from]}
+		if !slices.Contains(s.Feeds, move.From) || slices.Contains(s.PendingFeedMoves, move) {
+			continue
+		}
+		if s.FollowPermanentRedirects {
+			applyFeedMove(s, deps, move)
+			continue
+		}
+		s.PendingFeedMoves = append(s.PendingFeedMoves, move)
+	}
+	if len(s.PendingFeedMoves) == 0 {
+		return
+	}
+	switch s.Session {
+	case state.FeedView, state.ArticleView, state.NewsTopicView, state.DetailView, state.ManageFeedsView:
+		s.Previous = s.Session
+		s.Session = state.MoveFeedView
+	}
+}
+
+// handleMoveFeedView confirms or skips the first queued feed move and moves
+// on to the next one.
+func handleMoveFeedView(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.Cmd, bool) {
+	if len(s.PendingFeedMoves) == 0 {
+		s.Session = s.Previous
+		return nil, true
+	}
+	switch msg.String() {
+	case "y", "Y":
+		applyFeedMove(s, deps, s.PendingFeedMoves[0])
+	case "n", "N", "esc", "q", "Q":
+	default:
+		return nil, true
+	}
+	s.PendingFeedMoves = s.PendingFeedMoves[1:]
+	if len(s.PendingFeedMoves) == 0 {
+		s.PendingFeedMoves = nil
+		s.Session = s.Previous
+	}
+	return nil, true
+}
+
+func applyFeedMove(s *state.ModelState, deps Deps, move state.FeedMove) {
+	if deps.Subscriptions == nil {
+		s.Err = fmt.Errorf("subscription service is not configured")
+		return
+	}
+	feeds, supported, err := deps.Subscriptions.UpdateFeedURL(move.From, move.To)
+	if err != nil {
+		s.Err = err
+		return
+	}
+	if !supported {
+		s.StatusMessage = "Updating feed URLs is not supported"
+		return
+	}
+	s.Feeds = feeds
+	if !syncFeedGroupsFromRepository(s, deps) {
+		renameFeedInGroupState(s, move.From, move.To)
+	}
+	if deps.Reading != nil {
+		if err := deps.Reading.MoveFeed(s.History, move.From, move.To); err != nil {
+			s.Err = fmt.Errorf("move feed articles: %w", err)
+		}
+	}
+	if status, ok := s.FeedFetchStatus[move.From]; ok {
+		delete(s.FeedFetchStatus, move.From)
+		s.FeedFetchStatus[move.To] = status
+	}
+	if title, ok := s.FeedTitles[move.From]; ok {
+		delete(s.FeedTitles, move.From)
+		s.FeedTitles[move.To] = title
+	}
+	if s.FullTextFeeds[move.From] {
+		delete(s.FullTextFeeds, move.From)
+		s.FullTextFeeds[move.To] = true
+	}
+	applyFeedList(s)
+	UpdateListSizes(s)
+	s.StatusMessage = fmt.Sprintf("Feed moved: %s → %s", move.From, move.To)
+}
+
+// feedListUsesUnreadCounts reports whether the sidebar depends on unread
+// counts and so must be rebuilt when they change.
+func feedListUsesUnreadCounts(s *state.ModelState) bool {
+	return s.UnreadFeedsOnly || s.GroupSort == settings.GroupSortUnreadDesc
+}
+
+// applyFeedList rebuilds the sidebar in the configured group order, limited
+// to feeds with unread articles when the unread-only mode is on.
+func applyFeedList(s *state.ModelState) {
+	var unread map[string]int
+	if s.History != nil {
+		unread = s.History.UnreadCountByFeed()
+	}
+	var keep func(feedURL string) bool
+	if s.UnreadFeedsOnly {
+		keep = func(feedURL string) bool { return unread[feedURL] > 0 }
+	}
+	presenter.ApplyFilteredFeedList(&s.FeedList, s.Feeds, s.FeedGroups, s.BuiltinTabs, keep, s.GroupSort, unread, s.FeedTitles)
+	RefreshFeedHeaders(s)
+}
+
+// RefreshFeedHeaders recomputes the newest article date and, when previews
+// are on, the newest unread title per feed shown in the feed view header.
+// Call it after articles are added, hidden or marked read.
+func RefreshFeedHeaders(s *state.ModelState) {
+	s.FeedLatestDates = nil
+	s.FeedPreviews = nil
+	if s.History == nil {
+		return
+	}
+	s.FeedLatestDates = s.History.LatestItemDateByFeed()
+	if !s.FeedPreview {
+		return
+	}
+	newest := s.History.NewestUnreadItemByFeed()
+	s.FeedPreviews = make(map[string]string, len(newest))
+	for feedURL, item := range newest {
+		s.FeedPreviews[feedURL] = item.Title
+	}
+}
+
+func renameFeedInGroupState(s *state.ModelState, oldURL, newURL string) {
+	for groupIndex := range s.FeedGroups {
+		feeds := s.FeedGroups[groupIndex].Feeds
+		if idx := slices.Index(feeds, oldURL); idx >= 0 {
+			feeds[idx] = newURL
+			return
+		}
+	}
+}
+
+// HandleFetchProgressMsg marks one feed of the bulk refresh overlay as done
+// and keeps listening for the rest.
+func HandleFetchProgressMsg(s *state.ModelState, msg FetchProgressMsg) tea.Cmd {
+	if p := s.FetchProgress; p != nil {
+		if _, tracked := p.States[msg.Progress.URL]; tracked {
+			switch {
+			case msg.Progress.TimedOut:
+				p.States[msg.Progress.URL] = state.FeedProgressFailed
+				p.Errors[msg.Progress.URL] = "timed out"
+			case msg.Progress.Err != nil:
+				p.States[msg.Progress.URL] = state.FeedProgressFailed
+				p.Errors[msg.Progress.URL] = msg.Progress.Err.Error()
+			default:
+				p.States[msg.Progress.URL] = state.FeedProgressSucceeded
+			}
+		}
+	}
+	return WaitForFetchProgressCmd(msg.Updates)
+}
+
+// recordFeedFetchStatus remembers when a single subscription was last fetched
+// and why it failed. Aggregate fetches carry no per-feed outcome and are skipped.
+func recordFeedFetchStatus(s *state.ModelState, msg FeedFetchedMsg, now time.Time) {
+	if msg.URL == "" || reading.IsVirtualFeedURL(msg.URL) {
+		return
+	}
+	if s.FeedFetchStatus == nil {
+		s.FeedFetchStatus = make(map[string]state.FeedFetchStatus)
+	}
+	status := state.FeedFetchStatus{FetchedAt: now}
+	if msg.Err != nil {
+		status = s.FeedFetchStatus[msg.URL]
+		status.Err = msg.Err.Error()
+	}
+	s.FeedFetchStatus[msg.URL] = status
+}
+
 // HandleNewsDigestGeneratedMsg applies generated digest items to history and current news list.
 func HandleNewsDigestGeneratedMsg(s *state.ModelState, msg NewsDigestGeneratedMsg, deps Deps) {
-	s.Loading = false
+	showingNews := s.CurrentFeed != nil && s.CurrentFeed.URL == reading.NewsURL
+	if msg.Startup {
+		s.StartupDigestPending = false
+	}
+	// A startup digest runs in the background: it only ends the loading of
+	// a News tab waiting on it.
+	if !msg.Startup || showingNews {
+		s.Loading = false
+	}
 	defer UpdateListSizes(s)
 
 	if msg.Err != nil {
 		s.AIStatus = fmt.Sprintf("AI: daily news failed (%s)", strings.TrimSpace(msg.Err.Error()))
+		if msg.Startup && !showingNews {
+			return
+		}
 		s.Err = msg.Err
 		if s.CurrentFeed != nil && s.CurrentFeed.URL == reading.NewsURL {
-			presenter.ApplyArticleList(&s.ArticleList, s.History, reading.NewsURL)
+			ApplyArticleList(s, reading.NewsURL)
 		}
 		return
 	}
@@ -460,12 +929,15 @@ func HandleNewsDigestGeneratedMsg(s *state.ModelState, msg NewsDigestGeneratedMs
 			s.Err = err
 		}
 		s.AIStatus = fmt.Sprintf("AI: daily news updated %s", time.Now().Format("2006-01-02 15:04"))
+		if msg.Note != "" {
+			s.StatusMessage = msg.Note
+		}
 	} else {
 		s.AIStatus = fmt.Sprintf("AI: using daily news cache (%s)", msg.DateKey)
 	}
 
 	if s.CurrentFeed != nil && s.CurrentFeed.URL == reading.NewsURL {
-		presenter.ApplyArticleList(&s.ArticleList, s.History, reading.NewsURL)
+		ApplyArticleList(s, reading.NewsURL)
 	}
 }
 
@@ -482,44 +954,78 @@ func HandleFeedGroupingCompletedMsg(s *state.ModelState, msg FeedGroupingComplet
 	}
 
 	s.Err = nil
+	s.FeedGroupingUndo = &state.FeedGroupingSnapshot{
+		Groups:    cloneFeedGroups(s.FeedGroups),
+		Ungrouped: ungroupedFeeds(s.Feeds, s.FeedGroups),
+	}
 	s.Feeds = append([]string(nil), msg.Feeds...)
 	s.FeedGroups = cloneFeedGroups(msg.Groups)
-	presenter.ApplyFeedList(&s.FeedList, s.Feeds, s.FeedGroups)
+	applyFeedList(s)
 
 	groupedCount := len(msg.Feeds) - len(msg.Ungrouped)
 	if groupedCount < 0 {
 		groupedCount = 0
 	}
+	if msg.UsedCache {
+		s.ForceFeedRegroup = true
+		s.StatusMessage = fmt.Sprintf("AI: using cached grouping (%s again to regroup)", s.Keys.GroupFeeds.Help().Key)
+		return
+	}
+	if msg.UsedFallback {
+		s.StatusMessage = fmt.Sprintf("AI unavailable: grouped %d feeds into %d groups by domain", groupedCount, len(msg.Groups))
+		return
+	}
+	if msg.Heuristic {
+		s.StatusMessage = fmt.Sprintf("Grouped %d feeds into %d groups by domain/keyword", groupedCount, len(msg.Groups))
+		return
+	}
 	s.StatusMessage = fmt.Sprintf("AI grouped %d feeds into %d groups", groupedCount, len(msg.Groups))
+	if msg.UnknownFeeds > 0 {
+		s.StatusMessage += fmt.Sprintf(" (AI suggested %d unknown feeds, ignored)", msg.UnknownFeeds)
+	}
 }
 
 // HandleInsightGeneratedMsg applies AI-generated summary/tags to history and visible items.
-func HandleInsightGeneratedMsg(s *state.ModelState, msg InsightGeneratedMsg, deps Deps) {
+func HandleInsightGeneratedMsg(s *state.ModelState, msg InsightGeneratedMsg, deps Deps) tea.Cmd {
+	if msg.Batch {
+		return handleSummaryBatchResult(s, msg, deps)
+	}
 	s.Loading = false
 	defer UpdateListSizes(s)
 	if msg.Err != nil {
 		s.AIStatus = fmt.Sprintf("AI: generation failed (%s)", strings.TrimSpace(msg.Err.Error()))
-		return
+		if s.AIFallback && showFallbackInsight(s, msg.GUID) {
+			s.AIStatus = fmt.Sprintf("AI: unavailable, showing feed description (%s)", strings.TrimSpace(msg.Err.Error()))
+		}
+		return nil
 	}
 
-	updatedAt, ok, err := deps.Reading.ApplyInsight(s.History, msg.GUID, msg.Insight)
+	updatedAt, err := applyInsight(s, msg.GUID, msg.Insight, deps)
 	if err != nil {
-		s.AIStatus = fmt.Sprintf("AI: save failed (%s)", strings.TrimSpace(err.Error()))
-		return
+		s.AIStatus = fmt.Sprintf("AI: %s", err)
+		return nil
+	}
+	s.AIStatus = fmt.Sprintf("AI: updated %s", updatedAt.Format("2006-01-02 15:04"))
+	return nil
+}
+
+// applyInsight stores a generated insight and shows it on the listed article.
+func applyInsight(s *state.ModelState, guid string, insight usecase.Insight, deps Deps) (time.Time, error) {
+	updatedAt, ok, err := deps.Reading.ApplyInsight(s.History, guid, insight)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("save failed (%s)", strings.TrimSpace(err.Error()))
 	}
 	if !ok {
-		s.AIStatus = "AI: failed to attach generated insight"
-		return
+		return time.Time{}, errors.New("failed to attach generated insight")
 	}
-	s.AIStatus = fmt.Sprintf("AI: updated %s", updatedAt.Format("2006-01-02 15:04"))
 
 	for idx, listItem := range s.ArticleList.Items() {
 		item, ok := listItem.(*presenter.Item)
-		if !ok || item.GUID != msg.GUID {
+		if !ok || item.GUID != guid {
 			continue
 		}
-		item.AISummary = msg.Insight.Summary
-		item.AITags = append([]string(nil), msg.Insight.Tags...)
+		item.AISummary = insight.Summary
+		item.AITags = append([]string(nil), insight.Tags...)
 		item.AIUpdatedAt = updatedAt
 		s.ArticleList.SetItem(idx, item)
 		if s.Session == state.DetailView && s.ArticleList.Index() == idx {
@@ -527,6 +1033,29 @@ func HandleInsightGeneratedMsg(s *state.ModelState, msg InsightGeneratedMsg, dep
 		}
 		break
 	}
+	return updatedAt, nil
+}
+
+// showFallbackInsight shows the article's feed description as its summary
+// without persisting it, so a later successful generation replaces it.
+func showFallbackInsight(s *state.ModelState, guid string) bool {
+	for idx, listItem := range s.ArticleList.Items() {
+		item, ok := listItem.(*presenter.Item)
+		if !ok || item.GUID != guid {
+			continue
+		}
+		insight, ok := usecase.FallbackInsight(item.Desc)
+		if !ok {
+			return false
+		}
+		item.AISummary = insight.Summary
+		s.ArticleList.SetItem(idx, item)
+		if s.Session == state.DetailView && s.ArticleList.Index() == idx {
+			refreshDetailViewport(s, item)
+		}
+		return true
+	}
+	return false
 }
 
 // HandleArticleDetailLoadedMsg applies hydrated article payload to state.
@@ -539,6 +1068,11 @@ func HandleArticleDetailLoadedMsg(s *state.ModelState, msg ArticleDetailLoadedMs
 		s.PendingInsightGUID = ""
 		return nil
 	}
+	if msg.FullTextErr != nil {
+		s.StatusMessage = fmt.Sprintf("Full text unavailable, showing excerpt: %v", msg.FullTextErr)
+	} else if msg.FullTextFetched {
+		s.StatusMessage = "Showing full article text"
+	}
 	if msg.Item != nil {
 		msg.Item.BodyHydrated = true
 		s.History.UpsertItem(msg.Item)
@@ -553,12 +1087,14 @@ func HandleArticleDetailLoadedMsg(s *state.ModelState, msg ArticleDetailLoadedMs
 
 	if s.PendingInsightGUID != "" && s.PendingInsightGUID == msg.GUID {
 		s.PendingInsightGUID = ""
-		s.Loading = true
+		BeginLoading(s, loadingSummary)
 		s.AIStatus = "AI: generating summary and tags..."
 		if selected, ok := selectedActionableArticleItem(s); ok && selected.GUID == msg.GUID {
+			req := buildInsightRequest(selected, s.ContentSanitizer)
+			s.AIStatus = generatingInsightStatus(req)
 			return tea.Batch(
 				s.Spinner.Tick,
-				GenerateInsightCmd(deps.Insights, selected.GUID, buildInsightRequest(selected)),
+				GenerateInsightCmd(deps.Insights, selected.GUID, req),
 			)
 		}
 	}
@@ -570,21 +1106,102 @@ func HandleArticleDetailLoadedMsg(s *state.ModelState, msg ArticleDetailLoadedMs
 func handleAddingFeedView(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.Cmd, bool) {
 	switch msg.String() {
 	case "enter":
-		url := s.TextInput.Value()
+		if s.AddFeedStatus == state.AddFeedChecking {
+			return nil, true
+		}
+		url := strings.TrimSpace(s.TextInput.Value())
+		if url != "" && s.ValidateNewFeeds {
+			s.AddFeedStatus = state.AddFeedChecking
+			s.AddFeedURL = url
+			s.AddFeedError = ""
+			return ValidateFeedCmd(deps.fetchContext(), deps.Reading, url), true
+		}
 		if url != "" {
-			feeds, err := deps.Subscriptions.Add(url)
-			if err != nil {
-				s.Err = err
-			} else {
-				s.Feeds = feeds
-				syncFeedGroupsFromRepository(s, deps)
-				presenter.ApplyFeedList(&s.FeedList, s.Feeds, s.FeedGroups)
-				UpdateListSizes(s)
-			}
-			s.TextInput.Reset()
+			addFeed(s, deps, url)
 		}
-		s.Session = state.FeedView
+		closeAddFeedDialog(s)
 		return nil, true
+	case "esc":
+		closeAddFeedDialog(s)
+		return nil, true
+	}
+	if s.AddFeedStatus == state.AddFeedChecking {
+		return nil, true
+	}
+
+	var cmd tea.Cmd
+	before := s.TextInput.Value()
+	s.TextInput, cmd = s.TextInput.Update(msg)
+	if s.TextInput.Value() != before {
+		s.AddFeedStatus = state.AddFeedIdle
+		s.AddFeedError = ""
+	}
+	return cmd, true
+}
+
+// HandleFeedValidatedMsg subscribes to a URL that turned out to be a feed,
+// or keeps the add feed dialog open with the reason it is not.
+func HandleFeedValidatedMsg(s *state.ModelState, msg FeedValidatedMsg, deps Deps) {
+	if s.Session != state.AddingFeedView || s.AddFeedStatus != state.AddFeedChecking || s.AddFeedURL != msg.URL {
+		// The dialog was closed or its URL changed while checking.
+		return
+	}
+	if msg.Err != nil {
+		s.AddFeedStatus = state.AddFeedInvalid
+		s.AddFeedError = msg.Err.Error()
+		return
+	}
+	if addFeed(s, deps, msg.URL) {
+		if _, err := deps.Reading.MergeHistory(s.History, msg.Feed); err != nil {
+			s.Err = err
+		}
+		RefreshFeedHeaders(s)
+		if title := strings.TrimSpace(msg.Feed.Title); title != "" {
+			s.StatusMessage = fmt.Sprintf("Subscribed to %s", textutil.SingleLine(title))
+		}
+	}
+	closeAddFeedDialog(s)
+}
+
+// addFeed subscribes to url and refreshes the sidebar, reporting whether
+// the subscription was added.
+func addFeed(s *state.ModelState, deps Deps, url string) bool {
+	feeds, err := deps.Subscriptions.Add(url)
+	switch {
+	case errors.Is(err, usecase.ErrFeedAlreadySubscribed):
+		s.StatusMessage = fmt.Sprintf("Already subscribed: %s", strings.TrimSpace(url))
+		return false
+	case err != nil:
+		s.Err = err
+		return false
+	}
+	s.Feeds = feeds
+	syncFeedGroupsFromRepository(s, deps)
+	applyFeedList(s)
+	UpdateListSizes(s)
+	return true
+}
+
+func closeAddFeedDialog(s *state.ModelState) {
+	s.TextInput.Reset()
+	s.AddFeedStatus = state.AddFeedIdle
+	s.AddFeedURL = ""
+	s.AddFeedError = ""
+	s.Session = state.FeedView
+}
+
+// handleImportFeedsView reads the OPML path or URL, imports its feeds and
+// opens the import summary.
+func handleImportFeedsView(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "enter":
+		path := strings.TrimSpace(s.TextInput.Value())
+		s.TextInput.Reset()
+		s.Session = state.FeedView
+		if path == "" {
+			return nil, true
+		}
+		return startOPMLImport(s, deps, path), true
 	case "esc":
 		s.TextInput.Reset()
 		s.Session = state.FeedView
@@ -596,10 +1213,39 @@ func handleAddingFeedView(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.C
 	return cmd, true
 }
 
-func handleQuitView(s *state.ModelState, msg tea.KeyMsg) (tea.Cmd, bool) {
+// handleImportSummaryView closes the import summary, or undoes the import
+// by unsubscribing every feed it added.
+func handleImportSummaryView(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "u", "U":
+		if s.FeedImport != nil && len(s.FeedImport.Added) > 0 {
+			feeds, err := deps.Subscriptions.UndoImport(usecase.FeedImportResult{Added: s.FeedImport.Added})
+			if err != nil {
+				s.Err = err
+			} else {
+				s.Feeds = feeds
+				syncFeedGroupsFromRepository(s, deps)
+				applyFeedList(s)
+				UpdateListSizes(s)
+				s.StatusMessage = fmt.Sprintf("Import undone: removed %d feeds", len(s.FeedImport.Added))
+			}
+		}
+	case "enter", "esc", "q", "Q":
+	default:
+		return nil, true
+	}
+	s.FeedImport = nil
+	s.Session = state.FeedView
+	return nil, true
+}
+
+func handleQuitView(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.Cmd, bool) {
 	switch msg.String() {
 	case "y", "Y":
-		return tea.Quit, true
+		if deps.CancelFetches != nil {
+			deps.CancelFetches()
+		}
+		return shutdownCmd(deps, s.ShutdownTimeout), true
 	case "n", "N", "esc", "q", "Q":
 		s.Session = s.Previous
 		return nil, true
@@ -620,20 +1266,122 @@ func handleDeleteFeedView(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.C
 					if !syncFeedGroupsFromRepository(s, deps) {
 						removeFeedFromGroupState(s, item.GroupName, item.Link)
 					}
-					presenter.ApplyFeedList(&s.FeedList, s.Feeds, s.FeedGroups)
+					applyFeedList(s)
 					UpdateListSizes(s)
 				}
 			}
 		}
-		s.Session = state.FeedView
+		s.Session = deleteFeedReturnSession(s)
 		return nil, true
 	case "n", "N", "esc", "q", "Q":
+		s.Session = deleteFeedReturnSession(s)
+		return nil, true
+	}
+	return nil, true
+}
+
+// deleteFeedReturnSession returns the screen the delete confirmation was opened from.
+func deleteFeedReturnSession(s *state.ModelState) state.Session {
+	if s.Previous == state.ManageFeedsView {
+		return state.ManageFeedsView
+	}
+	return state.FeedView
+}
+
+// handleClearHistoryView runs a two-step confirmation before wiping history.
+// The first step chooses whether bookmarks are kept; the second previews what
+// would be deleted and confirms.
+func handleClearHistoryView(s *state.ModelState, msg tea.KeyMsg, deps Deps) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "y", "Y":
+		if !s.ClearHistoryConfirmed {
+			confirmClearHistory(s, deps, false)
+			return nil, true
+		}
+		cmd := clearHistory(s, deps, s.ClearHistoryKeepBookmarks)
+		resetClearHistory(s)
 		s.Session = state.FeedView
+		return cmd, true
+	case "b", "B":
+		if s.ClearHistoryConfirmed {
+			return nil, true
+		}
+		confirmClearHistory(s, deps, true)
+		return nil, true
+	case "n", "N", "esc", "q", "Q":
+	default:
 		return nil, true
 	}
+	resetClearHistory(s)
+	s.Session = state.FeedView
 	return nil, true
 }
 
+// clearPreviewExamples caps the titles listed in the final confirmation.
+const clearPreviewExamples = 3
+
+func confirmClearHistory(s *state.ModelState, deps Deps, keepBookmarks bool) {
+	s.ClearHistoryConfirmed = true
+	s.ClearHistoryKeepBookmarks = keepBookmarks
+	s.ClearHistoryPreview = nil
+	if deps.Reading == nil {
+		return
+	}
+	preview, supported, err := deps.Reading.PreviewClearHistory(keepBookmarks, clearPreviewExamples)
+	if err != nil || !supported {
+		return
+	}
+	s.ClearHistoryPreview = &state.ClearPreview{Count: preview.Count, Examples: preview.Examples}
+}
+
+func resetClearHistory(s *state.ModelState) {
+	s.ClearHistoryConfirmed = false
+	s.ClearHistoryKeepBookmarks = false
+	s.ClearHistoryPreview = nil
+}
+
+// clearHistory deletes history and returns the command compacting the
+// database afterwards, which runs in the background.
+func clearHistory(s *state.ModelState, deps Deps, keepBookmarks bool) tea.Cmd {
+	if deps.Reading == nil {
+		s.Err = fmt.Errorf("reading service is not configured")
+		return nil
+	}
+	history, supported, err := deps.Reading.ClearHistory(keepBookmarks)
+	if err != nil {
+		s.Err = err
+		return nil
+	}
+	if !supported {
+		s.StatusMessage = "Clearing history is not supported"
+		return nil
+	}
+	s.Err = nil
+	s.History = history
+	RefreshFeedHeaders(s)
+	s.CurrentFeed = nil
+	s.LastOpenedGUID = ""
+	s.NavHistory = nil
+	s.NavIndex = 0
+	s.ArticleList.ResetFilter()
+	s.ArticleList.SetItems(nil)
+	if keepBookmarks {
+		s.StatusMessage = "History cleared (bookmarks kept)"
+	} else {
+		s.StatusMessage = "History cleared"
+	}
+	return trackSave(deps, optimizeHistoryCmd(deps.Reading))
+}
+
+// optimizeHistoryCmd compacts the history database. It is best effort: the
+// clear it follows already succeeded, so failures are dropped.
+func optimizeHistoryCmd(readingSvc *usecase.ReadingService) tea.Cmd {
+	return func() tea.Msg {
+		_, _, _ = readingSvc.OptimizeHistory()
+		return nil
+	}
+}
+
 func handleFeedViewIntent(s *state.ModelState, in intent.Intent, deps Deps) (tea.Cmd, bool) {
 	switch in.Type {
 	case intent.Open:
@@ -641,26 +1389,59 @@ func handleFeedViewIntent(s *state.ModelState, in intent.Intent, deps Deps) (tea
 			if i.IsSectionHeader() {
 				return nil, true
 			}
-			s.Loading = true
+			BeginLoading(s, LoadingFeeds)
 			s.Session = state.ArticleView
 			s.ArticleList.ResetSelected()
 			s.ArticleList.ResetFilter()
-			return tea.Batch(s.Spinner.Tick, FetchFeedCmd(deps.Reading, i.Link, s.Feeds)), true
+			return tea.Batch(s.Spinner.Tick, FetchFeedCmd(deps.fetchContext(), deps.Reading, i.Link, s.Feeds)), true
 		}
 	case intent.AddFeed:
 		s.Session = state.AddingFeedView
 		s.TextInput.Reset()
 		return textinput.Blink, true
+	case intent.ImportFeeds:
+		s.Session = state.ImportFeedsView
+		s.TextInput.Reset()
+		return textinput.Blink, true
 	case intent.DeleteFeed:
-		if item, ok := selectedFeedItem(s); ok {
-			if reading.IsVirtualFeedURL(item.Link) {
-				return nil, true
-			}
-			s.Session = state.DeleteFeedView
+		openDeleteFeedConfirmation(s)
+		return nil, true
+	case intent.MarkFeedRead:
+		startMarkFeedRead(s, deps)
+		return nil, true
+	case intent.TagStats:
+		openTagStats(s)
+		return nil, true
+	case intent.OpenRandom:
+		return openRandomUnread(s, deps), true
+	case intent.ManageFeeds:
+		s.Session = state.ManageFeedsView
+		return nil, true
+	case intent.GotoFeed:
+		startGotoFeed(s)
+		return nil, true
+	case intent.RefreshAll:
+		return startRefreshAll(s, deps), true
+	case intent.RefreshGroup:
+		return startRefreshGroup(s, deps), true
+	case intent.ToggleUnreadFeeds:
+		s.UnreadFeedsOnly = !s.UnreadFeedsOnly
+		applyFeedList(s)
+		if s.UnreadFeedsOnly {
+			s.StatusMessage = "Showing feeds with unread articles"
+		} else {
+			s.StatusMessage = "Showing all feeds"
 		}
 		return nil, true
 	case intent.GroupFeeds, intent.Summarize:
 		return startFeedGrouping(s, deps), true
+	case intent.Undo:
+		undoFeedGrouping(s, deps)
+		return nil, true
+	case intent.ClearHistory:
+		resetClearHistory(s)
+		s.Session = state.ClearHistoryView
+		return nil, true
 	case intent.ToggleHelp:
 		s.Help.ShowAll = !s.Help.ShowAll
 		return nil, true
@@ -668,45 +1449,139 @@ func handleFeedViewIntent(s *state.ModelState, in intent.Intent, deps Deps) (tea
 	return nil, false
 }
 
+func openDeleteFeedConfirmation(s *state.ModelState) {
+	item, ok := selectedFeedItem(s)
+	if !ok || reading.IsVirtualFeedURL(item.Link) {
+		return
+	}
+	s.Previous = s.Session
+	s.Session = state.DeleteFeedView
+}
+
+// handleManageFeedsViewIntent handles actions on the Manage Feeds screen.
+// Cursor movement falls through to the feed list, which backs the table rows.
+func handleManageFeedsViewIntent(s *state.ModelState, in intent.Intent) (tea.Cmd, bool) {
+	switch in.Type {
+	case intent.Back, intent.ManageFeeds:
+		s.Session = state.FeedView
+		return nil, true
+	case intent.DeleteFeed:
+		openDeleteFeedConfirmation(s)
+		return nil, true
+	case intent.MoveToGroup:
+		return openGroupEdit(s, state.MoveFeedToGroup), true
+	case intent.RenameGroup:
+		return openGroupEdit(s, state.RenameFeedGroup), true
+	case intent.ToggleHelp:
+		s.Help.ShowAll = !s.Help.ShowAll
+		return nil, true
+	}
+	return nil, false
+}
+
+// minFeedGroupingFeeds mirrors the lower bound enforced by FeedGroupingService.Group.
+const minFeedGroupingFeeds = 2
+
 func startFeedGrouping(s *state.ModelState, deps Deps) tea.Cmd {
-	s.Loading = true
+	if minFeeds := max(s.MinGroupingFeeds, minFeedGroupingFeeds); len(s.Feeds) < minFeeds {
+		s.StatusMessage = fmt.Sprintf("Feed grouping needs at least %d feeds (%d subscribed)", minFeeds, len(s.Feeds))
+		return nil
+	}
+	BeginLoading(s, loadingGrouping)
 	s.Err = nil
 	s.StatusMessage = ""
 	s.AIStatus = "AI: grouping feeds..."
-	return tea.Batch(s.Spinner.Tick, GenerateFeedGroupingCmd(deps.FeedGrouping, deps.Subscriptions, s.Feeds))
+	if s.HeuristicGrouping {
+		s.AIStatus = "Grouping feeds by domain/keyword..."
+	}
+	var keepGroups []subscription.FeedGroup
+	if s.PreserveManualGroups {
+		keepGroups = s.FeedGroups
+	}
+	force := s.ForceFeedRegroup
+	s.ForceFeedRegroup = false
+	return tea.Batch(s.Spinner.Tick, GenerateFeedGroupingCmd(deps.FeedGrouping, deps.Subscriptions, s.Feeds, keepGroups, force))
+}
+
+// snapshotHasFeeds reports whether the snapshot holds exactly the feeds
+// subscribed now.
+func snapshotHasFeeds(snapshot *state.FeedGroupingSnapshot, feeds []string) bool {
+	saved := slices.Clone(snapshot.Ungrouped)
+	for _, group := range snapshot.Groups {
+		saved = append(saved, group.Feeds...)
+	}
+	current := slices.Clone(feeds)
+	slices.Sort(saved)
+	slices.Sort(current)
+	return slices.Equal(saved, current)
+}
+
+func undoFeedGrouping(s *state.ModelState, deps Deps) {
+	snapshot := s.FeedGroupingUndo
+	if snapshot == nil {
+		s.StatusMessage = "Nothing to undo"
+		return
+	}
+	if !snapshotHasFeeds(snapshot, s.Feeds) {
+		// Restoring would drop feeds added since, bring back deleted ones
+		// or undo a URL update.
+		s.FeedGroupingUndo = nil
+		s.StatusMessage = "Feeds changed since grouping; nothing to undo"
+		return
+	}
+	if deps.Subscriptions == nil {
+		s.Err = fmt.Errorf("subscription service is not configured")
+		return
+	}
+	feeds, supported, err := deps.Subscriptions.ReplaceFeedGroups(snapshot.Groups, snapshot.Ungrouped)
+	if err != nil {
+		s.Err = err
+		return
+	}
+	if !supported {
+		s.Err = fmt.Errorf("feed grouping persistence is not supported")
+		return
+	}
+
+	s.Err = nil
+	s.FeedGroupingUndo = nil
+	s.Feeds = feeds
+	if !syncFeedGroupsFromRepository(s, deps) {
+		s.FeedGroups = cloneFeedGroups(snapshot.Groups)
+	}
+	applyFeedList(s)
+	UpdateListSizes(s)
+	s.StatusMessage = "grouping undone"
 }
 
 func handleArticleViewIntent(s *state.ModelState, in intent.Intent, deps Deps) (tea.Cmd, bool) {
 	switch in.Type {
 	case intent.Back:
+		CollapseExpandedRow(s)
 		s.Session = state.FeedView
 		s.ArticleList.Title = "Articles"
 		s.CurrentFeed = nil
+		if feedListUsesUnreadCounts(s) {
+			applyFeedList(s)
+		}
 		return nil, true
 	case intent.Open:
 		if i, ok := selectedActionableArticleItem(s); ok {
 			if i.IsNewsDigest() {
 				enterNewsTopicView(s, i)
+				rememberNewsSelection(s, i, deps)
 				return nil, true
 			}
-			if err := deps.Reading.MarkRead(s.History, i.GUID); err == nil {
-				idx := s.ArticleList.Index()
-				i.Read = true
-				s.ArticleList.SetItem(idx, i)
+			if i.Sources > 1 {
+				expandDuplicateTitles(s, i)
+				return nil, true
 			}
-
-			s.DetailParentSession = state.ArticleView
-			s.Session = state.DetailView
-			if !i.BodyHydrated {
-				i.Content = ""
-				refreshDetailViewport(s, i)
-				s.Loading = true
-				return tea.Batch(
-					s.Spinner.Tick,
-					LoadArticleDetailCmd(deps.Reading, i.GUID, true),
-				), true
+			markArticleOpened(s, i, deps)
+			if s.OpenInBrowser {
+				_ = deps.OpenBrowser(i.Link)
+				return nil, true
 			}
-			refreshDetailViewport(s, i)
+			return openArticleDetail(s, i, deps, state.ArticleView), true
 		}
 		return nil, true
 	case intent.ToggleHelp:
@@ -717,9 +1592,16 @@ func handleArticleViewIntent(s *state.ModelState, in intent.Intent, deps Deps) (
 			if s.CurrentFeed.URL == reading.NewsURL {
 				s.ForceNewsDigestRefresh = true
 			}
-			s.Loading = true
-			return tea.Batch(s.Spinner.Tick, FetchFeedCmd(deps.Reading, s.CurrentFeed.URL, s.Feeds)), true
+			BeginLoading(s, LoadingFeeds)
+			if s.CurrentFeed.URL == reading.AllFeedsURL && len(s.Feeds) > 0 {
+				return startBulkRefresh(s, deps), true
+			}
+			return tea.Batch(s.Spinner.Tick, FetchFeedCmd(deps.fetchContext(), deps.Reading, s.CurrentFeed.URL, s.Feeds)), true
 		}
+	case intent.RefreshAll:
+		return startRefreshAll(s, deps), true
+	case intent.RefreshGroup:
+		return startRefreshGroup(s, deps), true
 	case intent.Bookmark:
 		if i, ok := selectedActionableArticleItem(s); ok {
 			_ = deps.Reading.ToggleBookmark(s.History, i.GUID)
@@ -730,49 +1612,132 @@ func handleArticleViewIntent(s *state.ModelState, in intent.Intent, deps Deps) (
 			s.ArticleList.SetItem(idx, i)
 			return nil, true
 		}
+	case intent.Snooze:
+		openSnoozeDialog(s)
+		return nil, true
+	case intent.Dismiss:
+		toggleDismissSelection(s, deps)
+		return nil, true
 	case intent.Summarize:
 		return startInsightGenerationForSelection(s, deps), true
+	case intent.SummarizeMissing:
+		return startSummarizeMissing(s, deps), true
+	case intent.OpenRandom:
+		return openRandomUnread(s, deps), true
 	case intent.ToggleSummary:
 		return nil, true
+	case intent.ExpandRow:
+		toggleExpandedRow(s)
+		return nil, true
+	case intent.ToggleSelect:
+		toggleArticleSelection(s)
+		return nil, true
+	case intent.ExportSelected:
+		return startExportSelected(s, deps), true
 	}
 	return nil, false
 }
 
+// startBulkRefresh refetches every subscription and shows per-feed progress
+// in an overlay until the aggregated result arrives.
+func startBulkRefresh(s *state.ModelState, deps Deps) tea.Cmd {
+	return startBulkFetch(s, deps, s.Feeds)
+}
+
+// startBulkFetch fetches feeds together with the bulk refresh overlay; the
+// result arrives as a FeedFetchedMsg for AllFeedsURL.
+func startBulkFetch(s *state.ModelState, deps Deps, feeds []string) tea.Cmd {
+	s.FetchProgress = state.NewFetchProgress(feeds)
+	updates := make(chan usecase.FeedFetchProgress, len(feeds))
+	return tea.Batch(
+		s.Spinner.Tick,
+		FetchFeedWithProgressCmd(deps.fetchContext(), deps.Reading, reading.AllFeedsURL, feeds, updates),
+		WaitForFetchProgressCmd(updates),
+	)
+}
+
+// markArticleOpened marks the selected article read, counts the open and
+// remembers it as the most recently opened one.
+func markArticleOpened(s *state.ModelState, i *presenter.Item, deps Deps) {
+	if marksReadOnOpen(s) {
+		if err := deps.Reading.MarkRead(s.History, i.GUID); err == nil {
+			i.Read = true
+			RefreshFeedHeaders(s)
+		}
+	}
+	if count, _ := deps.Reading.RecordOpen(s.History, i.GUID); count > 0 {
+		i.Opens = count
+	}
+	s.ArticleList.SetItem(s.ArticleList.Index(), i)
+	s.LastOpenedGUID = i.GUID
+	presenter.MarkLastOpened(&s.ArticleList, i.GUID)
+	pushNavHistory(s, i.GUID)
+}
+
+// marksReadOnOpen reports whether opening an article in the current view marks
+// it read. Without a configured set every view does.
+func marksReadOnOpen(s *state.ModelState) bool {
+	if s.MarkReadViews == nil {
+		return true
+	}
+	view := settings.MarkReadViewFeeds
+	switch {
+	case s.Session == state.NewsTopicView:
+		view = settings.MarkReadViewNews
+	case s.CurrentFeed == nil:
+	case s.CurrentFeed.URL == reading.AllFeedsURL:
+		view = settings.MarkReadViewAll
+	case s.CurrentFeed.URL == reading.NewsURL:
+		view = settings.MarkReadViewNews
+	case s.CurrentFeed.URL == reading.BookmarksURL:
+		view = settings.MarkReadViewBookmarks
+	case s.CurrentFeed.URL == reading.DismissedURL:
+		view = settings.MarkReadViewDismissed
+	}
+	return s.MarkReadViews[view]
+}
+
 func handleNewsTopicViewIntent(s *state.ModelState, in intent.Intent, deps Deps) (tea.Cmd, bool) {
 	switch in.Type {
 	case intent.Back:
+		CollapseExpandedRow(s)
 		s.Session = state.ArticleView
-		presenter.ApplyArticleList(&s.ArticleList, s.History, reading.NewsURL)
+		ApplyArticleList(s, reading.NewsURL)
 		selectArticleItemByGUID(&s.ArticleList, s.NewsTopicDigestGUID)
 		return nil, true
 	case intent.Open:
 		if i, ok := selectedActionableArticleItem(s); ok {
-			if err := deps.Reading.MarkRead(s.History, i.GUID); err == nil {
-				idx := s.ArticleList.Index()
-				i.Read = true
-				s.ArticleList.SetItem(idx, i)
-			}
-			s.DetailParentSession = state.NewsTopicView
-			s.Session = state.DetailView
-			if !i.BodyHydrated {
-				i.Content = ""
-				refreshDetailViewport(s, i)
-				s.Loading = true
-				return tea.Batch(
-					s.Spinner.Tick,
-					LoadArticleDetailCmd(deps.Reading, i.GUID, true),
-				), true
+			markArticleOpened(s, i, deps)
+			if s.OpenInBrowser {
+				_ = deps.OpenBrowser(i.Link)
+				return nil, true
 			}
-			refreshDetailViewport(s, i)
+			return openArticleDetail(s, i, deps, state.NewsTopicView), true
 		}
 		return nil, true
 	case intent.Refresh:
 		if s.CurrentFeed != nil && s.CurrentFeed.URL == reading.NewsURL {
 			s.ForceNewsDigestRefresh = true
 			s.Session = state.ArticleView
-			s.Loading = true
-			return tea.Batch(s.Spinner.Tick, FetchFeedCmd(deps.Reading, s.CurrentFeed.URL, s.Feeds)), true
+			BeginLoading(s, LoadingFeeds)
+			return tea.Batch(s.Spinner.Tick, FetchFeedCmd(deps.fetchContext(), deps.Reading, s.CurrentFeed.URL, s.Feeds)), true
+		}
+		return nil, true
+	case intent.ToggleTitles:
+		if len(s.NewsTopicRelatedTitles) == 0 {
+			s.StatusMessage = "No translated titles for this topic"
+			return nil, true
 		}
+		selected := ""
+		if i, ok := selectedActionableArticleItem(s); ok {
+			selected = i.GUID
+		}
+		s.ShowTranslatedTitles = !s.ShowTranslatedTitles
+		applyRelatedArticleList(s)
+		selectArticleItemByGUID(&s.ArticleList, selected)
+		return nil, true
+	case intent.ExpandRow:
+		toggleExpandedRow(s)
 		return nil, true
 	case intent.ToggleHelp:
 		s.Help.ShowAll = !s.Help.ShowAll
@@ -781,9 +1746,25 @@ func handleNewsTopicViewIntent(s *state.ModelState, in intent.Intent, deps Deps)
 	return nil, false
 }
 
+// handleDetailJumpKey moves the reading pane to the top or bottom of the
+// article with the Top and Bottom keys.
+func handleDetailJumpKey(s *state.ModelState, msg tea.KeyMsg) bool {
+	switch {
+	case key.Matches(msg, s.Keys.Top):
+		s.Viewport.GotoTop()
+	case key.Matches(msg, s.Keys.Bottom):
+		s.Viewport.GotoBottom()
+	default:
+		return false
+	}
+	return true
+}
+
 func handleDetailViewIntent(s *state.ModelState, in intent.Intent, deps Deps) (tea.Cmd, bool) {
 	switch in.Type {
 	case intent.Back:
+		clearDetailSearch(s)
+		saveDetailScroll(s, deps)
 		if s.DetailParentSession == state.NewsTopicView || s.DetailParentSession == state.ArticleView {
 			s.Session = s.DetailParentSession
 		} else {
@@ -800,17 +1781,42 @@ func handleDetailViewIntent(s *state.ModelState, in intent.Intent, deps Deps) (t
 		return nil, true
 	case intent.Summarize:
 		return startInsightGenerationForSelection(s, deps), true
+	case intent.AskAI:
+		return openAskAIDialog(s, deps), true
+	case intent.NavBack:
+		return navigateOpenedArticles(s, deps, -1), true
+	case intent.NavForward:
+		return navigateOpenedArticles(s, deps, 1), true
+	case intent.OpenRandom:
+		return openRandomUnread(s, deps), true
 	case intent.ToggleSummary:
 		s.ShowAISummary = !s.ShowAISummary
+		if deps.Subscriptions != nil {
+			if _, err := deps.Subscriptions.SaveShowAISummary(s.ShowAISummary); err != nil {
+				s.StatusMessage = fmt.Sprintf("Failed to save summary visibility: %v", err)
+			}
+		}
 		if i, ok := selectedActionableArticleItem(s); ok {
 			refreshDetailViewport(s, i)
 		}
 		return nil, true
+	case intent.ToggleFocus:
+		s.FocusMode = !s.FocusMode
+		return nil, true
+	case intent.FetchFullText:
+		i, ok := selectedActionableArticleItem(s)
+		if !ok || s.Loading {
+			return nil, true
+		}
+		BeginLoading(s, loadingFullText)
+		s.Err = nil
+		s.StatusMessage = "Fetching full article text..."
+		return tea.Batch(s.Spinner.Tick, trackSave(deps, FetchFullTextCmd(deps.Context, deps.Reading, i.GUID))), true
 	}
 	return nil, false
 }
 
-func buildInsightRequest(item *presenter.Item) usecase.InsightRequest {
+func buildInsightRequest(item *presenter.Item, sanitizer *reading.ContentSanitizer) usecase.InsightRequest {
 	if item == nil {
 		return usecase.InsightRequest{}
 	}
@@ -820,8 +1826,8 @@ func buildInsightRequest(item *presenter.Item) usecase.InsightRequest {
 	}
 	return usecase.InsightRequest{
 		Title:       title,
-		Description: item.Desc,
-		Content:     item.Content,
+		Description: sanitizer.Clean(item.Desc),
+		Content:     sanitizer.Clean(item.Content),
 		Link:        item.Link,
 		Published:   item.Published,
 		FeedTitle:   item.FeedTitleText,
@@ -833,11 +1839,38 @@ func refreshDetailViewport(s *state.ModelState, item *presenter.Item) {
 		return
 	}
 	wrapWidth := detailWrapWidth(s)
-	s.Viewport.SetContent(buildDetailContentForWidth(item, s.ShowAISummary, wrapWidth))
-	s.Viewport.GotoTop()
+	if item != nil {
+		cleaned := *item
+		cleaned.Content = s.ContentSanitizer.Clean(item.Content)
+		cleaned.Desc = s.ContentSanitizer.Clean(item.Desc)
+		if s.ResolveRelativeLinks {
+			cleaned.Content = textutil.ResolveLinks(cleaned.Content, item.Link)
+			cleaned.Desc = textutil.ResolveLinks(cleaned.Desc, item.Link)
+		}
+		item = &cleaned
+	}
+	content := buildDetailContentForWidth(item, s.ShowAISummary, wrapWidth)
+	s.DetailContent = centerDetailColumn(content, wrapWidth, detailContentWidth(s))
+	s.Viewport.SetContent(s.DetailContent)
+	restoreDetailScroll(s, item)
+	if s.DetailSearchQuery != "" {
+		applyDetailSearch(s, false)
+	}
 }
 
+// detailWrapWidth returns the reading column width, capped by ReadingWidth when set.
 func detailWrapWidth(s *state.ModelState) int {
+	if s == nil {
+		return 0
+	}
+	width := detailContentWidth(s)
+	if s.ReadingWidth > 0 && s.ReadingWidth < width {
+		return s.ReadingWidth
+	}
+	return width
+}
+
+func detailContentWidth(s *state.ModelState) int {
 	if s == nil {
 		return 0
 	}
@@ -850,6 +1883,34 @@ func detailWrapWidth(s *state.ModelState) int {
 	return clampMin(mainContentWidth-s.Viewport.Style.GetHorizontalFrameSize(), 1)
 }
 
+// openArticleDetail shows an article in the detail view, loading its full body
+// when needed and summarizing it automatically when configured.
+func openArticleDetail(s *state.ModelState, i *presenter.Item, deps Deps, parent state.Session) tea.Cmd {
+	s.DetailParentSession = parent
+	s.Session = state.DetailView
+	saveDetailScroll(s, deps)
+	s.AskQuestion = ""
+	s.AskAnswer = ""
+	refreshDetailViewport(s, i)
+	autoSummarize := s.AutoSummarizeOnOpen && deps.Insights.Enabled() && strings.TrimSpace(i.AISummary) == ""
+	if !i.BodyHydrated {
+		BeginLoading(s, loadingArticle)
+		if autoSummarize {
+			// The summary starts once the body arrives; see HandleArticleDetailLoadedMsg.
+			s.PendingInsightGUID = i.GUID
+			s.AIStatus = "AI: loading article content..."
+		}
+		return tea.Batch(
+			s.Spinner.Tick,
+			trackSave(deps, LoadArticleDetailCmd(deps.Context, deps.Reading, i.GUID, true, s.FullTextFeeds[i.FeedURL])),
+		)
+	}
+	if autoSummarize {
+		return startInsightGenerationForSelection(s, deps)
+	}
+	return nil
+}
+
 func startInsightGenerationForSelection(s *state.ModelState, deps Deps) tea.Cmd {
 	item, ok := selectedActionableArticleItem(s)
 	if !ok {
@@ -860,28 +1921,38 @@ func startInsightGenerationForSelection(s *state.ModelState, deps Deps) tea.Cmd
 	}
 
 	if !item.BodyHydrated {
-		s.Loading = true
+		BeginLoading(s, loadingArticle)
 		s.Err = nil
 		s.PendingInsightGUID = item.GUID
 		s.AIStatus = "AI: loading article content..."
 		return tea.Batch(
 			s.Spinner.Tick,
-			LoadArticleDetailCmd(deps.Reading, item.GUID, false),
+			trackSave(deps, LoadArticleDetailCmd(deps.Context, deps.Reading, item.GUID, false, s.FullTextFeeds[item.FeedURL])),
 		)
 	}
 
-	s.Loading = true
+	BeginLoading(s, loadingSummary)
 	s.Err = nil
 	s.PendingInsightGUID = ""
-	s.AIStatus = "AI: generating summary and tags..."
+	req := buildInsightRequest(item, s.ContentSanitizer)
+	s.AIStatus = generatingInsightStatus(req)
 
 	return tea.Batch(
 		s.Spinner.Tick,
-		GenerateInsightCmd(deps.Insights, item.GUID, buildInsightRequest(item)),
+		GenerateInsightCmd(deps.Insights, item.GUID, req),
 	)
 }
 
+// generatingInsightStatus announces an insight request with its estimated
+// prompt size.
+func generatingInsightStatus(req usecase.InsightRequest) string {
+	return fmt.Sprintf("AI: generating summary and tags (~%d tokens)...", req.EstimatedTokens())
+}
+
 func feedFetchStatusMessage(report usecase.FeedFetchReport) string {
+	if allFeedsFailed(report) {
+		return "Couldn't reach any feeds — check your connection (showing saved articles)"
+	}
 	if report.Requested <= 1 {
 		return ""
 	}
@@ -900,6 +1971,12 @@ func feedFetchStatusMessage(report usecase.FeedFetchReport) string {
 	return ""
 }
 
+// allFeedsFailed reports whether an aggregated fetch reached none of its feeds,
+// which usually means the network is down rather than a single feed is broken.
+func allFeedsFailed(report usecase.FeedFetchReport) bool {
+	return report.Requested > 0 && report.Succeeded == 0 && report.Failed+report.TimedOut > 0
+}
+
 func applyHydratedItemToList(model *list.Model, item *reading.HistoryItem) {
 	if model == nil || item == nil {
 		return
@@ -916,6 +1993,7 @@ func applyHydratedItemToList(model *list.Model, item *reading.HistoryItem) {
 		current.Link = item.Link
 		current.AISummary = item.AISummary
 		current.AITags = append([]string(nil), item.AITags...)
+		current.FeedCategories = append([]string(nil), item.FeedCategories...)
 		current.AIUpdatedAt = item.AIUpdatedAt
 		current.Bookmarked = item.IsBookmarked
 		current.Read = item.IsRead
@@ -952,6 +2030,28 @@ func syncFeedGroupsFromRepository(s *state.ModelState, deps Deps) bool {
 	return true
 }
 
+// syncFeedTitlesFromRepository reloads the feed display names, e.g. after an
+// import stored new ones.
+func syncFeedTitlesFromRepository(s *state.ModelState, deps Deps) {
+	if s == nil || deps.Subscriptions == nil {
+		return
+	}
+	metadata, supported, err := deps.Subscriptions.FeedMetadata()
+	if err != nil {
+		s.Err = err
+		return
+	}
+	if !supported {
+		return
+	}
+	s.FeedTitles = make(map[string]string, len(metadata))
+	for feedURL, meta := range metadata {
+		if meta.Title != "" {
+			s.FeedTitles[feedURL] = meta.Title
+		}
+	}
+}
+
 func removeFeedFromGroupState(s *state.ModelState, groupName, targetURL string) {
 	if s == nil || targetURL == "" {
 		return
@@ -979,6 +2079,72 @@ func removeFeedFromGroupState(s *state.ModelState, groupName, targetURL string)
 	}
 }
 
+func ungroupedFeeds(feeds []string, groups []subscription.FeedGroup) []string {
+	grouped := make(map[string]struct{})
+	for _, group := range groups {
+		for _, feedURL := range group.Feeds {
+			grouped[feedURL] = struct{}{}
+		}
+	}
+	out := make([]string, 0, len(feeds))
+	for _, feedURL := range feeds {
+		if _, ok := grouped[feedURL]; ok {
+			continue
+		}
+		out = append(out, feedURL)
+	}
+	return out
+}
+
+// ApplyArticleList rebuilds the article list for feedURL and restores the
+// per-view marks on its items.
+func ApplyArticleList(s *state.ModelState, feedURL string) {
+	if feedURL == reading.NewsURL && s.NewsShowsToday {
+		presenter.ApplyTodayArticleList(&s.ArticleList, s.History, s.Feeds, time.Now(), s.SectionHeaderFormat, s.FeedTagMaxChars)
+		presenter.MarkLastOpened(&s.ArticleList, s.LastOpenedGUID)
+		restoreSelection(s)
+		restoreExpandedRow(s)
+		return
+	}
+	presenter.ApplyArticleList(&s.ArticleList, s.History, feedURL, ArticleListOptions(s))
+	presenter.MarkLastOpened(&s.ArticleList, s.LastOpenedGUID)
+	if feedURL == reading.BookmarksURL && s.BookmarksIgnoreReadDim {
+		presenter.KeepBookmarksBright(&s.ArticleList)
+	}
+	restoreNewsSelection(s, feedURL)
+	restoreSelection(s)
+	restoreExpandedRow(s)
+}
+
+// SyncArticleAgeCutoff rebuilds the article list when a filter was started
+// or cleared since the list had filter state prev, so articles hidden by
+// max_article_age are listed exactly while a filter is applied.
+func SyncArticleAgeCutoff(s *state.ModelState, prev list.FilterState) {
+	if s.MaxArticleAge <= 0 || s.CurrentFeed == nil {
+		return
+	}
+	if (prev == list.Unfiltered) == (s.ArticleList.FilterState() == list.Unfiltered) {
+		return
+	}
+	ApplyArticleList(s, s.CurrentFeed.URL)
+}
+
+// ArticleListOptions returns the settings article lists are built with.
+// Articles older than max_article_age stay listed while a filter is applied,
+// so searching still finds them.
+func ArticleListOptions(s *state.ModelState) presenter.ArticleListOptions {
+	maxAge := s.MaxArticleAge
+	if s.ArticleList.FilterState() != list.Unfiltered {
+		maxAge = 0
+	}
+	return presenter.ArticleListOptions{
+		HeaderFormat:    s.SectionHeaderFormat,
+		FeedTagMaxChars: s.FeedTagMaxChars,
+		MaxAge:          maxAge,
+		Collapse:        presenter.TitleCollapse{Enabled: s.CollapseDuplicateTitles, Expanded: s.ExpandedTitles},
+	}
+}
+
 func cloneFeedGroups(groups []subscription.FeedGroup) []subscription.FeedGroup {
 	if len(groups) == 0 {
 		return nil
@@ -1019,11 +2185,26 @@ func enterNewsTopicView(s *state.ModelState, digestItem *presenter.Item) {
 		s.NewsTopicSummary = strings.TrimSpace(digestItem.Desc)
 	}
 	s.NewsTopicTags = append([]string(nil), digestItem.AITags...)
+	s.NewsTopicRelatedGUIDs = append([]string(nil), digestItem.RelatedGUIDs...)
+	s.NewsTopicRelatedTitles = maps.Clone(digestItem.RelatedTitles)
 
-	presenter.ApplyRelatedArticleList(&s.ArticleList, s.History, digestItem.RelatedGUIDs)
+	applyRelatedArticleList(s)
 	s.Session = state.NewsTopicView
 }
 
+// applyRelatedArticleList shows the open topic's related articles with either
+// their original or translated titles.
+func applyRelatedArticleList(s *state.ModelState) {
+	var titles map[string]string
+	if s.ShowTranslatedTitles {
+		titles = s.NewsTopicRelatedTitles
+	}
+	presenter.ApplyRelatedArticleList(&s.ArticleList, s.History, s.NewsTopicRelatedGUIDs, titles, s.FeedTagMaxChars)
+	presenter.MarkLastOpened(&s.ArticleList, s.LastOpenedGUID)
+	restoreSelection(s)
+	restoreExpandedRow(s)
+}
+
 func selectArticleItemByGUID(model *list.Model, guid string) {
 	if model == nil || strings.TrimSpace(guid) == "" {
 		return
@@ -9,6 +9,9 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/tesso57/reazy/internal/presentation/tui/glyph"
+	"github.com/tesso57/reazy/internal/presentation/tui/metrics"
 )
 
 // ArticleItem interface for items that can be rendered by ArticleDelegate.
@@ -22,15 +25,52 @@ type ArticleItem interface {
 	IsSectionHeader() bool
 }
 
+// lastOpenedItem is implemented by items that track the most recently opened article.
+type lastOpenedItem interface {
+	IsLastOpened() bool
+}
+
+// selectedItem is implemented by items that can be picked for export.
+type selectedItem interface {
+	IsSelected() bool
+}
+
+// readDimItem is implemented by items that can opt out of read dimming.
+type readDimItem interface {
+	DimsWhenRead() bool
+}
+
+// openCountItem is implemented by items that track how often they were opened.
+type openCountItem interface {
+	OpenCount() int
+}
+
+// sourceCountItem is implemented by items that can stand for several
+// same-title articles.
+type sourceCountItem interface {
+	SourceCount() int
+}
+
+// peekItem is implemented by items that can show their description under
+// the row.
+type peekItem interface {
+	PeekText() string
+}
+
 // ArticleDelegate handles rendering of article items.
 type ArticleDelegate struct {
 	Styles list.DefaultItemStyles
+	Glyphs glyph.Set
+	// PeekLines caps the description lines shown under an expanded row (see
+	// metrics.ExpandedRowLines).
+	PeekLines int
 }
 
-// NewArticleDelegate creates a new ArticleDelegate.
-func NewArticleDelegate() *ArticleDelegate {
+// NewArticleDelegate creates a new ArticleDelegate marking items with glyphs.
+func NewArticleDelegate(glyphs glyph.Set) *ArticleDelegate {
 	return &ArticleDelegate{
 		Styles: withItemPadding(list.NewDefaultItemStyles()),
+		Glyphs: glyphs.OrDefault(),
 	}
 }
 
@@ -64,16 +104,68 @@ func (d *ArticleDelegate) Render(w io.Writer, m list.Model, index int, item list
 	}
 
 	title := decorateArticleTitle(i.Title(), i.IsBookmarked(), i.HasAISummary())
+	if opened, ok := item.(lastOpenedItem); ok && opened.IsLastOpened() {
+		title = d.Glyphs.LastOpened + " " + title
+	}
+	if selected, ok := item.(selectedItem); ok && selected.IsSelected() {
+		title = d.Glyphs.Selected + " " + title
+	}
+	if sources, ok := item.(sourceCountItem); ok && sources.SourceCount() > 1 {
+		title = fmt.Sprintf("%s (%d sources)", title, sources.SourceCount())
+	}
+	if opened, ok := item.(openCountItem); ok && opened.OpenCount() > 1 {
+		title = fmt.Sprintf("%s %s%d", title, d.Glyphs.Reopened, opened.OpenCount())
+	}
 
 	style := itemStyle(d.Styles, m, index)
 	title = truncateItemText(m, style, title)
 
 	// If IsRead, Apply Faint
-	if i.IsRead() {
+	if i.IsRead() && dimsWhenRead(item) {
 		title = lipgloss.NewStyle().Faint(true).Render(title)
 	}
 
 	renderItemText(w, style, title)
+	if peek, ok := item.(peekItem); ok {
+		d.renderPeek(w, m, index, peek.PeekText())
+	}
+}
+
+// renderPeek writes the description of an expanded row below its title.
+func (d *ArticleDelegate) renderPeek(w io.Writer, m list.Model, index int, text string) {
+	if text == "" {
+		return
+	}
+	style := d.Styles.NormalDesc
+	if index == m.Index() {
+		style = d.Styles.SelectedDesc
+	}
+	width := m.Width() - style.GetHorizontalFrameSize() - metrics.ItemSafetyPadding
+	for _, line := range peekLines(text, width, metrics.ExpandedRowLines(d.PeekLines)) {
+		_, _ = io.WriteString(w, "\n")
+		renderItemText(w, style, line)
+	}
+}
+
+// peekLines wraps text to width and keeps at most maxLines lines, ending the
+// last one with "…" when the text goes on.
+func peekLines(text string, width, maxLines int) []string {
+	if width <= 0 {
+		return nil
+	}
+	lines := strings.Split(ansi.Wrap(text, width, ""), "\n")
+	if len(lines) <= maxLines {
+		return lines
+	}
+	lines = lines[:maxLines]
+	last := strings.TrimRight(lines[maxLines-1], " ")
+	lines[maxLines-1] = ansi.Truncate(last, width-1, "") + "…"
+	return lines
+}
+
+func dimsWhenRead(item list.Item) bool {
+	dimmed, ok := item.(readDimItem)
+	return !ok || dimmed.DimsWhenRead()
 }
 
 func decorateArticleTitle(title string, bookmarked, hasAISummary bool) string {
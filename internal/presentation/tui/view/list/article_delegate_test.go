@@ -2,11 +2,13 @@ package listview
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tesso57/reazy/internal/presentation/tui/glyph"
 )
 
 // testArticleItem satisfies the ArticleItem interface.
@@ -30,21 +32,61 @@ func (m testArticleItem) IsSectionHeader() bool {
 	return m.section
 }
 
+// testLastOpenedArticleItem also reports the last-opened state.
+type testLastOpenedArticleItem struct {
+	testArticleItem
+	lastOpened bool
+}
+
+func (m testLastOpenedArticleItem) IsLastOpened() bool { return m.lastOpened }
+
+// testOpenCountArticleItem also reports how often it was opened.
+type testOpenCountArticleItem struct {
+	testArticleItem
+	opens int
+}
+
+func (m testOpenCountArticleItem) OpenCount() int { return m.opens }
+
+// testSourceCountArticleItem also stands for several same-title articles.
+type testSourceCountArticleItem struct {
+	testArticleItem
+	sources int
+}
+
+func (m testSourceCountArticleItem) SourceCount() int { return m.sources }
+
+// testPeekArticleItem also shows its description under the row.
+type testPeekArticleItem struct {
+	testArticleItem
+	peek string
+}
+
+func (m testPeekArticleItem) PeekText() string { return m.peek }
+
 func TestNewArticleDelegate(t *testing.T) {
-	d := NewArticleDelegate()
+	d := NewArticleDelegate(glyph.Emoji)
 	require.NotNil(t, d)
 	assert.Equal(t, 1, d.Height())
 	assert.Equal(t, 0, d.Spacing())
 }
 
 func TestArticleDelegate_Update(t *testing.T) {
-	d := NewArticleDelegate()
+	d := NewArticleDelegate(glyph.Emoji)
 	cmd := d.Update(nil, nil)
 	assert.Nil(t, cmd)
 }
 
+// testReadDimArticleItem also decides whether it is dimmed once read.
+type testReadDimArticleItem struct {
+	testArticleItem
+	dims bool
+}
+
+func (m testReadDimArticleItem) DimsWhenRead() bool { return m.dims }
+
 func TestArticleDelegate_Render(t *testing.T) {
-	d := NewArticleDelegate()
+	d := NewArticleDelegate(glyph.Emoji)
 	// m := list.Model{} // Unused
 	// We need to set the index of the model to match or not match
 	// However, list.Model internals are complex to mock perfectly without initialization.
@@ -142,3 +184,96 @@ func TestArticleDelegate_Render(t *testing.T) {
 		})
 	}
 }
+
+func TestArticleDelegate_RenderLastOpened(t *testing.T) {
+	d := NewArticleDelegate(glyph.Emoji)
+	l := list.New([]list.Item{}, d, 80, 10)
+	l.Select(1)
+
+	buf := &bytes.Buffer{}
+	d.Render(buf, l, 0, testLastOpenedArticleItem{testArticleItem: testArticleItem{title: "Opened"}, lastOpened: true})
+	assert.Contains(t, buf.String(), "· Opened")
+
+	buf.Reset()
+	d.Render(buf, l, 0, testLastOpenedArticleItem{testArticleItem: testArticleItem{title: "Other"}})
+	assert.NotContains(t, buf.String(), "·")
+}
+
+func TestArticleDelegate_RenderOpenCount(t *testing.T) {
+	d := NewArticleDelegate(glyph.Emoji)
+	l := list.New([]list.Item{}, d, 80, 10)
+	l.Select(1)
+
+	buf := &bytes.Buffer{}
+	d.Render(buf, l, 0, testOpenCountArticleItem{testArticleItem: testArticleItem{title: "Reopened"}, opens: 3})
+	assert.Contains(t, buf.String(), "Reopened ×3")
+
+	buf.Reset()
+	d.Render(buf, l, 0, testOpenCountArticleItem{testArticleItem: testArticleItem{title: "Once"}, opens: 1})
+	assert.NotContains(t, buf.String(), "×")
+}
+
+func TestArticleDelegate_RenderSourceCount(t *testing.T) {
+	d := NewArticleDelegate(glyph.Emoji)
+	l := list.New([]list.Item{}, d, 80, 10)
+	l.Select(1)
+
+	buf := &bytes.Buffer{}
+	d.Render(buf, l, 0, testSourceCountArticleItem{testArticleItem: testArticleItem{title: "Story"}, sources: 3})
+	assert.Contains(t, buf.String(), "Story (3 sources)")
+
+	buf.Reset()
+	d.Render(buf, l, 0, testSourceCountArticleItem{testArticleItem: testArticleItem{title: "Single"}, sources: 0})
+	assert.NotContains(t, buf.String(), "sources")
+}
+
+func TestArticleDelegate_RenderPeek(t *testing.T) {
+	d := NewArticleDelegate(glyph.Emoji)
+	d.PeekLines = 2
+	l := list.New([]list.Item{}, d, 30, 10)
+
+	buf := &bytes.Buffer{}
+	d.Render(buf, l, 0, testPeekArticleItem{testArticleItem: testArticleItem{title: "Story"}, peek: "short"})
+	lines := strings.Split(buf.String(), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[1], "short")
+	assert.NotContains(t, lines[1], "…")
+
+	buf.Reset()
+	long := "one two three four five six seven eight nine ten eleven twelve thirteen fourteen"
+	d.Render(buf, l, 0, testPeekArticleItem{testArticleItem: testArticleItem{title: "Story"}, peek: long})
+	lines = strings.Split(buf.String(), "\n")
+	require.Len(t, lines, 3, "the description is cut to PeekLines lines")
+	assert.Contains(t, lines[2], "…")
+
+	buf.Reset()
+	d.Render(buf, l, 0, testPeekArticleItem{testArticleItem: testArticleItem{title: "Story"}})
+	assert.NotContains(t, buf.String(), "\n", "collapsed rows stay one line")
+}
+
+func TestPeekLines(t *testing.T) {
+	assert.Equal(t, []string{"alpha beta"}, peekLines("alpha beta", 20, 3))
+	assert.Equal(t, []string{"alpha", "beta…"}, peekLines("alpha beta gamma", 6, 2))
+	assert.Nil(t, peekLines("alpha", 0, 3))
+}
+
+func TestDimsWhenRead(t *testing.T) {
+	read := testArticleItem{title: "Kept", isRead: true, bookmarked: true}
+	assert.True(t, dimsWhenRead(read), "items without a preference are dimmed when read")
+	assert.True(t, dimsWhenRead(testReadDimArticleItem{testArticleItem: read, dims: true}))
+	assert.False(t, dimsWhenRead(testReadDimArticleItem{testArticleItem: read, dims: false}))
+}
+
+func TestArticleDelegate_RenderASCIIMarkers(t *testing.T) {
+	d := NewArticleDelegate(glyph.ASCII)
+	l := list.New([]list.Item{}, d, 80, 10)
+	l.Select(1)
+
+	buf := &bytes.Buffer{}
+	d.Render(buf, l, 0, testLastOpenedArticleItem{testArticleItem: testArticleItem{title: "Opened"}, lastOpened: true})
+	assert.Contains(t, buf.String(), "> Opened")
+
+	buf.Reset()
+	d.Render(buf, l, 0, testOpenCountArticleItem{testArticleItem: testArticleItem{title: "Reopened"}, opens: 3})
+	assert.Contains(t, buf.String(), "Reopened x3")
+}
@@ -2,6 +2,7 @@
 package view
 
 import (
+	"github.com/charmbracelet/lipgloss"
 	"github.com/tesso57/reazy/internal/presentation/tui/components/header"
 	"github.com/tesso57/reazy/internal/presentation/tui/components/layout"
 	mainview "github.com/tesso57/reazy/internal/presentation/tui/components/main"
@@ -16,6 +17,9 @@ type Props struct {
 	Main    mainview.Props
 	Modal   modal.Props
 	Footer  string
+	// Focus leaves out the sidebar and header; an empty Footer is left out
+	// too instead of taking a line.
+	Focus bool
 }
 
 // Render renders the complete UI view based on the provided props.
@@ -24,6 +28,14 @@ func Render(p Props) string {
 		return modal.Render(p.Modal)
 	}
 
+	if p.Focus {
+		mainStr := mainview.Render(p.Main)
+		if p.Footer == "" {
+			return mainStr
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, mainStr, p.Footer)
+	}
+
 	sidebarStr := sidebar.Render(p.Sidebar)
 	headerStr := header.Render(p.Header)
 
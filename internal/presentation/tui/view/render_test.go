@@ -12,10 +12,11 @@ import (
 
 func TestRender(t *testing.T) {
 	tests := []struct {
-		name      string
-		props     Props
-		wantExact string   // if set, check exact string (for modal)
-		wantParts []string // check containment
+		name        string
+		props       Props
+		wantExact   string   // if set, check exact string (for modal)
+		wantParts   []string // check containment
+		unwantParts []string // check absence
 	}{
 		{
 			name: "Modal Overlay",
@@ -58,6 +59,28 @@ func TestRender(t *testing.T) {
 				"FOOTER_HELP",
 			},
 		},
+		{
+			name: "Focus Layout",
+			props: Props{
+				Sidebar: sidebar.Props{
+					View:   "SIDEBAR_CONTENT",
+					Width:  20,
+					Height: 10,
+				},
+				Header: header.Props{
+					Visible: true,
+					Link:    "LINK",
+				},
+				Main: mainview.Props{
+					Width:  80,
+					Height: 10,
+					Body:   "MAIN_CONTENT",
+				},
+				Focus: true,
+			},
+			wantParts:   []string{"MAIN_CONTENT"},
+			unwantParts: []string{"SIDEBAR_CONTENT", "LINK"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -68,6 +91,11 @@ func TestRender(t *testing.T) {
 					t.Errorf("Render() expected to contain %q", part)
 				}
 			}
+			for _, part := range tt.unwantParts {
+				if strings.Contains(got, part) {
+					t.Errorf("Render() expected not to contain %q", part)
+				}
+			}
 		})
 	}
 }